@@ -9,13 +9,19 @@ import (
 type (
 	// Config -.
 	Config struct {
-		App   `yaml:"app"`
-		HTTP  `yaml:"http"`
-		Log   `yaml:"logger"`
-		PG    `yaml:"postgres"`
-		JWT   `yaml:"jwt"`
-		Redis `yaml:"redis"`
-		Gmail `yaml:"gmail"`
+		App       `yaml:"app"`
+		HTTP      `yaml:"http"`
+		Log       `yaml:"logger"`
+		PG        `yaml:"postgres"`
+		JWT       `yaml:"jwt"`
+		Redis     `yaml:"redis"`
+		Gmail     `yaml:"gmail"`
+		TwoFactor `yaml:"two_factor"`
+		OAuth     `yaml:"oauth"`
+		Storage   `yaml:"storage"`
+		Money     `yaml:"money"`
+		Fiscal    `yaml:"fiscal"`
+		PDF       `yaml:"pdf"`
 	}
 
 	// App -.
@@ -58,6 +64,61 @@ type (
 		Host      string `env-required:"true" yaml:"host" env:"SMTP_HOST"`
 		Port      string `env-required:"true" yaml:"port" env:"SMTP_PORT"`
 	}
+
+	// TwoFactor -.
+	TwoFactor struct {
+		Issuer       string   `yaml:"issuer" env:"TWO_FACTOR_ISSUER"`
+		EnforceRoles []string `yaml:"enforce_roles" env:"TWO_FACTOR_ENFORCE_ROLES" env-separator:","`
+	}
+
+	// OAuth -. A provider is enabled when its client id (bot token for Telegram) is set.
+	OAuth struct {
+		GoogleClientID     string `yaml:"google_client_id" env:"GOOGLE_CLIENT_ID"`
+		GoogleClientSecret string `yaml:"google_client_secret" env:"GOOGLE_CLIENT_SECRET"`
+		GoogleRedirectURL  string `yaml:"google_redirect_url" env:"GOOGLE_REDIRECT_URL"`
+		AppleClientID      string `yaml:"apple_client_id" env:"APPLE_CLIENT_ID"`
+		AppleTeamID        string `yaml:"apple_team_id" env:"APPLE_TEAM_ID"`
+		AppleKeyID         string `yaml:"apple_key_id" env:"APPLE_KEY_ID"`
+		ApplePrivateKey    string `yaml:"apple_private_key" env:"APPLE_PRIVATE_KEY"`
+		AppleRedirectURL   string `yaml:"apple_redirect_url" env:"APPLE_REDIRECT_URL"`
+		TelegramBotToken   string `yaml:"telegram_bot_token" env:"TELEGRAM_BOT_TOKEN"`
+	}
+
+	// Storage -. Driver is firebase, s3 or local.
+	Storage struct {
+		Driver              string   `env-required:"true" yaml:"driver" env:"STORAGE_DRIVER"`
+		MaxUploadSize       int64    `env-required:"true" yaml:"max_upload_size" env:"STORAGE_MAX_UPLOAD_SIZE"`
+		AllowedTypes        []string `yaml:"allowed_types" env:"STORAGE_ALLOWED_TYPES" env-separator:","`
+		FirebaseCredentials string   `yaml:"firebase_credentials" env:"FIREBASE_CREDENTIALS"`
+		FirebaseBucket      string   `yaml:"firebase_bucket" env:"FIREBASE_BUCKET"`
+		S3Endpoint          string   `yaml:"s3_endpoint" env:"S3_ENDPOINT"`
+		S3Region            string   `yaml:"s3_region" env:"S3_REGION"`
+		S3Bucket            string   `yaml:"s3_bucket" env:"S3_BUCKET"`
+		S3AccessKey         string   `yaml:"s3_access_key" env:"S3_ACCESS_KEY"`
+		S3SecretKey         string   `yaml:"s3_secret_key" env:"S3_SECRET_KEY"`
+		S3UseSSL            bool     `yaml:"s3_use_ssl" env:"S3_USE_SSL"`
+		S3PublicURL         string   `yaml:"s3_public_url" env:"S3_PUBLIC_URL"`
+		LocalDir            string   `yaml:"local_dir" env:"STORAGE_LOCAL_DIR"`
+		LocalBaseURL        string   `yaml:"local_base_url" env:"STORAGE_LOCAL_BASE_URL"`
+	}
+
+	// Money -. Prices are in Currency. JSONEncoding is number or string.
+	Money struct {
+		Currency     string `env-required:"true" yaml:"currency" env:"CURRENCY"`
+		JSONEncoding string `yaml:"json_encoding" env:"MONEY_JSON_ENCODING"`
+	}
+
+	// Fiscal -. Provider registers the receipts of delivered orders, only fake is built in.
+	Fiscal struct {
+		Provider   string `env-required:"true" yaml:"provider" env:"FISCAL_PROVIDER"`
+		TerminalID string `yaml:"terminal_id" env:"FISCAL_TERMINAL_ID"`
+		CheckURL   string `yaml:"check_url" env:"FISCAL_CHECK_URL"`
+	}
+
+	// PDF -. FontDir holds DejaVuSans.ttf and DejaVuSans-Bold.ttf for receipts.
+	PDF struct {
+		FontDir string `yaml:"font_dir" env:"PDF_FONT_DIR"`
+	}
 )
 
 // NewConfig returns app config.
@@ -17,8 +17,101 @@ var (
 	ErrorConflict       = "CONFLICT"
 	ErrorBadRequest     = "BAD_REQUEST"
 	ErrorDuplicateKey   = "DUPLICATE_KEY"
+	ErrorInvalidOtp     = "INVALID_OTP"
+	ErrorBranchBusy     = "BRANCH_BUSY"
 )
 
 var (
 	TokenExpireTime = 24 * time.Hour * 7 // 7 days
+
+	TwoFactorTokenExpireTime = 5 * time.Minute
+	TwoFactorMaxAttempts     = 5
+	TwoFactorLockout         = 15 * time.Minute // after TwoFactorMaxAttempts codes of a user
+	RecoveryCodesCount       = 10
+
+	OAuthStateExpireTime = 10 * time.Minute
+	TelegramAuthMaxAge   = 24 * time.Hour
+
+	APIKeyDefaultRateLimit = 60 // requests per minute
+	APIKeyMaxGracePeriod   = 7 * 24 * time.Hour
+	APIKeyLastUsedInterval = time.Minute
+
+	UploadOrphanGracePeriod = 24 * time.Hour
+	UploadSweepInterval     = time.Hour
+
+	PriceScheduleInterval = time.Minute
+
+	// A receipt the fiscal provider did not take is sent again after
+	// ReceiptRetryDelay, after ReceiptMaxAttempts it is failed.
+	ReceiptRegisterInterval = 30 * time.Second
+	ReceiptRetryDelay       = 5 * time.Minute
+	ReceiptMaxAttempts      = 10
+
+	// A product without prep time samples at a branch is expected to take
+	// DefaultPrepTime. The prep time averages the last PrepTimeSamples.
+	DefaultPrepTime = 10 * time.Minute
+	PrepTimeSamples = 20
+
+	// KitchenBumpedLimit is how many bumped orders a kitchen display lists for
+	// recall, KitchenKeepAlive how often an idle kitchen stream is pinged.
+	KitchenBumpedLimit = 20
+	KitchenKeepAlive   = 25 * time.Second
+
+	// Tickets are laid out for 80 mm paper. A printer agent waits up to
+	// PrintPollMaxWait for a job and has PrintJobLease to ack it, a job is
+	// failed after PrintJobMaxAttempts.
+	PrintTicketWidth    = 48
+	PrintPollWait       = 30 * time.Second
+	PrintPollMaxWait    = time.Minute
+	PrintJobLease       = time.Minute
+	PrintJobMaxAttempts = 5
+
+	// BranchStaffRoles only see the orders, kitchen and printers of the
+	// branches they are assigned to.
+	BranchStaffRoles = []string{"branch_manager", "branch_operator"}
+
+	// RestaurantAdminRole manages the branches, catalog and banners of one
+	// restaurant. DefaultRestaurantID owns what was there before restaurants
+	// and what is created or listed without naming one.
+	RestaurantAdminRole = "restaurant_admin"
+	DefaultRestaurantID = "00000000-0000-0000-0000-000000000001"
+
+	// DeliveryRadius is how far from a branch, in meters, it delivers.
+	DeliveryRadius = 10000.0
+
+	// A branch takes its max items per CapacityWindow, an order for a full
+	// branch is promised a window later per window of work ahead of it.
+	CapacityWindow = 15 * time.Minute
+
+	// Scheduled orders are for slots of SlotLength, booked ScheduleMinLead to
+	// ScheduleMaxAhead ahead. One is released to the kitchen its prep time,
+	// plus DeliveryLeadTime for a delivery, before its slot.
+	SlotLength              = 30 * time.Minute
+	ScheduleMinLead         = time.Hour
+	ScheduleMaxAhead        = 7 * 24 * time.Hour
+	DeliveryLeadTime        = 30 * time.Minute
+	ScheduleReleaseInterval = time.Minute
+
+	// LocalTime is the time zone of the branches, pricing rule windows are in it.
+	LocalTime = time.FixedZone("Asia/Tashkent", 5*60*60)
+
+	MenuCacheTTL = time.Hour
+
+	// DefaultLocale is the language catalog content is written in, the other
+	// locales are translations of it.
+	DefaultLocale = "uz"
+	Locales       = []string{"uz", "ru", "en"}
+	// LocaleFallbacks are tried after the locales a client asked for and
+	// before the default one.
+	LocaleFallbacks = map[string][]string{
+		"en": {"ru"},
+	}
+
+	// Allergens and DietaryTags are the values products can be tagged with.
+	Allergens = []string{
+		"gluten", "crustaceans", "eggs", "fish", "peanuts", "soy", "lactose",
+		"nuts", "celery", "mustard", "sesame", "sulphites", "lupin", "molluscs",
+	}
+	DietaryTags   = []string{"halal", "vegetarian", "vegan", "gluten_free", "lactose_free"}
+	MaxSpicyLevel = 3
 )
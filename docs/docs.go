@@ -15,14 +15,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/assign-courier/{order_id}": {
-            "post": {
+        "/api-key": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves an order by ID, finds the nearest available courier, and assigns the order",
+                "description": "Changes the name, scopes, branches, rate limit (requests per minute) and expiry of an active key.",
                 "consumes": [
                     "application/json"
                 ],
@@ -30,26 +30,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "courier"
+                    "api-key"
                 ],
-                "summary": "Assign a courier to an order",
+                "summary": "Update an API key",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Order ID",
-                        "name": "order_id",
-                        "in": "path",
-                        "required": true
+                        "description": "API key object",
+                        "name": "api_key",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.APIKey"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/entity.APIKey"
                         }
                     },
                     "400": {
@@ -57,21 +56,46 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/entity.ErrorResponse"
                         }
-                    },
-                    "403": {
-                        "description": "Forbidden",
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a key for a partner or service account. The key acts as user_id and may call what its scopes (roles) allow. A key with the printer scope only prints for its branch_ids. The key is only returned in this response.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "api-key"
+                ],
+                "summary": "Create an API key",
+                "parameters": [
+                    {
+                        "description": "API key object",
+                        "name": "api_key",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/entity.ErrorResponse"
+                            "$ref": "#/definitions/entity.APIKey"
                         }
-                    },
-                    "404": {
-                        "description": "Not Found",
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/entity.ErrorResponse"
+                            "$ref": "#/definitions/entity.APIKeySecret"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/entity.ErrorResponse"
                         }
@@ -79,9 +103,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/login": {
-            "post": {
-                "description": "Login",
+        "/api-key/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of API keys",
                 "consumes": [
                     "application/json"
                 ],
@@ -89,25 +118,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "api-key"
                 ],
-                "summary": "Login",
+                "summary": "Get a list of API keys",
                 "parameters": [
                     {
-                        "description": "User",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/entity.LoginRequest"
-                        }
+                        "type": "number",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "user_id",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "is_active",
+                        "name": "is_active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "search by name",
+                        "name": "search",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.SuccessResponse"
+                            "$ref": "#/definitions/entity.APIKeyList"
                         }
                     },
                     "400": {
@@ -119,14 +171,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/logout": {
-            "post": {
+        "/api-key/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Logout",
+                "description": "Get an API key by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -134,14 +186,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "api-key"
+                ],
+                "summary": "Get an API key by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API key ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Logout",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.SuccessResponse"
+                            "$ref": "#/definitions/entity.APIKey"
                         }
                     },
                     "400": {
@@ -151,11 +212,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/auth/register": {
-            "post": {
-                "description": "Register",
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deactivates the key and removes its scopes. The key stays listed for auditing.",
                 "consumes": [
                     "application/json"
                 ],
@@ -163,25 +227,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "api-key"
                 ],
-                "summary": "Register",
+                "summary": "Revoke an API key",
                 "parameters": [
                     {
-                        "description": "User",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/entity.RegisterRequest"
-                        }
+                        "type": "string",
+                        "description": "API key ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.User"
+                            "$ref": "#/definitions/entity.SuccessResponse"
                         }
                     },
                     "400": {
@@ -193,9 +255,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/verify-email": {
+        "/api-key/{id}/rotate": {
             "post": {
-                "description": "Register",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Issues a new key with the same prefix. The old key keeps working for grace_minutes (at most 7 days) so the partner can switch without downtime.",
                 "consumes": [
                     "application/json"
                 ],
@@ -203,17 +270,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "api-key"
                 ],
-                "summary": "Register",
+                "summary": "Rotate an API key",
                 "parameters": [
                     {
-                        "description": "User",
-                        "name": "body",
+                        "type": "string",
+                        "description": "API key ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rotate request",
+                        "name": "rotate",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/entity.VerifyEmail"
+                            "$ref": "#/definitions/entity.APIKeyRotateRequest"
                         }
                     }
                 ],
@@ -221,7 +294,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.User"
+                            "$ref": "#/definitions/entity.APIKeySecret"
                         }
                     },
                     "400": {
@@ -233,14 +306,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/banner": {
-            "put": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Update a banner",
+        "/auth/2fa/setup": {
+            "post": {
+                "description": "Generates a TOTP secret for a user whose role requires two factor authentication but who has not enrolled yet",
                 "consumes": [
                     "application/json"
                 ],
@@ -248,17 +316,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "banner"
+                    "auth"
                 ],
-                "summary": "Update a banner",
+                "summary": "Start two factor enrollment during login",
                 "parameters": [
                     {
-                        "description": "Banner object",
-                        "name": "banner",
+                        "description": "Two factor token from login",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/entity.Banner"
+                            "$ref": "#/definitions/entity.TwoFactorSetupRequest"
                         }
                     }
                 ],
@@ -266,7 +334,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.Banner"
+                            "$ref": "#/definitions/entity.TwoFactorSetupResponse"
                         }
                     },
                     "400": {
@@ -276,52 +344,37 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/2fa/verify": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Upload a banner",
+                "description": "Checks a TOTP or recovery code and issues the access token. The first successful code after enrollment enables two factor authentication and returns recovery codes.",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "banner"
+                    "auth"
                 ],
-                "summary": "Upload a banner",
-                "operationId": "create_banner_pic_file",
+                "summary": "Complete login with a second factor",
                 "parameters": [
                     {
-                        "type": "array",
-                        "items": {
-                            "type": "file"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "File to upload",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "description": "Banner object",
-                        "name": "banner",
+                        "description": "Code",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/entity.Banner"
+                            "$ref": "#/definitions/entity.TwoFactorVerifyRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success Request",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.MultipleFileUploadResponse"
+                            "$ref": "#/definitions/entity.SuccessResponse"
                         }
                     },
                     "400": {
@@ -330,8 +383,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/entity.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Server error",
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
                             "$ref": "#/definitions/entity.ErrorResponse"
                         }
@@ -339,14 +392,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/banner/list": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get a list of banners",
+        "/auth/login": {
+            "post": {
+                "description": "Login",
                 "consumes": [
                     "application/json"
                 ],
@@ -354,36 +402,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "banner"
+                    "auth"
                 ],
-                "summary": "Get a list of banners",
+                "summary": "Login",
                 "parameters": [
                     {
-                        "type": "number",
-                        "description": "page",
-                        "name": "page",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "number",
-                        "description": "limit",
-                        "name": "limit",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "search",
-                        "name": "search",
-                        "in": "query"
+                        "description": "User",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.LoginRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.BannerList"
+                            "$ref": "#/definitions/entity.SuccessResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/entity.TwoFactorChallenge"
                         }
                     },
                     "400": {
@@ -395,14 +438,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/banner/{id}": {
-            "get": {
-                "security": [
+        "/auth/logout": {
+            "post": {
+                "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a banner by ID",
+                "description": "Logout",
                 "consumes": [
                     "application/json"
                 ],
@@ -410,23 +453,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "banner"
-                ],
-                "summary": "Get a banner by ID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Banner ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "auth"
                 ],
+                "summary": "Logout",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.Banner"
+                            "$ref": "#/definitions/entity.SuccessResponse"
                         }
                     },
                     "400": {
@@ -436,14 +470,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Delete a banner",
+            }
+        },
+        "/auth/oauth/telegram": {
+            "post": {
+                "description": "Checks the signature of the data sent by the Telegram login widget and opens a session",
                 "consumes": [
                     "application/json"
                 ],
@@ -451,16 +482,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "banner"
+                    "auth"
                 ],
-                "summary": "Delete a banner",
+                "summary": "Login with the Telegram login widget",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Banner ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Widget data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.TelegramLoginRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -470,6 +503,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/entity.SuccessResponse"
                         }
                     },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/entity.TwoFactorChallenge"
+                        }
+                    },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
@@ -479,14 +518,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/branch": {
-            "put": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Update a branch",
+        "/auth/oauth/{provider}": {
+            "get": {
+                "description": "Returns the provider authorization URL (authorization code flow with PKCE). The client opens it and the provider redirects back to the callback.",
                 "consumes": [
                     "application/json"
                 ],
@@ -494,25 +528,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "branch"
+                    "auth"
                 ],
-                "summary": "Update a branch",
+                "summary": "Start social login",
                 "parameters": [
                     {
-                        "description": "Branch object",
-                        "name": "branch",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/entity.Branch"
-                        }
+                        "type": "string",
+                        "description": "google or apple",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "web or mobile",
+                        "name": "platform",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.Branch"
+                            "$ref": "#/definitions/entity.OAuthStartResponse"
                         }
                     },
                     "400": {
@@ -520,46 +558,9 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/entity.ErrorResponse"
                         }
-                    }
-                }
-            },
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Create a new branch",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "branch"
-                ],
-                "summary": "Create a new branch",
-                "parameters": [
-                    {
-                        "description": "Branch object",
-                        "name": "branch",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/entity.Branch"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/entity.Branch"
-                        }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/entity.ErrorResponse"
                         }
@@ -567,14 +568,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/branch/list": {
+        "/auth/oauth/{provider}/callback": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get a list of branchs",
+                "description": "Exchanges the authorization code, links the external identity to a user (by verified email) or creates a new one and opens a session. Apple posts the same parameters as a form.",
                 "consumes": [
                     "application/json"
                 ],
@@ -582,36 +578,43 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "branch"
+                    "auth"
                 ],
-                "summary": "Get a list of branchs",
+                "summary": "Finish social login",
                 "parameters": [
                     {
-                        "type": "number",
-                        "description": "page",
-                        "name": "page",
-                        "in": "query",
+                        "type": "string",
+                        "description": "google or apple",
+                        "name": "provider",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "number",
-                        "description": "limit",
-                        "name": "limit",
+                        "type": "string",
+                        "description": "Authorization code",
+                        "name": "code",
                         "in": "query",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "search",
-                        "name": "search",
-                        "in": "query"
+                        "description": "State returned by the start endpoint",
+                        "name": "state",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.BranchList"
+                            "$ref": "#/definitions/entity.SuccessResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/entity.TwoFactorChallenge"
                         }
                     },
                     "400": {
@@ -623,14 +626,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/branch/{id}": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get a branch by ID",
+        "/auth/register": {
+            "post": {
+                "description": "Register",
                 "consumes": [
                     "application/json"
                 ],
@@ -638,23 +636,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "branch"
+                    "auth"
                 ],
-                "summary": "Get a branch by ID",
+                "summary": "Register",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Branch ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "User",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.RegisterRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.Branch"
+                            "$ref": "#/definitions/entity.User"
                         }
                     },
                     "400": {
@@ -664,14 +664,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Delete a branch",
+            }
+        },
+        "/auth/verify-email": {
+            "post": {
+                "description": "Register",
                 "consumes": [
                     "application/json"
                 ],
@@ -679,23 +676,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "branch"
+                    "auth"
                 ],
-                "summary": "Delete a branch",
+                "summary": "Register",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Branch ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "User",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.VerifyEmail"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.SuccessResponse"
+                            "$ref": "#/definitions/entity.User"
                         }
                     },
                     "400": {
@@ -707,14 +706,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/category": {
+        "/banner": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update a category",
+                "description": "Update a banner",
                 "consumes": [
                     "application/json"
                 ],
@@ -722,17 +721,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "category"
+                    "banner"
                 ],
-                "summary": "Update a category",
+                "summary": "Update a banner",
                 "parameters": [
                     {
-                        "description": "Category object",
-                        "name": "category",
+                        "description": "Banner object",
+                        "name": "banner",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/entity.Category"
+                            "$ref": "#/definitions/entity.Banner"
                         }
                     }
                 ],
@@ -740,7 +739,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.Category"
+                            "$ref": "#/definitions/entity.Banner"
                         }
                     },
                     "400": {
@@ -757,33 +756,45 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new category",
+                "description": "Upload a banner",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "category"
+                    "banner"
                 ],
-                "summary": "Create a new category",
+                "summary": "Upload a banner",
+                "operationId": "create_banner_pic_file",
                 "parameters": [
                     {
-                        "description": "Category object",
-                        "name": "category",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/entity.Category"
-                        }
+                        "type": "file",
+                        "description": "Banner image",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Banner title",
+                        "name": "title",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Restaurant ID, the default restaurant when empty",
+                        "name": "restaurant_id",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "Success Request",
                         "schema": {
-                            "$ref": "#/definitions/entity.Category"
+                            "$ref": "#/definitions/entity.Banner"
                         }
                     },
                     "400": {
@@ -791,18 +802,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/entity.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/category/list": {
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/banner/list": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a list of categorys",
+                "description": "Get a list of banners",
                 "consumes": [
                     "application/json"
                 ],
@@ -810,9 +827,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "category"
+                    "banner"
                 ],
-                "summary": "Get a list of categorys",
+                "summary": "Get a list of banners",
                 "parameters": [
                     {
                         "type": "number",
@@ -833,13 +850,25 @@ const docTemplate = `{
                         "description": "search",
                         "name": "search",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Restaurant ID, the default restaurant when empty",
+                        "name": "restaurant_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "locale: uz, ru or en, overrides Accept-Language",
+                        "name": "lang",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.CategoryList"
+                            "$ref": "#/definitions/entity.BannerList"
                         }
                     },
                     "400": {
@@ -851,14 +880,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/category/{id}": {
+        "/banner/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a category by ID",
+                "description": "Get a banner by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -866,23 +895,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "category"
+                    "banner"
                 ],
-                "summary": "Get a category by ID",
+                "summary": "Get a banner by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Category ID",
+                        "description": "Banner ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "locale: uz, ru or en, overrides Accept-Language",
+                        "name": "lang",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.Category"
+                            "$ref": "#/definitions/entity.Banner"
                         }
                     },
                     "400": {
@@ -899,7 +934,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a category",
+                "description": "Delete a banner",
                 "consumes": [
                     "application/json"
                 ],
@@ -907,13 +942,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "category"
+                    "banner"
                 ],
-                "summary": "Delete a category",
+                "summary": "Delete a banner",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Category ID",
+                        "description": "Banner ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -935,43 +970,40 @@ const docTemplate = `{
                 }
             }
         },
-        "/firebase": {
-            "post": {
+        "/branch": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Upload Multiple Files",
+                "description": "Update a branch",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Upload File"
+                    "branch"
                 ],
-                "summary": "Upload Multiple Files",
-                "operationId": "upload_multiple_files",
+                "summary": "Update a branch",
                 "parameters": [
                     {
-                        "type": "array",
-                        "items": {
-                            "type": "file"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "File to upload",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
+                        "description": "Branch object",
+                        "name": "branch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.Branch"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success Request",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.MultipleFileUploadResponse"
+                            "$ref": "#/definitions/entity.Branch"
                         }
                     },
                     "400": {
@@ -979,24 +1011,16 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/entity.ErrorResponse"
                         }
-                    },
-                    "500": {
-                        "description": "Server error",
-                        "schema": {
-                            "$ref": "#/definitions/entity.ErrorResponse"
-                        }
                     }
                 }
-            }
-        },
-        "/firebase/{id}": {
-            "delete": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete File",
+                "description": "Create a new branch of the restaurant, the default one when it is empty.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1004,24 +1028,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Upload File"
+                    "branch"
                 ],
-                "summary": "Delete File",
-                "operationId": "delete_file",
+                "summary": "Create a new branch",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "ID of the file to delete",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
+                        "description": "Branch object",
+                        "name": "branch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.Branch"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Success Request",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/entity.Branch"
                         }
                     },
                     "400": {
@@ -1029,24 +1054,18 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/entity.ErrorResponse"
                         }
-                    },
-                    "500": {
-                        "description": "Server error",
-                        "schema": {
-                            "$ref": "#/definitions/entity.ErrorResponse"
-                        }
                     }
                 }
             }
         },
-        "/notification": {
+        "/branch-staff": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update a notification",
+                "description": "Superadmin only. Makes the user a branch manager or operator of the branches, replacing the\nbranches they had. Admins and couriers can not be made staff. The user is logged out so the role\napplies from their next login.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1054,17 +1073,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "notification"
+                    "branch-staff"
                 ],
-                "summary": "Update a notification",
+                "summary": "Assign a user to branches",
                 "parameters": [
                     {
-                        "description": "Notification object",
-                        "name": "notification",
+                        "description": "Branch staff",
+                        "name": "staff",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/entity.Notification"
+                            "$ref": "#/definitions/entity.BranchStaff"
                         }
                     }
                 ],
@@ -1072,7 +1091,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.Notification"
+                            "$ref": "#/definitions/entity.BranchStaff"
                         }
                     },
                     "400": {
@@ -1080,46 +1099,9 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/entity.ErrorResponse"
                         }
-                    }
-                }
-            },
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Create a notification and send an email to user",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "notification"
-                ],
-                "summary": "Create a new notification",
-                "parameters": [
-                    {
-                        "description": "Notification data",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/entity.Notification"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/entity.Notification"
-                        }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/entity.ErrorResponse"
                         }
@@ -1127,14 +1109,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/notification/list": {
+        "/branch-staff/list": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a list of notifications",
+                "description": "Superadmin only.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1142,9 +1124,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "notification"
+                    "branch-staff"
                 ],
-                "summary": "Get a list of notifications",
+                "summary": "Get a list of branch staff",
                 "parameters": [
                     {
                         "type": "number",
@@ -1162,8 +1144,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "user_id",
-                        "name": "user_id",
+                        "description": "Only the staff of this branch",
+                        "name": "branch_id",
                         "in": "query"
                     }
                 ],
@@ -1171,7 +1153,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.NotificationList"
+                            "$ref": "#/definitions/entity.BranchStaffList"
                         }
                     },
                     "400": {
@@ -1183,14 +1165,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/notification/status": {
-            "put": {
+        "/branch-staff/{user_id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update a notification's status",
+                "description": "Superadmin only.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1198,25 +1180,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "notification"
+                    "branch-staff"
                 ],
-                "summary": "Update a notification status",
+                "summary": "Get the branches of a staff member",
                 "parameters": [
                     {
-                        "description": "Notification object",
-                        "name": "notification",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/entity.Notification"
-                        }
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.Notification"
+                            "$ref": "#/definitions/entity.BranchStaff"
                         }
                     },
                     "400": {
@@ -1224,18 +1204,22 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/entity.ErrorResponse"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
                     }
                 }
-            }
-        },
-        "/notification/update-status": {
-            "put": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update status notification by ID",
+                "description": "Superadmin only. The user becomes a plain user again and is logged out.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1243,25 +1227,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "notification"
+                    "branch-staff"
                 ],
-                "summary": "Update status a notification by ID",
+                "summary": "Remove a staff member from their branches",
                 "parameters": [
                     {
-                        "description": "Notification object",
-                        "name": "notification",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/entity.Notification"
-                        }
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.Notification"
+                            "$ref": "#/definitions/entity.SuccessResponse"
                         }
                     },
                     "400": {
@@ -1269,18 +1251,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/entity.ErrorResponse"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/notification/{id}": {
-            "get": {
+        "/branch/capacity": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a notification by ID",
+                "description": "A zero limit is no limit. Active orders are confirmed or preparing ones, items are the cooked lines of\nthe orders taken in the last 15 minutes. A new order for a full branch is promised a later ready_eta\nwith extend_eta, goes to the next nearest branch of the restaurant with reroute, or is turned down\nwith reject. max_orders_per_slot limits the scheduled orders of a delivery slot. Branch managers may\nset the capacity of their own branches.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1288,23 +1276,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "notification"
+                    "branch"
                 ],
-                "summary": "Get a notification by ID",
+                "summary": "Set the capacity of a branch",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Notification ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Capacity",
+                        "name": "capacity",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.BranchCapacity"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.Notification"
+                            "$ref": "#/definitions/entity.BranchCapacity"
                         }
                     },
                     "400": {
@@ -1312,44 +1302,9 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/entity.ErrorResponse"
                         }
-                    }
-                }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Delete a notification",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "notification"
-                ],
-                "summary": "Delete a notification",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Notification ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/entity.SuccessResponse"
-                        }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/entity.ErrorResponse"
                         }
@@ -1357,14 +1312,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/order": {
+        "/branch/hours": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update a order",
+                "description": "Replaces the week of the branch, times are local HH:MM and closes_at may be 24:00. A branch without\nhours is open around the clock, one with hours is closed on the weekdays it has none for. Scheduled\norders are only taken for slots within the opening hours. Branch managers may set the hours of their\nown branches.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1372,17 +1327,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "order"
+                    "branch"
                 ],
-                "summary": "Update a order",
+                "summary": "Set the opening hours of a branch",
                 "parameters": [
                     {
-                        "description": "Order object",
-                        "name": "order",
+                        "description": "Opening hours",
+                        "name": "hours",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/entity.Order"
+                            "$ref": "#/definitions/entity.BranchHoursList"
                         }
                     }
                 ],
@@ -1390,7 +1345,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.Order"
+                            "$ref": "#/definitions/entity.BranchHoursList"
                         }
                     },
                     "400": {
@@ -1398,16 +1353,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/entity.ErrorResponse"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/branch/list": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new order",
+                "description": "Get a list of branchs",
                 "consumes": [
                     "application/json"
                 ],
@@ -1415,25 +1378,42 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "order"
+                    "branch"
                 ],
-                "summary": "Create a new order",
+                "summary": "Get a list of branchs",
                 "parameters": [
                     {
-                        "description": "Order object",
-                        "name": "order",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/entity.Order"
-                        }
+                        "type": "number",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "search",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Restaurant ID, the default restaurant when empty",
+                        "name": "restaurant_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.Order"
+                            "$ref": "#/definitions/entity.BranchList"
                         }
                     },
                     "400": {
@@ -1445,14 +1425,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/order/bybranch": {
+        "/branch/load/list": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a list of branch orders",
+                "description": "Active orders and items of the last 15 minutes against the capacity of every branch, and the delay\nin minutes a new order would get. Branch staff see their branches, restaurant admins those of their\nrestaurant.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1460,20 +1440,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "order"
+                    "branch"
                 ],
-                "summary": "Get a list of branch orders",
+                "summary": "Get the current load of branches",
                 "parameters": [
                     {
                         "type": "number",
-                        "description": "Page number",
+                        "description": "page",
                         "name": "page",
                         "in": "query",
                         "required": true
                     },
                     {
                         "type": "number",
-                        "description": "Number of results per page",
+                        "description": "limit",
                         "name": "limit",
                         "in": "query",
                         "required": true
@@ -1482,29 +1462,20 @@ const docTemplate = `{
                         "type": "string",
                         "description": "Branch ID",
                         "name": "branch_id",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Order status",
-                        "name": "status",
-                        "in": "query",
-                        "required": true
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Delivery status",
-                        "name": "delivery_status",
-                        "in": "query",
-                        "required": true
+                        "description": "Restaurant ID",
+                        "name": "restaurant_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.OrderList"
+                            "$ref": "#/definitions/entity.BranchLoadList"
                         }
                     },
                     "400": {
@@ -1512,18 +1483,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/entity.ErrorResponse"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/order/list": {
+        "/branch/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a list of orders",
+                "description": "Get a branch by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1531,29 +1508,56 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "order"
+                    "branch"
                 ],
-                "summary": "Get a list of orders",
+                "summary": "Get a branch by ID",
                 "parameters": [
                     {
-                        "type": "number",
-                        "description": "page",
-                        "name": "page",
-                        "in": "query",
+                        "type": "string",
+                        "description": "Branch ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Branch"
+                        }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
                     {
-                        "type": "number",
-                        "description": "limit",
-                        "name": "limit",
-                        "in": "query",
-                        "required": true
-                    },
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a branch",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "branch"
+                ],
+                "summary": "Delete a branch",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "branch_id",
-                        "name": "branch_id",
-                        "in": "query",
+                        "description": "Branch ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -1561,7 +1565,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.OrderList"
+                            "$ref": "#/definitions/entity.SuccessResponse"
                         }
                     },
                     "400": {
@@ -1573,14 +1577,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/order/{id}": {
+        "/branch/{id}/hours": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a order by ID",
+                "description": "By weekday, 0 is Sunday. Empty when the branch is open around the clock.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1588,13 +1592,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "order"
+                    "branch"
                 ],
-                "summary": "Get a order by ID",
+                "summary": "Get the opening hours of a branch",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Order ID",
+                        "description": "Branch ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1604,7 +1608,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.Order"
+                            "$ref": "#/definitions/entity.BranchHoursList"
                         }
                     },
                     "400": {
@@ -1614,14 +1618,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Delete a order",
+            }
+        },
+        "/branch/{id}/slots": {
+            "get": {
+                "description": "The 30 minute slots of a day a scheduled order can still be placed for at the branch, within its\nopening hours, from an hour up to a week ahead. Full slots are left out, remaining is set when the\nbranch limits the orders per slot. Pass a slot's starts_at as scheduled_at when creating the order.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1629,23 +1630,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "order"
+                    "branch"
                 ],
-                "summary": "Delete a order",
+                "summary": "Get the delivery slots of a branch",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Order ID",
+                        "description": "Branch ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Local date, YYYY-MM-DD, today when empty",
+                        "name": "date",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.SuccessResponse"
+                            "$ref": "#/definitions/entity.DeliverySlotList"
                         }
                     },
                     "400": {
@@ -1653,18 +1660,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/entity.ErrorResponse"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/product": {
+        "/category": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update a product",
+                "description": "Update a category",
                 "consumes": [
                     "application/json"
                 ],
@@ -1672,17 +1685,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "product"
+                    "category"
                 ],
-                "summary": "Update a product",
+                "summary": "Update a category",
                 "parameters": [
                     {
-                        "description": "Product object",
-                        "name": "product",
+                        "description": "Category object",
+                        "name": "category",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/entity.Product"
+                            "$ref": "#/definitions/entity.Category"
                         }
                     }
                 ],
@@ -1690,7 +1703,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.Product"
+                            "$ref": "#/definitions/entity.Category"
                         }
                     },
                     "400": {
@@ -1707,7 +1720,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new product",
+                "description": "Create a new category. Without a restaurant it is in the one of its parent, or the default one.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1715,17 +1728,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "product"
+                    "category"
                 ],
-                "summary": "Create a new product",
+                "summary": "Create a new category",
                 "parameters": [
                     {
-                        "description": "Product object",
-                        "name": "product",
+                        "description": "Category object",
+                        "name": "category",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/entity.Product"
+                            "$ref": "#/definitions/entity.Category"
                         }
                     }
                 ],
@@ -1733,7 +1746,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/entity.Product"
+                            "$ref": "#/definitions/entity.Category"
                         }
                     },
                     "400": {
@@ -1745,14 +1758,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/product/list": {
+        "/category/list": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a list of products",
+                "description": "Get a list of categorys",
                 "consumes": [
                     "application/json"
                 ],
@@ -1760,9 +1773,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "product"
+                    "category"
                 ],
-                "summary": "Get a list of products",
+                "summary": "Get a list of categorys",
                 "parameters": [
                     {
                         "type": "number",
@@ -1783,13 +1796,25 @@ const docTemplate = `{
                         "description": "search",
                         "name": "search",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Restaurant ID, the default restaurant when empty",
+                        "name": "restaurant_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "locale: uz, ru or en, overrides Accept-Language",
+                        "name": "lang",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.ProductList"
+                            "$ref": "#/definitions/entity.CategoryList"
                         }
                     },
                     "400": {
@@ -1801,14 +1826,59 @@ const docTemplate = `{
                 }
             }
         },
-        "/product/upload/{id}": {
+        "/category/sort": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Upload Multiple Files",
+                "description": "Set the display order of categories among their siblings",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "category"
+                ],
+                "summary": "Set the display order of categories",
+                "parameters": [
+                    {
+                        "description": "Positions",
+                        "name": "sort",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.SortRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/category/upload/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload a category image",
                 "consumes": [
                     "multipart/form-data"
                 ],
@@ -1816,25 +1886,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "product"
+                    "category"
                 ],
-                "summary": "Upload Multiple Files",
-                "operationId": "upload_product_pic_file",
+                "summary": "Upload a category image",
+                "operationId": "upload_category_pic_file",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Product ID",
+                        "description": "Category ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "array",
-                        "items": {
-                            "type": "file"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "File to upload",
+                        "type": "file",
+                        "description": "Category image",
                         "name": "file",
                         "in": "formData",
                         "required": true
@@ -1844,7 +1910,7 @@ const docTemplate = `{
                     "200": {
                         "description": "Success Request",
                         "schema": {
-                            "$ref": "#/definitions/entity.MultipleFileUploadResponse"
+                            "$ref": "#/definitions/entity.ImageVariants"
                         }
                     },
                     "400": {
@@ -1862,14 +1928,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/product/{id}": {
+        "/category/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a product by ID",
+                "description": "Get a category by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1877,23 +1943,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "product"
+                    "category"
                 ],
-                "summary": "Get a product by ID",
+                "summary": "Get a category by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Product ID",
+                        "description": "Category ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "locale: uz, ru or en, overrides Accept-Language",
+                        "name": "lang",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.Product"
+                            "$ref": "#/definitions/entity.Category"
                         }
                     },
                     "400": {
@@ -1910,7 +1982,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a product",
+                "description": "Delete a category",
                 "consumes": [
                     "application/json"
                 ],
@@ -1918,13 +1990,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "product"
+                    "category"
                 ],
-                "summary": "Delete a product",
+                "summary": "Delete a category",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Product ID",
+                        "description": "Category ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1946,14 +2018,118 @@ const docTemplate = `{
                 }
             }
         },
-        "/report": {
+        "/firebase": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload Multiple Files",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Upload File"
+                ],
+                "summary": "Upload Multiple Files",
+                "operationId": "upload_multiple_files",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "file"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "File to upload",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.MultipleFileUploadResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/firebase/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete File",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Upload File"
+                ],
+                "summary": "Delete File",
+                "operationId": "delete_file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the file to delete",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Success Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/kitchen-station": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update a report",
+                "description": "Update a kitchen station",
                 "consumes": [
                     "application/json"
                 ],
@@ -1961,17 +2137,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "report"
+                    "kitchen-station"
                 ],
-                "summary": "Update a report",
+                "summary": "Update a kitchen station",
                 "parameters": [
                     {
-                        "description": "Report object",
-                        "name": "report",
+                        "description": "Kitchen station",
+                        "name": "station",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/entity.Report"
+                            "$ref": "#/definitions/entity.KitchenStation"
                         }
                     }
                 ],
@@ -1979,7 +2155,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.Report"
+                            "$ref": "#/definitions/entity.KitchenStation"
                         }
                     },
                     "400": {
@@ -1996,7 +2172,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new report",
+                "description": "A station such as grill, fryer or drinks. Categories route their products to it with kitchen_station_id.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2004,17 +2180,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "report"
+                    "kitchen-station"
                 ],
-                "summary": "Create a new report",
+                "summary": "Create a kitchen station",
                 "parameters": [
                     {
-                        "description": "Report object",
-                        "name": "report",
+                        "description": "Kitchen station",
+                        "name": "station",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/entity.Report"
+                            "$ref": "#/definitions/entity.KitchenStation"
                         }
                     }
                 ],
@@ -2022,7 +2198,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/entity.Report"
+                            "$ref": "#/definitions/entity.KitchenStation"
                         }
                     },
                     "400": {
@@ -2034,14 +2210,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/report/list": {
+        "/kitchen-station/list": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a list of reports",
+                "description": "Get a list of kitchen stations",
                 "consumes": [
                     "application/json"
                 ],
@@ -2049,9 +2225,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "report"
+                    "kitchen-station"
                 ],
-                "summary": "Get a list of reports",
+                "summary": "Get a list of kitchen stations",
                 "parameters": [
                     {
                         "type": "number",
@@ -2066,19 +2242,13 @@ const docTemplate = `{
                         "name": "limit",
                         "in": "query",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "search",
-                        "name": "search",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.ReportList"
+                            "$ref": "#/definitions/entity.KitchenStationList"
                         }
                     },
                     "400": {
@@ -2090,14 +2260,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/report/{id}": {
+        "/kitchen-station/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a report by ID",
+                "description": "Get a kitchen station by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -2105,13 +2275,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "report"
+                    "kitchen-station"
                 ],
-                "summary": "Get a report by ID",
+                "summary": "Get a kitchen station by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Report ID",
+                        "description": "Kitchen station ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2121,7 +2291,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.Report"
+                            "$ref": "#/definitions/entity.KitchenStation"
                         }
                     },
                     "400": {
@@ -2138,7 +2308,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a report",
+                "description": "Categories of the station are left without one.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2146,13 +2316,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "report"
+                    "kitchen-station"
                 ],
-                "summary": "Delete a report",
+                "summary": "Delete a kitchen station",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Report ID",
+                        "description": "Kitchen station ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2174,14 +2344,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/session": {
+        "/kitchen/item/status": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update a session",
+                "description": "queued, cooking or ready, moving a line back recalls it. Cooking the first line moves the order\nto preparing. The time from cooking to ready updates the prep time of the product at the branch,\nwhich the ready_eta of orders is estimated from.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2189,25 +2359,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "session"
+                    "kitchen"
                 ],
-                "summary": "Update a session",
+                "summary": "Move an order line in the kitchen",
                 "parameters": [
                     {
-                        "description": "Session object",
-                        "name": "session",
+                        "description": "Item status",
+                        "name": "status",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/entity.Session"
+                            "$ref": "#/definitions/entity.KitchenItemStatus"
                         }
+                    },
+                    {
+                        "enum": [
+                            "uz",
+                            "ru",
+                            "en"
+                        ],
+                        "type": "string",
+                        "description": "Language",
+                        "name": "lang",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.Session"
+                            "$ref": "#/definitions/entity.KitchenTicket"
                         }
                     },
                     "400": {
@@ -2215,18 +2396,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/entity.ErrorResponse"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/session/list": {
-            "get": {
+        "/kitchen/order/{id}/bump": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a list of users",
+                "description": "Marks the lines of the order ready and takes it off the display.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2234,28 +2421,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "session"
+                    "kitchen"
                 ],
-                "summary": "Get a list of users",
+                "summary": "Bump an order off the kitchen display",
                 "parameters": [
                     {
-                        "type": "number",
-                        "description": "page",
-                        "name": "page",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "number",
-                        "description": "limit",
-                        "name": "limit",
-                        "in": "query",
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
+                        "enum": [
+                            "uz",
+                            "ru",
+                            "en"
+                        ],
                         "type": "string",
-                        "description": "user_id",
-                        "name": "user_id",
+                        "description": "Language",
+                        "name": "lang",
                         "in": "query"
                     }
                 ],
@@ -2263,7 +2448,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.SessionList"
+                            "$ref": "#/definitions/entity.KitchenTicket"
                         }
                     },
                     "400": {
@@ -2271,18 +2456,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/entity.ErrorResponse"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/session/{id}": {
-            "get": {
+        "/kitchen/order/{id}/recall": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a session by ID",
+                "description": "Only orders that are still open can be recalled. Their lines keep their status.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2290,23 +2481,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "session"
+                    "kitchen"
                 ],
-                "summary": "Get a session by ID",
+                "summary": "Recall a bumped order to the kitchen display",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Session ID",
+                        "description": "Order ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "uz",
+                            "ru",
+                            "en"
+                        ],
+                        "type": "string",
+                        "description": "Language",
+                        "name": "lang",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.Session"
+                            "$ref": "#/definitions/entity.KitchenTicket"
                         }
                     },
                     "400": {
@@ -2314,40 +2516,62 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/entity.ErrorResponse"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/kitchen/stream": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a session",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Server-sent events. A snapshot event has the open orders like /kitchen/tickets, it is sent\nfirst and again when changes may have been missed. A ticket event has an order that was added or\nchanged, a removed event the order_id of one that was bumped, cancelled or handed over.",
                 "produces": [
-                    "application/json"
+                    "text/event-stream"
                 ],
                 "tags": [
-                    "session"
+                    "kitchen"
                 ],
-                "summary": "Delete a session",
+                "summary": "Stream the kitchen display of a branch",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Session ID",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Branch ID",
+                        "name": "branch_id",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only the lines of this kitchen station",
+                        "name": "station_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "uz",
+                            "ru",
+                            "en"
+                        ],
+                        "type": "string",
+                        "description": "Language",
+                        "name": "lang",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.SuccessResponse"
+                            "$ref": "#/definitions/entity.KitchenTicketList"
                         }
                     },
                     "400": {
@@ -2359,14 +2583,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user": {
-            "put": {
+        "/kitchen/tickets": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update a user",
+                "description": "Open orders of the branch oldest first with the lines the kitchen makes. Bundle lines are\nleft out, their components are listed. With bumped the latest bumped orders are listed for recall.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2374,68 +2598,46 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "kitchen"
                 ],
-                "summary": "Update a user",
+                "summary": "Get the kitchen display of a branch",
                 "parameters": [
                     {
-                        "description": "User object",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/entity.User"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/entity.User"
-                        }
+                        "type": "string",
+                        "description": "Branch ID",
+                        "name": "branch_id",
+                        "in": "query",
+                        "required": true
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/entity.ErrorResponse"
-                        }
-                    }
-                }
-            },
-            "post": {
-                "security": [
                     {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Create a new user",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "user"
-                ],
-                "summary": "Create a new user",
-                "parameters": [
+                        "type": "string",
+                        "description": "Only the lines of this kitchen station",
+                        "name": "station_id",
+                        "in": "query"
+                    },
                     {
-                        "description": "User object",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/entity.User"
-                        }
+                        "type": "boolean",
+                        "description": "List bumped orders",
+                        "name": "bumped",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "uz",
+                            "ru",
+                            "en"
+                        ],
+                        "type": "string",
+                        "description": "Language",
+                        "name": "lang",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.User"
+                            "$ref": "#/definitions/entity.KitchenTicketList"
                         }
                     },
                     "400": {
@@ -2447,14 +2649,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/list": {
+        "/menu": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get a list of users",
+                "description": "The tree of active, visible categories of a restaurant with their products in display order, and its banners.\nThe restaurant is the one of the branch when only a branch is given, else the default one.\nWith a branch, products sold out there are marked unavailable.\nWith changed_since, only what changed after that catalog version is returned as entity.MenuChanges.\nResponses carry an ETag and the catalog version in X-Catalog-Version, send If-None-Match to get 304 when nothing changed.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2462,38 +2659,69 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "menu"
                 ],
-                "summary": "Get a list of users",
+                "summary": "Get the menu",
                 "parameters": [
                     {
-                        "type": "number",
-                        "description": "page",
-                        "name": "page",
-                        "in": "query",
-                        "required": true
+                        "type": "string",
+                        "description": "Restaurant ID",
+                        "name": "restaurant_id",
+                        "in": "query"
                     },
                     {
-                        "type": "number",
-                        "description": "limit",
-                        "name": "limit",
-                        "in": "query",
-                        "required": true
+                        "type": "string",
+                        "description": "Branch ID",
+                        "name": "branch_id",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "search",
-                        "name": "search",
+                        "description": "locale: uz, ru or en, overrides Accept-Language",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated allergens to leave out, the profile ones of a signed in user by default",
+                        "name": "exclude_allergens",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated tags the products must all have",
+                        "name": "dietary_tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "0 to 3",
+                        "name": "max_spicy_level",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "catalog version the client has",
+                        "name": "changed_since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the menu the client has",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.UserList"
+                            "$ref": "#/definitions/entity.Menu"
                         }
                     },
+                    "304": {
+                        "description": "Not Modified"
+                    },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
@@ -2503,14 +2731,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/location": {
+        "/notification": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update a userlocation",
+                "description": "Update a notification",
                 "consumes": [
                     "application/json"
                 ],
@@ -2518,17 +2746,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "userlocation"
+                    "notification"
                 ],
-                "summary": "Update a userlocation",
+                "summary": "Update a notification",
                 "parameters": [
                     {
-                        "description": "UserLocation object",
-                        "name": "userlocation",
+                        "description": "Notification object",
+                        "name": "notification",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/entity.UserLocation"
+                            "$ref": "#/definitions/entity.Notification"
                         }
                     }
                 ],
@@ -2536,7 +2764,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.UserLocation"
+                            "$ref": "#/definitions/entity.Notification"
                         }
                     },
                     "400": {
@@ -2553,7 +2781,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new userlocation",
+                "description": "Create a notification and send an email to user",
                 "consumes": [
                     "application/json"
                 ],
@@ -2561,17 +2789,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "userlocation"
+                    "notification"
                 ],
-                "summary": "Create a new userlocation",
+                "summary": "Create a new notification",
                 "parameters": [
                     {
-                        "description": "UserLocation object",
-                        "name": "userlocation",
+                        "description": "Notification data",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/entity.UserLocation"
+                            "$ref": "#/definitions/entity.Notification"
                         }
                     }
                 ],
@@ -2579,7 +2807,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/entity.UserLocation"
+                            "$ref": "#/definitions/entity.Notification"
                         }
                     },
                     "400": {
@@ -2591,14 +2819,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/location/list": {
+        "/notification/list": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a list of userlocations",
+                "description": "Get a list of notifications",
                 "consumes": [
                     "application/json"
                 ],
@@ -2606,9 +2834,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "userlocation"
+                    "notification"
                 ],
-                "summary": "Get a list of userlocations",
+                "summary": "Get a list of notifications",
                 "parameters": [
                     {
                         "type": "number",
@@ -2635,7 +2863,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.ListUserLocation"
+                            "$ref": "#/definitions/entity.NotificationList"
                         }
                     },
                     "400": {
@@ -2647,14 +2875,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/location/{id}": {
-            "get": {
+        "/notification/status": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a userlocation by ID",
+                "description": "Update a notification's status",
                 "consumes": [
                     "application/json"
                 ],
@@ -2662,23 +2890,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "userlocation"
+                    "notification"
                 ],
-                "summary": "Get a userlocation by ID",
+                "summary": "Update a notification status",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "UserLocation ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Notification object",
+                        "name": "notification",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.Notification"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.UserLocation"
+                            "$ref": "#/definitions/entity.Notification"
                         }
                     },
                     "400": {
@@ -2688,14 +2918,102 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/notification/update-status": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update status notification by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notification"
+                ],
+                "summary": "Update status a notification by ID",
+                "parameters": [
+                    {
+                        "description": "Notification object",
+                        "name": "notification",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.Notification"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Notification"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notification/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a notification by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notification"
+                ],
+                "summary": "Get a notification by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Notification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Notification"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            },
             "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a userlocation",
+                "description": "Delete a notification",
                 "consumes": [
                     "application/json"
                 ],
@@ -2703,13 +3021,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "userlocation"
+                    "notification"
                 ],
-                "summary": "Delete a userlocation",
+                "summary": "Delete a notification",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "UserLocation ID",
+                        "description": "Notification ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2731,43 +3049,40 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/upload": {
-            "post": {
+        "/order": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Upload Multiple Files",
+                "description": "Update a order. Its price, tax and currency are set when it is placed and can not be changed.\nCustomers may only cancel a scheduled, pending or confirmed order. Staff of its branch move it through the\nkitchen, its courier picks it up and delivers it; other status changes are refused with 409.",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "order"
                 ],
-                "summary": "Upload Multiple Files",
-                "operationId": "upload_profile_pic_file",
+                "summary": "Update a order",
                 "parameters": [
                     {
-                        "type": "array",
-                        "items": {
-                            "type": "file"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "File to upload",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
+                        "description": "Order object",
+                        "name": "order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.Order"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success Request",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.MultipleFileUploadResponse"
+                            "$ref": "#/definitions/entity.Order"
                         }
                     },
                     "400": {
@@ -2776,8 +3091,57 @@ const docTemplate = `{
                             "$ref": "#/definitions/entity.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Server error",
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new order. A bundle takes the options chosen in selections, slots without a\nselection take their default option. Bundles are returned with a line per component\npointing to the bundle line in parent_item_id, component lines cost nothing.\nActive pricing rules are applied to every line, applied_rules lists them with their discount.\nPrices include VAT, tax is the VAT of a line at the rate of the tax category of its product.\nAll items have to be from one restaurant, the order goes to its nearest branch. When that branch\nis at capacity it either takes the order with a later ready_eta, passes it to its next nearest\nbranch or turns it down with 503 BRANCH_BUSY.\nWith scheduled_at, the starts_at of a slot from /branch/{id}/slots, the order is scheduled at the\nnearest branch with that slot free and released to its kitchen as pending ahead of the slot.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Create a new order",
+                "parameters": [
+                    {
+                        "description": "Order object",
+                        "name": "order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.Order"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
                         "schema": {
                             "$ref": "#/definitions/entity.ErrorResponse"
                         }
@@ -2785,14 +3149,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/{id}": {
+        "/order/bybranch": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a user by ID",
+                "description": "Get a list of branch orders",
                 "consumes": [
                     "application/json"
                 ],
@@ -2800,15 +3164,43 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "order"
                 ],
-                "summary": "Get a user by ID",
+                "summary": "Get a list of branch orders",
                 "parameters": [
+                    {
+                        "type": "number",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Number of results per page",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Branch ID",
+                        "name": "branch_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order status",
+                        "name": "status",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Delivery status",
+                        "name": "delivery_status",
+                        "in": "query",
                         "required": true
                     }
                 ],
@@ -2816,7 +3208,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.User"
+                            "$ref": "#/definitions/entity.OrderList"
                         }
                     },
                     "400": {
@@ -2826,14 +3218,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/order/check": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a user",
+                "description": "Lists the items of the cart, and the components of bundles in it, that have one of\nexclude_allergens, or of the allergens excluded in the profile of the user when it is empty.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2841,23 +3235,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "order"
                 ],
-                "summary": "Delete a user",
+                "summary": "Warn about allergens in a cart",
                 "parameters": [
+                    {
+                        "description": "Cart",
+                        "name": "cart",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.CartCheckRequest"
+                        }
+                    },
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "locale: uz, ru or en, overrides Accept-Language",
+                        "name": "lang",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.SuccessResponse"
+                            "$ref": "#/definitions/entity.CartCheck"
                         }
                     },
                     "400": {
@@ -2868,30 +3270,5593 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "entity.Banner": {
-            "type": "object",
-            "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "image_url": {
-                    "type": "string"
-                },
+        },
+        "/order/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of orders",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Get a list of orders",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "branch_id",
+                        "name": "branch_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.OrderList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a order by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Get a order by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Delete a order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/{id}/receipt": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "A receipt is made when the order is delivered. It is pending until the fiscal provider\nregisters it, then it has the fiscal sign and the URL for the QR code.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Get the fiscal receipt of an order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Receipt"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Makes the receipt when the order has none and sends a failed receipt to the fiscal provider again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Issue the fiscal receipt of a delivered order again",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Receipt"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/{id}/receipt.pdf": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The receipt lists the items with their bundle components, discounts and VAT. Registered\nreceipts also have the fiscal sign and QR code. The language follows lang or Accept-Language.",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "Download the receipt of an order as PDF",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "uz",
+                            "ru",
+                            "en"
+                        ],
+                        "type": "string",
+                        "description": "Language",
+                        "name": "lang",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/policy": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Allows a role to call the methods (regex, e.g. GET|POST) on a path pattern (e.g. /v1/order/*). Applied on all replicas without a restart.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "policy"
+                ],
+                "summary": "Create a new policy",
+                "parameters": [
+                    {
+                        "description": "Policy object",
+                        "name": "policy",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.Policy"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Policy"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/policy/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of policies",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "policy"
+                ],
+                "summary": "Get a list of policies",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "subject",
+                        "name": "subject",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "search by path",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.PolicyList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/policy/role": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The role gets every permission of the parent role",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "policy"
+                ],
+                "summary": "Create a role inheritance rule",
+                "parameters": [
+                    {
+                        "description": "Role inheritance object",
+                        "name": "role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.RoleInheritance"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.RoleInheritance"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/policy/role/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of role inheritance rules",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "policy"
+                ],
+                "summary": "Get a list of role inheritance rules",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.RoleInheritanceList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/policy/role/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a role inheritance rule",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "policy"
+                ],
+                "summary": "Delete a role inheritance rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role inheritance ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/policy/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a policy",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "policy"
+                ],
+                "summary": "Delete a policy",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Policy ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pricing-rule": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces the whole rule",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pricing-rule"
+                ],
+                "summary": "Update a pricing rule",
+                "parameters": [
+                    {
+                        "description": "Pricing rule",
+                        "name": "rule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.PricingRule"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.PricingRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Discounts applied to order lines: percent off the line, an amount off every unit or buy X get Y free.\nHigher priority rules come first, then product rules, category rules and rules for everything, branch rules before rules for all branches.\nThe first matching rule applies, when it is stackable the following stackable rules apply too.\nstart_time and end_time are \"15:04\" in local time, either may be left out to run from or until\nmidnight. starts_at and ends_at are RFC 3339.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pricing-rule"
+                ],
+                "summary": "Create a pricing rule",
+                "parameters": [
+                    {
+                        "description": "Pricing rule",
+                        "name": "rule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.PricingRule"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.PricingRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pricing-rule/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rules in precedence order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pricing-rule"
+                ],
+                "summary": "Get a list of pricing rules",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "branch_id",
+                        "name": "branch_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.PricingRuleList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pricing-rule/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a pricing rule by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pricing-rule"
+                ],
+                "summary": "Get a pricing rule by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pricing rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.PricingRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a pricing rule",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pricing-rule"
+                ],
+                "summary": "Delete a pricing rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pricing rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/print/agent/next": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "For printer agents. Claims the oldest pending job with its ESC/POS data, waiting up to wait\nseconds for one, and returns 204 when none came. The job has to be acked before its lease runs out,\nelse it is claimed again. An agent may print only the customer copies, or only the kitchen copies\nof one station. API keys only claim the jobs of the branches they are bound to.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "print"
+                ],
+                "summary": "Wait for the next print job of a branch",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Branch ID",
+                        "name": "branch_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "customer",
+                            "kitchen"
+                        ],
+                        "type": "string",
+                        "description": "Only jobs of this kind",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only kitchen copies of this kitchen station",
+                        "name": "station_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Seconds to wait for a job, at most 60",
+                        "name": "wait",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.PrintJob"
+                        }
+                    },
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/print/agent/{id}/ack": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "For printer agents. A job that did not print is claimed again until it is out of attempts.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "print"
+                ],
+                "summary": "Report whether a print job was printed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Print job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Outcome",
+                        "name": "ack",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.PrintJobAck"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.PrintJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/print/jobs/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Newest first, without their data.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "print"
+                ],
+                "summary": "Get a list of print jobs",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Number of results per page",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Branch ID",
+                        "name": "branch_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "order_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "printing",
+                            "printed",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "description": "Status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.PrintJobList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/print/jobs/{id}/retry": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queues the job again with its attempts reset.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "print"
+                ],
+                "summary": "Retry a failed print job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Print job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.PrintJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/print/order/{id}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queues the customer copy and the kitchen copies of the order, or the ones selected. Kitchen copies\nare only printed for open orders. The tickets are queued on their own when an order is confirmed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "print"
+                ],
+                "summary": "Print the tickets of an order again",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tickets to print",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.PrintRequest"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "uz",
+                            "ru",
+                            "en"
+                        ],
+                        "type": "string",
+                        "description": "Language",
+                        "name": "lang",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.PrintJobList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/product": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a product",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Update a product",
+                "parameters": [
+                    {
+                        "description": "Product object",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.Product"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new product, it is in the restaurant of its category.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Create a new product",
+                "parameters": [
+                    {
+                        "description": "Product object",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.Product"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/availability": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Products are available at every branch until marked otherwise.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Set whether a product is available at a branch",
+                "parameters": [
+                    {
+                        "description": "Availability",
+                        "name": "availability",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.ProductAvailability"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ProductAvailability"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of products",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Get a list of products",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "search",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "category id",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Restaurant ID, the default restaurant when empty",
+                        "name": "restaurant_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated allergens to leave out, the profile ones by default",
+                        "name": "exclude_allergens",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated tags the products must all have",
+                        "name": "dietary_tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "0 to 3",
+                        "name": "max_spicy_level",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "locale: uz, ru or en, overrides Accept-Language",
+                        "name": "lang",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ProductList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/search": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Full text search over product name, category name and description.\nCyrillic and Latin spellings match each other and small typos in the name are tolerated.\nMatched words are wrapped in \u003cmark\u003e in the highlight. Without a restaurant every restaurant is searched.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Search products",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "search text",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Restaurant ID",
+                        "name": "restaurant_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "locale: uz, ru or en, overrides Accept-Language",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "category id",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only products available at this branch",
+                        "name": "branch_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "minimum price",
+                        "name": "price_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "maximum price",
+                        "name": "price_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated allergens to leave out, the profile ones by default",
+                        "name": "exclude_allergens",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated tags the products must all have",
+                        "name": "dietary_tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "0 to 3",
+                        "name": "max_spicy_level",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ProductSearchResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/sort": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set the display order of products within their categories",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Set the display order of products",
+                "parameters": [
+                    {
+                        "description": "Positions",
+                        "name": "sort",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.SortRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/suggest": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Autocomplete product and category names",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Autocomplete product and category names",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "typed text",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Restaurant ID, the default restaurant when empty",
+                        "name": "restaurant_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.SuggestionList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/upload/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload Multiple Files",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Upload Multiple Files",
+                "operationId": "upload_product_pic_file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Product image",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ImageVariants"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a product by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Get a product by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "locale: uz, ru or en, overrides Accept-Language",
+                        "name": "lang",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a product",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Delete a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/{id}/bundle": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "A slot with one option is a fixed item, with more the customer chooses one.\nWith a branch, options sold out there are marked unavailable.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Get the slots of a bundle",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Branch ID",
+                        "name": "branch_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "locale: uz, ru or en, overrides Accept-Language",
+                        "name": "lang",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Bundle"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The price of the product is the bundle price, the price_delta of a chosen option is added to it.\nEvery slot needs at least one option and at most one default. Bundles cannot be options.\nOptions have to be products of the restaurant of the bundle.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Make a product a bundle or replace its slots",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Bundle",
+                        "name": "bundle",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.Bundle"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Bundle"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The product stays as a plain product.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Remove the slots of a bundle",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/{id}/prices": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Scheduled, current and past prices, latest effective first. Order lines reference them by price_version_id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Get the price history of a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.PriceHistory"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The price becomes the price of the product at effective_at (RFC 3339), which has to be in the future.\nTo change the price right away update the product.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Schedule a price change",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "price and effective_at",
+                        "name": "price",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.PriceVersion"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.PriceVersion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/{id}/prices/{price_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Only prices that are not applied yet can be cancelled.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Cancel a scheduled price change",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Price version ID",
+                        "name": "price_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/report": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a report",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "report"
+                ],
+                "summary": "Update a report",
+                "parameters": [
+                    {
+                        "description": "Report object",
+                        "name": "report",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.Report"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Report"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new report",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "report"
+                ],
+                "summary": "Create a new report",
+                "parameters": [
+                    {
+                        "description": "Report object",
+                        "name": "report",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.Report"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Report"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/report/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of reports",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "report"
+                ],
+                "summary": "Get a list of reports",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "search",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ReportList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/report/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a report by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "report"
+                ],
+                "summary": "Get a report by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Report ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Report"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a report",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "report"
+                ],
+                "summary": "Delete a report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Report ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurant": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Restaurant admins may update their own restaurant but not whether it is active.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restaurant"
+                ],
+                "summary": "Update a restaurant",
+                "parameters": [
+                    {
+                        "description": "Restaurant",
+                        "name": "restaurant",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.Restaurant"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Restaurant"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admins only. Its branches, categories and banners are created with its restaurant_id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restaurant"
+                ],
+                "summary": "Create a new restaurant",
+                "parameters": [
+                    {
+                        "description": "Restaurant",
+                        "name": "restaurant",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.Restaurant"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Restaurant"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurant-admin": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Superadmin only. The user manages the branches, catalog and banners of the restaurant, replacing\nthe restaurant they had. Only plain users can be made restaurant admins. The user is logged out so\nthe role applies from their next login.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restaurant"
+                ],
+                "summary": "Make a user the admin of a restaurant",
+                "parameters": [
+                    {
+                        "description": "Restaurant admin",
+                        "name": "admin",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.RestaurantAdmin"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.RestaurantAdmin"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurant-admin/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Superadmin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restaurant"
+                ],
+                "summary": "Get a list of restaurant admins",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only the admins of this restaurant",
+                        "name": "restaurant_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.RestaurantAdminList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurant-admin/{user_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Superadmin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restaurant"
+                ],
+                "summary": "Get the restaurant of a restaurant admin",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.RestaurantAdmin"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Superadmin only. The user becomes a plain user again and is logged out.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restaurant"
+                ],
+                "summary": "Remove a restaurant admin",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurant/list": {
+            "get": {
+                "description": "By name. With latitude and longitude only the active restaurants delivering there are listed,\nnearest first, with their nearest branch and its distance in meters. Only admins see inactive ones.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restaurant"
+                ],
+                "summary": "Get a list of restaurants",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "search",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Delivery latitude",
+                        "name": "latitude",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Delivery longitude",
+                        "name": "longitude",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.RestaurantList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurant/{id}": {
+            "get": {
+                "description": "Get a restaurant by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restaurant"
+                ],
+                "summary": "Get a restaurant by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Restaurant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Restaurant"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admins only. Only a restaurant without branches, categories, products and banners can be deleted,\nits admins become plain users. The default restaurant can not be deleted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restaurant"
+                ],
+                "summary": "Delete a restaurant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Restaurant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/session": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a session",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "session"
+                ],
+                "summary": "Update a session",
+                "parameters": [
+                    {
+                        "description": "Session object",
+                        "name": "session",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.Session"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Session"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/session/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of users",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "session"
+                ],
+                "summary": "Get a list of users",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "user_id",
+                        "name": "user_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.SessionList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/session/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a session by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "session"
+                ],
+                "summary": "Get a session by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Session"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a session",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "session"
+                ],
+                "summary": "Delete a session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/storage/report": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Number of stored objects and bytes per entity type referencing them. Objects nothing references are reported as \"unreferenced\" and deleted by the sweeper after a grace period.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Upload File"
+                ],
+                "summary": "Storage usage report",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.StorageReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tax-category": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "A new rate applies to orders placed from now on, existing orders keep theirs.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax-category"
+                ],
+                "summary": "Update a tax category",
+                "parameters": [
+                    {
+                        "description": "Tax category",
+                        "name": "category",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.TaxCategory"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.TaxCategory"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "A VAT rate in percent, products point to it with tax_category_id. Prices include VAT.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax-category"
+                ],
+                "summary": "Create a tax category",
+                "parameters": [
+                    {
+                        "description": "Tax category",
+                        "name": "category",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.TaxCategory"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.TaxCategory"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tax-category/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of tax categories",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax-category"
+                ],
+                "summary": "Get a list of tax categories",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.TaxCategoryList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tax-category/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a tax category by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax-category"
+                ],
+                "summary": "Get a tax category by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tax category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.TaxCategory"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Categories used by products cannot be deleted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax-category"
+                ],
+                "summary": "Delete a tax category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tax category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/translation/{entity_type}/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the translations of a product, category or banner",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "translation"
+                ],
+                "summary": "Get the translations of a product, category or banner",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "product, category or banner",
+                        "name": "entity_type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.TranslationList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Products take name and description, categories name and banners title.\nThe default locale (uz) is edited on the entity itself.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "translation"
+                ],
+                "summary": "Create or replace a translation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "product, category or banner",
+                        "name": "entity_type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Translation",
+                        "name": "translation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.Translation"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.TranslationList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/translation/{entity_type}/{id}/{locale}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a translation",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "translation"
+                ],
+                "summary": "Delete a translation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "product, category or banner",
+                        "name": "entity_type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Locale",
+                        "name": "locale",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.SuccessResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Update a user",
+                "parameters": [
+                    {
+                        "description": "User object",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.User"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Create a new user",
+                "parameters": [
+                    {
+                        "description": "User object",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.User"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/2fa/activate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Confirms the enrollment with a code from the authenticator app and returns recovery codes",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Activate two factor authentication",
+                "parameters": [
+                    {
+                        "description": "Code",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.TwoFactorCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.RecoveryCodesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/2fa/disable": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Disables two factor authentication for the current user. Not allowed for roles where it is enforced.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Disable two factor authentication",
+                "parameters": [
+                    {
+                        "description": "Code",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.TwoFactorCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/2fa/recovery-codes": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces all recovery codes of the current user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Regenerate recovery codes",
+                "parameters": [
+                    {
+                        "description": "Code",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.TwoFactorCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.RecoveryCodesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/2fa/setup": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Generates a new TOTP secret for the current user. It becomes active after /user/2fa/activate.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Start two factor enrollment",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.TwoFactorSetupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/2fa/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Removes the TOTP secret and recovery codes of a user, e.g. after a lost phone. Users with an enforced role have to enroll again on next login.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Reset a user's two factor authentication",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of users",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get a list of users",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "search",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.UserList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/location": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a userlocation",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "userlocation"
+                ],
+                "summary": "Update a userlocation",
+                "parameters": [
+                    {
+                        "description": "UserLocation object",
+                        "name": "userlocation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.UserLocation"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.UserLocation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new userlocation",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "userlocation"
+                ],
+                "summary": "Create a new userlocation",
+                "parameters": [
+                    {
+                        "description": "UserLocation object",
+                        "name": "userlocation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.UserLocation"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.UserLocation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/location/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of userlocations",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "userlocation"
+                ],
+                "summary": "Get a list of userlocations",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "user_id",
+                        "name": "user_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ListUserLocation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/location/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a userlocation by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "userlocation"
+                ],
+                "summary": "Get a userlocation by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UserLocation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.UserLocation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a userlocation",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "userlocation"
+                ],
+                "summary": "Delete a userlocation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UserLocation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/upload": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload Multiple Files",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Upload Multiple Files",
+                "operationId": "upload_profile_pic_file",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Profile picture",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ImageVariants"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a user by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get a user by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Delete a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "entity.APIKey": {
+            "type": "object",
+            "properties": {
+                "branch_ids": {
+                    "description": "BranchIDs are the branches the key may act for, a printer key needs at least one.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "previous_expires_at": {
+                    "type": "string"
+                },
+                "rate_limit": {
+                    "description": "RateLimit is the number of requests allowed per minute.",
+                    "type": "integer"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.APIKeyList": {
+            "type": "object",
+            "properties": {
+                "api_keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.APIKey"
+                    }
+                },
+                "count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.APIKeyRotateRequest": {
+            "type": "object",
+            "properties": {
+                "grace_minutes": {
+                    "description": "GraceMinutes keeps the old key working for a while so the partner can switch without downtime.",
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.APIKeySecret": {
+            "type": "object",
+            "properties": {
+                "branch_ids": {
+                    "description": "BranchIDs are the branches the key may act for, a printer key needs at least one.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "previous_expires_at": {
+                    "type": "string"
+                },
+                "rate_limit": {
+                    "description": "RateLimit is the number of requests allowed per minute.",
+                    "type": "integer"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.AllergenWarning": {
+            "type": "object",
+            "properties": {
+                "allergens": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "bundle_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.AppliedRule": {
+            "type": "object",
+            "properties": {
+                "discount": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rule_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.Banner": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "images": {
+                    "$ref": "#/definitions/entity.ImageVariants"
+                },
+                "restaurant_id": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.BannerList": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Banner"
+                    }
+                }
+            }
+        },
+        "entity.Branch": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "string"
+                },
+                "longitude": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "restaurant_id": {
+                    "description": "RestaurantID is the default restaurant when empty on create and can not\nbe changed later.",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.BranchCapacity": {
+            "type": "object",
+            "properties": {
+                "branch_id": {
+                    "type": "string"
+                },
+                "max_active_orders": {
+                    "type": "integer"
+                },
+                "max_items_per_window": {
+                    "type": "integer"
+                },
+                "max_orders_per_slot": {
+                    "description": "MaxOrdersPerSlot limits the scheduled orders of a delivery slot.",
+                    "type": "integer"
+                },
+                "overflow": {
+                    "type": "string",
+                    "enum": [
+                        "extend_eta",
+                        "reroute",
+                        "reject"
+                    ],
+                    "example": "extend_eta"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.BranchHours": {
+            "type": "object",
+            "properties": {
+                "closes_at": {
+                    "type": "string",
+                    "example": "23:00"
+                },
+                "opens_at": {
+                    "type": "string",
+                    "example": "09:00"
+                },
+                "weekday": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "entity.BranchHoursList": {
+            "type": "object",
+            "properties": {
+                "branch_id": {
+                    "type": "string"
+                },
+                "hours": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.BranchHours"
+                    }
+                }
+            }
+        },
+        "entity.BranchList": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Branch"
+                    }
+                }
+            }
+        },
+        "entity.BranchLoad": {
+            "type": "object",
+            "properties": {
+                "active_orders": {
+                    "type": "integer"
+                },
+                "branch_id": {
+                    "type": "string"
+                },
+                "branch_name": {
+                    "type": "string"
+                },
+                "capacity": {
+                    "$ref": "#/definitions/entity.BranchCapacity"
+                },
+                "delay": {
+                    "description": "Delay is how many minutes later a new order of one item would be ready.",
+                    "type": "integer"
+                },
+                "restaurant_id": {
+                    "type": "string"
+                },
+                "window_items": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.BranchLoadList": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.BranchLoad"
+                    }
+                }
+            }
+        },
+        "entity.BranchStaff": {
+            "type": "object",
+            "properties": {
+                "branch_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "branch_manager",
+                        "branch_operator"
+                    ]
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.BranchStaffList": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.BranchStaff"
+                    }
+                }
+            }
+        },
+        "entity.Bundle": {
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "type": "string"
+                },
+                "slots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.BundleSlot"
+                    }
+                }
+            }
+        },
+        "entity.BundleSlot": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.BundleSlotOption"
+                    }
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "sort_order": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.BundleSlotOption": {
+            "type": "object",
+            "properties": {
+                "is_available": {
+                    "description": "IsAvailable is false when the product is inactive or sold out at the branch.",
+                    "type": "boolean"
+                },
+                "is_default": {
+                    "description": "IsDefault is chosen when the customer does not choose.",
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price_delta": {
+                    "type": "number"
+                },
+                "product_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.CartCheck": {
+            "type": "object",
+            "properties": {
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.AllergenWarning"
+                    }
+                }
+            }
+        },
+        "entity.CartCheckRequest": {
+            "type": "object",
+            "properties": {
+                "exclude_allergens": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.OrderItems"
+                    }
+                }
+            }
+        },
+        "entity.Category": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "images": {
+                    "$ref": "#/definitions/entity.ImageVariants"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "is_hidden": {
+                    "type": "boolean"
+                },
+                "kitchen_station_id": {
+                    "description": "KitchenStationID routes the products to a kitchen station, subcategories\nwithout one use the station of their parent.",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "ParentID is empty for top level categories.",
+                    "type": "string"
+                },
+                "restaurant_id": {
+                    "description": "RestaurantID is the default restaurant when empty on create and can not\nbe changed later, subcategories are in the restaurant of their parent.",
+                    "type": "string"
+                },
+                "sort_order": {
+                    "description": "SortOrder positions the category among its siblings. It and the flags\nare left unchanged when missing from an update. Hidden categories are\nleft out of the menu along with everything under them, inactive ones\nout of search too.",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.CategoryList": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Category"
+                    }
+                }
+            }
+        },
+        "entity.DeliverySlot": {
+            "type": "object",
+            "properties": {
+                "ends_at": {
+                    "type": "string",
+                    "example": "2026-10-20T13:30:00+05:00"
+                },
+                "remaining": {
+                    "description": "Remaining is how many more orders the slot takes, unset when unlimited.",
+                    "type": "integer"
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "2026-10-20T13:00:00+05:00"
+                }
+            }
+        },
+        "entity.DeliverySlotList": {
+            "type": "object",
+            "properties": {
+                "branch_id": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2026-10-20"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.DeliverySlot"
+                    }
+                }
+            }
+        },
+        "entity.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.ImageVariants": {
+            "type": "object",
+            "additionalProperties": {
+                "type": "string"
+            }
+        },
+        "entity.KitchenItem": {
+            "type": "object",
+            "properties": {
+                "cooking_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_item_id": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "ready_at": {
+                    "type": "string"
+                },
+                "station_id": {
+                    "type": "string"
+                },
+                "station_name": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "queued",
+                        "cooking",
+                        "ready"
+                    ]
+                }
+            }
+        },
+        "entity.KitchenItemStatus": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "queued",
+                        "cooking",
+                        "ready"
+                    ]
+                }
+            }
+        },
+        "entity.KitchenStation": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "grill"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.KitchenStationList": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.KitchenStation"
+                    }
+                }
+            }
+        },
+        "entity.KitchenTicket": {
+            "type": "object",
+            "properties": {
+                "branch_id": {
+                    "type": "string"
+                },
+                "bumped_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "delivery_status": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.KitchenItem"
+                    }
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "ready_eta": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.KitchenTicketList": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.KitchenTicket"
+                    }
+                }
+            }
+        },
+        "entity.ListUserLocation": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.UserLocation"
+                    }
+                }
+            }
+        },
+        "entity.LoginRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "platform": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.Menu": {
+            "type": "object",
+            "properties": {
+                "banners": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.MenuBanner"
+                    }
+                },
+                "branch_id": {
+                    "type": "string"
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.MenuCategory"
+                    }
+                },
+                "restaurant_id": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.MenuBanner": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "images": {
+                    "$ref": "#/definitions/entity.ImageVariants"
+                },
                 "title": {
                     "type": "string"
+                }
+            }
+        },
+        "entity.MenuCategory": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "images": {
+                    "$ref": "#/definitions/entity.ImageVariants"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.MenuProduct"
+                    }
+                },
+                "sort_order": {
+                    "type": "integer"
+                },
+                "subcategories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.MenuCategory"
+                    }
+                }
+            }
+        },
+        "entity.MenuProduct": {
+            "type": "object",
+            "properties": {
+                "allergens": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "category_id": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "dietary_tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "images": {
+                    "$ref": "#/definitions/entity.ImageVariants"
+                },
+                "is_available": {
+                    "description": "IsAvailable is false when the product, or every option of a bundle\nslot, is sold out at the branch.",
+                    "type": "boolean"
+                },
+                "is_bundle": {
+                    "description": "IsBundle tells to get the slots of the product from /product/{id}/bundle.",
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "nutrition": {
+                    "$ref": "#/definitions/entity.Nutrition"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "sort_order": {
+                    "type": "integer"
+                },
+                "spicy_level": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.MultipleFileUploadResponse": {
+            "type": "object",
+            "properties": {
+                "url": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Url"
+                    }
+                }
+            }
+        },
+        "entity.Notification": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "owner_id": {
+                    "type": "string"
+                },
+                "ownerrole": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.NotificationList": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "notifications": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Notification"
+                    }
+                }
+            }
+        },
+        "entity.Nutrition": {
+            "type": "object",
+            "properties": {
+                "carbohydrates": {
+                    "type": "number"
+                },
+                "fat": {
+                    "type": "number"
+                },
+                "kcal": {
+                    "type": "number"
+                },
+                "portion_weight": {
+                    "description": "PortionWeight is in grams.",
+                    "type": "integer"
+                },
+                "protein": {
+                    "type": "number"
+                }
+            }
+        },
+        "entity.OAuthStartResponse": {
+            "type": "object",
+            "properties": {
+                "authorization_url": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.Order": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "branch_id": {
+                    "type": "string"
+                },
+                "courier_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "UZS"
+                },
+                "delivery_status": {
+                    "type": "string",
+                    "enum": [
+                        "olib ketish",
+                        "yetkazib berish"
+                    ],
+                    "example": "yetkazib berish"
+                },
+                "door_number": {
+                    "type": "integer"
+                },
+                "entrance": {
+                    "type": "integer"
+                },
+                "floor": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "order_items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.OrderItems"
+                    }
+                },
+                "ready_eta": {
+                    "type": "string"
+                },
+                "scheduled_at": {
+                    "description": "ScheduledAt is the start of the slot a scheduled order is delivered or\npicked up in, the order is ASAP when it is empty.",
+                    "type": "string",
+                    "example": "2026-10-20T13:00:00+05:00"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "scheduled",
+                        " pending",
+                        " confirmed",
+                        " cancelled",
+                        " preparing",
+                        " picked_up",
+                        " delivered"
+                    ],
+                    "example": "pending"
+                },
+                "tax": {
+                    "type": "number"
+                },
+                "total_price": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.OrderItemSelection": {
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "type": "string"
+                },
+                "slot_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.OrderItems": {
+            "type": "object",
+            "properties": {
+                "applied_rules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.AppliedRule"
+                    }
+                },
+                "bundle_slot_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "discount": {
+                    "description": "Discount is taken off Price * Quantity by AppliedRules, TotalPrice is net of it.",
+                    "type": "number"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "mxik_code": {
+                    "description": "MxikCode is the product code on the receipt, Tax the VAT at TaxRate included in TotalPrice.",
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "parent_item_id": {
+                    "description": "ParentItemID is the line of the bundle a component line belongs to.\nComponent lines go to the kitchen, the bundle line carries the price.",
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "price_version_id": {
+                    "description": "PriceVersionID is the entry of the price history Price was taken from.",
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "selections": {
+                    "description": "Selections choose the options of the slots when the product is a bundle.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.OrderItemSelection"
+                    }
+                },
+                "tax": {
+                    "type": "number"
+                },
+                "tax_rate": {
+                    "type": "number"
+                },
+                "total_price": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.OrderList": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Order"
+                    }
+                }
+            }
+        },
+        "entity.Policy": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "object": {
+                    "type": "string"
+                },
+                "subject": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.PolicyList": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "policies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Policy"
+                    }
+                }
+            }
+        },
+        "entity.PriceHistory": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.PriceVersion"
+                    }
+                }
+            }
+        },
+        "entity.PriceVersion": {
+            "type": "object",
+            "properties": {
+                "applied_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "effective_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "scheduled",
+                        "current",
+                        "past"
+                    ]
+                }
+            }
+        },
+        "entity.PricingRule": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "branch_id": {
+                    "type": "string"
+                },
+                "buy_quantity": {
+                    "type": "integer"
+                },
+                "category_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "days_of_week": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "end_time": {
+                    "type": "string",
+                    "example": "17:00"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "first_order_only": {
+                    "type": "boolean"
+                },
+                "get_quantity": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "percent",
+                        "amount",
+                        "buy_get"
+                    ]
+                },
+                "name": {
+                    "type": "string"
+                },
+                "percent": {
+                    "type": "number"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "stackable": {
+                    "type": "boolean"
+                },
+                "start_time": {
+                    "type": "string",
+                    "example": "14:00"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.PricingRuleList": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.PricingRule"
+                    }
+                }
+            }
+        },
+        "entity.PrintJob": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "branch_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "data": {
+                    "type": "string",
+                    "format": "base64"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "customer",
+                        "kitchen"
+                    ]
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "printed_at": {
+                    "type": "string"
+                },
+                "station_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "printing",
+                        "printed",
+                        "failed"
+                    ]
+                }
+            }
+        },
+        "entity.PrintJobAck": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "printed": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "entity.PrintJobList": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.PrintJob"
+                    }
+                }
+            }
+        },
+        "entity.PrintRequest": {
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "customer",
+                        "kitchen"
+                    ]
+                },
+                "station_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.Product": {
+            "type": "object",
+            "properties": {
+                "allergens": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "category_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "dietary_tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "images": {
+                    "$ref": "#/definitions/entity.ImageVariants"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "is_bundle": {
+                    "description": "IsBundle is set by saving the slots of the bundle.",
+                    "type": "boolean"
+                },
+                "is_hidden": {
+                    "type": "boolean"
+                },
+                "mxik_code": {
+                    "type": "string",
+                    "example": "10202001001000000"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "nutrition": {
+                    "description": "Nutrition, allergens, dietary tags and the spicy level are left\nunchanged when missing from an update too.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.Nutrition"
+                        }
+                    ]
+                },
+                "package_code": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "price_version_id": {
+                    "description": "PriceVersionID is the entry of the price history the price comes from.",
+                    "type": "string"
+                },
+                "restaurant_id": {
+                    "description": "RestaurantID is read only, it is the restaurant of the category.",
+                    "type": "string"
+                },
+                "sort_order": {
+                    "description": "SortOrder positions the product within its category. It and the flags\nare left unchanged when missing from an update. Hidden products are\nleft out of the menu, inactive ones out of search too.",
+                    "type": "integer"
+                },
+                "spicy_level": {
+                    "type": "integer"
+                },
+                "tax_category_id": {
+                    "description": "TaxCategoryID, MxikCode and PackageCode go on the fiscal receipt, they\nare left unchanged when empty in an update. TaxRate is read only.",
+                    "type": "string"
+                },
+                "tax_rate": {
+                    "type": "number"
                 },
                 "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "entity.BannerList": {
+        "entity.ProductAvailability": {
+            "type": "object",
+            "properties": {
+                "branch_id": {
+                    "type": "string"
+                },
+                "is_available": {
+                    "type": "boolean"
+                },
+                "product_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.ProductHighlight": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.ProductList": {
             "type": "object",
             "properties": {
                 "count": {
@@ -2900,41 +8865,114 @@ const docTemplate = `{
                 "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/entity.Banner"
+                        "$ref": "#/definitions/entity.Product"
                     }
                 }
             }
         },
-        "entity.Branch": {
+        "entity.ProductSearchHit": {
             "type": "object",
             "properties": {
-                "address": {
+                "allergens": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "category_id": {
+                    "type": "string"
+                },
+                "category_name": {
                     "type": "string"
                 },
                 "created_at": {
                     "type": "string"
                 },
+                "description": {
+                    "type": "string"
+                },
+                "dietary_tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "highlight": {
+                    "description": "Highlight has name and description HTML escaped, with the matched words wrapped in \u003cmark\u003e.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.ProductHighlight"
+                        }
+                    ]
+                },
                 "id": {
                     "type": "string"
                 },
-                "latitude": {
+                "images": {
+                    "$ref": "#/definitions/entity.ImageVariants"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "is_bundle": {
+                    "description": "IsBundle is set by saving the slots of the bundle.",
+                    "type": "boolean"
+                },
+                "is_hidden": {
+                    "type": "boolean"
+                },
+                "mxik_code": {
+                    "type": "string",
+                    "example": "10202001001000000"
+                },
+                "name": {
                     "type": "string"
                 },
-                "longitude": {
+                "nutrition": {
+                    "description": "Nutrition, allergens, dietary tags and the spicy level are left\nunchanged when missing from an update too.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.Nutrition"
+                        }
+                    ]
+                },
+                "package_code": {
                     "type": "string"
                 },
-                "name": {
+                "price": {
+                    "type": "number"
+                },
+                "price_version_id": {
+                    "description": "PriceVersionID is the entry of the price history the price comes from.",
                     "type": "string"
                 },
-                "phone": {
+                "rank": {
+                    "type": "number"
+                },
+                "restaurant_id": {
+                    "description": "RestaurantID is read only, it is the restaurant of the category.",
+                    "type": "string"
+                },
+                "sort_order": {
+                    "description": "SortOrder positions the product within its category. It and the flags\nare left unchanged when missing from an update. Hidden products are\nleft out of the menu, inactive ones out of search too.",
+                    "type": "integer"
+                },
+                "spicy_level": {
+                    "type": "integer"
+                },
+                "tax_category_id": {
+                    "description": "TaxCategoryID, MxikCode and PackageCode go on the fiscal receipt, they\nare left unchanged when empty in an update. TaxRate is read only.",
                     "type": "string"
                 },
+                "tax_rate": {
+                    "type": "number"
+                },
                 "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "entity.BranchList": {
+        "entity.ProductSearchResult": {
             "type": "object",
             "properties": {
                 "count": {
@@ -2943,29 +8981,159 @@ const docTemplate = `{
                 "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/entity.Branch"
+                        "$ref": "#/definitions/entity.ProductSearchHit"
                     }
                 }
             }
         },
-        "entity.Category": {
+        "entity.Receipt": {
             "type": "object",
             "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "branch_id": {
+                    "type": "string"
+                },
                 "created_at": {
                     "type": "string"
                 },
+                "currency": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "fiscal_sign": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "string"
                 },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.ReceiptItem"
+                    }
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "qr_url": {
+                    "type": "string"
+                },
+                "registered_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "registered",
+                        "failed"
+                    ]
+                },
+                "tax": {
+                    "type": "number"
+                },
+                "terminal_id": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "number"
+                }
+            }
+        },
+        "entity.ReceiptItem": {
+            "type": "object",
+            "properties": {
+                "discount": {
+                    "type": "number"
+                },
+                "mxik_code": {
+                    "type": "string"
+                },
                 "name": {
                     "type": "string"
                 },
+                "package_code": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "tax": {
+                    "type": "number"
+                },
+                "tax_rate": {
+                    "type": "number"
+                },
+                "total": {
+                    "type": "number"
+                }
+            }
+        },
+        "entity.RecoveryCodesResponse": {
+            "type": "object",
+            "properties": {
+                "recovery_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "entity.RegisterRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "gender": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.Report": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
                 "updated_at": {
                     "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
                 }
             }
         },
-        "entity.CategoryList": {
+        "entity.ReportList": {
             "type": "object",
             "properties": {
                 "count": {
@@ -2974,23 +9142,74 @@ const docTemplate = `{
                 "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/entity.Category"
+                        "$ref": "#/definitions/entity.Report"
                     }
                 }
             }
         },
-        "entity.ErrorResponse": {
+        "entity.Restaurant": {
             "type": "object",
             "properties": {
-                "code": {
+                "created_at": {
                     "type": "string"
                 },
-                "message": {
+                "description": {
+                    "type": "string"
+                },
+                "distance": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "nearest_branch_id": {
+                    "description": "NearestBranchID and Distance, in meters, are set when the list is\nfiltered by a location.",
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "plov-house"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.RestaurantAdmin": {
+            "type": "object",
+            "properties": {
+                "full_name": {
+                    "type": "string"
+                },
+                "restaurant_id": {
+                    "type": "string"
+                },
+                "user_id": {
                     "type": "string"
                 }
             }
         },
-        "entity.ListUserLocation": {
+        "entity.RestaurantAdminList": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.RestaurantAdmin"
+                    }
+                }
+            }
+        },
+        "entity.RestaurantList": {
             "type": "object",
             "properties": {
                 "count": {
@@ -2999,61 +9218,70 @@ const docTemplate = `{
                 "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/entity.UserLocation"
+                        "$ref": "#/definitions/entity.Restaurant"
                     }
                 }
             }
         },
-        "entity.LoginRequest": {
+        "entity.RoleInheritance": {
             "type": "object",
             "properties": {
-                "email": {
+                "created_at": {
                     "type": "string"
                 },
-                "password": {
+                "id": {
                     "type": "string"
                 },
-                "platform": {
+                "parent": {
                     "type": "string"
                 },
-                "username": {
+                "role": {
                     "type": "string"
                 }
             }
         },
-        "entity.MultipleFileUploadResponse": {
+        "entity.RoleInheritanceList": {
             "type": "object",
             "properties": {
-                "url": {
+                "count": {
+                    "type": "integer"
+                },
+                "roles": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/entity.Url"
+                        "$ref": "#/definitions/entity.RoleInheritance"
                     }
                 }
             }
         },
-        "entity.Notification": {
+        "entity.Session": {
             "type": "object",
             "properties": {
                 "created_at": {
                     "type": "string"
                 },
-                "email": {
+                "expires_at": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "message": {
+                "ip_address": {
                     "type": "string"
                 },
-                "owner_id": {
+                "is_active": {
+                    "type": "boolean"
+                },
+                "last_active_at": {
                     "type": "string"
                 },
-                "ownerrole": {
+                "platform": {
                     "type": "string"
                 },
-                "status": {
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_agent": {
                     "type": "string"
                 },
                 "user_id": {
@@ -3061,163 +9289,128 @@ const docTemplate = `{
                 }
             }
         },
-        "entity.NotificationList": {
+        "entity.SessionList": {
             "type": "object",
             "properties": {
                 "count": {
                     "type": "integer"
                 },
-                "notifications": {
+                "sessions": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/entity.Notification"
+                        "$ref": "#/definitions/entity.Session"
                     }
                 }
             }
         },
-        "entity.Order": {
+        "entity.SortItem": {
             "type": "object",
             "properties": {
-                "address": {
-                    "type": "string"
-                },
-                "branch_id": {
-                    "type": "string"
-                },
-                "courier_id": {
-                    "type": "string"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "delivery_status": {
-                    "type": "string",
-                    "enum": [
-                        "olib ketish",
-                        "yetkazib berish"
-                    ],
-                    "example": "yetkazib berish"
-                },
-                "door_number": {
-                    "type": "integer"
-                },
-                "entrance": {
-                    "type": "integer"
-                },
-                "floor": {
-                    "type": "integer"
-                },
                 "id": {
                     "type": "string"
                 },
-                "latitude": {
-                    "type": "number"
-                },
-                "longitude": {
-                    "type": "number"
-                },
-                "order_items": {
+                "sort_order": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.SortRequest": {
+            "type": "object",
+            "properties": {
+                "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/entity.OrderItems"
+                        "$ref": "#/definitions/entity.SortItem"
+                    }
+                }
+            }
+        },
+        "entity.StorageReport": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.StorageUsage"
                     }
                 },
-                "status": {
-                    "type": "string",
-                    "enum": [
-                        "pending",
-                        " confirmed",
-                        " cancelled",
-                        " preparing",
-                        " picked_up",
-                        " delivered"
-                    ],
-                    "example": "pending"
+                "total_bytes": {
+                    "type": "integer"
                 },
-                "total_price": {
-                    "type": "number"
+                "total_objects": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.StorageUsage": {
+            "type": "object",
+            "properties": {
+                "bytes": {
+                    "type": "integer"
                 },
-                "updated_at": {
+                "entity_type": {
                     "type": "string"
                 },
-                "user_id": {
-                    "type": "string"
+                "objects": {
+                    "type": "integer"
                 }
             }
         },
-        "entity.OrderItems": {
+        "entity.SuccessResponse": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "message": {
                     "type": "string"
-                },
+                }
+            }
+        },
+        "entity.Suggestion": {
+            "type": "object",
+            "properties": {
                 "id": {
                     "type": "string"
                 },
-                "order_id": {
-                    "type": "string"
-                },
-                "price": {
-                    "type": "number"
-                },
-                "product_id": {
+                "text": {
                     "type": "string"
                 },
-                "quantity": {
-                    "type": "integer"
-                },
-                "total_price": {
-                    "type": "number"
-                },
-                "updated_at": {
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "entity.OrderList": {
+        "entity.SuggestionList": {
             "type": "object",
             "properties": {
-                "count": {
-                    "type": "integer"
-                },
                 "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/entity.Order"
+                        "$ref": "#/definitions/entity.Suggestion"
                     }
                 }
             }
         },
-        "entity.Product": {
+        "entity.TaxCategory": {
             "type": "object",
             "properties": {
-                "category_id": {
-                    "type": "string"
-                },
                 "created_at": {
                     "type": "string"
                 },
-                "description": {
-                    "type": "string"
-                },
                 "id": {
                     "type": "string"
                 },
-                "image_url": {
-                    "type": "string"
-                },
                 "name": {
                     "type": "string"
                 },
-                "price": {
-                    "type": "number"
+                "rate": {
+                    "type": "number",
+                    "example": 12
                 },
                 "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "entity.ProductList": {
+        "entity.TaxCategoryList": {
             "type": "object",
             "properties": {
                 "count": {
@@ -3226,24 +9419,33 @@ const docTemplate = `{
                 "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/entity.Product"
+                        "$ref": "#/definitions/entity.TaxCategory"
                     }
                 }
             }
         },
-        "entity.RegisterRequest": {
+        "entity.TelegramLoginRequest": {
             "type": "object",
             "properties": {
-                "email": {
+                "auth_date": {
+                    "type": "integer"
+                },
+                "first_name": {
                     "type": "string"
                 },
-                "full_name": {
+                "hash": {
                     "type": "string"
                 },
-                "gender": {
+                "id": {
+                    "type": "integer"
+                },
+                "last_name": {
                     "type": "string"
                 },
-                "password": {
+                "photo_url": {
+                    "type": "string"
+                },
+                "platform": {
                     "type": "string"
                 },
                 "username": {
@@ -3251,93 +9453,94 @@ const docTemplate = `{
                 }
             }
         },
-        "entity.Report": {
+        "entity.Translation": {
             "type": "object",
             "properties": {
                 "created_at": {
                     "type": "string"
                 },
-                "id": {
+                "description": {
                     "type": "string"
                 },
-                "reason": {
+                "locale": {
                     "type": "string"
                 },
-                "updated_at": {
+                "name": {
                     "type": "string"
                 },
-                "user_id": {
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "entity.ReportList": {
+        "entity.TranslationList": {
             "type": "object",
             "properties": {
-                "count": {
-                    "type": "integer"
-                },
                 "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/entity.Report"
+                        "$ref": "#/definitions/entity.Translation"
                     }
                 }
             }
         },
-        "entity.Session": {
+        "entity.TwoFactorChallenge": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "expires_at": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "ip_address": {
-                    "type": "string"
+                "expires_in": {
+                    "type": "integer"
                 },
-                "is_active": {
+                "setup_required": {
                     "type": "boolean"
                 },
-                "last_active_at": {
-                    "type": "string"
-                },
-                "platform": {
-                    "type": "string"
+                "two_factor_required": {
+                    "type": "boolean"
                 },
-                "updated_at": {
+                "two_factor_token": {
                     "type": "string"
-                },
-                "user_agent": {
+                }
+            }
+        },
+        "entity.TwoFactorCodeRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
                     "type": "string"
-                },
-                "user_id": {
+                }
+            }
+        },
+        "entity.TwoFactorSetupRequest": {
+            "type": "object",
+            "properties": {
+                "two_factor_token": {
                     "type": "string"
                 }
             }
         },
-        "entity.SessionList": {
+        "entity.TwoFactorSetupResponse": {
             "type": "object",
             "properties": {
-                "count": {
-                    "type": "integer"
+                "provisioning_uri": {
+                    "type": "string"
                 },
-                "sessions": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/entity.Session"
-                    }
+                "secret": {
+                    "type": "string"
                 }
             }
         },
-        "entity.SuccessResponse": {
+        "entity.TwoFactorVerifyRequest": {
             "type": "object",
             "properties": {
-                "message": {
+                "code": {
+                    "type": "string"
+                },
+                "recovery_code": {
+                    "type": "string"
+                },
+                "two_factor_token": {
                     "type": "string"
                 }
             }
@@ -3368,6 +9571,13 @@ const docTemplate = `{
                 "email": {
                     "type": "string"
                 },
+                "excluded_allergens": {
+                    "description": "ExcludedAllergens are left out of the menu of the user, unchanged when\nmissing from an update.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "full_name": {
                     "type": "string"
                 },
@@ -3377,10 +9587,10 @@ const docTemplate = `{
                 "id": {
                     "type": "string"
                 },
-                "password": {
-                    "type": "string"
+                "images": {
+                    "$ref": "#/definitions/entity.ImageVariants"
                 },
-                "profile_picture": {
+                "password": {
                     "type": "string"
                 },
                 "status": {
@@ -3465,6 +9675,11 @@ const docTemplate = `{
         }
     },
     "securityDefinitions": {
+        "ApiKeyAuth": {
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
         "BearerAuth": {
             "type": "apiKey",
             "name": "Authorization",
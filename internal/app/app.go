@@ -2,19 +2,28 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/Akrom0181/Food-Delivery/config"
 	v1 "github.com/Akrom0181/Food-Delivery/internal/controller/http/v1"
+	"github.com/Akrom0181/Food-Delivery/internal/kitchen"
 	"github.com/Akrom0181/Food-Delivery/internal/usecase"
+	"github.com/Akrom0181/Food-Delivery/internal/worker"
+	"github.com/Akrom0181/Food-Delivery/pkg/fiscal"
 	"github.com/Akrom0181/Food-Delivery/pkg/httpserver"
 	"github.com/Akrom0181/Food-Delivery/pkg/logger"
+	"github.com/Akrom0181/Food-Delivery/pkg/money"
+	"github.com/Akrom0181/Food-Delivery/pkg/oauth"
 	"github.com/Akrom0181/Food-Delivery/pkg/postgres"
+	"github.com/Akrom0181/Food-Delivery/pkg/rbac"
+	"github.com/Akrom0181/Food-Delivery/pkg/storage"
 	rediscache "github.com/golanguzb70/redis-cache"
 )
 
@@ -22,6 +31,16 @@ import (
 func Run(cfg *config.Config) {
 	l := logger.New(cfg.Log.Level)
 
+	// Money
+	if _, ok := money.LookupCurrency(cfg.Money.Currency); !ok {
+		l.Fatal(fmt.Errorf("app - Run - unsupported currency %q", cfg.Money.Currency))
+	}
+	encoding, ok := money.ParseEncoding(cfg.Money.JSONEncoding)
+	if !ok {
+		l.Fatal(fmt.Errorf("app - Run - unknown money json encoding %q", cfg.Money.JSONEncoding))
+	}
+	money.SetEncoding(encoding)
+
 	// Repository
 	pg, err := postgres.New(cfg.PG.URL, postgres.MaxPoolSize(cfg.PG.PoolMax))
 	if err != nil {
@@ -41,9 +60,46 @@ func Run(cfg *config.Config) {
 		l.Fatal(fmt.Errorf("app - Run - rediscache.New: %w", err))
 	}
 
+	// Access policies
+	enforcer, err := rbac.NewEnforcer("config/rbac.conf", pg, cfg.PG.URL, l)
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - Run - rbac.NewEnforcer: %w", err))
+	}
+	defer enforcer.Close()
+
+	// Social login
+	providers := newOAuthProviders(cfg, l)
+
+	// File storage
+	store, err := newStorage(cfg)
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - Run - newStorage: %w", err))
+	}
+
+	// Fiscal receipts
+	fiscalProvider, err := newFiscalProvider(cfg)
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - Run - newFiscalProvider: %w", err))
+	}
+
+	// Kitchen display updates
+	kitchenBroker := kitchen.NewBroker(cfg.PG.URL, func(err error) {
+		l.Error(fmt.Errorf("app - Run - kitchen.Broker: %w", err))
+	})
+	defer kitchenBroker.Close()
+
+	// Background jobs
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	go worker.NewUploadSweeper(useCase.UploadRepo, store, l, config.UploadOrphanGracePeriod, config.UploadSweepInterval).Run(workerCtx)
+	go worker.NewPriceScheduler(useCase.ProductRepo, l, config.PriceScheduleInterval).Run(workerCtx)
+	go worker.NewOrderScheduler(useCase.OrderRepo, l, config.ScheduleReleaseInterval).Run(workerCtx)
+	go worker.NewReceiptRegistrar(useCase.ReceiptRepo, fiscalProvider, l, config.ReceiptRegisterInterval, config.ReceiptRetryDelay).Run(workerCtx)
+
 	// HTTP Server
 	handler := gin.New()
-	v1.NewRouter(handler, l, cfg, useCase, redis)
+	v1.NewRouter(handler, l, cfg, useCase, redis, providers, enforcer, store, kitchenBroker)
 
 	httpServer := httpserver.New(handler, httpserver.Port(cfg.HTTP.Port))
 
@@ -66,3 +122,72 @@ func Run(cfg *config.Config) {
 		l.Error(fmt.Errorf("app - Run - httpServer.Shutdown: %w", err))
 	}
 }
+
+// newOAuthProviders discovers the configured social login providers. A provider
+// that can not be reached is logged and left out instead of stopping the app.
+func newOAuthProviders(cfg *config.Config, l *logger.Logger) map[string]oauth.Provider {
+	providers := map[string]oauth.Provider{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if cfg.OAuth.GoogleClientID != "" {
+		provider, err := oauth.NewGoogle(ctx, cfg.OAuth.GoogleClientID, cfg.OAuth.GoogleClientSecret, cfg.OAuth.GoogleRedirectURL)
+		if err != nil {
+			l.Error(fmt.Errorf("app - Run - oauth.NewGoogle: %w", err))
+		} else {
+			providers[provider.Name()] = provider
+		}
+	}
+
+	if cfg.OAuth.AppleClientID != "" {
+		provider, err := oauth.NewApple(ctx, oauth.AppleConfig{
+			ClientID:    cfg.OAuth.AppleClientID,
+			TeamID:      cfg.OAuth.AppleTeamID,
+			KeyID:       cfg.OAuth.AppleKeyID,
+			PrivateKey:  cfg.OAuth.ApplePrivateKey,
+			RedirectURL: cfg.OAuth.AppleRedirectURL,
+		})
+		if err != nil {
+			l.Error(fmt.Errorf("app - Run - oauth.NewApple: %w", err))
+		} else {
+			providers[provider.Name()] = provider
+		}
+	}
+
+	return providers
+}
+
+// newStorage returns the storage backend selected by cfg.Storage.Driver.
+func newStorage(cfg *config.Config) (storage.Storage, error) {
+	switch cfg.Storage.Driver {
+	case "firebase":
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		return storage.NewFirebase(ctx, cfg.Storage.FirebaseCredentials, cfg.Storage.FirebaseBucket)
+	case "s3":
+		return storage.NewS3(storage.S3Config{
+			Endpoint:  cfg.Storage.S3Endpoint,
+			Region:    cfg.Storage.S3Region,
+			Bucket:    cfg.Storage.S3Bucket,
+			AccessKey: cfg.Storage.S3AccessKey,
+			SecretKey: cfg.Storage.S3SecretKey,
+			UseSSL:    cfg.Storage.S3UseSSL,
+			PublicURL: cfg.Storage.S3PublicURL,
+		})
+	case "local":
+		return storage.NewLocal(cfg.Storage.LocalDir, cfg.Storage.LocalBaseURL)
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", cfg.Storage.Driver)
+	}
+}
+
+func newFiscalProvider(cfg *config.Config) (fiscal.Provider, error) {
+	switch cfg.Fiscal.Provider {
+	case "fake":
+		return fiscal.NewFake(cfg.Fiscal.TerminalID, cfg.Fiscal.CheckURL), nil
+	default:
+		return nil, fmt.Errorf("unknown fiscal provider %q", cfg.Fiscal.Provider)
+	}
+}
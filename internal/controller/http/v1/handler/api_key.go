@@ -0,0 +1,481 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/Akrom0181/Food-Delivery/pkg/apikey"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"golang.org/x/time/rate"
+)
+
+// apiKeyLimiter keeps a token bucket per API key and remembers when
+// last_used_at was last written. Limits are counted per instance.
+type apiKeyLimiter struct {
+	mu       sync.Mutex
+	buckets  map[string]*rate.Limiter
+	lastUsed map[string]time.Time
+}
+
+func newAPIKeyLimiter() *apiKeyLimiter {
+	return &apiKeyLimiter{
+		buckets:  map[string]*rate.Limiter{},
+		lastUsed: map[string]time.Time{},
+	}
+}
+
+// allow takes a token from the bucket of the key, perMinute may change between calls.
+func (l *apiKeyLimiter) allow(id string, perMinute int) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	bucket, ok := l.buckets[id]
+	if !ok || bucket.Burst() != perMinute {
+		bucket = rate.NewLimiter(rate.Limit(float64(perMinute)/60), perMinute)
+		l.buckets[id] = bucket
+	}
+
+	return bucket.Allow()
+}
+
+// touch reports whether last_used_at of the key is due to be written.
+func (l *apiKeyLimiter) touch(id string, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastUsed[id]) < config.APIKeyLastUsedInterval {
+		return false
+	}
+
+	l.lastUsed[id] = now
+	return true
+}
+
+// apiKeyFromRequest returns the key sent as X-API-Key or as "Authorization: ApiKey <key>".
+func apiKeyFromRequest(c *gin.Context) string {
+	if key := c.GetHeader("X-API-Key"); key != "" {
+		return key
+	}
+
+	if auth := c.GetHeader("Authorization"); strings.HasPrefix(auth, "ApiKey ") {
+		return strings.TrimSpace(strings.TrimPrefix(auth, "ApiKey "))
+	}
+
+	return ""
+}
+
+// authenticateAPIKey checks the key and its rate limit and sets the identity
+// headers of the account the key acts for.
+func (h *Handler) authenticateAPIKey(c *gin.Context, key string) (entity.APIKey, bool) {
+	prefix, ok := apikey.Prefix(key)
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid API key"})
+		return entity.APIKey{}, false
+	}
+
+	apiKey, err := h.UseCase.APIKeyRepo.GetByPrefix(c, prefix)
+	if err != nil {
+		if err != pgx.ErrNoRows {
+			h.Logger.Error(err, "Error getting api key")
+		}
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid API key"})
+		return entity.APIKey{}, false
+	}
+
+	now := time.Now().UTC()
+
+	valid := apikey.Check(key, apiKey.KeyHash)
+	if !valid && apiKey.PreviousExpiresAt != "" {
+		graceUntil, err := time.Parse(time.RFC3339, apiKey.PreviousExpiresAt)
+		valid = err == nil && now.Before(graceUntil) && apikey.Check(key, apiKey.PreviousKeyHash)
+	}
+
+	if !valid {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid API key"})
+		return entity.APIKey{}, false
+	}
+
+	if !apiKey.IsActive {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "API key is revoked"})
+		return entity.APIKey{}, false
+	}
+
+	if apiKey.ExpiresAt != "" {
+		expiresAt, err := time.Parse(time.RFC3339, apiKey.ExpiresAt)
+		if err == nil && !now.Before(expiresAt) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "API key has expired"})
+			return entity.APIKey{}, false
+		}
+	}
+
+	if !h.apiKeys.allow(apiKey.ID, apiKey.RateLimit) {
+		c.Header("Retry-After", "60")
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "Rate limit exceeded"})
+		return entity.APIKey{}, false
+	}
+
+	if h.apiKeys.touch(apiKey.ID, now) {
+		go func(id string) {
+			err := h.UseCase.APIKeyRepo.UpdateLastUsed(context.Background(), entity.Id{ID: id})
+			if err != nil {
+				h.Logger.Error(err, "Error updating api key last_used_at")
+			}
+		}(apiKey.ID)
+	}
+
+	c.Request.Header.Set("sub", apiKey.UserID)
+	c.Request.Header.Set("user_role", "apikey")
+	c.Request.Header.Set("user_type", "apikey")
+	c.Request.Header.Set("platform", "api")
+	c.Request.Header.Set("api_key_id", apiKey.ID)
+	c.Set("api_key_branches", apiKey.BranchIDs)
+
+	return apiKey, true
+}
+
+// prepareAPIKey trims and checks the fields an admin may set on a key.
+func (h *Handler) prepareAPIKey(ctx *gin.Context, body *entity.APIKey) bool {
+	body.Name = strings.TrimSpace(body.Name)
+	if body.Name == "" {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Name is required", 400)
+		return false
+	}
+
+	seen := map[string]bool{}
+	scopes := make([]string, 0, len(body.Scopes))
+	for _, scope := range body.Scopes {
+		scope = strings.TrimSpace(scope)
+		if scope == "" || seen[scope] {
+			continue
+		}
+
+		// a key must never get more than an admin can grant, and keys are not
+		// limited to branches or a restaurant like branch staff and
+		// restaurant admins are
+		if scope == "admin" || scope == "superadmin" || strings.HasPrefix(scope, "apikey:") || assignedRole(scope) {
+			h.ReturnError(ctx, config.ErrorBadRequest, "Scope "+scope+" can not be granted to an API key", 400)
+			return false
+		}
+
+		seen[scope] = true
+		scopes = append(scopes, scope)
+	}
+
+	if len(scopes) == 0 {
+		h.ReturnError(ctx, config.ErrorBadRequest, "At least one scope is required", 400)
+		return false
+	}
+	body.Scopes = scopes
+
+	branchIDs := make([]string, 0, len(body.BranchIDs))
+	for _, branchID := range body.BranchIDs {
+		if _, err := uuid.Parse(branchID); err != nil {
+			h.ReturnError(ctx, config.ErrorBadRequest, "Invalid branch_ids", 400)
+			return false
+		}
+		if !slices.Contains(branchIDs, branchID) {
+			branchIDs = append(branchIDs, branchID)
+		}
+	}
+	body.BranchIDs = branchIDs
+
+	// a printer key would else claim the tickets of every branch
+	if slices.Contains(scopes, "printer") && len(branchIDs) == 0 {
+		h.ReturnError(ctx, config.ErrorBadRequest, "A printer key needs at least one branch in branch_ids", 400)
+		return false
+	}
+
+	if body.RateLimit <= 0 {
+		body.RateLimit = config.APIKeyDefaultRateLimit
+	}
+
+	if body.ExpiresAt != "" {
+		expiresAt, err := time.Parse(time.RFC3339, body.ExpiresAt)
+		if err != nil {
+			h.ReturnError(ctx, config.ErrorBadRequest, "expires_at must be an RFC3339 time", 400)
+			return false
+		}
+		body.ExpiresAt = expiresAt.UTC().Format(time.RFC3339)
+	}
+
+	return true
+}
+
+// CreateAPIKey godoc
+// @Router /api-key [post]
+// @Summary Create an API key
+// @Description Creates a key for a partner or service account. The key acts as user_id and may call what its scopes (roles) allow. A key with the printer scope only prints for its branch_ids. The key is only returned in this response.
+// @Security BearerAuth
+// @Tags api-key
+// @Accept  json
+// @Produce  json
+// @Param api_key body entity.APIKey true "API key object"
+// @Success 201 {object} entity.APIKeySecret
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) CreateAPIKey(ctx *gin.Context) {
+	var (
+		body entity.APIKey
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	if body.UserID == "" {
+		h.ReturnError(ctx, config.ErrorBadRequest, "user_id is required", 400)
+		return
+	}
+
+	if !h.prepareAPIKey(ctx, &body) {
+		return
+	}
+
+	key, prefix, err := apikey.Generate()
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorInternalServer, "Oops, something went wrong!!!", http.StatusInternalServerError)
+		return
+	}
+
+	body.Prefix = prefix
+	body.KeyHash = apikey.Hash(key)
+	body.CreatedBy = ctx.GetHeader("sub")
+
+	apiKey, err := h.UseCase.APIKeyRepo.Create(ctx, body)
+	if h.HandleDbError(ctx, err, "Error creating api key") {
+		return
+	}
+
+	if !h.applyPolicies(ctx) {
+		return
+	}
+
+	ctx.JSON(201, entity.APIKeySecret{APIKey: apiKey, Key: key})
+}
+
+// GetAPIKey godoc
+// @Router /api-key/{id} [get]
+// @Summary Get an API key by ID
+// @Description Get an API key by ID
+// @Security BearerAuth
+// @Tags api-key
+// @Accept  json
+// @Produce  json
+// @Param id path string true "API key ID"
+// @Success 200 {object} entity.APIKey
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetAPIKey(ctx *gin.Context) {
+	var (
+		req entity.Id
+	)
+
+	req.ID = ctx.Param("id")
+
+	apiKey, err := h.UseCase.APIKeyRepo.GetSingle(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting api key") {
+		return
+	}
+
+	ctx.JSON(200, apiKey)
+}
+
+// GetAPIKeys godoc
+// @Router /api-key/list [get]
+// @Summary Get a list of API keys
+// @Description Get a list of API keys
+// @Security BearerAuth
+// @Tags api-key
+// @Accept  json
+// @Produce  json
+// @Param page query number true "page"
+// @Param limit query number true "limit"
+// @Param user_id query string false "user_id"
+// @Param is_active query bool false "is_active"
+// @Param search query string false "search by name"
+// @Success 200 {object} entity.APIKeyList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetAPIKeys(ctx *gin.Context) {
+	var (
+		req entity.GetListFilter
+	)
+
+	page := ctx.DefaultQuery("page", "1")
+	limit := ctx.DefaultQuery("limit", "10")
+	userId := ctx.DefaultQuery("user_id", "")
+	isActive := ctx.DefaultQuery("is_active", "")
+	search := ctx.DefaultQuery("search", "")
+
+	req.Page, _ = strconv.Atoi(page)
+	req.Limit, _ = strconv.Atoi(limit)
+
+	if userId != "" {
+		req.Filters = append(req.Filters, entity.Filter{
+			Column: "user_id",
+			Type:   "eq",
+			Value:  userId,
+		})
+	}
+
+	if isActive != "" {
+		req.Filters = append(req.Filters, entity.Filter{
+			Column: "is_active",
+			Type:   "eq",
+			Value:  strconv.FormatBool(isActive == "true"),
+		})
+	}
+
+	req.Filters = append(req.Filters, entity.Filter{
+		Column: "name",
+		Type:   "search",
+		Value:  search,
+	})
+
+	req.OrderBy = append(req.OrderBy, entity.OrderBy{
+		Column: "created_at",
+		Order:  "desc",
+	})
+
+	apiKeys, err := h.UseCase.APIKeyRepo.GetList(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting api keys") {
+		return
+	}
+
+	ctx.JSON(200, apiKeys)
+}
+
+// UpdateAPIKey godoc
+// @Router /api-key [put]
+// @Summary Update an API key
+// @Description Changes the name, scopes, branches, rate limit (requests per minute) and expiry of an active key.
+// @Security BearerAuth
+// @Tags api-key
+// @Accept  json
+// @Produce  json
+// @Param api_key body entity.APIKey true "API key object"
+// @Success 200 {object} entity.APIKey
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) UpdateAPIKey(ctx *gin.Context) {
+	var (
+		body entity.APIKey
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	if !h.prepareAPIKey(ctx, &body) {
+		return
+	}
+
+	apiKey, err := h.UseCase.APIKeyRepo.Update(ctx, body)
+	if h.HandleDbError(ctx, err, "Error updating api key") {
+		return
+	}
+
+	if !h.applyPolicies(ctx) {
+		return
+	}
+
+	ctx.JSON(200, apiKey)
+}
+
+// RotateAPIKey godoc
+// @Router /api-key/{id}/rotate [post]
+// @Summary Rotate an API key
+// @Description Issues a new key with the same prefix. The old key keeps working for grace_minutes (at most 7 days) so the partner can switch without downtime.
+// @Security BearerAuth
+// @Tags api-key
+// @Accept  json
+// @Produce  json
+// @Param id path string true "API key ID"
+// @Param rotate body entity.APIKeyRotateRequest false "Rotate request"
+// @Success 200 {object} entity.APIKeySecret
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) RotateAPIKey(ctx *gin.Context) {
+	var (
+		req  entity.Id
+		body entity.APIKeyRotateRequest
+	)
+
+	req.ID = ctx.Param("id")
+
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&body); err != nil {
+			h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+			return
+		}
+	}
+
+	grace := time.Duration(body.GraceMinutes) * time.Minute
+	if grace < 0 || grace > config.APIKeyMaxGracePeriod {
+		h.ReturnError(ctx, config.ErrorBadRequest, "grace_minutes must be between 0 and 10080", 400)
+		return
+	}
+
+	apiKey, err := h.UseCase.APIKeyRepo.GetSingle(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting api key") {
+		return
+	}
+
+	key, err := apikey.WithPrefix(apiKey.Prefix)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorInternalServer, "Oops, something went wrong!!!", http.StatusInternalServerError)
+		return
+	}
+
+	err = h.UseCase.APIKeyRepo.Rotate(ctx, req, apikey.Hash(key), time.Now().UTC().Add(grace))
+	if h.HandleDbError(ctx, err, "Error rotating api key") {
+		return
+	}
+
+	apiKey, err = h.UseCase.APIKeyRepo.GetSingle(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting api key") {
+		return
+	}
+
+	ctx.JSON(200, entity.APIKeySecret{APIKey: apiKey, Key: key})
+}
+
+// RevokeAPIKey godoc
+// @Router /api-key/{id} [delete]
+// @Summary Revoke an API key
+// @Description Deactivates the key and removes its scopes. The key stays listed for auditing.
+// @Security BearerAuth
+// @Tags api-key
+// @Accept  json
+// @Produce  json
+// @Param id path string true "API key ID"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) RevokeAPIKey(ctx *gin.Context) {
+	var (
+		req entity.Id
+	)
+
+	req.ID = ctx.Param("id")
+
+	err := h.UseCase.APIKeyRepo.Revoke(ctx, req)
+	if h.HandleDbError(ctx, err, "Error revoking api key") {
+		return
+	}
+
+	if !h.applyPolicies(ctx) {
+		return
+	}
+
+	ctx.JSON(200, entity.SuccessResponse{
+		Message: "API key revoked successfully",
+	})
+}
@@ -22,6 +22,7 @@ import (
 // @Produce  json
 // @Param body body entity.LoginRequest true "User"
 // @Success 200 {object} entity.SuccessResponse
+// @Success 202 {object} entity.TwoFactorChallenge
 // @Failure 400 {object} entity.ErrorResponse
 func (h *Handler) Login(ctx *gin.Context) {
 	var (
@@ -58,7 +59,29 @@ func (h *Handler) Login(ctx *gin.Context) {
 		return
 	}
 
-	// create session
+	challenge, required, ok := h.requireTwoFactor(ctx, user, body.Platform)
+	if !ok {
+		return
+	}
+
+	if required {
+		ctx.JSON(202, challenge)
+		return
+	}
+
+	user, session, ok := h.openSession(ctx, user, body.Platform)
+	if !ok {
+		return
+	}
+
+	ctx.JSON(200, gin.H{
+		"user":    user,
+		"session": session,
+	})
+}
+
+// openSession creates a session for the user and signs its access token.
+func (h *Handler) openSession(ctx *gin.Context, user entity.User, platform string) (entity.User, entity.Session, bool) {
 	newSession := entity.Session{
 		UserID:       user.ID,
 		IPAddress:    ctx.ClientIP(),
@@ -66,12 +89,12 @@ func (h *Handler) Login(ctx *gin.Context) {
 		UserAgent:    ctx.Request.UserAgent(),
 		IsActive:     true,
 		LastActiveAt: time.Now().Format(time.RFC3339),
-		Platform:     body.Platform,
+		Platform:     platform,
 	}
 
 	session, err := h.UseCase.SessionRepo.Create(ctx, newSession)
 	if h.HandleDbError(ctx, err, "Error while creating new session") {
-		return
+		return user, session, false
 	}
 
 	// generate jwt token
@@ -79,20 +102,17 @@ func (h *Handler) Login(ctx *gin.Context) {
 		"sub":        user.ID,
 		"user_role":  user.UserRole,
 		"user_type":  user.UserType,
-		"platform":   body.Platform,
+		"platform":   platform,
 		"session_id": session.ID,
 	}
 
 	user.AccessToken, err = jwt.GenerateJWT(jwtFields, h.Config.JWT.Secret)
 	if err != nil {
 		h.ReturnError(ctx, config.ErrorInternalServer, "Oops, something went wrong!!!", http.StatusInternalServerError)
-		return
+		return user, session, false
 	}
 
-	ctx.JSON(200, gin.H{
-		"user":    user,
-		"session": session,
-	})
+	return user, session, true
 }
 
 // Logout godoc
@@ -248,34 +268,8 @@ func (h *Handler) VerifyEmail(ctx *gin.Context) {
 		return
 	}
 
-	// create session
-	newSession := entity.Session{
-		UserID:       user.ID,
-		IPAddress:    ctx.ClientIP(),
-		ExpiresAt:    time.Now().Add(time.Hour * 999999).Format(time.RFC3339),
-		UserAgent:    ctx.Request.UserAgent(),
-		IsActive:     true,
-		LastActiveAt: time.Now().Format(time.RFC3339),
-		Platform:     body.Platform,
-	}
-
-	session, err := h.UseCase.SessionRepo.Create(ctx, newSession)
-	if h.HandleDbError(ctx, err, "Error while creating new session") {
-		return
-	}
-
-	// generate jwt token
-	jwtFields := map[string]interface{}{
-		"sub":        user.ID,
-		"user_role":  user.UserRole,
-		"user_type":  user.UserType,
-		"platform":   body.Platform,
-		"session_id": session.ID,
-	}
-
-	user.AccessToken, err = jwt.GenerateJWT(jwtFields, h.Config.JWT.Secret)
-	if err != nil {
-		h.ReturnError(ctx, config.ErrorInternalServer, "Oops, something went wrong!!!", http.StatusInternalServerError)
+	user, session, ok := h.openSession(ctx, user, body.Platform)
+	if !ok {
 		return
 	}
 
@@ -6,12 +6,16 @@ import (
 	"strings"
 
 	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/Akrom0181/Food-Delivery/pkg/apikey"
 	"github.com/Akrom0181/Food-Delivery/pkg/jwt"
-	"github.com/casbin/casbin"
+	"github.com/Akrom0181/Food-Delivery/pkg/rbac"
 	"github.com/gin-gonic/gin"
 )
 
-func (h *Handler) AuthMiddleware(e *casbin.Enforcer) gin.HandlerFunc {
+// identityHeaders are set from the token or API key, never taken from the client.
+var identityHeaders = []string{"sub", "user_role", "user_type", "platform", "session_id", "api_key_id"}
+
+func (h *Handler) AuthMiddleware(e *rbac.Enforcer) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var (
 			userRole string
@@ -19,6 +23,21 @@ func (h *Handler) AuthMiddleware(e *casbin.Enforcer) gin.HandlerFunc {
 			obj      = c.FullPath()
 		)
 
+		for _, key := range identityHeaders {
+			c.Request.Header.Del(key)
+		}
+
+		// partner and service accounts authenticate with an API key instead of a session
+		if key := apiKeyFromRequest(c); key != "" {
+			apiKey, ok := h.authenticateAPIKey(c, key)
+			if !ok {
+				return
+			}
+
+			h.enforce(c, e, apikey.Subject(apiKey.ID), obj, act)
+			return
+		}
+
 		token := c.GetHeader("Authorization")
 		if token == "" {
 			userRole = "unauthorized"
@@ -60,18 +79,22 @@ func (h *Handler) AuthMiddleware(e *casbin.Enforcer) gin.HandlerFunc {
 			}
 		}
 
-		ok, err := e.EnforceSafe(userRole, obj, act)
-		if err != nil {
-			h.Logger.Error(err, "Error enforcing policy")
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "access denied"})
-			return
-		}
+		h.enforce(c, e, userRole, obj, act)
+	}
+}
 
-		if !ok {
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "access denied"})
-			return
-		}
+func (h *Handler) enforce(c *gin.Context, e *rbac.Enforcer, sub, obj, act string) {
+	ok, err := e.EnforceSafe(sub, obj, act)
+	if err != nil {
+		h.Logger.Error(err, "Error enforcing policy")
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "access denied"})
+		return
+	}
 
-		c.Next()
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "access denied"})
+		return
 	}
+
+	c.Next()
 }
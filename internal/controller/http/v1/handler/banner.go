@@ -5,7 +5,6 @@ import (
 
 	"github.com/Akrom0181/Food-Delivery/config"
 	"github.com/Akrom0181/Food-Delivery/internal/entity"
-	"github.com/Akrom0181/Food-Delivery/pkg/firebase"
 	"github.com/gin-gonic/gin"
 )
 
@@ -18,6 +17,7 @@ import (
 // @Accept  json
 // @Produce  json
 // @Param id path string true "Banner ID"
+// @Param lang query string false "locale: uz, ru or en, overrides Accept-Language"
 // @Success 200 {object} entity.Banner
 // @Failure 400 {object} entity.ErrorResponse
 func (h *Handler) GetBanner(ctx *gin.Context) {
@@ -26,6 +26,7 @@ func (h *Handler) GetBanner(ctx *gin.Context) {
 	)
 
 	req.ID = ctx.Param("id")
+	req.Locales = h.locales(ctx)
 
 	banner, err := h.UseCase.BannerRepo.GetSingle(ctx, req)
 	if h.HandleDbError(ctx, err, "Error getting banner") {
@@ -46,6 +47,8 @@ func (h *Handler) GetBanner(ctx *gin.Context) {
 // @Param page query number true "page"
 // @Param limit query number true "limit"
 // @Param search query string false "search"
+// @Param restaurant_id query string false "Restaurant ID, the default restaurant when empty"
+// @Param lang query string false "locale: uz, ru or en, overrides Accept-Language"
 // @Success 200 {object} entity.BannerList
 // @Failure 400 {object} entity.ErrorResponse
 func (h *Handler) GetBanners(ctx *gin.Context) {
@@ -59,6 +62,7 @@ func (h *Handler) GetBanners(ctx *gin.Context) {
 
 	req.Page, _ = strconv.Atoi(page)
 	req.Limit, _ = strconv.Atoi(limit)
+	req.Locales = h.locales(ctx)
 	req.Filters = append(req.Filters,
 		entity.Filter{
 			Column: "title",
@@ -67,6 +71,17 @@ func (h *Handler) GetBanners(ctx *gin.Context) {
 		},
 	)
 
+	restaurantID, ok := h.restaurantScope(ctx)
+	if !ok {
+		return
+	}
+
+	req.Filters = append(req.Filters, entity.Filter{
+		Column: "restaurant_id",
+		Type:   "eq",
+		Value:  restaurantID,
+	})
+
 	req.OrderBy = append(req.OrderBy, entity.OrderBy{
 		Column: "created_at",
 		Order:  "desc",
@@ -102,6 +117,10 @@ func (h *Handler) UpdateBanner(ctx *gin.Context) {
 		return
 	}
 
+	if !h.checkRestaurantRows(ctx, "banner", body.Id) {
+		return
+	}
+
 	banner, err := h.UseCase.BannerRepo.Update(ctx, body)
 	if h.HandleDbError(ctx, err, "Error updating banner") {
 		return
@@ -132,6 +151,10 @@ func (h *Handler) DeleteBanner(ctx *gin.Context) {
 		req.ID = ctx.GetHeader("sub")
 	}
 
+	if !h.checkRestaurantRows(ctx, "banner", req.ID) {
+		return
+	}
+
 	err := h.UseCase.BannerRepo.Delete(ctx, req)
 	if h.HandleDbError(ctx, err, "Error deleting banner") {
 		return
@@ -151,9 +174,10 @@ func (h *Handler) DeleteBanner(ctx *gin.Context) {
 // @Tags banner
 // @Accept multipart/form-data
 // @Produce json
-// @Param file formData []file true "File to upload"
-// @Param banner body entity.Banner true "Banner object"
-// @Success 200 {object} entity.MultipleFileUploadResponse "Success Request"
+// @Param file formData file true "Banner image"
+// @Param title formData string true "Banner title"
+// @Param restaurant_id formData string false "Restaurant ID, the default restaurant when empty"
+// @Success 200 {object} entity.Banner "Success Request"
 // @Failure 400 {object} entity.ErrorResponse "Bad Request"
 // @Failure 500 {object} entity.ErrorResponse "Server error"
 func (h *Handler) UploadBanner(ctx *gin.Context) {
@@ -167,16 +191,23 @@ func (h *Handler) UploadBanner(ctx *gin.Context) {
 		return
 	}
 
-	if ctx.GetHeader("user_type") != "admin" {
+	if ctx.GetHeader("user_type") != "admin" && !isRestaurantAdmin(ctx) {
 		return
 	}
 
-	resp, err := firebase.UploadFiles(form)
-	if h.HandleDbError(ctx, err, "Error uploading files") {
+	body.Title = ctx.PostForm("title")
+
+	restaurantID, ok := h.restaurantFor(ctx, ctx.PostForm("restaurant_id"))
+	if !ok {
 		return
 	}
+	body.RestaurantID = restaurantID
 
-	body.ImageUrl = resp.Url[0].Url
+	images, ok := h.uploadImage(ctx, form)
+	if !ok {
+		return
+	}
+	body.Images = images
 
 	banner, err := h.UseCase.BannerRepo.Create(ctx, body)
 	if h.HandleDbError(ctx, err, "Error creating banner") {
@@ -11,7 +11,7 @@ import (
 // CreateBranch godoc
 // @Router /branch [post]
 // @Summary Create a new branch
-// @Description Create a new branch
+// @Description Create a new branch of the restaurant, the default one when it is empty.
 // @Security BearerAuth
 // @Tags branch
 // @Accept  json
@@ -30,6 +30,12 @@ func (h *Handler) CreateBranch(ctx *gin.Context) {
 		return
 	}
 
+	restaurantID, ok := h.restaurantFor(ctx, body.RestaurantID)
+	if !ok {
+		return
+	}
+	body.RestaurantID = restaurantID
+
 	branch, err := h.UseCase.BranchRepo.Create(ctx, body)
 	if h.HandleDbError(ctx, err, "Error creating branch") {
 		return
@@ -75,6 +81,7 @@ func (h *Handler) GetBranch(ctx *gin.Context) {
 // @Param page query number true "page"
 // @Param limit query number true "limit"
 // @Param search query string false "search"
+// @Param restaurant_id query string false "Restaurant ID, the default restaurant when empty"
 // @Success 200 {object} entity.BranchList
 // @Failure 400 {object} entity.ErrorResponse
 func (h *Handler) GetBranches(ctx *gin.Context) {
@@ -96,6 +103,17 @@ func (h *Handler) GetBranches(ctx *gin.Context) {
 		},
 	)
 
+	restaurantID, ok := h.restaurantScope(ctx)
+	if !ok {
+		return
+	}
+
+	req.Filters = append(req.Filters, entity.Filter{
+		Column: "restaurant_id",
+		Type:   "eq",
+		Value:  restaurantID,
+	})
+
 	req.OrderBy = append(req.OrderBy, entity.OrderBy{
 		Column: "created_at",
 		Order:  "desc",
@@ -131,6 +149,12 @@ func (h *Handler) UpdateBranch(ctx *gin.Context) {
 		return
 	}
 
+	// branch managers may update their own branches, restaurant admins
+	// those of their restaurant
+	if !h.checkBranchAccess(ctx, body.Id) || !h.checkRestaurantRows(ctx, "branch", body.Id) {
+		return
+	}
+
 	branch, err := h.UseCase.BranchRepo.Update(ctx, body)
 	if h.HandleDbError(ctx, err, "Error updating branch") {
 		return
@@ -157,6 +181,10 @@ func (h *Handler) DeleteBranch(ctx *gin.Context) {
 
 	req.ID = ctx.Param("id")
 
+	if !h.checkRestaurantRows(ctx, "branch", req.ID) {
+		return
+	}
+
 	err := h.UseCase.BranchRepo.Delete(ctx, req)
 	if h.HandleDbError(ctx, err, "Error deleting branch") {
 		return
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/Akrom0181/Food-Delivery/internal/usecase/repo"
+	"github.com/gin-gonic/gin"
+)
+
+var capacityOverflows = []string{"extend_eta", "reroute", "reject"}
+
+// SaveBranchCapacity godoc
+// @Router /branch/capacity [put]
+// @Summary Set the capacity of a branch
+// @Description A zero limit is no limit. Active orders are confirmed or preparing ones, items are the cooked lines of
+// @Description the orders taken in the last 15 minutes. A new order for a full branch is promised a later ready_eta
+// @Description with extend_eta, goes to the next nearest branch of the restaurant with reroute, or is turned down
+// @Description with reject. max_orders_per_slot limits the scheduled orders of a delivery slot. Branch managers may
+// @Description set the capacity of their own branches.
+// @Security BearerAuth
+// @Tags branch
+// @Accept  json
+// @Produce  json
+// @Param capacity body entity.BranchCapacity true "Capacity"
+// @Success 200 {object} entity.BranchCapacity
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) SaveBranchCapacity(ctx *gin.Context) {
+	var (
+		body entity.BranchCapacity
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil || body.BranchID == "" || body.MaxActiveOrders < 0 || body.MaxItemsPerWindow < 0 || body.MaxOrdersPerSlot < 0 {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	if body.Overflow == "" {
+		body.Overflow = "extend_eta"
+	}
+	if !slices.Contains(capacityOverflows, body.Overflow) {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid overflow", 400)
+		return
+	}
+
+	if !h.checkBranchAccess(ctx, body.BranchID) || !h.checkRestaurantRows(ctx, "branch", body.BranchID) {
+		return
+	}
+
+	capacity, err := h.UseCase.BranchCapacityRepo.Save(ctx, body)
+	if h.HandleDbError(ctx, err, "Error saving branch capacity") {
+		return
+	}
+
+	ctx.JSON(200, capacity)
+}
+
+// GetBranchLoads godoc
+// @Router /branch/load/list [get]
+// @Summary Get the current load of branches
+// @Description Active orders and items of the last 15 minutes against the capacity of every branch, and the delay
+// @Description in minutes a new order would get. Branch staff see their branches, restaurant admins those of their
+// @Description restaurant.
+// @Security BearerAuth
+// @Tags branch
+// @Accept  json
+// @Produce  json
+// @Param page query number true "page"
+// @Param limit query number true "limit"
+// @Param branch_id query string false "Branch ID"
+// @Param restaurant_id query string false "Restaurant ID"
+// @Success 200 {object} entity.BranchLoadList
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+func (h *Handler) GetBranchLoads(ctx *gin.Context) {
+	var (
+		req entity.GetListFilter
+	)
+
+	// customers and couriers may read branches but not how busy they are
+	if !isAdmin(ctx) && !isRestaurantAdmin(ctx) && !isBranchStaff(ctx) {
+		h.ReturnError(ctx, config.ErrorForbidden, "Permission denied", 403)
+		return
+	}
+
+	req.Page, _ = strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	req.Limit, _ = strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+
+	restaurantID := ctx.Query("restaurant_id")
+	if isRestaurantAdmin(ctx) {
+		var ok bool
+		if restaurantID, ok = h.callerRestaurant(ctx); !ok {
+			return
+		}
+	}
+
+	if restaurantID != "" {
+		req.Filters = append(req.Filters, entity.Filter{Column: "b.restaurant_id", Type: "eq", Value: restaurantID})
+	}
+	if branchID := ctx.Query("branch_id"); branchID != "" {
+		req.Filters = append(req.Filters, entity.Filter{Column: "b.id", Type: "eq", Value: branchID})
+	}
+
+	if !h.scopeBranches(ctx, &req, "b.id") {
+		return
+	}
+
+	req.OrderBy = append(req.OrderBy, entity.OrderBy{
+		Column: "b.name",
+		Order:  "asc",
+	})
+
+	loads, err := h.UseCase.BranchCapacityRepo.GetLoads(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting branch loads") {
+		return
+	}
+
+	for i := range loads.Items {
+		loads.Items[i].Delay = int(repo.CapacityDelay(loads.Items[i], 1) / time.Minute)
+	}
+
+	ctx.JSON(200, loads)
+}
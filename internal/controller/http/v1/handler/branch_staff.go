@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"slices"
+	"strconv"
+
+	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// SaveBranchStaff godoc
+// @Router /branch-staff [put]
+// @Summary Assign a user to branches
+// @Description Superadmin only. Makes the user a branch manager or operator of the branches, replacing the
+// @Description branches they had. Admins and couriers can not be made staff. The user is logged out so the role
+// @Description applies from their next login.
+// @Security BearerAuth
+// @Tags branch-staff
+// @Accept  json
+// @Produce  json
+// @Param staff body entity.BranchStaff true "Branch staff"
+// @Success 200 {object} entity.BranchStaff
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) SaveBranchStaff(ctx *gin.Context) {
+	var (
+		body entity.BranchStaff
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil || body.UserID == "" || !slices.Contains(config.BranchStaffRoles, body.Role) {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	slices.Sort(body.BranchIDs)
+	body.BranchIDs = slices.Compact(body.BranchIDs)
+	if len(body.BranchIDs) == 0 || body.BranchIDs[0] == "" {
+		h.ReturnError(ctx, config.ErrorBadRequest, "At least one branch is required", 400)
+		return
+	}
+
+	staff, err := h.UseCase.BranchStaffRepo.Save(ctx, body)
+	if h.HandleDbError(ctx, err, "Error saving branch staff") {
+		return
+	}
+
+	ctx.JSON(200, staff)
+}
+
+// GetBranchStaff godoc
+// @Router /branch-staff/{user_id} [get]
+// @Summary Get the branches of a staff member
+// @Description Superadmin only.
+// @Security BearerAuth
+// @Tags branch-staff
+// @Accept  json
+// @Produce  json
+// @Param user_id path string true "User ID"
+// @Success 200 {object} entity.BranchStaff
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) GetBranchStaff(ctx *gin.Context) {
+	staff, err := h.UseCase.BranchStaffRepo.GetSingle(ctx, entity.Id{ID: ctx.Param("user_id")})
+	if h.HandleDbError(ctx, err, "Error getting branch staff") {
+		return
+	}
+
+	ctx.JSON(200, staff)
+}
+
+// GetBranchStaffList godoc
+// @Router /branch-staff/list [get]
+// @Summary Get a list of branch staff
+// @Description Superadmin only.
+// @Security BearerAuth
+// @Tags branch-staff
+// @Accept  json
+// @Produce  json
+// @Param page query number true "page"
+// @Param limit query number true "limit"
+// @Param branch_id query string false "Only the staff of this branch"
+// @Success 200 {object} entity.BranchStaffList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetBranchStaffList(ctx *gin.Context) {
+	var (
+		req entity.BranchStaffRequest
+	)
+
+	req.Page, _ = strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	req.Limit, _ = strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	req.BranchID = ctx.Query("branch_id")
+
+	staff, err := h.UseCase.BranchStaffRepo.GetList(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting branch staff") {
+		return
+	}
+
+	ctx.JSON(200, staff)
+}
+
+// DeleteBranchStaff godoc
+// @Router /branch-staff/{user_id} [delete]
+// @Summary Remove a staff member from their branches
+// @Description Superadmin only. The user becomes a plain user again and is logged out.
+// @Security BearerAuth
+// @Tags branch-staff
+// @Accept  json
+// @Produce  json
+// @Param user_id path string true "User ID"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) DeleteBranchStaff(ctx *gin.Context) {
+	err := h.UseCase.BranchStaffRepo.Delete(ctx, entity.Id{ID: ctx.Param("user_id")})
+	if h.HandleDbError(ctx, err, "Error deleting branch staff") {
+		return
+	}
+
+	ctx.JSON(200, entity.SuccessResponse{
+		Message: "Branch staff deleted successfully",
+	})
+}
@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// GetBundle godoc
+// @Router /product/{id}/bundle [get]
+// @Summary Get the slots of a bundle
+// @Description A slot with one option is a fixed item, with more the customer chooses one.
+// @Description With a branch, options sold out there are marked unavailable.
+// @Security BearerAuth
+// @Tags product
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Product ID"
+// @Param branch_id query string false "Branch ID"
+// @Param lang query string false "locale: uz, ru or en, overrides Accept-Language"
+// @Success 200 {object} entity.Bundle
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) GetBundle(ctx *gin.Context) {
+	req := entity.BundleRequest{
+		ProductID: ctx.Param("id"),
+		BranchID:  ctx.Query("branch_id"),
+		Locales:   h.locales(ctx),
+	}
+
+	bundle, err := h.UseCase.BundleRepo.Get(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting bundle") {
+		return
+	}
+
+	ctx.JSON(200, bundle)
+}
+
+// SaveBundle godoc
+// @Router /product/{id}/bundle [put]
+// @Summary Make a product a bundle or replace its slots
+// @Description The price of the product is the bundle price, the price_delta of a chosen option is added to it.
+// @Description Every slot needs at least one option and at most one default. Bundles cannot be options.
+// @Description Options have to be products of the restaurant of the bundle.
+// @Security BearerAuth
+// @Tags product
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Product ID"
+// @Param bundle body entity.Bundle true "Bundle"
+// @Success 200 {object} entity.Bundle
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) SaveBundle(ctx *gin.Context) {
+	var (
+		body entity.Bundle
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil || len(body.Slots) == 0 {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	body.ProductID = ctx.Param("id")
+
+	if !h.checkRestaurantRows(ctx, "product", body.ProductID) {
+		return
+	}
+
+	var options []string
+	for i := range body.Slots {
+		slot := &body.Slots[i]
+		if slot.Quantity == 0 {
+			slot.Quantity = 1
+		}
+
+		if slot.Name == "" || slot.Quantity < 0 || len(slot.Options) == 0 {
+			h.ReturnError(ctx, config.ErrorBadRequest, "Every slot needs a name and an option", 400)
+			return
+		}
+
+		defaults := 0
+		for _, option := range slot.Options {
+			if option.ProductID == "" || option.ProductID == body.ProductID {
+				h.ReturnError(ctx, config.ErrorBadRequest, "Invalid option product", 400)
+				return
+			}
+			if option.IsDefault {
+				defaults++
+			}
+			options = append(options, option.ProductID)
+		}
+
+		if defaults > 1 {
+			h.ReturnError(ctx, config.ErrorBadRequest, "A slot can have one default option", 400)
+			return
+		}
+	}
+
+	product, err := h.UseCase.ProductRepo.GetSingle(ctx, entity.Id{ID: body.ProductID})
+	if h.HandleDbError(ctx, err, "Error getting product") {
+		return
+	}
+
+	owns, err := h.UseCase.RestaurantRepo.Owns(ctx, "product", product.RestaurantID, options)
+	if h.HandleDbError(ctx, err, "Error checking bundle options") {
+		return
+	}
+	if !owns {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Options must be products of the restaurant of the bundle", 400)
+		return
+	}
+
+	_, err = h.UseCase.BundleRepo.Save(ctx, body)
+	if h.HandleDbError(ctx, err, "Error saving bundle") {
+		return
+	}
+
+	bundle, err := h.UseCase.BundleRepo.Get(ctx, entity.BundleRequest{ProductID: body.ProductID})
+	if h.HandleDbError(ctx, err, "Error getting bundle") {
+		return
+	}
+
+	ctx.JSON(200, bundle)
+}
+
+// DeleteBundle godoc
+// @Router /product/{id}/bundle [delete]
+// @Summary Remove the slots of a bundle
+// @Description The product stays as a plain product.
+// @Security BearerAuth
+// @Tags product
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Product ID"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) DeleteBundle(ctx *gin.Context) {
+	if !h.checkRestaurantRows(ctx, "product", ctx.Param("id")) {
+		return
+	}
+
+	err := h.UseCase.BundleRepo.Delete(ctx, entity.Id{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error deleting bundle") {
+		return
+	}
+
+	ctx.JSON(200, entity.SuccessResponse{
+		Message: "Bundle deleted successfully",
+	})
+}
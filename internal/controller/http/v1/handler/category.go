@@ -11,7 +11,7 @@ import (
 // CreateCategory godoc
 // @Router /category [post]
 // @Summary Create a new category
-// @Description Create a new category
+// @Description Create a new category. Without a restaurant it is in the one of its parent, or the default one.
 // @Security BearerAuth
 // @Tags category
 // @Accept  json
@@ -30,10 +30,19 @@ func (h *Handler) CreateCategory(ctx *gin.Context) {
 		return
 	}
 
-	if ctx.GetHeader("user_type") != "admin" {
+	if ctx.GetHeader("user_type") != "admin" && !isRestaurantAdmin(ctx) {
 		return
 	}
 
+	// restaurant admins create in their restaurant, a subcategory is in the one of its parent
+	if isRestaurantAdmin(ctx) {
+		restaurantID, ok := h.callerRestaurant(ctx)
+		if !ok {
+			return
+		}
+		body.RestaurantID = restaurantID
+	}
+
 	category, err := h.UseCase.CategoryRepo.Create(ctx, body)
 	if h.HandleDbError(ctx, err, "Error creating category") {
 		return
@@ -51,6 +60,7 @@ func (h *Handler) CreateCategory(ctx *gin.Context) {
 // @Accept  json
 // @Produce  json
 // @Param id path string true "Category ID"
+// @Param lang query string false "locale: uz, ru or en, overrides Accept-Language"
 // @Success 200 {object} entity.Category
 // @Failure 400 {object} entity.ErrorResponse
 func (h *Handler) GetCategory(ctx *gin.Context) {
@@ -59,6 +69,7 @@ func (h *Handler) GetCategory(ctx *gin.Context) {
 	)
 
 	req.ID = ctx.Param("id")
+	req.Locales = h.locales(ctx)
 
 	category, err := h.UseCase.CategoryRepo.GetSingle(ctx, req)
 	if h.HandleDbError(ctx, err, "Error getting category") {
@@ -79,6 +90,8 @@ func (h *Handler) GetCategory(ctx *gin.Context) {
 // @Param page query number true "page"
 // @Param limit query number true "limit"
 // @Param search query string false "search"
+// @Param restaurant_id query string false "Restaurant ID, the default restaurant when empty"
+// @Param lang query string false "locale: uz, ru or en, overrides Accept-Language"
 // @Success 200 {object} entity.CategoryList
 // @Failure 400 {object} entity.ErrorResponse
 func (h *Handler) GetCategories(ctx *gin.Context) {
@@ -92,6 +105,7 @@ func (h *Handler) GetCategories(ctx *gin.Context) {
 
 	req.Page, _ = strconv.Atoi(page)
 	req.Limit, _ = strconv.Atoi(limit)
+	req.Locales = h.locales(ctx)
 	req.Filters = append(req.Filters,
 		entity.Filter{
 			Column: "name",
@@ -100,7 +114,21 @@ func (h *Handler) GetCategories(ctx *gin.Context) {
 		},
 	)
 
+	restaurantID, ok := h.restaurantScope(ctx)
+	if !ok {
+		return
+	}
+
+	req.Filters = append(req.Filters, entity.Filter{
+		Column: "restaurant_id",
+		Type:   "eq",
+		Value:  restaurantID,
+	})
+
 	req.OrderBy = append(req.OrderBy, entity.OrderBy{
+		Column: "sort_order",
+		Order:  "asc",
+	}, entity.OrderBy{
 		Column: "created_at",
 		Order:  "desc",
 	})
@@ -135,8 +163,8 @@ func (h *Handler) UpdateCategory(ctx *gin.Context) {
 		return
 	}
 
-	if ctx.GetHeader("user_type") == "admin" {
-		body.Id = ctx.GetHeader("sub")
+	if !h.checkRestaurantRows(ctx, "category", body.Id) {
+		return
 	}
 
 	category, err := h.UseCase.CategoryRepo.Update(ctx, body)
@@ -165,7 +193,11 @@ func (h *Handler) DeleteCategory(ctx *gin.Context) {
 
 	req.ID = ctx.Param("id")
 
-	if ctx.GetHeader("user_type") != "admin" {
+	if ctx.GetHeader("user_type") != "admin" && !isRestaurantAdmin(ctx) {
+		return
+	}
+
+	if !h.checkRestaurantRows(ctx, "category", req.ID) {
 		return
 	}
 
@@ -178,3 +210,98 @@ func (h *Handler) DeleteCategory(ctx *gin.Context) {
 		Message: "Category deleted successfully",
 	})
 }
+
+// SortCategories godoc
+// @Router /category/sort [put]
+// @Summary Set the display order of categories
+// @Description Set the display order of categories among their siblings
+// @Security BearerAuth
+// @Tags category
+// @Accept  json
+// @Produce  json
+// @Param sort body entity.SortRequest true "Positions"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) SortCategories(ctx *gin.Context) {
+	var (
+		body entity.SortRequest
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil || len(body.Items) == 0 {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	if !h.checkRestaurantRows(ctx, "category", sortIDs(body)...) {
+		return
+	}
+
+	err = h.UseCase.CategoryRepo.Sort(ctx, body)
+	if h.HandleDbError(ctx, err, "Error sorting categories") {
+		return
+	}
+
+	ctx.JSON(200, entity.SuccessResponse{
+		Message: "Categories sorted successfully",
+	})
+}
+
+// UploadCategoryPic godoc
+// @ID upload_category_pic_file
+// @Router /category/upload/{id} [put]
+// @Summary Upload a category image
+// @Description Upload a category image
+// @Security BearerAuth
+// @Tags category
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Category ID"
+// @Param file formData file true "Category image"
+// @Success 200 {object} entity.ImageVariants "Success Request"
+// @Failure 400 {object} entity.ErrorResponse "Bad Request"
+// @Failure 500 {object} entity.ErrorResponse "Server error"
+func (h *Handler) UploadCategoryPic(ctx *gin.Context) {
+	form, err := ctx.MultipartForm()
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid file upload request", 400)
+		return
+	}
+
+	if !h.checkRestaurantRows(ctx, "category", ctx.Param("id")) {
+		return
+	}
+
+	category, err := h.UseCase.CategoryRepo.GetSingle(ctx, entity.CategorySingleRequest{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting category") {
+		return
+	}
+
+	images, ok := h.uploadImage(ctx, form)
+	if !ok {
+		return
+	}
+
+	_, err = h.UseCase.CategoryRepo.Update(ctx, entity.Category{
+		Id:               category.Id,
+		ParentID:         category.ParentID,
+		Name:             category.Name,
+		Images:           images,
+		KitchenStationID: category.KitchenStationID,
+	})
+	if h.HandleDbError(ctx, err, "Error updating category") {
+		return
+	}
+
+	ctx.JSON(200, images)
+}
+
+// sortIDs returns the ids of the rows a sort request positions.
+func sortIDs(req entity.SortRequest) []string {
+	ids := make([]string, 0, len(req.Items))
+	for _, item := range req.Items {
+		ids = append(ids, item.ID)
+	}
+
+	return ids
+}
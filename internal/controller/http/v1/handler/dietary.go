@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// dietaryFilter reads the exclude_allergens, dietary_tags and max_spicy_level
+// query parameters. Without exclude_allergens a signed in customer gets the
+// allergens excluded in their profile.
+func (h *Handler) dietaryFilter(ctx *gin.Context) (entity.DietaryFilter, bool) {
+	var filter entity.DietaryFilter
+
+	filter.DietaryTags = splitList(ctx.Query("dietary_tags"))
+	if !validValues(filter.DietaryTags, config.DietaryTags) {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid dietary_tags", 400)
+		return filter, false
+	}
+
+	if value, ok := ctx.GetQuery("exclude_allergens"); ok {
+		filter.ExcludeAllergens = splitList(value)
+		if !validValues(filter.ExcludeAllergens, config.Allergens) {
+			h.ReturnError(ctx, config.ErrorBadRequest, "Invalid exclude_allergens", 400)
+			return filter, false
+		}
+	} else if ctx.GetHeader("user_type") == "user" {
+		user, err := h.UseCase.UserRepo.GetSingle(ctx, entity.UserSingleRequest{ID: ctx.GetHeader("sub")})
+		if h.HandleDbError(ctx, err, "Error getting user") {
+			return filter, false
+		}
+
+		filter.ExcludeAllergens = user.ExcludedAllergens
+	}
+
+	if value := ctx.Query("max_spicy_level"); value != "" {
+		level, err := strconv.Atoi(value)
+		if err != nil || level < 0 || level > config.MaxSpicyLevel {
+			h.ReturnError(ctx, config.ErrorBadRequest, "Invalid max_spicy_level", 400)
+			return filter, false
+		}
+
+		filter.MaxSpicyLevel = &level
+	}
+
+	return filter, true
+}
+
+// validDietary checks the allergens, dietary tags and spicy level of a product.
+func validDietary(product entity.Product) bool {
+	if product.SpicyLevel != nil && (*product.SpicyLevel < 0 || *product.SpicyLevel > config.MaxSpicyLevel) {
+		return false
+	}
+
+	if n := product.Nutrition; n != nil && (n.Kcal < 0 || n.Protein < 0 || n.Fat < 0 || n.Carbohydrates < 0 || n.PortionWeight < 0) {
+		return false
+	}
+
+	return validValues(product.Allergens, config.Allergens) && validValues(product.DietaryTags, config.DietaryTags)
+}
+
+// allergenWarning returns a warning when the product has any of the excluded allergens.
+func allergenWarning(product entity.Product, excluded []string) (entity.AllergenWarning, bool) {
+	warning := entity.AllergenWarning{ProductID: product.Id, Name: product.Name}
+
+	for _, allergen := range product.Allergens {
+		if containsValue(excluded, allergen) {
+			warning.Allergens = append(warning.Allergens, allergen)
+		}
+	}
+
+	return warning, len(warning.Allergens) > 0
+}
+
+func splitList(value string) []string {
+	var values []string
+
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			values = append(values, item)
+		}
+	}
+
+	return values
+}
+
+func validValues(values, allowed []string) bool {
+	for _, value := range values {
+		if !containsValue(allowed, value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func containsValue(values []string, value string) bool {
+	for _, item := range values {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
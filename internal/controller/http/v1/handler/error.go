@@ -47,6 +47,13 @@ func (h Handler) HandleDbError(c *gin.Context, err error, message string) bool {
 				Code:    config.ErrorConflict,
 			}
 			statusCode = http.StatusBadRequest
+		case "23514":
+			// Check constraint violation, e.g. a category nested under itself
+			errorResponse = entity.ErrorResponse{
+				Message: "The record violates a check constraint.",
+				Code:    config.ErrorInvalidRequest,
+			}
+			statusCode = http.StatusBadRequest
 		case "22001":
 			// Value too long for column
 			errorResponse = entity.ErrorResponse{
@@ -2,8 +2,15 @@ package handler
 
 import (
 	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/internal/kitchen"
+	"github.com/Akrom0181/Food-Delivery/internal/orderpdf"
 	"github.com/Akrom0181/Food-Delivery/internal/usecase"
 	"github.com/Akrom0181/Food-Delivery/pkg/logger"
+	"github.com/Akrom0181/Food-Delivery/pkg/money"
+	"github.com/Akrom0181/Food-Delivery/pkg/oauth"
+	"github.com/Akrom0181/Food-Delivery/pkg/rbac"
+	"github.com/Akrom0181/Food-Delivery/pkg/rediskey"
+	"github.com/Akrom0181/Food-Delivery/pkg/storage"
 	rediscache "github.com/golanguzb70/redis-cache"
 )
 
@@ -12,13 +19,39 @@ type Handler struct {
 	Config  *config.Config
 	UseCase *usecase.UseCase
 	Redis   rediscache.RedisCache
+	// OAuth holds the enabled social login providers by name.
+	OAuth map[string]oauth.Provider
+	// Enforcer checks access and is updated after policy changes.
+	Enforcer *rbac.Enforcer
+	// Storage keeps uploaded files.
+	Storage storage.Storage
+	// Kitchen tells the kitchen displays which orders changed.
+	Kitchen *kitchen.Broker
+
+	apiKeys *apiKeyLimiter
+	// currency rounds the discounts of orders.
+	currency money.Currency
+	// pdf renders the receipts of orders.
+	pdf *orderpdf.Renderer
+	// keys claims used codes and counts code attempts.
+	keys *rediskey.Client
 }
 
-func NewHandler(l *logger.Logger, c *config.Config, useCase *usecase.UseCase, redis rediscache.RedisCache) *Handler {
+func NewHandler(l *logger.Logger, c *config.Config, useCase *usecase.UseCase, redis rediscache.RedisCache, providers map[string]oauth.Provider, enforcer *rbac.Enforcer, store storage.Storage, kitchenBroker *kitchen.Broker) *Handler {
+	currency, _ := money.LookupCurrency(c.Money.Currency)
+
 	return &Handler{
-		Logger:  l,
-		Config:  c,
-		UseCase: useCase,
-		Redis:   redis,
+		Logger:   l,
+		Config:   c,
+		UseCase:  useCase,
+		Redis:    redis,
+		OAuth:    providers,
+		Enforcer: enforcer,
+		Storage:  store,
+		Kitchen:  kitchenBroker,
+		apiKeys:  newAPIKeyLimiter(),
+		currency: currency,
+		pdf:      orderpdf.New(c.PDF.FontDir),
+		keys:     rediskey.New(c.Redis.RedisHost, c.Redis.RedisPort),
 	}
 }
@@ -0,0 +1,247 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/Akrom0181/Food-Delivery/internal/kitchen"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4"
+)
+
+// GetKitchenTickets godoc
+// @Router /kitchen/tickets [get]
+// @Summary Get the kitchen display of a branch
+// @Description Open orders of the branch oldest first with the lines the kitchen makes. Bundle lines are
+// @Description left out, their components are listed. With bumped the latest bumped orders are listed for recall.
+// @Security BearerAuth
+// @Tags kitchen
+// @Accept  json
+// @Produce  json
+// @Param branch_id query string true "Branch ID"
+// @Param station_id query string false "Only the lines of this kitchen station"
+// @Param bumped query bool false "List bumped orders"
+// @Param lang query string false "Language" Enums(uz, ru, en)
+// @Success 200 {object} entity.KitchenTicketList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetKitchenTickets(ctx *gin.Context) {
+	req, ok := h.kitchenTicketsRequest(ctx)
+	if !ok {
+		return
+	}
+
+	req.Bumped = ctx.Query("bumped") == "true"
+	req.Limit = config.KitchenBumpedLimit
+
+	tickets, err := h.UseCase.KitchenRepo.GetTickets(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting kitchen tickets") {
+		return
+	}
+
+	ctx.JSON(200, tickets)
+}
+
+// StreamKitchen godoc
+// @Router /kitchen/stream [get]
+// @Summary Stream the kitchen display of a branch
+// @Description Server-sent events. A snapshot event has the open orders like /kitchen/tickets, it is sent
+// @Description first and again when changes may have been missed. A ticket event has an order that was added or
+// @Description changed, a removed event the order_id of one that was bumped, cancelled or handed over.
+// @Security BearerAuth
+// @Tags kitchen
+// @Produce text/event-stream
+// @Param branch_id query string true "Branch ID"
+// @Param station_id query string false "Only the lines of this kitchen station"
+// @Param lang query string false "Language" Enums(uz, ru, en)
+// @Success 200 {object} entity.KitchenTicketList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) StreamKitchen(ctx *gin.Context) {
+	req, ok := h.kitchenTicketsRequest(ctx)
+	if !ok {
+		return
+	}
+
+	// subscribed before the snapshot so no change falls in between
+	sub := h.Kitchen.Subscribe(kitchen.ChannelOrders, req.BranchID)
+	defer sub.Close()
+
+	tickets, err := h.UseCase.KitchenRepo.GetTickets(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting kitchen tickets") {
+		return
+	}
+
+	// the stream stays open past the write timeout of the server
+	err = http.NewResponseController(ctx.Writer).SetWriteDeadline(time.Time{})
+	if err != nil {
+		h.Logger.Error(err, "Error clearing kitchen stream deadline")
+	}
+
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.SSEvent("snapshot", tickets)
+	ctx.Writer.Flush()
+
+	keepAlive := time.NewTicker(config.KitchenKeepAlive)
+	defer keepAlive.Stop()
+
+	ctx.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Request.Context().Done():
+			return false
+		case <-keepAlive.C:
+			_, err := io.WriteString(w, ": ping\n\n")
+			return err == nil
+		case <-sub.Ready():
+			return h.sendKitchenChanges(ctx, sub, req)
+		}
+	})
+}
+
+func (h *Handler) sendKitchenChanges(ctx *gin.Context, sub *kitchen.Subscription, req entity.KitchenTicketsRequest) bool {
+	orderIDs, resync := sub.Take()
+	if resync {
+		tickets, err := h.UseCase.KitchenRepo.GetTickets(ctx, req)
+		if err != nil {
+			h.Logger.Error(err, "Error getting kitchen tickets")
+			return false
+		}
+
+		ctx.SSEvent("snapshot", tickets)
+		return true
+	}
+
+	for _, id := range orderIDs {
+		one := req
+		one.OrderID = id
+
+		ticket, err := h.UseCase.KitchenRepo.GetTicket(ctx, one)
+		switch {
+		case err == nil && ticket.BumpedAt == "":
+			ctx.SSEvent("ticket", ticket)
+		case err == nil || err == pgx.ErrNoRows:
+			ctx.SSEvent("removed", gin.H{"order_id": id})
+		default:
+			h.Logger.Error(err, "Error getting kitchen ticket")
+			return false
+		}
+	}
+
+	return true
+}
+
+// SetKitchenItemStatus godoc
+// @Router /kitchen/item/status [put]
+// @Summary Move an order line in the kitchen
+// @Description queued, cooking or ready, moving a line back recalls it. Cooking the first line moves the order
+// @Description to preparing. The time from cooking to ready updates the prep time of the product at the branch,
+// @Description which the ready_eta of orders is estimated from.
+// @Security BearerAuth
+// @Tags kitchen
+// @Accept  json
+// @Produce  json
+// @Param status body entity.KitchenItemStatus true "Item status"
+// @Param lang query string false "Language" Enums(uz, ru, en)
+// @Success 200 {object} entity.KitchenTicket
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) SetKitchenItemStatus(ctx *gin.Context) {
+	var (
+		body entity.KitchenItemStatus
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil || body.ID == "" || !validKitchenStatus(body.Status) {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	if isBranchStaff(ctx) {
+		branchID, err := h.UseCase.KitchenRepo.GetItemBranch(ctx, entity.Id{ID: body.ID})
+		if h.HandleDbError(ctx, err, "Error getting kitchen item") || !h.checkBranchAccess(ctx, branchID) {
+			return
+		}
+	}
+
+	body.Locales = h.locales(ctx)
+
+	ticket, err := h.UseCase.KitchenRepo.SetItemStatus(ctx, body)
+	if h.HandleDbError(ctx, err, "Error updating kitchen item") {
+		return
+	}
+
+	ctx.JSON(200, ticket)
+}
+
+// BumpKitchenOrder godoc
+// @Router /kitchen/order/{id}/bump [post]
+// @Summary Bump an order off the kitchen display
+// @Description Marks the lines of the order ready and takes it off the display.
+// @Security BearerAuth
+// @Tags kitchen
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Order ID"
+// @Param lang query string false "Language" Enums(uz, ru, en)
+// @Success 200 {object} entity.KitchenTicket
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) BumpKitchenOrder(ctx *gin.Context) {
+	if !h.checkOrderBranch(ctx, ctx.Param("id")) {
+		return
+	}
+
+	ticket, err := h.UseCase.KitchenRepo.Bump(ctx, entity.Id{ID: ctx.Param("id"), Locales: h.locales(ctx)})
+	if h.HandleDbError(ctx, err, "Error bumping order") {
+		return
+	}
+
+	ctx.JSON(200, ticket)
+}
+
+// RecallKitchenOrder godoc
+// @Router /kitchen/order/{id}/recall [post]
+// @Summary Recall a bumped order to the kitchen display
+// @Description Only orders that are still open can be recalled. Their lines keep their status.
+// @Security BearerAuth
+// @Tags kitchen
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Order ID"
+// @Param lang query string false "Language" Enums(uz, ru, en)
+// @Success 200 {object} entity.KitchenTicket
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) RecallKitchenOrder(ctx *gin.Context) {
+	if !h.checkOrderBranch(ctx, ctx.Param("id")) {
+		return
+	}
+
+	ticket, err := h.UseCase.KitchenRepo.Recall(ctx, entity.Id{ID: ctx.Param("id"), Locales: h.locales(ctx)})
+	if h.HandleDbError(ctx, err, "Error recalling order") {
+		return
+	}
+
+	ctx.JSON(200, ticket)
+}
+
+func (h *Handler) kitchenTicketsRequest(ctx *gin.Context) (entity.KitchenTicketsRequest, bool) {
+	req := entity.KitchenTicketsRequest{
+		BranchID:  ctx.Query("branch_id"),
+		StationID: ctx.Query("station_id"),
+		Locales:   h.locales(ctx),
+	}
+
+	if req.BranchID == "" {
+		h.ReturnError(ctx, config.ErrorBadRequest, "branch_id is required", 400)
+		return req, false
+	}
+
+	return req, h.checkBranchAccess(ctx, req.BranchID)
+}
+
+func validKitchenStatus(status string) bool {
+	return status == "queued" || status == "cooking" || status == "ready"
+}
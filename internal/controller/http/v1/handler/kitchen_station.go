@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateKitchenStation godoc
+// @Router /kitchen-station [post]
+// @Summary Create a kitchen station
+// @Description A station such as grill, fryer or drinks. Categories route their products to it with kitchen_station_id.
+// @Security BearerAuth
+// @Tags kitchen-station
+// @Accept  json
+// @Produce  json
+// @Param station body entity.KitchenStation true "Kitchen station"
+// @Success 201 {object} entity.KitchenStation
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) CreateKitchenStation(ctx *gin.Context) {
+	var (
+		body entity.KitchenStation
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil || !validKitchenStation(body) {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	station, err := h.UseCase.KitchenStationRepo.Create(ctx, body)
+	if h.HandleDbError(ctx, err, "Error creating kitchen station") {
+		return
+	}
+
+	ctx.JSON(201, station)
+}
+
+// GetKitchenStation godoc
+// @Router /kitchen-station/{id} [get]
+// @Summary Get a kitchen station by ID
+// @Description Get a kitchen station by ID
+// @Security BearerAuth
+// @Tags kitchen-station
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Kitchen station ID"
+// @Success 200 {object} entity.KitchenStation
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetKitchenStation(ctx *gin.Context) {
+	station, err := h.UseCase.KitchenStationRepo.GetSingle(ctx, entity.Id{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting kitchen station") {
+		return
+	}
+
+	ctx.JSON(200, station)
+}
+
+// GetKitchenStations godoc
+// @Router /kitchen-station/list [get]
+// @Summary Get a list of kitchen stations
+// @Description Get a list of kitchen stations
+// @Security BearerAuth
+// @Tags kitchen-station
+// @Accept  json
+// @Produce  json
+// @Param page query number true "page"
+// @Param limit query number true "limit"
+// @Success 200 {object} entity.KitchenStationList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetKitchenStations(ctx *gin.Context) {
+	var (
+		req entity.GetListFilter
+	)
+
+	req.Page, _ = strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	req.Limit, _ = strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+
+	req.OrderBy = append(req.OrderBy, entity.OrderBy{
+		Column: "name",
+		Order:  "asc",
+	})
+
+	categories, err := h.UseCase.KitchenStationRepo.GetList(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting kitchen stations") {
+		return
+	}
+
+	ctx.JSON(200, categories)
+}
+
+// UpdateKitchenStation godoc
+// @Router /kitchen-station [put]
+// @Summary Update a kitchen station
+// @Description Update a kitchen station
+// @Security BearerAuth
+// @Tags kitchen-station
+// @Accept  json
+// @Produce  json
+// @Param station body entity.KitchenStation true "Kitchen station"
+// @Success 200 {object} entity.KitchenStation
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) UpdateKitchenStation(ctx *gin.Context) {
+	var (
+		body entity.KitchenStation
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil || body.ID == "" || !validKitchenStation(body) {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	station, err := h.UseCase.KitchenStationRepo.Update(ctx, body)
+	if h.HandleDbError(ctx, err, "Error updating kitchen station") {
+		return
+	}
+
+	ctx.JSON(200, station)
+}
+
+// DeleteKitchenStation godoc
+// @Router /kitchen-station/{id} [delete]
+// @Summary Delete a kitchen station
+// @Description Categories of the station are left without one.
+// @Security BearerAuth
+// @Tags kitchen-station
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Kitchen station ID"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) DeleteKitchenStation(ctx *gin.Context) {
+	err := h.UseCase.KitchenStationRepo.Delete(ctx, entity.Id{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error deleting kitchen station") {
+		return
+	}
+
+	ctx.JSON(200, entity.SuccessResponse{
+		Message: "Kitchen station deleted successfully",
+	})
+}
+
+func validKitchenStation(station entity.KitchenStation) bool {
+	return station.Name != ""
+}
@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/pkg/locale"
+	"github.com/gin-gonic/gin"
+)
+
+// locales negotiates the locales catalog content is translated into from the
+// lang query parameter and the Accept-Language header, most preferred first.
+// The default locale is stored in the entities themselves and is left out, the
+// repos fall back to it.
+func (h *Handler) locales(ctx *gin.Context) []string {
+	preferred := locale.Preferred(ctx.Query("lang"), ctx.GetHeader("Accept-Language"), config.Locales)
+	chain := locale.Chain(preferred, config.LocaleFallbacks, config.DefaultLocale)
+
+	ctx.Writer.Header().Add("Vary", "Accept-Language")
+	ctx.Header("Content-Language", chain[0])
+
+	return chain[:len(chain)-1]
+}
+
+func validTranslationLocale(l string) bool {
+	if l == config.DefaultLocale {
+		return false
+	}
+
+	for _, item := range config.Locales {
+		if item == l {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// GetMenu godoc
+// @Router /menu [get]
+// @Summary Get the menu
+// @Description The tree of active, visible categories of a restaurant with their products in display order, and its banners.
+// @Description The restaurant is the one of the branch when only a branch is given, else the default one.
+// @Description With a branch, products sold out there are marked unavailable.
+// @Description With changed_since, only what changed after that catalog version is returned as entity.MenuChanges.
+// @Description Responses carry an ETag and the catalog version in X-Catalog-Version, send If-None-Match to get 304 when nothing changed.
+// @Tags menu
+// @Accept  json
+// @Produce  json
+// @Param restaurant_id query string false "Restaurant ID"
+// @Param branch_id query string false "Branch ID"
+// @Param lang query string false "locale: uz, ru or en, overrides Accept-Language"
+// @Param exclude_allergens query string false "comma separated allergens to leave out, the profile ones of a signed in user by default"
+// @Param dietary_tags query string false "comma separated tags the products must all have"
+// @Param max_spicy_level query integer false "0 to 3"
+// @Param changed_since query integer false "catalog version the client has"
+// @Param If-None-Match header string false "ETag of the menu the client has"
+// @Success 200 {object} entity.Menu
+// @Success 304
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetMenu(ctx *gin.Context) {
+	var (
+		req = entity.MenuRequest{
+			RestaurantID: ctx.Query("restaurant_id"),
+			BranchID:     ctx.Query("branch_id"),
+			Locales:      h.locales(ctx),
+		}
+		err error
+	)
+
+	if req.RestaurantID == "" && req.BranchID != "" {
+		branch, err := h.UseCase.BranchRepo.GetSingle(ctx, entity.Id{ID: req.BranchID})
+		if h.HandleDbError(ctx, err, "Error getting branch") {
+			return
+		}
+		req.RestaurantID = branch.RestaurantID
+	}
+	if req.RestaurantID == "" {
+		req.RestaurantID = config.DefaultRestaurantID
+	}
+
+	if value := ctx.Query("changed_since"); value != "" {
+		req.ChangedSince, err = strconv.ParseInt(value, 10, 64)
+		if err != nil || req.ChangedSince < 0 {
+			h.ReturnError(ctx, config.ErrorBadRequest, "Invalid changed_since", 400)
+			return
+		}
+	}
+
+	var ok bool
+	req.Dietary, ok = h.dietaryFilter(ctx)
+	if !ok {
+		return
+	}
+
+	version, err := h.UseCase.MenuRepo.GetVersion(ctx)
+	if h.HandleDbError(ctx, err, "Error getting catalog version") {
+		return
+	}
+
+	// a client ahead of the catalog, e.g. after a restore, starts over
+	if req.ChangedSince > version {
+		req.ChangedSince = 0
+	}
+
+	variant := menuVariant(req)
+	etag := fmt.Sprintf(`"%d-%s"`, version, variant)
+
+	ctx.Header("ETag", etag)
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("X-Catalog-Version", strconv.FormatInt(version, 10))
+
+	if etagMatches(ctx.GetHeader("If-None-Match"), etag) {
+		ctx.Status(304)
+		return
+	}
+
+	if req.ChangedSince > 0 {
+		changes, err := h.UseCase.MenuRepo.GetChanges(ctx, req)
+		if h.HandleDbError(ctx, err, "Error getting menu changes") {
+			return
+		}
+
+		ctx.JSON(200, changes)
+		return
+	}
+
+	// a write bumps the version, so cached menus of older versions are never read again and expire
+	key := fmt.Sprintf("menu:%d:%s", version, variant)
+	if cached, err := h.Redis.Get(ctx, key); err == nil && cached != "" {
+		ctx.Data(200, "application/json; charset=utf-8", []byte(cached))
+		return
+	}
+
+	menu, err := h.UseCase.MenuRepo.Get(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting menu") {
+		return
+	}
+
+	body, err := json.Marshal(menu)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorInternalServer, "Error encoding menu", 500)
+		return
+	}
+
+	// only a menu built at the version the ETag names is cached under it
+	if menu.Version == version {
+		err = h.Redis.Set(ctx, key, string(body), int(config.MenuCacheTTL.Seconds()))
+		if err != nil {
+			h.Logger.Error(err, "Error caching menu")
+		}
+	}
+
+	ctx.Data(200, "application/json; charset=utf-8", body)
+}
+
+// menuVariant names what besides the version a menu response depends on.
+func menuVariant(req entity.MenuRequest) string {
+	spicy := ""
+	if req.Dietary.MaxSpicyLevel != nil {
+		spicy = strconv.Itoa(*req.Dietary.MaxSpicyLevel)
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		req.RestaurantID, req.BranchID, strings.Join(req.Locales, ","), strconv.FormatInt(req.ChangedSince, 10),
+		strings.Join(req.Dietary.ExcludeAllergens, ","), strings.Join(req.Dietary.DietaryTags, ","), spicy,
+	}, "|")))
+
+	return hex.EncodeToString(sum[:8])
+}
+
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+
+	return false
+}
@@ -80,6 +80,10 @@ func (h *Handler) GetNotification(ctx *gin.Context) {
 		return
 	}
 
+	if !h.checkNotificationAccess(ctx, notification) {
+		return
+	}
+
 	ctx.JSON(200, notification)
 }
 
@@ -104,6 +108,16 @@ func (h *Handler) UpdateStatusNotification(ctx *gin.Context) {
 		return
 	}
 
+	current, err := h.UseCase.NotificationRepo.GetSingle(ctx, entity.Id{ID: req.ID})
+	if h.HandleDbError(ctx, err, "Error getting notification") {
+		return
+	}
+
+	// only the recipient marks a notification as read
+	if !h.checkOwner(ctx, current.UserID) {
+		return
+	}
+
 	notification, err := h.UseCase.NotificationRepo.UpdateStatus(ctx, req)
 	if h.HandleDbError(ctx, err, "Error update status notification") {
 		return
@@ -132,8 +146,8 @@ func (h *Handler) GetNotifications(ctx *gin.Context) {
 	limit := ctx.DefaultQuery("limit", "10")
 	userId := ctx.DefaultQuery("user_id", "")
 
-	if ctx.GetHeader("user_type") == "user" {
-		userId = ctx.GetHeader("sub")
+	if scope := ownerScope(ctx); scope != "" {
+		userId = scope
 	}
 
 	req.Page, _ = strconv.Atoi(page)
@@ -184,13 +198,25 @@ func (h *Handler) UpdateNotification(ctx *gin.Context) {
 		body entity.Notification
 	)
 
-	body.OwnerRole = ctx.GetHeader("user_role")
 	err := ctx.ShouldBindJSON(&body)
 	if err != nil {
 		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
 		return
 	}
 
+	body.OwnerRole = ctx.GetHeader("user_role")
+	body.OwnerId = ctx.GetHeader("sub")
+
+	current, err := h.UseCase.NotificationRepo.GetSingle(ctx, entity.Id{ID: body.ID})
+	if h.HandleDbError(ctx, err, "Error getting notification") {
+		return
+	}
+
+	// only the sender edits a notification
+	if !h.checkOwner(ctx, current.OwnerId) {
+		return
+	}
+
 	notification, err := h.UseCase.NotificationRepo.Update(ctx, body)
 	if h.HandleDbError(ctx, err, "Error updating notification") {
 		return
@@ -217,7 +243,16 @@ func (h *Handler) DeleteNotification(ctx *gin.Context) {
 
 	req.ID = ctx.Param("id")
 
-	err := h.UseCase.NotificationRepo.Delete(ctx, req)
+	notification, err := h.UseCase.NotificationRepo.GetSingle(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting notification") {
+		return
+	}
+
+	if !h.checkNotificationAccess(ctx, notification) {
+		return
+	}
+
+	err = h.UseCase.NotificationRepo.Delete(ctx, req)
 	if h.HandleDbError(ctx, err, "Error deleting notification") {
 		return
 	}
@@ -249,6 +284,15 @@ func (h *Handler) UpdateNotificationStatus(ctx *gin.Context) {
 		return
 	}
 
+	current, err := h.UseCase.NotificationRepo.GetSingle(ctx, entity.Id{ID: body.ID})
+	if h.HandleDbError(ctx, err, "Error getting notification") {
+		return
+	}
+
+	if !h.checkOwner(ctx, current.UserID) {
+		return
+	}
+
 	notification, err := h.UseCase.NotificationRepo.UpdateStatus(ctx, body)
 	if h.HandleDbError(ctx, err, "Error updating notification status") {
 		return
@@ -256,3 +300,12 @@ func (h *Handler) UpdateNotificationStatus(ctx *gin.Context) {
 
 	ctx.JSON(200, notification)
 }
+
+// checkNotificationAccess lets the recipient and the sender of a notification through.
+func (h *Handler) checkNotificationAccess(ctx *gin.Context, notification entity.Notification) bool {
+	if notification.OwnerId != "" && notification.OwnerId == ctx.GetHeader("sub") {
+		return true
+	}
+
+	return h.checkOwner(ctx, notification.UserID)
+}
@@ -0,0 +1,436 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/Akrom0181/Food-Delivery/pkg/etc"
+	"github.com/Akrom0181/Food-Delivery/pkg/hash"
+	"github.com/Akrom0181/Food-Delivery/pkg/oauth"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4"
+)
+
+// oauthState is kept in redis between the redirect to the provider and the callback.
+type oauthState struct {
+	Provider string `json:"provider"`
+	Verifier string `json:"verifier"`
+	Nonce    string `json:"nonce"`
+	Platform string `json:"platform"`
+}
+
+// OAuthStart godoc
+// @Router /auth/oauth/{provider} [get]
+// @Summary Start social login
+// @Description Returns the provider authorization URL (authorization code flow with PKCE). The client opens it and the provider redirects back to the callback.
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Param provider path string true "google or apple"
+// @Param platform query string false "web or mobile"
+// @Success 200 {object} entity.OAuthStartResponse
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) OAuthStart(ctx *gin.Context) {
+	provider, ok := h.OAuth[ctx.Param("provider")]
+	if !ok {
+		h.ReturnError(ctx, config.ErrorNotFound, "Unknown login provider", 404)
+		return
+	}
+
+	platform := ctx.DefaultQuery("platform", "web")
+	if platform != "web" && platform != "mobile" {
+		h.ReturnError(ctx, config.ErrorForbidden, "Social login is not available for this platform", http.StatusBadRequest)
+		return
+	}
+
+	state, err := oauth.RandomString()
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorInternalServer, "Oops, something went wrong!!!", http.StatusInternalServerError)
+		return
+	}
+
+	nonce, err := oauth.RandomString()
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorInternalServer, "Oops, something went wrong!!!", http.StatusInternalServerError)
+		return
+	}
+
+	pending := oauthState{
+		Provider: provider.Name(),
+		Verifier: oauth.GenerateVerifier(),
+		Nonce:    nonce,
+		Platform: platform,
+	}
+
+	value, err := json.Marshal(pending)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorInternalServer, "Oops, something went wrong!!!", http.StatusInternalServerError)
+		return
+	}
+
+	err = h.Redis.Set(ctx, oauthStateKey(state), string(value), int(config.OAuthStateExpireTime.Seconds()))
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorInternalServer, "Oops, something went wrong!!!", http.StatusInternalServerError)
+		return
+	}
+
+	ctx.JSON(200, entity.OAuthStartResponse{
+		AuthorizationURL: provider.AuthCodeURL(state, pending.Verifier, pending.Nonce),
+		State:            state,
+	})
+}
+
+// OAuthCallback godoc
+// @Router /auth/oauth/{provider}/callback [get]
+// @Summary Finish social login
+// @Description Exchanges the authorization code, links the external identity to a user (by verified email) or creates a new one and opens a session. Apple posts the same parameters as a form.
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Param provider path string true "google or apple"
+// @Param code query string true "Authorization code"
+// @Param state query string true "State returned by the start endpoint"
+// @Success 200 {object} entity.SuccessResponse
+// @Success 202 {object} entity.TwoFactorChallenge
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) OAuthCallback(ctx *gin.Context) {
+	var (
+		body entity.OAuthCallbackRequest
+	)
+
+	err := ctx.ShouldBind(&body)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	if body.Error != "" {
+		h.ReturnError(ctx, config.ErrorUnauthorized, fmt.Sprintf("Login was cancelled: %s %s", body.Error, body.ErrorDescription), http.StatusUnauthorized)
+		return
+	}
+
+	provider, ok := h.OAuth[ctx.Param("provider")]
+	if !ok {
+		h.ReturnError(ctx, config.ErrorNotFound, "Unknown login provider", 404)
+		return
+	}
+
+	value, err := h.Redis.Get(ctx, oauthStateKey(body.State))
+	if err != nil || body.State == "" {
+		h.ReturnError(ctx, config.ErrorInvalidToken, "Login state is invalid or expired", http.StatusBadRequest)
+		return
+	}
+	// the state is single use
+	_ = h.Redis.Del(ctx, oauthStateKey(body.State))
+
+	var pending oauthState
+	if err = json.Unmarshal([]byte(value), &pending); err != nil || pending.Provider != provider.Name() {
+		h.ReturnError(ctx, config.ErrorInvalidToken, "Login state is invalid or expired", http.StatusBadRequest)
+		return
+	}
+
+	identity, err := provider.Exchange(ctx, body.Code, pending.Verifier, pending.Nonce)
+	if err != nil {
+		h.Logger.Error(err, "oauth exchange")
+		h.ReturnError(ctx, config.ErrorUnauthorized, "Could not verify the login with the provider", http.StatusUnauthorized)
+		return
+	}
+
+	h.finishOAuthLogin(ctx, identity, pending.Platform)
+}
+
+// TelegramLogin godoc
+// @Router /auth/oauth/telegram [post]
+// @Summary Login with the Telegram login widget
+// @Description Checks the signature of the data sent by the Telegram login widget and opens a session
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Param body body entity.TelegramLoginRequest true "Widget data"
+// @Success 200 {object} entity.SuccessResponse
+// @Success 202 {object} entity.TwoFactorChallenge
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) TelegramLogin(ctx *gin.Context) {
+	var (
+		body entity.TelegramLoginRequest
+	)
+
+	if h.Config.OAuth.TelegramBotToken == "" {
+		h.ReturnError(ctx, config.ErrorNotFound, "Unknown login provider", 404)
+		return
+	}
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	// only the fields telegram sent take part in the signature
+	data := map[string]string{
+		"id":        strconv.FormatInt(body.ID, 10),
+		"auth_date": strconv.FormatInt(body.AuthDate, 10),
+		"hash":      body.Hash,
+	}
+	for key, value := range map[string]string{
+		"first_name": body.FirstName,
+		"last_name":  body.LastName,
+		"username":   body.Username,
+		"photo_url":  body.PhotoURL,
+	} {
+		if value != "" {
+			data[key] = value
+		}
+	}
+
+	identity, err := oauth.VerifyTelegram(h.Config.OAuth.TelegramBotToken, data, config.TelegramAuthMaxAge, time.Now())
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorUnauthorized, "Telegram login data is invalid or expired", http.StatusUnauthorized)
+		return
+	}
+
+	platform := body.Platform
+	if platform == "" {
+		platform = "web"
+	}
+
+	h.finishOAuthLogin(ctx, identity, platform)
+}
+
+// finishOAuthLogin resolves the user behind an external identity and logs them in.
+// Identities are linked to existing users only through an email the provider verified.
+func (h *Handler) finishOAuthLogin(ctx *gin.Context, identity oauth.Identity, platform string) {
+	if platform != "web" && platform != "mobile" {
+		h.ReturnError(ctx, config.ErrorForbidden, "Social login is not available for this platform", http.StatusBadRequest)
+		return
+	}
+
+	user, ok := h.oauthUser(ctx, identity)
+	if !ok {
+		return
+	}
+
+	if user.UserType == "admin" {
+		h.ReturnError(ctx, config.ErrorForbidden, "Admin can only login to admin web", http.StatusBadRequest)
+		return
+	}
+
+	if user.Status == "blocked" {
+		h.ReturnError(ctx, config.ErrorForbidden, "User is blocked", http.StatusForbidden)
+		return
+	}
+
+	challenge, required, ok := h.requireTwoFactor(ctx, user, platform)
+	if !ok {
+		return
+	}
+
+	if required {
+		ctx.JSON(202, challenge)
+		return
+	}
+
+	user, session, ok := h.openSession(ctx, user, platform)
+	if !ok {
+		return
+	}
+
+	ctx.JSON(200, gin.H{
+		"user":    user,
+		"session": session,
+	})
+}
+
+func (h *Handler) oauthUser(ctx *gin.Context, identity oauth.Identity) (entity.User, bool) {
+	linked, err := h.UseCase.UserIdentityRepo.GetSingle(ctx, entity.UserIdentitySingleRequest{
+		Provider: identity.Provider,
+		Subject:  identity.Subject,
+	})
+	if err == nil {
+		user, err := h.UseCase.UserRepo.GetSingle(ctx, entity.UserSingleRequest{ID: linked.UserID})
+		if h.HandleDbError(ctx, err, "Error getting user") {
+			return entity.User{}, false
+		}
+
+		return user, true
+	}
+
+	if err != pgx.ErrNoRows {
+		h.HandleDbError(ctx, err, "Error getting user identity")
+		return entity.User{}, false
+	}
+
+	var user entity.User
+
+	if identity.EmailVerified && identity.Email != "" {
+		user, err = h.UseCase.UserRepo.GetSingle(ctx, entity.UserSingleRequest{Email: identity.Email})
+		if err != nil && err != pgx.ErrNoRows {
+			h.HandleDbError(ctx, err, "Error getting user")
+			return entity.User{}, false
+		}
+
+		// the provider vouches for the address, so a pending email verification
+		// is done. Whoever signed up with the address without owning it loses
+		// the password they chose and their sessions.
+		if err == nil && user.Status == "inverify" {
+			password, ok := h.unknownPassword(ctx)
+			if !ok {
+				return entity.User{}, false
+			}
+			user.Status = "active"
+			user.Password = password
+
+			_, err = h.UseCase.UserRepo.Update(ctx, user)
+			if h.HandleDbError(ctx, err, "Error updating user") {
+				return entity.User{}, false
+			}
+			user.Password = ""
+
+			_, err = h.UseCase.SessionRepo.UpdateField(ctx, entity.UpdateFieldRequest{
+				Filter: []entity.Filter{{Column: "user_id", Type: "eq", Value: user.ID}},
+				Items:  []entity.UpdateFieldItem{{Column: "is_active", Value: "false"}},
+			})
+			if h.HandleDbError(ctx, err, "Error ending sessions") {
+				return entity.User{}, false
+			}
+		}
+	}
+
+	if user.ID == "" {
+		user, ok := h.createOAuthUser(ctx, identity)
+		if !ok {
+			return entity.User{}, false
+		}
+
+		return user, h.linkIdentity(ctx, user, identity)
+	}
+
+	if user.UserType == "admin" {
+		// admin accounts are never linked to social logins
+		return user, true
+	}
+
+	return user, h.linkIdentity(ctx, user, identity)
+}
+
+// unknownPassword returns the hash of a random password nobody knows.
+func (h *Handler) unknownPassword(ctx *gin.Context) (string, bool) {
+	secret, err := oauth.RandomString()
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorInternalServer, "Oops, something went wrong!!!", http.StatusInternalServerError)
+		return "", false
+	}
+
+	password, err := hash.HashPassword(secret)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorInternalServer, "Oops, something went wrong!!!", http.StatusInternalServerError)
+		return "", false
+	}
+
+	return password, true
+}
+
+func (h *Handler) createOAuthUser(ctx *gin.Context, identity oauth.Identity) (entity.User, bool) {
+	username, ok := h.freeUsername(ctx, identity)
+	if !ok {
+		return entity.User{}, false
+	}
+
+	// social accounts have no password
+	password, ok := h.unknownPassword(ctx)
+	if !ok {
+		return entity.User{}, false
+	}
+
+	email := ""
+	if identity.EmailVerified {
+		email = identity.Email
+	}
+
+	fullName := identity.Name
+	if fullName == "" {
+		fullName = username
+	}
+	// full_name holds 50 characters, not bytes
+	if runes := []rune(fullName); len(runes) > 50 {
+		fullName = string(runes[:50])
+	}
+
+	user, err := h.UseCase.UserRepo.Create(ctx, entity.User{
+		FullName: fullName,
+		UserType: "user",
+		UserRole: "user",
+		UserName: username,
+		Email:    email,
+		Status:   "active",
+		Password: password,
+	})
+	if h.HandleDbError(ctx, err, "Error creating user") {
+		return entity.User{}, false
+	}
+
+	return user, true
+}
+
+func (h *Handler) linkIdentity(ctx *gin.Context, user entity.User, identity oauth.Identity) bool {
+	_, err := h.UseCase.UserIdentityRepo.Create(ctx, entity.UserIdentity{
+		UserID:   user.ID,
+		Provider: identity.Provider,
+		Subject:  identity.Subject,
+		Email:    identity.Email,
+	})
+
+	return !h.HandleDbError(ctx, err, "Error linking user identity")
+}
+
+// freeUsername derives a username from the identity and appends digits until it is unused.
+func (h *Handler) freeUsername(ctx *gin.Context, identity oauth.Identity) (string, bool) {
+	base := identity.Provider + "_" + identity.Subject
+	if at := strings.Index(identity.Email, "@"); at > 0 {
+		base = identity.Email[:at]
+	}
+
+	base = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_', r == '.':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		}
+		return -1
+	}, base)
+	if len(base) > 40 {
+		base = base[:40]
+	}
+	if base == "" {
+		base = identity.Provider
+	}
+
+	username := base
+	for i := 0; i < 5; i++ {
+		_, err := h.UseCase.UserRepo.GetSingle(ctx, entity.UserSingleRequest{UserName: username})
+		if err == pgx.ErrNoRows {
+			return username, true
+		}
+		if err != nil {
+			h.HandleDbError(ctx, err, "Error getting user")
+			return "", false
+		}
+
+		username = base + etc.GenerateOTP(6)
+	}
+
+	h.ReturnError(ctx, config.ErrorConflict, "Could not pick a username", http.StatusConflict)
+	return "", false
+}
+
+func oauthStateKey(state string) string {
+	return "oauth-state-" + state
+}
@@ -1,17 +1,34 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
+	"slices"
 	"strconv"
+	"time"
 
 	"github.com/Akrom0181/Food-Delivery/config"
 	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/Akrom0181/Food-Delivery/internal/pricing"
+	"github.com/Akrom0181/Food-Delivery/internal/usecase/repo"
+	"github.com/Akrom0181/Food-Delivery/pkg/money"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 // CreateOrder godoc
 // @Router /order [post]
 // @Summary Create a new order
-// @Description Create a new order
+// @Description Create a new order. A bundle takes the options chosen in selections, slots without a
+// @Description selection take their default option. Bundles are returned with a line per component
+// @Description pointing to the bundle line in parent_item_id, component lines cost nothing.
+// @Description Active pricing rules are applied to every line, applied_rules lists them with their discount.
+// @Description Prices include VAT, tax is the VAT of a line at the rate of the tax category of its product.
+// @Description All items have to be from one restaurant, the order goes to its nearest branch. When that branch
+// @Description is at capacity it either takes the order with a later ready_eta, passes it to its next nearest
+// @Description branch or turns it down with 503 BRANCH_BUSY.
+// @Description With scheduled_at, the starts_at of a slot from /branch/{id}/slots, the order is scheduled at the
+// @Description nearest branch with that slot free and released to its kitchen as pending ahead of the slot.
 // @Security BearerAuth
 // @Tags order
 // @Accept  json
@@ -19,6 +36,7 @@ import (
 // @Param order body entity.Order true "Order object"
 // @Success 201 {object} entity.Order
 // @Failure 400 {object} entity.ErrorResponse
+// @Failure 503 {object} entity.ErrorResponse
 func (h *Handler) CreateOrder(ctx *gin.Context) {
 	var body entity.Order
 
@@ -28,38 +46,256 @@ func (h *Handler) CreateOrder(ctx *gin.Context) {
 		return
 	}
 
-	branch, err := h.UseCase.BranchRepo.GetNearestBranch(ctx, body.Latitude, body.Longitude)
-	if h.HandleDbError(ctx, err, "Error getting nearest branch") {
+	// an order goes to the nearest branch of the restaurant its items are of
+	productIDs := make([]string, 0, len(body.OrderItems))
+	for _, item := range body.OrderItems {
+		productIDs = append(productIDs, item.ProductId)
+	}
+
+	restaurants, err := h.UseCase.RestaurantRepo.GetProductRestaurants(ctx, productIDs)
+	if h.HandleDbError(ctx, err, "Error getting restaurants of products") {
+		return
+	}
+	if len(restaurants) > 1 {
+		h.ReturnError(ctx, config.ErrorBadRequest, "All items must be from the same restaurant", 400)
+		return
+	}
+
+	restaurantID := config.DefaultRestaurantID
+	if len(restaurants) == 1 {
+		restaurantID = restaurants[0]
+	}
+
+	branches, err := h.UseCase.BranchRepo.GetNearestBranches(ctx, restaurantID, body.Latitude, body.Longitude)
+	if h.HandleDbError(ctx, err, "Error getting nearest branches") {
+		return
+	}
+	if len(branches) == 0 {
+		h.ReturnError(ctx, config.ErrorBadRequest,
+			fmt.Sprintf("There is no branch near you in %g km radius", config.DeliveryRadius/1000), 400)
 		return
 	}
 
-	body.BranchId = branch.Id
 	body.UserID = ctx.GetHeader("sub")
+	body.Status = "pending"
+
+	// a scheduled order waits for its slot at the nearest branch that has it free
+	scheduled := body.ScheduledAt != ""
+	if scheduled {
+		branch, ok := h.scheduleBranch(ctx, &body, branches)
+		if !ok {
+			return
+		}
+		branches = []entity.Branch{branch}
+		body.Status = "scheduled"
+	}
 
-	// Calculate total price
-	var totalPrice float64
-	for i, item := range body.OrderItems {
+	firstOrder := false
+	if body.UserID != "" {
+		hasOrders, err := h.UseCase.OrderRepo.HasOrders(ctx, body.UserID)
+		if h.HandleDbError(ctx, err, "Error getting orders") {
+			return
+		}
+		firstOrder = !hasOrders
+	}
+
+	// the nearest branch takes the order unless it is full, then its overflow
+	// decides; the order is priced again at every branch it is offered to
+	for i, branch := range branches {
+		order := body
+		order.BranchId = branch.Id
+		if !h.priceOrder(ctx, &order, firstOrder) {
+			return
+		}
+
+		// Create counts the load of the branch as it takes the order, a full
+		// branch is turned down there unless its overflow extends the ETA
+		created, err := h.UseCase.OrderRepo.Create(ctx, order)
+		var full *repo.BranchFullError
+		if errors.As(err, &full) {
+			if full.Overflow == "reroute" && i < len(branches)-1 {
+				continue
+			}
+			ctx.Header("Retry-After", strconv.Itoa(int(config.CapacityWindow.Seconds())))
+			h.ReturnError(ctx, config.ErrorBranchBusy, "The restaurant is too busy to take the order, try again later", 503)
+			return
+		}
+		if errors.Is(err, repo.ErrSlotFull) {
+			h.ReturnError(ctx, config.ErrorBadRequest, "The delivery slot is not available", 400)
+			return
+		}
+		if h.HandleDbError(ctx, err, "Error creating order") {
+			return
+		}
+
+		ctx.JSON(201, created)
+		return
+	}
+}
+
+// priceOrder prices the lines of an order at its branch, bundles are expanded
+// into their component lines. It writes the error response when it fails.
+func (h *Handler) priceOrder(ctx *gin.Context, order *entity.Order, firstOrder bool) bool {
+	var (
+		totalPrice money.Amount
+		tax        money.Amount
+		items      []entity.OrderItems
+		priced     []int
+		lines      []pricing.Line
+	)
+	for _, item := range order.OrderItems {
 		if item.Quantity == 0 {
 			h.ReturnError(ctx, config.ErrorBadRequest, "Quantity must be greater than 0", 400)
-			return
+			return false
 		}
 		product, err := h.UseCase.ProductRepo.GetSingle(ctx, entity.Id{ID: item.ProductId})
 		if h.HandleDbError(ctx, err, "Error getting product") {
-			return
+			return false
+		}
+		item.Id = uuid.NewString()
+		item.ParentItemID = ""
+		item.BundleSlotID = ""
+		item.Price = product.Price
+		item.PriceVersionID = product.PriceVersionID
+		item.MxikCode = product.MxikCode
+		item.TaxRate = product.TaxRate
+		item.ProductName = product.Name
+		item.PackageCode = product.PackageCode
+
+		var components []entity.OrderItems
+		if product.IsBundle {
+			bundle, err := h.UseCase.BundleRepo.Get(ctx, entity.BundleRequest{ProductID: item.ProductId, BranchID: order.BranchId})
+			if h.HandleDbError(ctx, err, "Error getting bundle") {
+				return false
+			}
+
+			components, item.Price, err = expandBundle(item, bundle, product.Price)
+			if err != nil {
+				h.ReturnError(ctx, config.ErrorBadRequest, err.Error(), 400)
+				return false
+			}
 		}
-		body.OrderItems[i].Price = product.Price
-		body.OrderItems[i].TotalPrice = product.Price * float64(item.Quantity)
-		totalPrice += body.OrderItems[i].TotalPrice
+
+		priced = append(priced, len(items))
+		lines = append(lines, pricing.Line{
+			ProductID:  product.Id,
+			CategoryID: product.CategoryId,
+			UnitPrice:  item.Price,
+			Quantity:   item.Quantity,
+		})
+		items = append(items, item)
+		items = append(items, components...)
 	}
-	body.TotalPrice = totalPrice
-	body.Status = "pending"
 
-	order, err := h.UseCase.OrderRepo.Create(ctx, body)
-	if h.HandleDbError(ctx, err, "Error creating order") {
+	rules, err := h.UseCase.PricingRuleRepo.GetActive(ctx, order.BranchId)
+	if h.HandleDbError(ctx, err, "Error getting pricing rules") {
+		return false
+	}
+
+	results := pricing.Apply(rules, pricing.Order{
+		BranchID:   order.BranchId,
+		Time:       time.Now().In(config.LocalTime),
+		FirstOrder: firstOrder,
+		Currency:   h.currency,
+	}, lines)
+
+	// component lines cost nothing, the bundle line carries the price
+	for i, index := range priced {
+		item := &items[index]
+		item.Discount = results[i].Discount
+		item.AppliedRules = results[i].Applied
+		if item.AppliedRules == nil {
+			item.AppliedRules = []entity.AppliedRule{}
+		}
+		item.TotalPrice = item.Price.Mul(item.Quantity) - item.Discount
+		item.Tax = item.TotalPrice.IncludedTax(item.TaxRate, h.currency)
+		totalPrice += item.TotalPrice
+		tax += item.Tax
+	}
+
+	order.OrderItems = items
+	order.TotalPrice = totalPrice
+	order.Tax = tax
+	order.Currency = h.currency.Code
+
+	return true
+}
+
+// CheckCart godoc
+// @Router /order/check [post]
+// @Summary Warn about allergens in a cart
+// @Description Lists the items of the cart, and the components of bundles in it, that have one of
+// @Description exclude_allergens, or of the allergens excluded in the profile of the user when it is empty.
+// @Security BearerAuth
+// @Tags order
+// @Accept  json
+// @Produce  json
+// @Param cart body entity.CartCheckRequest true "Cart"
+// @Param lang query string false "locale: uz, ru or en, overrides Accept-Language"
+// @Success 200 {object} entity.CartCheck
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) CheckCart(ctx *gin.Context) {
+	var (
+		body     entity.CartCheckRequest
+		response = entity.CartCheck{Warnings: []entity.AllergenWarning{}}
+		locales  = h.locales(ctx)
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil || !validValues(body.ExcludeAllergens, config.Allergens) {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
 		return
 	}
 
-	ctx.JSON(201, order)
+	excluded := body.ExcludeAllergens
+	if len(excluded) == 0 && ctx.GetHeader("sub") != "" {
+		user, err := h.UseCase.UserRepo.GetSingle(ctx, entity.UserSingleRequest{ID: ctx.GetHeader("sub")})
+		if h.HandleDbError(ctx, err, "Error getting user") {
+			return
+		}
+
+		excluded = user.ExcludedAllergens
+	}
+
+	for _, item := range body.Items {
+		product, err := h.UseCase.ProductRepo.GetSingle(ctx, entity.Id{ID: item.ProductId, Locales: locales})
+		if h.HandleDbError(ctx, err, "Error getting product") {
+			return
+		}
+
+		if warning, ok := allergenWarning(product, excluded); ok {
+			response.Warnings = append(response.Warnings, warning)
+		}
+
+		if !product.IsBundle {
+			continue
+		}
+
+		bundle, err := h.UseCase.BundleRepo.Get(ctx, entity.BundleRequest{ProductID: product.Id})
+		if h.HandleDbError(ctx, err, "Error getting bundle") {
+			return
+		}
+
+		components, _, err := expandBundle(item, bundle, product.Price)
+		if err != nil {
+			h.ReturnError(ctx, config.ErrorBadRequest, err.Error(), 400)
+			return
+		}
+
+		for _, component := range components {
+			componentProduct, err := h.UseCase.ProductRepo.GetSingle(ctx, entity.Id{ID: component.ProductId, Locales: locales})
+			if h.HandleDbError(ctx, err, "Error getting product") {
+				return
+			}
+
+			if warning, ok := allergenWarning(componentProduct, excluded); ok {
+				warning.BundleID = product.Id
+				response.Warnings = append(response.Warnings, warning)
+			}
+		}
+	}
+
+	ctx.JSON(200, response)
 }
 
 // GetOrder godoc
@@ -85,6 +321,10 @@ func (h *Handler) GetOrder(ctx *gin.Context) {
 		return
 	}
 
+	if !h.checkOrderAccess(ctx, order) {
+		return
+	}
+
 	ctx.JSON(200, order)
 }
 
@@ -112,16 +352,22 @@ func (h *Handler) GetOrders(ctx *gin.Context) {
 
 	req.Page, _ = strconv.Atoi(page)
 	req.Limit, _ = strconv.Atoi(limit)
-	req.Filters = append(req.Filters,
-		entity.Filter{
-			Column: "branch_id",
-			Type:   "eq",
-			Value:  search,
-		},
-	)
+	if search != "" {
+		req.Filters = append(req.Filters,
+			entity.Filter{
+				Column: "branch_id",
+				Type:   "eq",
+				Value:  search,
+			},
+		)
+	}
+
+	if !h.scopeOrders(ctx, &req) {
+		return
+	}
 
 	req.OrderBy = append(req.OrderBy, entity.OrderBy{
-		Column: "created_at",
+		Column: "o.created_at",
 		Order:  "desc",
 	})
 
@@ -136,7 +382,9 @@ func (h *Handler) GetOrders(ctx *gin.Context) {
 // UpdateOrder godoc
 // @Router /order [put]
 // @Summary Update a order
-// @Description Update a order
+// @Description Update a order. Its price, tax and currency are set when it is placed and can not be changed.
+// @Description Customers may only cancel a scheduled, pending or confirmed order. Staff of its branch move it through the
+// @Description kitchen, its courier picks it up and delivers it; other status changes are refused with 409.
 // @Security BearerAuth
 // @Tags order
 // @Accept  json
@@ -144,6 +392,7 @@ func (h *Handler) GetOrders(ctx *gin.Context) {
 // @Param order body entity.Order true "Order object"
 // @Success 200 {object} entity.Order
 // @Failure 400 {object} entity.ErrorResponse
+// @Failure 409 {object} entity.ErrorResponse
 func (h *Handler) UpdateOrder(ctx *gin.Context) {
 	var (
 		body entity.Order
@@ -155,28 +404,157 @@ func (h *Handler) UpdateOrder(ctx *gin.Context) {
 		return
 	}
 
-	if ctx.GetHeader("user_type") == "user" {
-		body.UserID = ctx.GetHeader("sub")
-	}
-
 	getorder, err := h.UseCase.OrderRepo.GetSingle(ctx, entity.Id{ID: body.ID})
 	if h.HandleDbError(ctx, err, "Error getting order") {
 		return
 	}
 
+	if !h.checkOrderAccess(ctx, getorder) {
+		return
+	}
+
+	if !isAdmin(ctx) {
+		// only admins may move an order to another user or courier
+		body.UserID = getorder.UserID
+		body.CourierId = getorder.CourierId
+	}
+
+	if isBranchStaff(ctx) {
+		// staff may not hand an order to a branch they do not work at
+		body.BranchId = getorder.BranchId
+	}
+
+	if body.Status == "" {
+		body.Status = getorder.Status
+	}
+
+	// only order creation schedules an order
+	if body.Status == "scheduled" && getorder.Status != "scheduled" {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Order can not be scheduled after it was placed", 400)
+		return
+	}
+
 	if body.Status == "cancelled" && getorder.Status == "picked_up" {
 		h.ReturnError(ctx, config.ErrorBadRequest, "Order already picked up and cannot be cancelled", 400)
 		return
 	}
 
+	moved := body.Status != getorder.Status
+	if moved {
+		actor, ok := h.orderActor(ctx, getorder)
+		if !ok {
+			return
+		}
+
+		if !canMoveOrder(actor, getorder.Status, body.Status) {
+			h.ReturnError(ctx, config.ErrorConflict,
+				fmt.Sprintf("Order can not be moved from %s to %s", getorder.Status, body.Status), 409)
+			return
+		}
+	}
+
 	order, err := h.UseCase.OrderRepo.Update(ctx, body)
 	if h.HandleDbError(ctx, err, "Error updating order") {
 		return
 	}
 
+	// the printers of the branch get the tickets once, when staff or an admin
+	// confirm a pending order
+	if moved && getorder.Status == "pending" && order.Status == "confirmed" {
+		go h.printConfirmed(order.ID)
+	}
+
+	// the receipt is registered with the fiscal provider and emailed in the
+	// background, only couriers, staff and admins deliver an order
+	if moved && order.Status == "delivered" {
+		h.deliverReceipt(ctx, order.ID)
+	}
+
 	ctx.JSON(200, order)
 }
 
+// deliverReceipt makes the receipt of a delivered order and emails it to the
+// customer. An order delivered again keeps its receipt and is not emailed twice.
+func (h *Handler) deliverReceipt(ctx *gin.Context, orderID string) {
+	// only the call that made the receipt sends it
+	_, created, err := h.UseCase.ReceiptRepo.Create(ctx, entity.Id{ID: orderID})
+	if err != nil {
+		h.Logger.Error(err, "Error creating receipt")
+		return
+	}
+	if !created {
+		return
+	}
+
+	delivered, err := h.UseCase.OrderRepo.GetSingle(ctx, entity.Id{ID: orderID})
+	if err != nil {
+		h.Logger.Error(err, "Error getting order")
+		return
+	}
+
+	go h.emailReceipt(delivered)
+}
+
+// orderMoves lists the status changes each kind of caller may make on an
+// order, admins may make any. A scheduled order is released by the scheduler,
+// others may only cancel it.
+var orderMoves = map[string]map[string][]string{
+	"customer": {
+		"scheduled": {"cancelled"},
+		"pending":   {"cancelled"},
+		"confirmed": {"cancelled"},
+	},
+	"courier": {
+		"confirmed": {"picked_up"},
+		"preparing": {"picked_up"},
+		"picked_up": {"delivered"},
+	},
+	"staff": {
+		"scheduled": {"cancelled"},
+		"pending":   {"confirmed", "cancelled"},
+		"confirmed": {"preparing", "cancelled"},
+		"preparing": {"picked_up", "cancelled"},
+		"picked_up": {"delivered"},
+	},
+}
+
+// canMoveOrder reports whether actor may move an order from one status to another.
+func canMoveOrder(actor, from, to string) bool {
+	if actor == "admin" {
+		return true
+	}
+
+	return slices.Contains(orderMoves[actor][from], to)
+}
+
+// orderActor tells what the caller is to an order: an admin, staff of its
+// branch, its courier or else its customer.
+func (h *Handler) orderActor(ctx *gin.Context, order entity.Order) (string, bool) {
+	if isAdmin(ctx) {
+		return "admin", true
+	}
+
+	if isBranchStaff(ctx) {
+		branches, ok := h.staffBranches(ctx)
+		if !ok {
+			return "", false
+		}
+		if order.BranchId != "" && slices.Contains(branches, order.BranchId) {
+			return "staff", true
+		}
+	}
+
+	courier, isCourier, ok := h.callerCourier(ctx)
+	if !ok {
+		return "", false
+	}
+	if isCourier && courier.ID != "" && courier.ID == order.CourierId {
+		return "courier", true
+	}
+
+	return "customer", true
+}
+
 // DeleteOrder godoc
 // @Router /order/{id} [delete]
 // @Summary Delete a order
@@ -195,7 +573,8 @@ func (h *Handler) DeleteOrder(ctx *gin.Context) {
 
 	req.ID = ctx.Param("id")
 
-	if ctx.GetHeader("user_type") != "admin" {
+	if !isAdmin(ctx) {
+		h.notFound(ctx)
 		return
 	}
 
@@ -256,8 +635,12 @@ func (h *Handler) GetBranchOrders(ctx *gin.Context) {
 		},
 	)
 
+	if !h.scopeOrders(ctx, &req) {
+		return
+	}
+
 	req.OrderBy = append(req.OrderBy, entity.OrderBy{
-		Column: "created_at",
+		Column: "o.created_at",
 		Order:  "desc",
 	})
 
@@ -269,3 +652,55 @@ func (h *Handler) GetBranchOrders(ctx *gin.Context) {
 
 	ctx.JSON(200, orders)
 }
+
+// expandBundle resolves the option of every slot of the bundle in item and
+// returns the component lines for the kitchen and the price of one bundle.
+// A slot takes the selected option, else its default, else its only option.
+func expandBundle(item entity.OrderItems, bundle entity.Bundle, price money.Amount) ([]entity.OrderItems, money.Amount, error) {
+	selected := map[string]string{}
+	for _, selection := range item.Selections {
+		selected[selection.SlotID] = selection.ProductID
+	}
+
+	var components []entity.OrderItems
+	for _, slot := range bundle.Slots {
+		var option *entity.BundleSlotOption
+		for i := range slot.Options {
+			candidate := &slot.Options[i]
+			if productID, ok := selected[slot.ID]; ok {
+				if candidate.ProductID == productID {
+					option = candidate
+				}
+			} else if candidate.IsDefault || len(slot.Options) == 1 {
+				option = candidate
+			}
+		}
+
+		if option == nil {
+			if _, ok := selected[slot.ID]; ok {
+				return nil, 0, fmt.Errorf("Invalid option for %s", slot.Name)
+			}
+			return nil, 0, fmt.Errorf("Choose an option for %s", slot.Name)
+		}
+		if !option.IsAvailable {
+			return nil, 0, fmt.Errorf("%s is not available", option.Name)
+		}
+		delete(selected, slot.ID)
+
+		price += option.PriceDelta
+		components = append(components, entity.OrderItems{
+			Id:           uuid.NewString(),
+			ProductId:    option.ProductID,
+			ProductName:  option.Name,
+			Quantity:     item.Quantity * slot.Quantity,
+			ParentItemID: item.Id,
+			BundleSlotID: slot.ID,
+		})
+	}
+
+	if len(selected) > 0 {
+		return nil, 0, errors.New("Selection for a slot the bundle does not have")
+	}
+
+	return components, price, nil
+}
@@ -0,0 +1,275 @@
+package handler
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4"
+)
+
+// Casbin only decides by role and path. The helpers below make sure a caller
+// only touches their own rows; admins may act on anyone's, branch staff on
+// those of their branches and restaurant admins on those of their restaurant.
+// A row that belongs to someone else is reported as not found so its
+// existence is not leaked.
+
+// isAdmin reports whether the caller may access resources of other users.
+func isAdmin(ctx *gin.Context) bool {
+	role := ctx.GetHeader("user_role")
+	return role == "admin" || role == "superadmin"
+}
+
+// isBranchStaff reports whether the caller is limited to the branches they are assigned to.
+func isBranchStaff(ctx *gin.Context) bool {
+	return slices.Contains(config.BranchStaffRoles, ctx.GetHeader("user_role"))
+}
+
+// staffBranches returns the branches of the caller, loaded once per request.
+func (h *Handler) staffBranches(ctx *gin.Context) ([]string, bool) {
+	if branches, ok := ctx.Get("staff_branches"); ok {
+		return branches.([]string), true
+	}
+
+	branches, err := h.UseCase.BranchStaffRepo.GetBranches(ctx, entity.Id{ID: ctx.GetHeader("sub")})
+	if h.HandleDbError(ctx, err, "Error getting staff branches") {
+		return nil, false
+	}
+
+	ctx.Set("staff_branches", branches)
+	return branches, true
+}
+
+// checkBranchAccess writes a not found response when the caller is branch
+// staff and branchID is not one of their branches.
+func (h *Handler) checkBranchAccess(ctx *gin.Context, branchID string) bool {
+	if !isBranchStaff(ctx) {
+		return true
+	}
+
+	branches, ok := h.staffBranches(ctx)
+	if !ok {
+		return false
+	}
+
+	if branchID != "" && slices.Contains(branches, branchID) {
+		return true
+	}
+
+	h.notFound(ctx)
+	return false
+}
+
+// checkOrderBranch is checkBranchAccess for the branch of an order.
+func (h *Handler) checkOrderBranch(ctx *gin.Context, orderID string) bool {
+	if !isBranchStaff(ctx) {
+		return true
+	}
+
+	order, err := h.UseCase.OrderRepo.GetSingle(ctx, entity.Id{ID: orderID})
+	if h.HandleDbError(ctx, err, "Error getting order") {
+		return false
+	}
+
+	return h.checkBranchAccess(ctx, order.BranchId)
+}
+
+// scopeBranches limits a list to the branches of branch staff, column holds
+// the branch id of a row.
+func (h *Handler) scopeBranches(ctx *gin.Context, req *entity.GetListFilter, column string) bool {
+	if !isBranchStaff(ctx) {
+		return true
+	}
+
+	branches, ok := h.staffBranches(ctx)
+	if !ok {
+		return false
+	}
+
+	if len(branches) == 0 {
+		// no branches: match nothing
+		req.Filters = append(req.Filters, entity.Filter{Column: column, Type: "eq", Value: "00000000-0000-0000-0000-000000000000"})
+		return true
+	}
+
+	req.Filters = append(req.Filters, entity.Filter{Column: column, Type: "in", Value: strings.Join(branches, ",")})
+	return true
+}
+
+// isRestaurantAdmin reports whether the caller manages the catalog of one restaurant only.
+func isRestaurantAdmin(ctx *gin.Context) bool {
+	return ctx.GetHeader("user_role") == config.RestaurantAdminRole
+}
+
+// assignedRole reports whether role is bound to branches or a restaurant, such
+// roles are only given through /branch-staff and /restaurant-admin.
+func assignedRole(role string) bool {
+	return role == config.RestaurantAdminRole || slices.Contains(config.BranchStaffRoles, role)
+}
+
+// callerRestaurant returns the restaurant of a restaurant admin, loaded once per request.
+func (h *Handler) callerRestaurant(ctx *gin.Context) (string, bool) {
+	if restaurantID, ok := ctx.Get("admin_restaurant"); ok {
+		return restaurantID.(string), true
+	}
+
+	admin, err := h.UseCase.RestaurantRepo.GetAdmin(ctx, entity.Id{ID: ctx.GetHeader("sub")})
+	if h.HandleDbError(ctx, err, "Error getting restaurant of admin") {
+		return "", false
+	}
+
+	ctx.Set("admin_restaurant", admin.RestaurantID)
+	return admin.RestaurantID, true
+}
+
+// restaurantFor returns the restaurant the caller works on: their own for a
+// restaurant admin, else requested or the default restaurant when it is empty.
+func (h *Handler) restaurantFor(ctx *gin.Context, requested string) (string, bool) {
+	if isRestaurantAdmin(ctx) {
+		return h.callerRestaurant(ctx)
+	}
+
+	if requested == "" {
+		return config.DefaultRestaurantID, true
+	}
+
+	return requested, true
+}
+
+// restaurantScope is restaurantFor the restaurant_id query parameter.
+func (h *Handler) restaurantScope(ctx *gin.Context) (string, bool) {
+	return h.restaurantFor(ctx, ctx.Query("restaurant_id"))
+}
+
+// checkRestaurantRows writes a not found response when the caller is a
+// restaurant admin and one of the rows of table is not of their restaurant.
+func (h *Handler) checkRestaurantRows(ctx *gin.Context, table string, ids ...string) bool {
+	if !isRestaurantAdmin(ctx) {
+		return true
+	}
+
+	restaurantID, ok := h.callerRestaurant(ctx)
+	if !ok {
+		return false
+	}
+
+	owns, err := h.UseCase.RestaurantRepo.Owns(ctx, table, restaurantID, ids)
+	if h.HandleDbError(ctx, err, "Error checking restaurant") {
+		return false
+	}
+
+	if !owns {
+		h.notFound(ctx)
+		return false
+	}
+
+	return true
+}
+
+// ownerScope returns the user id list queries have to be limited to, or an
+// empty string when the caller is an admin and may see everything.
+func ownerScope(ctx *gin.Context) string {
+	if isAdmin(ctx) {
+		return ""
+	}
+
+	return ctx.GetHeader("sub")
+}
+
+// checkOwner writes a not found response unless the caller is an admin or ownerID is the caller.
+func (h *Handler) checkOwner(ctx *gin.Context, ownerID string) bool {
+	if isAdmin(ctx) || (ownerID != "" && ownerID == ctx.GetHeader("sub")) {
+		return true
+	}
+
+	h.notFound(ctx)
+	return false
+}
+
+// callerCourier returns the courier row of the caller when the caller is a courier.
+func (h *Handler) callerCourier(ctx *gin.Context) (entity.Courier, bool, bool) {
+	if ctx.GetHeader("user_role") != "courier" {
+		return entity.Courier{}, false, true
+	}
+
+	courier, err := h.UseCase.CourierRepo.GetByUserID(ctx, ctx.GetHeader("sub"))
+	if err == pgx.ErrNoRows {
+		// a courier account without a courier profile has no orders
+		return entity.Courier{}, true, true
+	}
+	if h.HandleDbError(ctx, err, "Error getting courier") {
+		return entity.Courier{}, true, false
+	}
+
+	return courier, true, true
+}
+
+// checkOrderAccess lets admins through, branch staff to the orders of their
+// branches, couriers only to orders assigned to them and users only to their
+// own orders.
+func (h *Handler) checkOrderAccess(ctx *gin.Context, order entity.Order) bool {
+	if isAdmin(ctx) {
+		return true
+	}
+
+	if isBranchStaff(ctx) {
+		if order.UserID != "" && order.UserID == ctx.GetHeader("sub") {
+			return true
+		}
+
+		return h.checkBranchAccess(ctx, order.BranchId)
+	}
+
+	courier, isCourier, ok := h.callerCourier(ctx)
+	if !ok {
+		return false
+	}
+
+	if isCourier {
+		if courier.ID != "" && order.CourierId == courier.ID {
+			return true
+		}
+
+		h.notFound(ctx)
+		return false
+	}
+
+	return h.checkOwner(ctx, order.UserID)
+}
+
+// scopeOrders limits an order list to what the caller may see.
+func (h *Handler) scopeOrders(ctx *gin.Context, req *entity.GetListFilter) bool {
+	if isAdmin(ctx) {
+		return true
+	}
+
+	if isBranchStaff(ctx) {
+		return h.scopeBranches(ctx, req, "o.branch_id")
+	}
+
+	courier, isCourier, ok := h.callerCourier(ctx)
+	if !ok {
+		return false
+	}
+
+	if isCourier {
+		if courier.ID == "" {
+			// no courier profile: match nothing
+			req.Filters = append(req.Filters, entity.Filter{Column: "o.id", Type: "eq", Value: "00000000-0000-0000-0000-000000000000"})
+			return true
+		}
+
+		req.Filters = append(req.Filters, entity.Filter{Column: "o.courier_id", Type: "eq", Value: courier.ID})
+		return true
+	}
+
+	req.Filters = append(req.Filters, entity.Filter{Column: "o.user_id", Type: "eq", Value: ctx.GetHeader("sub")})
+	return true
+}
+
+func (h *Handler) notFound(ctx *gin.Context) {
+	h.ReturnError(ctx, config.ErrorNotFound, "The requested resource was not found.", http.StatusNotFound)
+}
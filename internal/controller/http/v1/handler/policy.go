@@ -0,0 +1,274 @@
+package handler
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// CreatePolicy godoc
+// @Router /policy [post]
+// @Summary Create a new policy
+// @Description Allows a role to call the methods (regex, e.g. GET|POST) on a path pattern (e.g. /v1/order/*). Applied on all replicas without a restart.
+// @Security BearerAuth
+// @Tags policy
+// @Accept  json
+// @Produce  json
+// @Param policy body entity.Policy true "Policy object"
+// @Success 201 {object} entity.Policy
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) CreatePolicy(ctx *gin.Context) {
+	var (
+		body entity.Policy
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	body.Subject = strings.TrimSpace(body.Subject)
+	body.Object = strings.TrimSpace(body.Object)
+	body.Action = strings.TrimSpace(body.Action)
+
+	if body.Subject == "" || !strings.HasPrefix(body.Object, "/") || body.Action == "" {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Subject, object (path) and action are required", 400)
+		return
+	}
+
+	if _, err = regexp.Compile(body.Action); err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Action must be a valid regular expression", 400)
+		return
+	}
+
+	policy, err := h.UseCase.PolicyRepo.CreatePolicy(ctx, body)
+	if h.HandleDbError(ctx, err, "Error creating policy") {
+		return
+	}
+
+	if !h.applyPolicies(ctx) {
+		return
+	}
+
+	ctx.JSON(201, policy)
+}
+
+// GetPolicies godoc
+// @Router /policy/list [get]
+// @Summary Get a list of policies
+// @Description Get a list of policies
+// @Security BearerAuth
+// @Tags policy
+// @Accept  json
+// @Produce  json
+// @Param page query number true "page"
+// @Param limit query number true "limit"
+// @Param subject query string false "subject"
+// @Param search query string false "search by path"
+// @Success 200 {object} entity.PolicyList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetPolicies(ctx *gin.Context) {
+	var (
+		req entity.GetListFilter
+	)
+
+	page := ctx.DefaultQuery("page", "1")
+	limit := ctx.DefaultQuery("limit", "10")
+	subject := ctx.DefaultQuery("subject", "")
+	search := ctx.DefaultQuery("search", "")
+
+	req.Page, _ = strconv.Atoi(page)
+	req.Limit, _ = strconv.Atoi(limit)
+
+	if subject != "" {
+		req.Filters = append(req.Filters, entity.Filter{
+			Column: "v0",
+			Type:   "eq",
+			Value:  subject,
+		})
+	}
+
+	req.Filters = append(req.Filters, entity.Filter{
+		Column: "v1",
+		Type:   "search",
+		Value:  search,
+	})
+
+	req.OrderBy = append(req.OrderBy,
+		entity.OrderBy{
+			Column: "v1",
+			Order:  "asc",
+		},
+		entity.OrderBy{
+			Column: "v0",
+			Order:  "asc",
+		},
+	)
+
+	policies, err := h.UseCase.PolicyRepo.GetPolicies(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting policies") {
+		return
+	}
+
+	ctx.JSON(200, policies)
+}
+
+// DeletePolicy godoc
+// @Router /policy/{id} [delete]
+// @Summary Delete a policy
+// @Description Delete a policy
+// @Security BearerAuth
+// @Tags policy
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Policy ID"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) DeletePolicy(ctx *gin.Context) {
+	var (
+		req entity.Id
+	)
+
+	req.ID = ctx.Param("id")
+
+	err := h.UseCase.PolicyRepo.DeletePolicy(ctx, req)
+	if h.HandleDbError(ctx, err, "Error deleting policy") {
+		return
+	}
+
+	if !h.applyPolicies(ctx) {
+		return
+	}
+
+	ctx.JSON(200, entity.SuccessResponse{
+		Message: "Policy deleted successfully",
+	})
+}
+
+// CreateRoleInheritance godoc
+// @Router /policy/role [post]
+// @Summary Create a role inheritance rule
+// @Description The role gets every permission of the parent role
+// @Security BearerAuth
+// @Tags policy
+// @Accept  json
+// @Produce  json
+// @Param role body entity.RoleInheritance true "Role inheritance object"
+// @Success 201 {object} entity.RoleInheritance
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) CreateRoleInheritance(ctx *gin.Context) {
+	var (
+		body entity.RoleInheritance
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	body.Role = strings.TrimSpace(body.Role)
+	body.Parent = strings.TrimSpace(body.Parent)
+
+	if body.Role == "" || body.Parent == "" || body.Role == body.Parent {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Role and a different parent role are required", 400)
+		return
+	}
+
+	role, err := h.UseCase.PolicyRepo.CreateRoleInheritance(ctx, body)
+	if h.HandleDbError(ctx, err, "Error creating role inheritance") {
+		return
+	}
+
+	if !h.applyPolicies(ctx) {
+		return
+	}
+
+	ctx.JSON(201, role)
+}
+
+// GetRoleInheritances godoc
+// @Router /policy/role/list [get]
+// @Summary Get a list of role inheritance rules
+// @Description Get a list of role inheritance rules
+// @Security BearerAuth
+// @Tags policy
+// @Accept  json
+// @Produce  json
+// @Param page query number true "page"
+// @Param limit query number true "limit"
+// @Success 200 {object} entity.RoleInheritanceList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetRoleInheritances(ctx *gin.Context) {
+	var (
+		req entity.GetListFilter
+	)
+
+	page := ctx.DefaultQuery("page", "1")
+	limit := ctx.DefaultQuery("limit", "10")
+
+	req.Page, _ = strconv.Atoi(page)
+	req.Limit, _ = strconv.Atoi(limit)
+
+	req.OrderBy = append(req.OrderBy, entity.OrderBy{
+		Column: "v0",
+		Order:  "asc",
+	})
+
+	roles, err := h.UseCase.PolicyRepo.GetRoleInheritances(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting role inheritances") {
+		return
+	}
+
+	ctx.JSON(200, roles)
+}
+
+// DeleteRoleInheritance godoc
+// @Router /policy/role/{id} [delete]
+// @Summary Delete a role inheritance rule
+// @Description Delete a role inheritance rule
+// @Security BearerAuth
+// @Tags policy
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Role inheritance ID"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) DeleteRoleInheritance(ctx *gin.Context) {
+	var (
+		req entity.Id
+	)
+
+	req.ID = ctx.Param("id")
+
+	err := h.UseCase.PolicyRepo.DeleteRoleInheritance(ctx, req)
+	if h.HandleDbError(ctx, err, "Error deleting role inheritance") {
+		return
+	}
+
+	if !h.applyPolicies(ctx) {
+		return
+	}
+
+	ctx.JSON(200, entity.SuccessResponse{
+		Message: "Role inheritance deleted successfully",
+	})
+}
+
+// applyPolicies reloads the enforcer here and on the other replicas after casbin_rule changed.
+func (h *Handler) applyPolicies(ctx *gin.Context) bool {
+	err := h.Enforcer.Update()
+	if err != nil {
+		h.Logger.Error(err, "Error applying policies")
+		h.ReturnError(ctx, config.ErrorInternalServer, "Saved, but the policies could not be applied yet", http.StatusInternalServerError)
+		return false
+	}
+
+	return true
+}
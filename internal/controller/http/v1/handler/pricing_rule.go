@@ -0,0 +1,214 @@
+package handler
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/Akrom0181/Food-Delivery/internal/pricing"
+	"github.com/gin-gonic/gin"
+)
+
+// CreatePricingRule godoc
+// @Router /pricing-rule [post]
+// @Summary Create a pricing rule
+// @Description Discounts applied to order lines: percent off the line, an amount off every unit or buy X get Y free.
+// @Description Higher priority rules come first, then product rules, category rules and rules for everything, branch rules before rules for all branches.
+// @Description The first matching rule applies, when it is stackable the following stackable rules apply too.
+// @Description start_time and end_time are "15:04" in local time, either may be left out to run from or until
+// @Description midnight. starts_at and ends_at are RFC 3339.
+// @Security BearerAuth
+// @Tags pricing-rule
+// @Accept  json
+// @Produce  json
+// @Param rule body entity.PricingRule true "Pricing rule"
+// @Success 201 {object} entity.PricingRule
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) CreatePricingRule(ctx *gin.Context) {
+	var (
+		body entity.PricingRule
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil || !validPricingRule(&body) {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	rule, err := h.UseCase.PricingRuleRepo.Create(ctx, body)
+	if h.HandleDbError(ctx, err, "Error creating pricing rule") {
+		return
+	}
+
+	ctx.JSON(201, rule)
+}
+
+// GetPricingRule godoc
+// @Router /pricing-rule/{id} [get]
+// @Summary Get a pricing rule by ID
+// @Description Get a pricing rule by ID
+// @Security BearerAuth
+// @Tags pricing-rule
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Pricing rule ID"
+// @Success 200 {object} entity.PricingRule
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetPricingRule(ctx *gin.Context) {
+	rule, err := h.UseCase.PricingRuleRepo.GetSingle(ctx, entity.Id{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting pricing rule") {
+		return
+	}
+
+	ctx.JSON(200, rule)
+}
+
+// GetPricingRules godoc
+// @Router /pricing-rule/list [get]
+// @Summary Get a list of pricing rules
+// @Description Rules in precedence order
+// @Security BearerAuth
+// @Tags pricing-rule
+// @Accept  json
+// @Produce  json
+// @Param page query number true "page"
+// @Param limit query number true "limit"
+// @Param branch_id query string false "branch_id"
+// @Success 200 {object} entity.PricingRuleList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetPricingRules(ctx *gin.Context) {
+	var (
+		req entity.GetListFilter
+	)
+
+	req.Page, _ = strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	req.Limit, _ = strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+
+	if branchID := ctx.Query("branch_id"); branchID != "" {
+		req.Filters = append(req.Filters, entity.Filter{
+			Column: "branch_id",
+			Type:   "eq",
+			Value:  branchID,
+		})
+	}
+
+	req.OrderBy = append(req.OrderBy, entity.OrderBy{
+		Column: "priority",
+		Order:  "desc",
+	}, entity.OrderBy{
+		Column: "created_at",
+		Order:  "desc",
+	})
+
+	rules, err := h.UseCase.PricingRuleRepo.GetList(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting pricing rules") {
+		return
+	}
+
+	rules.Items = pricing.Sort(rules.Items)
+
+	ctx.JSON(200, rules)
+}
+
+// UpdatePricingRule godoc
+// @Router /pricing-rule [put]
+// @Summary Update a pricing rule
+// @Description Replaces the whole rule
+// @Security BearerAuth
+// @Tags pricing-rule
+// @Accept  json
+// @Produce  json
+// @Param rule body entity.PricingRule true "Pricing rule"
+// @Success 200 {object} entity.PricingRule
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) UpdatePricingRule(ctx *gin.Context) {
+	var (
+		body entity.PricingRule
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil || body.ID == "" || !validPricingRule(&body) {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	rule, err := h.UseCase.PricingRuleRepo.Update(ctx, body)
+	if h.HandleDbError(ctx, err, "Error updating pricing rule") {
+		return
+	}
+
+	ctx.JSON(200, rule)
+}
+
+// DeletePricingRule godoc
+// @Router /pricing-rule/{id} [delete]
+// @Summary Delete a pricing rule
+// @Description Delete a pricing rule
+// @Security BearerAuth
+// @Tags pricing-rule
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Pricing rule ID"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) DeletePricingRule(ctx *gin.Context) {
+	err := h.UseCase.PricingRuleRepo.Delete(ctx, entity.Id{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error deleting pricing rule") {
+		return
+	}
+
+	ctx.JSON(200, entity.SuccessResponse{
+		Message: "Pricing rule deleted successfully",
+	})
+}
+
+// validPricingRule checks a rule and stores its dates in UTC.
+func validPricingRule(rule *entity.PricingRule) bool {
+	if rule.Name == "" {
+		return false
+	}
+
+	switch rule.Kind {
+	case "percent":
+		if rule.Percent <= 0 || rule.Percent > 100 {
+			return false
+		}
+	case "amount":
+		if rule.Amount <= 0 {
+			return false
+		}
+	case "buy_get":
+		if rule.BuyQuantity <= 0 || rule.GetQuantity <= 0 {
+			return false
+		}
+	default:
+		return false
+	}
+
+	for _, day := range rule.DaysOfWeek {
+		if day < 0 || day > 6 {
+			return false
+		}
+	}
+
+	for _, value := range []string{rule.StartTime, rule.EndTime} {
+		if value != "" && !pricing.ValidTime(value) {
+			return false
+		}
+	}
+
+	for _, value := range []*string{&rule.StartsAt, &rule.EndsAt} {
+		if *value == "" {
+			continue
+		}
+
+		t, err := time.Parse(time.RFC3339, *value)
+		if err != nil {
+			return false
+		}
+		*value = t.UTC().Format(time.RFC3339)
+	}
+
+	return rule.StartsAt == "" || rule.EndsAt == "" || rule.StartsAt < rule.EndsAt
+}
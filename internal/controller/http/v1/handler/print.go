@@ -0,0 +1,382 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/Akrom0181/Food-Delivery/internal/kitchen"
+	"github.com/Akrom0181/Food-Delivery/internal/ticket"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4"
+)
+
+// NextPrintJob godoc
+// @Router /print/agent/next [get]
+// @Summary Wait for the next print job of a branch
+// @Description For printer agents. Claims the oldest pending job with its ESC/POS data, waiting up to wait
+// @Description seconds for one, and returns 204 when none came. The job has to be acked before its lease runs out,
+// @Description else it is claimed again. An agent may print only the customer copies, or only the kitchen copies
+// @Description of one station. API keys only claim the jobs of the branches they are bound to.
+// @Security BearerAuth
+// @Tags print
+// @Produce  json
+// @Param branch_id query string true "Branch ID"
+// @Param kind query string false "Only jobs of this kind" Enums(customer, kitchen)
+// @Param station_id query string false "Only kitchen copies of this kitchen station"
+// @Param wait query int false "Seconds to wait for a job, at most 60"
+// @Success 200 {object} entity.PrintJob
+// @Success 204
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) NextPrintJob(ctx *gin.Context) {
+	req := entity.PrintJobClaim{
+		BranchID:  ctx.Query("branch_id"),
+		Kind:      ctx.Query("kind"),
+		StationID: ctx.Query("station_id"),
+	}
+
+	if req.BranchID == "" {
+		h.ReturnError(ctx, config.ErrorBadRequest, "branch_id is required", 400)
+		return
+	}
+	if req.Kind != "" && !validPrintKind(req.Kind) {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid kind", 400)
+		return
+	}
+	if !h.checkAgentBranch(ctx, req.BranchID) {
+		return
+	}
+
+	wait := config.PrintPollWait
+	if value := ctx.Query("wait"); value != "" {
+		seconds, err := strconv.Atoi(value)
+		if err != nil || seconds < 0 {
+			h.ReturnError(ctx, config.ErrorBadRequest, "Invalid wait", 400)
+			return
+		}
+		wait = min(time.Duration(seconds)*time.Second, config.PrintPollMaxWait)
+	}
+
+	// subscribed before the first claim so no job falls in between
+	sub := h.Kitchen.Subscribe(kitchen.ChannelPrintJobs, req.BranchID)
+	defer sub.Close()
+
+	// the poll may outlast the write timeout of the server
+	err := http.NewResponseController(ctx.Writer).SetWriteDeadline(time.Now().Add(wait + 10*time.Second))
+	if err != nil {
+		h.Logger.Error(err, "Error extending print poll deadline")
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	for {
+		job, err := h.UseCase.PrintJobRepo.Claim(ctx, req, config.PrintJobLease)
+		if err == nil {
+			ctx.JSON(200, job)
+			return
+		}
+		if err != pgx.ErrNoRows {
+			h.HandleDbError(ctx, err, "Error claiming print job")
+			return
+		}
+
+		select {
+		case <-ctx.Request.Context().Done():
+			return
+		case <-timer.C:
+			ctx.Status(http.StatusNoContent)
+			return
+		case <-sub.Ready():
+			sub.Take()
+		}
+	}
+}
+
+// AckPrintJob godoc
+// @Router /print/agent/{id}/ack [post]
+// @Summary Report whether a print job was printed
+// @Description For printer agents. A job that did not print is claimed again until it is out of attempts.
+// @Security BearerAuth
+// @Tags print
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Print job ID"
+// @Param ack body entity.PrintJobAck true "Outcome"
+// @Success 200 {object} entity.PrintJob
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) AckPrintJob(ctx *gin.Context) {
+	var (
+		body entity.PrintJobAck
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	body.ID = ctx.Param("id")
+
+	job, err := h.UseCase.PrintJobRepo.GetSingle(ctx, entity.Id{ID: body.ID})
+	if h.HandleDbError(ctx, err, "Error getting print job") {
+		return
+	}
+	if !h.checkAgentBranch(ctx, job.BranchID) {
+		return
+	}
+
+	job, err = h.UseCase.PrintJobRepo.Ack(ctx, body)
+	if h.HandleDbError(ctx, err, "Error acking print job") {
+		return
+	}
+
+	ctx.JSON(200, job)
+}
+
+// GetPrintJobs godoc
+// @Router /print/jobs/list [get]
+// @Summary Get a list of print jobs
+// @Description Newest first, without their data.
+// @Security BearerAuth
+// @Tags print
+// @Accept  json
+// @Produce  json
+// @Param page query number true "Page number"
+// @Param limit query number true "Number of results per page"
+// @Param branch_id query string false "Branch ID"
+// @Param order_id query string false "Order ID"
+// @Param status query string false "Status" Enums(pending, printing, printed, failed)
+// @Success 200 {object} entity.PrintJobList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetPrintJobs(ctx *gin.Context) {
+	var (
+		req entity.GetListFilter
+	)
+
+	page := ctx.DefaultQuery("page", "1")
+	limit := ctx.DefaultQuery("limit", "10")
+
+	req.Page, _ = strconv.Atoi(page)
+	req.Limit, _ = strconv.Atoi(limit)
+
+	for _, column := range []string{"branch_id", "order_id", "status"} {
+		if value := ctx.Query(column); value != "" {
+			req.Filters = append(req.Filters, entity.Filter{
+				Column: column,
+				Type:   "eq",
+				Value:  value,
+			})
+		}
+	}
+
+	if !h.scopeBranches(ctx, &req, "branch_id") {
+		return
+	}
+
+	req.OrderBy = append(req.OrderBy, entity.OrderBy{
+		Column: "created_at",
+		Order:  "desc",
+	})
+
+	jobs, err := h.UseCase.PrintJobRepo.GetList(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting print jobs") {
+		return
+	}
+
+	ctx.JSON(200, jobs)
+}
+
+// RetryPrintJob godoc
+// @Router /print/jobs/{id}/retry [post]
+// @Summary Retry a failed print job
+// @Description Queues the job again with its attempts reset.
+// @Security BearerAuth
+// @Tags print
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Print job ID"
+// @Success 200 {object} entity.PrintJob
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) RetryPrintJob(ctx *gin.Context) {
+	if !h.checkPrintJobBranch(ctx, ctx.Param("id")) {
+		return
+	}
+
+	job, err := h.UseCase.PrintJobRepo.Retry(ctx, entity.Id{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error retrying print job") {
+		return
+	}
+
+	ctx.JSON(200, job)
+}
+
+// PrintOrder godoc
+// @Router /print/order/{id} [post]
+// @Summary Print the tickets of an order again
+// @Description Queues the customer copy and the kitchen copies of the order, or the ones selected. Kitchen copies
+// @Description are only printed for open orders. The tickets are queued on their own when an order is confirmed.
+// @Security BearerAuth
+// @Tags print
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Order ID"
+// @Param request body entity.PrintRequest true "Tickets to print"
+// @Param lang query string false "Language" Enums(uz, ru, en)
+// @Success 200 {object} entity.PrintJobList
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) PrintOrder(ctx *gin.Context) {
+	var (
+		body entity.PrintRequest
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil || (body.Kind != "" && !validPrintKind(body.Kind)) {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	order, err := h.UseCase.OrderRepo.GetSingle(ctx, entity.Id{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting order") {
+		return
+	}
+
+	if !h.checkBranchAccess(ctx, order.BranchId) {
+		return
+	}
+
+	if order.BranchId == "" {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Order has no branch", 400)
+		return
+	}
+
+	jobs, err := h.printTickets(ctx, order, body, h.locales(ctx))
+	if h.HandleDbError(ctx, err, "Error printing order") {
+		return
+	}
+
+	if jobs.Count == 0 {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Nothing to print", 400)
+		return
+	}
+
+	ctx.JSON(200, jobs)
+}
+
+// printTickets lays out the tickets of the order req selects, in the first of
+// locales, and queues them for the printers of its branch.
+func (h *Handler) printTickets(ctx context.Context, order entity.Order, req entity.PrintRequest, locales []string) (entity.PrintJobList, error) {
+	doc := ticket.Document{Order: order, Locale: config.DefaultLocale}
+	if len(locales) > 0 {
+		doc.Locale = locales[0]
+	}
+
+	branch, err := h.UseCase.BranchRepo.GetSingle(ctx, entity.Id{ID: order.BranchId})
+	if err != nil {
+		return entity.PrintJobList{}, err
+	}
+	doc.Branch = branch
+
+	doc.Names, err = h.productNames(ctx, order.OrderItems, locales)
+	if err != nil {
+		return entity.PrintJobList{}, err
+	}
+
+	var jobs []entity.PrintJob
+	if req.Kind != "kitchen" && req.StationID == "" {
+		jobs = append(jobs, entity.PrintJob{
+			BranchID: order.BranchId,
+			OrderID:  order.ID,
+			Kind:     "customer",
+			Data:     ticket.Customer(doc),
+		})
+	}
+
+	if req.Kind != "customer" {
+		doc.Kitchen, err = h.UseCase.KitchenRepo.GetTicket(ctx, entity.KitchenTicketsRequest{
+			BranchID:  order.BranchId,
+			StationID: req.StationID,
+			OrderID:   order.ID,
+			Locales:   locales,
+		})
+		if err != nil && err != pgx.ErrNoRows {
+			return entity.PrintJobList{}, err
+		}
+
+		for _, kitchenCopy := range ticket.Kitchen(doc) {
+			jobs = append(jobs, entity.PrintJob{
+				BranchID:  order.BranchId,
+				OrderID:   order.ID,
+				Kind:      "kitchen",
+				StationID: kitchenCopy.StationID,
+				Data:      kitchenCopy.Data,
+			})
+		}
+	}
+
+	if len(jobs) == 0 {
+		return entity.PrintJobList{Items: []entity.PrintJob{}}, nil
+	}
+
+	return h.UseCase.PrintJobRepo.Create(ctx, jobs)
+}
+
+// printConfirmed queues the tickets of an order that was just confirmed.
+func (h *Handler) printConfirmed(orderID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	order, err := h.UseCase.OrderRepo.GetSingle(ctx, entity.Id{ID: orderID})
+	if err != nil {
+		h.Logger.Error(err, "Error getting order for printing")
+		return
+	}
+	if order.BranchId == "" {
+		return
+	}
+
+	if _, err = h.printTickets(ctx, order, entity.PrintRequest{}, nil); err != nil {
+		h.Logger.Error(err, "Error printing order tickets")
+	}
+}
+
+// checkPrintJobBranch is checkBranchAccess for the branch of a print job.
+func (h *Handler) checkPrintJobBranch(ctx *gin.Context, id string) bool {
+	if !isBranchStaff(ctx) {
+		return true
+	}
+
+	job, err := h.UseCase.PrintJobRepo.GetSingle(ctx, entity.Id{ID: id})
+	if h.HandleDbError(ctx, err, "Error getting print job") {
+		return false
+	}
+
+	return h.checkBranchAccess(ctx, job.BranchID)
+}
+
+// checkAgentBranch writes a not found response when a printer agent may not
+// print for branchID. API keys reach only the branches they are bound to,
+// branch staff only their own.
+func (h *Handler) checkAgentBranch(ctx *gin.Context, branchID string) bool {
+	if ctx.GetHeader("api_key_id") == "" {
+		return h.checkBranchAccess(ctx, branchID)
+	}
+
+	if !slices.Contains(ctx.GetStringSlice("api_key_branches"), branchID) {
+		h.notFound(ctx)
+		return false
+	}
+
+	return true
+}
+
+func validPrintKind(kind string) bool {
+	return kind == "customer" || kind == "kitchen"
+}
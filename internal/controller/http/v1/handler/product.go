@@ -3,17 +3,17 @@ package handler
 import (
 	"log"
 	"strconv"
+	"strings"
 
 	"github.com/Akrom0181/Food-Delivery/config"
 	"github.com/Akrom0181/Food-Delivery/internal/entity"
-	"github.com/Akrom0181/Food-Delivery/pkg/firebase"
 	"github.com/gin-gonic/gin"
 )
 
 // CreateProduct godoc
 // @Router /product [post]
 // @Summary Create a new product
-// @Description Create a new product
+// @Description Create a new product, it is in the restaurant of its category.
 // @Security BearerAuth
 // @Tags product
 // @Accept  json
@@ -27,11 +27,15 @@ func (h *Handler) CreateProduct(ctx *gin.Context) {
 	)
 
 	err := ctx.ShouldBindJSON(&body)
-	if err != nil {
+	if err != nil || !validDietary(body) {
 		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
 		return
 	}
 
+	if !h.checkRestaurantRows(ctx, "category", body.CategoryId) {
+		return
+	}
+
 	product, err := h.UseCase.ProductRepo.Create(ctx, body)
 	if h.HandleDbError(ctx, err, "Error creating product") {
 		return
@@ -49,6 +53,7 @@ func (h *Handler) CreateProduct(ctx *gin.Context) {
 // @Accept  json
 // @Produce  json
 // @Param id path string true "Product ID"
+// @Param lang query string false "locale: uz, ru or en, overrides Accept-Language"
 // @Success 200 {object} entity.Product
 // @Failure 400 {object} entity.ErrorResponse
 func (h *Handler) GetProduct(ctx *gin.Context) {
@@ -57,6 +62,7 @@ func (h *Handler) GetProduct(ctx *gin.Context) {
 	)
 
 	req.ID = ctx.Param("id")
+	req.Locales = h.locales(ctx)
 
 	product, err := h.UseCase.ProductRepo.GetSingle(ctx, req)
 	if h.HandleDbError(ctx, err, "Error getting product") {
@@ -77,6 +83,12 @@ func (h *Handler) GetProduct(ctx *gin.Context) {
 // @Param page query number true "page"
 // @Param limit query number true "limit"
 // @Param search query string false "search"
+// @Param category_id query string false "category id"
+// @Param restaurant_id query string false "Restaurant ID, the default restaurant when empty"
+// @Param exclude_allergens query string false "comma separated allergens to leave out, the profile ones by default"
+// @Param dietary_tags query string false "comma separated tags the products must all have"
+// @Param max_spicy_level query integer false "0 to 3"
+// @Param lang query string false "locale: uz, ru or en, overrides Accept-Language"
 // @Success 200 {object} entity.ProductList
 // @Failure 400 {object} entity.ErrorResponse
 func (h *Handler) GetProducts(ctx *gin.Context) {
@@ -90,6 +102,7 @@ func (h *Handler) GetProducts(ctx *gin.Context) {
 
 	req.Page, _ = strconv.Atoi(page)
 	req.Limit, _ = strconv.Atoi(limit)
+	req.Locales = h.locales(ctx)
 	req.Filters = append(req.Filters,
 		entity.Filter{
 			Column: "name",
@@ -98,7 +111,56 @@ func (h *Handler) GetProducts(ctx *gin.Context) {
 		},
 	)
 
+	restaurantID, ok := h.restaurantScope(ctx)
+	if !ok {
+		return
+	}
+
+	req.Filters = append(req.Filters, entity.Filter{
+		Column: "restaurant_id",
+		Type:   "eq",
+		Value:  restaurantID,
+	})
+
+	if categoryID := ctx.Query("category_id"); categoryID != "" {
+		req.Filters = append(req.Filters, entity.Filter{
+			Column: "category_id",
+			Type:   "eq",
+			Value:  categoryID,
+		})
+	}
+
+	dietary, ok := h.dietaryFilter(ctx)
+	if !ok {
+		return
+	}
+
+	if len(dietary.ExcludeAllergens) > 0 {
+		req.Filters = append(req.Filters, entity.Filter{
+			Column: "allergens",
+			Type:   "excludes",
+			Value:  strings.Join(dietary.ExcludeAllergens, ","),
+		})
+	}
+	if len(dietary.DietaryTags) > 0 {
+		req.Filters = append(req.Filters, entity.Filter{
+			Column: "dietary_tags",
+			Type:   "contains",
+			Value:  strings.Join(dietary.DietaryTags, ","),
+		})
+	}
+	if dietary.MaxSpicyLevel != nil {
+		req.Filters = append(req.Filters, entity.Filter{
+			Column: "spicy_level",
+			Type:   "lte",
+			Value:  strconv.Itoa(*dietary.MaxSpicyLevel),
+		})
+	}
+
 	req.OrderBy = append(req.OrderBy, entity.OrderBy{
+		Column: "sort_order",
+		Order:  "asc",
+	}, entity.OrderBy{
 		Column: "created_at",
 		Order:  "desc",
 	})
@@ -128,12 +190,16 @@ func (h *Handler) UpdateProduct(ctx *gin.Context) {
 	)
 
 	err := ctx.ShouldBindJSON(&body)
-	if err != nil {
+	if err != nil || !validDietary(body) {
 		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
 		return
 	}
 
-	if ctx.GetHeader("user_type") != "admin" {
+	if ctx.GetHeader("user_type") != "admin" && !isRestaurantAdmin(ctx) {
+		return
+	}
+
+	if !h.checkRestaurantRows(ctx, "product", body.Id) {
 		return
 	}
 
@@ -167,6 +233,10 @@ func (h *Handler) DeleteProduct(ctx *gin.Context) {
 		req.ID = ctx.GetHeader("sub")
 	}
 
+	if !h.checkRestaurantRows(ctx, "product", req.ID) {
+		return
+	}
+
 	err := h.UseCase.ProductRepo.Delete(ctx, req)
 	if h.HandleDbError(ctx, err, "Error deleting product") {
 		return
@@ -187,8 +257,8 @@ func (h *Handler) DeleteProduct(ctx *gin.Context) {
 // @Accept multipart/form-data
 // @Produce json
 // @Param id path string true "Product ID"
-// @Param file formData []file true "File to upload"
-// @Success 200 {object} entity.MultipleFileUploadResponse "Success Request"
+// @Param file formData file true "Product image"
+// @Success 200 {object} entity.ImageVariants "Success Request"
 // @Failure 400 {object} entity.ErrorResponse "Bad Request"
 // @Failure 500 {object} entity.ErrorResponse "Server error"
 func (h *Handler) UploadProductPic(ctx *gin.Context) {
@@ -205,7 +275,10 @@ func (h *Handler) UploadProductPic(ctx *gin.Context) {
 		return
 	}
 
-	if ctx.GetHeader("user_type") != "admin" {
+	if ctx.GetHeader("user_type") != "admin" && !isRestaurantAdmin(ctx) {
+		return
+	}
+	if !h.checkRestaurantRows(ctx, "product", req.ID) {
 		return
 	}
 	log.Println("product_id", req.ID)
@@ -214,23 +287,58 @@ func (h *Handler) UploadProductPic(ctx *gin.Context) {
 		return
 	}
 
-	resp, err := firebase.UploadFiles(form)
-	if h.HandleDbError(ctx, err, "Error uploading files") {
+	images, ok := h.uploadImage(ctx, form)
+	if !ok {
 		return
 	}
-	log.Print("resp ------------------------", resp.Url[0].Url)
 	_, err = h.UseCase.ProductRepo.Update(ctx, entity.Product{
 		Id:          product.Id,
 		CategoryId:  product.CategoryId,
 		Name:        product.Name,
 		Description: product.Description,
 		Price:       product.Price,
-		ImageUrl:    resp.Url[0].Url,
+		Images:      images,
 	})
 
 	if h.HandleDbError(ctx, err, "Error updating product") {
 		return
 	}
 
-	ctx.JSON(200, resp)
+	ctx.JSON(200, images)
+}
+
+// SortProducts godoc
+// @Router /product/sort [put]
+// @Summary Set the display order of products
+// @Description Set the display order of products within their categories
+// @Security BearerAuth
+// @Tags product
+// @Accept  json
+// @Produce  json
+// @Param sort body entity.SortRequest true "Positions"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) SortProducts(ctx *gin.Context) {
+	var (
+		body entity.SortRequest
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil || len(body.Items) == 0 {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	if !h.checkRestaurantRows(ctx, "product", sortIDs(body)...) {
+		return
+	}
+
+	err = h.UseCase.ProductRepo.Sort(ctx, body)
+	if h.HandleDbError(ctx, err, "Error sorting products") {
+		return
+	}
+
+	ctx.JSON(200, entity.SuccessResponse{
+		Message: "Products sorted successfully",
+	})
 }
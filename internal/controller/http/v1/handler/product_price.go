@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// GetPriceHistory godoc
+// @Router /product/{id}/prices [get]
+// @Summary Get the price history of a product
+// @Description Scheduled, current and past prices, latest effective first. Order lines reference them by price_version_id.
+// @Security BearerAuth
+// @Tags product
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Product ID"
+// @Success 200 {object} entity.PriceHistory
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) GetPriceHistory(ctx *gin.Context) {
+	// customers may read products but not their pricing history
+	if !isAdmin(ctx) && !isRestaurantAdmin(ctx) {
+		h.ReturnError(ctx, config.ErrorForbidden, "Permission denied", 403)
+		return
+	}
+
+	if !h.checkRestaurantRows(ctx, "product", ctx.Param("id")) {
+		return
+	}
+
+	history, err := h.UseCase.ProductRepo.GetPriceHistory(ctx, entity.Id{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting price history") {
+		return
+	}
+
+	ctx.JSON(200, history)
+}
+
+// SchedulePrice godoc
+// @Router /product/{id}/prices [post]
+// @Summary Schedule a price change
+// @Description The price becomes the price of the product at effective_at (RFC 3339), which has to be in the future.
+// @Description To change the price right away update the product.
+// @Security BearerAuth
+// @Tags product
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Product ID"
+// @Param price body entity.PriceVersion true "price and effective_at"
+// @Success 201 {object} entity.PriceVersion
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) SchedulePrice(ctx *gin.Context) {
+	var (
+		body entity.PriceVersion
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil || body.Price < 0 {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	effectiveAt, err := time.Parse(time.RFC3339, body.EffectiveAt)
+	if err != nil || !effectiveAt.After(time.Now()) {
+		h.ReturnError(ctx, config.ErrorBadRequest, "effective_at has to be a future RFC 3339 time", 400)
+		return
+	}
+
+	// timestamps are stored in UTC
+	body.EffectiveAt = effectiveAt.UTC().Format(time.RFC3339)
+	body.ProductID = ctx.Param("id")
+	body.CreatedBy = ctx.GetHeader("sub")
+
+	if !h.checkRestaurantRows(ctx, "product", body.ProductID) {
+		return
+	}
+
+	price, err := h.UseCase.ProductRepo.SchedulePrice(ctx, body)
+	if h.HandleDbError(ctx, err, "Error scheduling price") {
+		return
+	}
+
+	ctx.JSON(201, price)
+}
+
+// CancelScheduledPrice godoc
+// @Router /product/{id}/prices/{price_id} [delete]
+// @Summary Cancel a scheduled price change
+// @Description Only prices that are not applied yet can be cancelled.
+// @Security BearerAuth
+// @Tags product
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Product ID"
+// @Param price_id path string true "Price version ID"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) CancelScheduledPrice(ctx *gin.Context) {
+	if !h.checkRestaurantRows(ctx, "product", ctx.Param("id")) {
+		return
+	}
+
+	err := h.UseCase.ProductRepo.CancelScheduledPrice(ctx, entity.PriceVersion{
+		ID:        ctx.Param("price_id"),
+		ProductID: ctx.Param("id"),
+	})
+	if h.HandleDbError(ctx, err, "Error cancelling scheduled price") {
+		return
+	}
+
+	ctx.JSON(200, entity.SuccessResponse{
+		Message: "Scheduled price cancelled successfully",
+	})
+}
@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/Akrom0181/Food-Delivery/pkg/money"
+	"github.com/Akrom0181/Food-Delivery/pkg/translit"
+	"github.com/gin-gonic/gin"
+)
+
+// SearchProducts godoc
+// @Router /product/search [get]
+// @Summary Search products
+// @Description Full text search over product name, category name and description.
+// @Description Cyrillic and Latin spellings match each other and small typos in the name are tolerated.
+// @Description Matched words are wrapped in <mark> in the highlight. Without a restaurant every restaurant is searched.
+// @Security BearerAuth
+// @Tags product
+// @Accept  json
+// @Produce  json
+// @Param q query string false "search text"
+// @Param restaurant_id query string false "Restaurant ID"
+// @Param lang query string false "locale: uz, ru or en, overrides Accept-Language"
+// @Param category_id query string false "category id"
+// @Param branch_id query string false "only products available at this branch"
+// @Param price_min query number false "minimum price"
+// @Param price_max query number false "maximum price"
+// @Param exclude_allergens query string false "comma separated allergens to leave out, the profile ones by default"
+// @Param dietary_tags query string false "comma separated tags the products must all have"
+// @Param max_spicy_level query integer false "0 to 3"
+// @Param page query number false "page"
+// @Param limit query number false "limit"
+// @Success 200 {object} entity.ProductSearchResult
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) SearchProducts(ctx *gin.Context) {
+	var (
+		req entity.ProductSearchRequest
+		err error
+	)
+
+	req.Terms = translit.Terms(ctx.Query("q"))
+	req.CategoryID = ctx.Query("category_id")
+	req.RestaurantID = ctx.Query("restaurant_id")
+	req.BranchID = ctx.Query("branch_id")
+	req.Page, _ = strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	req.Limit, _ = strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	req.Locales = h.locales(ctx)
+
+	req.PriceMin, err = parsePrice(ctx.Query("price_min"))
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid price_min", 400)
+		return
+	}
+
+	req.PriceMax, err = parsePrice(ctx.Query("price_max"))
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid price_max", 400)
+		return
+	}
+
+	var ok bool
+	req.Dietary, ok = h.dietaryFilter(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := h.UseCase.ProductRepo.Search(ctx, req)
+	if h.HandleDbError(ctx, err, "Error searching products") {
+		return
+	}
+
+	for i := range result.Items {
+		item := &result.Items[i]
+		item.Highlight.Name = translit.Highlight(item.Name, req.Terms, "<mark>", "</mark>")
+		item.Highlight.Description = translit.Highlight(item.Description, req.Terms, "<mark>", "</mark>")
+	}
+
+	ctx.JSON(200, result)
+}
+
+// SuggestProducts godoc
+// @Router /product/suggest [get]
+// @Summary Autocomplete product and category names
+// @Description Autocomplete product and category names
+// @Security BearerAuth
+// @Tags product
+// @Accept  json
+// @Produce  json
+// @Param q query string true "typed text"
+// @Param restaurant_id query string false "Restaurant ID, the default restaurant when empty"
+// @Param limit query number false "limit"
+// @Success 200 {object} entity.SuggestionList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) SuggestProducts(ctx *gin.Context) {
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "5"))
+	if limit <= 0 || limit > 20 {
+		limit = 5
+	}
+
+	restaurantID, ok := h.restaurantScope(ctx)
+	if !ok {
+		return
+	}
+
+	suggestions, err := h.UseCase.ProductRepo.Suggest(ctx, restaurantID, translit.Terms(ctx.Query("q")), limit)
+	if h.HandleDbError(ctx, err, "Error getting suggestions") {
+		return
+	}
+
+	ctx.JSON(200, suggestions)
+}
+
+// SetProductAvailability godoc
+// @Router /product/availability [put]
+// @Summary Set whether a product is available at a branch
+// @Description Products are available at every branch until marked otherwise.
+// @Security BearerAuth
+// @Tags product
+// @Accept  json
+// @Produce  json
+// @Param availability body entity.ProductAvailability true "Availability"
+// @Success 200 {object} entity.ProductAvailability
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) SetProductAvailability(ctx *gin.Context) {
+	var (
+		body entity.ProductAvailability
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil || body.ProductID == "" || body.BranchID == "" {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	if !h.checkBranchAccess(ctx, body.BranchID) ||
+		!h.checkRestaurantRows(ctx, "branch", body.BranchID) || !h.checkRestaurantRows(ctx, "product", body.ProductID) {
+		return
+	}
+
+	availability, err := h.UseCase.ProductRepo.SetAvailability(ctx, body)
+	if h.HandleDbError(ctx, err, "Error setting product availability") {
+		return
+	}
+
+	ctx.JSON(200, availability)
+}
+
+func parsePrice(value string) (*money.Amount, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	price, err := money.Parse(value)
+	if err != nil || price < 0 {
+		return nil, money.ErrInvalid
+	}
+
+	return &price, nil
+}
@@ -0,0 +1,222 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/Akrom0181/Food-Delivery/internal/orderpdf"
+	"github.com/Akrom0181/Food-Delivery/pkg/etc"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4"
+)
+
+// GetReceipt godoc
+// @Router /order/{id}/receipt [get]
+// @Summary Get the fiscal receipt of an order
+// @Description A receipt is made when the order is delivered. It is pending until the fiscal provider
+// @Description registers it, then it has the fiscal sign and the URL for the QR code.
+// @Security BearerAuth
+// @Tags order
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Order ID"
+// @Success 200 {object} entity.Receipt
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) GetReceipt(ctx *gin.Context) {
+	order, err := h.UseCase.OrderRepo.GetSingle(ctx, entity.Id{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting order") {
+		return
+	}
+
+	if !h.checkOrderAccess(ctx, order) {
+		return
+	}
+
+	receipt, err := h.UseCase.ReceiptRepo.GetByOrder(ctx, entity.Id{ID: order.ID})
+	if h.HandleDbError(ctx, err, "Error getting receipt") {
+		return
+	}
+
+	ctx.JSON(200, receipt)
+}
+
+// IssueReceipt godoc
+// @Router /order/{id}/receipt [post]
+// @Summary Issue the fiscal receipt of a delivered order again
+// @Description Makes the receipt when the order has none and sends a failed receipt to the fiscal provider again.
+// @Security BearerAuth
+// @Tags order
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Order ID"
+// @Success 200 {object} entity.Receipt
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) IssueReceipt(ctx *gin.Context) {
+	if !isAdmin(ctx) {
+		h.ReturnError(ctx, config.ErrorForbidden, "Permission denied", 403)
+		return
+	}
+
+	order, err := h.UseCase.OrderRepo.GetSingle(ctx, entity.Id{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting order") {
+		return
+	}
+
+	if order.Status != "delivered" {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Only delivered orders get a receipt", 400)
+		return
+	}
+
+	receipt, _, err := h.UseCase.ReceiptRepo.Create(ctx, entity.Id{ID: order.ID})
+	if h.HandleDbError(ctx, err, "Error creating receipt") {
+		return
+	}
+
+	if receipt.Status == "failed" {
+		err = h.UseCase.ReceiptRepo.Retry(ctx, entity.Id{ID: order.ID})
+		if h.HandleDbError(ctx, err, "Error retrying receipt") {
+			return
+		}
+
+		receipt, err = h.UseCase.ReceiptRepo.GetByOrder(ctx, entity.Id{ID: order.ID})
+		if h.HandleDbError(ctx, err, "Error getting receipt") {
+			return
+		}
+	}
+
+	ctx.JSON(200, receipt)
+}
+
+// GetReceiptPDF godoc
+// @Router /order/{id}/receipt.pdf [get]
+// @Summary Download the receipt of an order as PDF
+// @Description The receipt lists the items with their bundle components, discounts and VAT. Registered
+// @Description receipts also have the fiscal sign and QR code. The language follows lang or Accept-Language.
+// @Security BearerAuth
+// @Tags order
+// @Produce application/pdf
+// @Param id path string true "Order ID"
+// @Param lang query string false "Language" Enums(uz, ru, en)
+// @Success 200 {file} file
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) GetReceiptPDF(ctx *gin.Context) {
+	order, err := h.UseCase.OrderRepo.GetSingle(ctx, entity.Id{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting order") {
+		return
+	}
+
+	if !h.checkOrderAccess(ctx, order) {
+		return
+	}
+
+	doc, err := h.receiptDocument(ctx, order, h.locales(ctx))
+	if h.HandleDbError(ctx, err, "Error getting receipt") {
+		return
+	}
+
+	var buf bytes.Buffer
+	if err = h.pdf.Render(&buf, doc); err != nil {
+		h.ReturnError(ctx, config.ErrorInternalServer, "Error rendering receipt", 500)
+		h.Logger.Error(err, "Error rendering receipt")
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", orderpdf.Filename(doc)))
+	ctx.Data(200, "application/pdf", buf.Bytes())
+}
+
+// receiptDocument collects what the receipt of an order shows, with product
+// names in the first of locales.
+func (h *Handler) receiptDocument(ctx context.Context, order entity.Order, locales []string) (orderpdf.Document, error) {
+	doc := orderpdf.Document{Order: order, Locale: config.DefaultLocale}
+	if len(locales) > 0 {
+		doc.Locale = locales[0]
+	}
+
+	if order.BranchId != "" {
+		branch, err := h.UseCase.BranchRepo.GetSingle(ctx, entity.Id{ID: order.BranchId})
+		if err != nil {
+			return orderpdf.Document{}, err
+		}
+		doc.Branch = branch
+	}
+
+	names, err := h.productNames(ctx, order.OrderItems, locales)
+	if err != nil {
+		return orderpdf.Document{}, err
+	}
+	doc.Names = names
+
+	receipt, err := h.UseCase.ReceiptRepo.GetByOrder(ctx, entity.Id{ID: order.ID})
+	if err == nil {
+		doc.Receipt = &receipt
+	} else if err != pgx.ErrNoRows {
+		return orderpdf.Document{}, err
+	}
+
+	return doc, nil
+}
+
+// productNames returns the names of the products of items in the first of
+// locales by product id. Deleted products are left out.
+func (h *Handler) productNames(ctx context.Context, items []entity.OrderItems, locales []string) (map[string]string, error) {
+	names := map[string]string{}
+	for _, item := range items {
+		if _, ok := names[item.ProductId]; ok {
+			continue
+		}
+
+		product, err := h.UseCase.ProductRepo.GetSingle(ctx, entity.Id{ID: item.ProductId, Locales: locales})
+		if err == pgx.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		names[item.ProductId] = product.Name
+	}
+
+	return names, nil
+}
+
+// emailReceipt sends the PDF receipt of a delivered order to its customer.
+func (h *Handler) emailReceipt(order entity.Order) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	user, err := h.UseCase.UserRepo.GetSingle(ctx, entity.UserSingleRequest{ID: order.UserID})
+	if err != nil {
+		h.Logger.Error(err, "Error getting user for receipt email")
+		return
+	}
+	if user.Email == "" {
+		return
+	}
+
+	doc, err := h.receiptDocument(ctx, order, nil)
+	if err != nil {
+		h.Logger.Error(err, "Error getting receipt for email")
+		return
+	}
+
+	var buf bytes.Buffer
+	if err = h.pdf.Render(&buf, doc); err != nil {
+		h.Logger.Error(err, "Error rendering receipt for email")
+		return
+	}
+
+	subject, body := orderpdf.Email(doc)
+	err = etc.SendEmailAttachment(h.Config.Gmail.Host, h.Config.Gmail.Port, h.Config.Gmail.Email, h.Config.Gmail.EmailPass,
+		user.Email, subject, body, orderpdf.Filename(doc), "application/pdf", buf.Bytes())
+	if err != nil {
+		h.Logger.Error(err, "Error sending receipt email")
+	}
+}
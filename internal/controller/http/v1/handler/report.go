@@ -63,6 +63,10 @@ func (h *Handler) GetReport(ctx *gin.Context) {
 		return
 	}
 
+	if !h.checkOwner(ctx, report.UserID) {
+		return
+	}
+
 	ctx.JSON(200, report)
 }
 
@@ -108,6 +112,14 @@ func (h *Handler) GetReports(ctx *gin.Context) {
 		},
 	)
 
+	if scope := ownerScope(ctx); scope != "" {
+		req.Filters = append(req.Filters, entity.Filter{
+			Column: "user_id",
+			Type:   "eq",
+			Value:  scope,
+		})
+	}
+
 	req.OrderBy = append(req.OrderBy, entity.OrderBy{
 		Column: "created_at",
 		Order:  "desc",
@@ -143,8 +155,13 @@ func (h *Handler) UpdateReport(ctx *gin.Context) {
 		return
 	}
 
-	if ctx.GetHeader("user_type") == "user" {
-		body.ID = ctx.GetHeader("sub")
+	current, err := h.UseCase.ReportRepo.GetSingle(ctx, entity.Id{ID: body.ID})
+	if h.HandleDbError(ctx, err, "Error getting report") {
+		return
+	}
+
+	if !h.checkOwner(ctx, current.UserID) {
+		return
 	}
 
 	report, err := h.UseCase.ReportRepo.Update(ctx, body)
@@ -173,11 +190,16 @@ func (h *Handler) DeleteReport(ctx *gin.Context) {
 
 	req.ID = ctx.Param("id")
 
-	if ctx.GetHeader("user_type") == "user" {
-		req.ID = ctx.GetHeader("sub")
+	report, err := h.UseCase.ReportRepo.GetSingle(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting report") {
+		return
+	}
+
+	if !h.checkOwner(ctx, report.UserID) {
+		return
 	}
 
-	err := h.UseCase.ReportRepo.Delete(ctx, req)
+	err = h.UseCase.ReportRepo.Delete(ctx, req)
 	if h.HandleDbError(ctx, err, "Error deleting user") {
 		return
 	}
@@ -0,0 +1,304 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateRestaurant godoc
+// @Router /restaurant [post]
+// @Summary Create a new restaurant
+// @Description Admins only. Its branches, categories and banners are created with its restaurant_id.
+// @Security BearerAuth
+// @Tags restaurant
+// @Accept  json
+// @Produce  json
+// @Param restaurant body entity.Restaurant true "Restaurant"
+// @Success 201 {object} entity.Restaurant
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) CreateRestaurant(ctx *gin.Context) {
+	var (
+		body entity.Restaurant
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil || body.Name == "" || !validSlug(body.Slug) {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	restaurant, err := h.UseCase.RestaurantRepo.Create(ctx, body)
+	if h.HandleDbError(ctx, err, "Error creating restaurant") {
+		return
+	}
+
+	ctx.JSON(201, restaurant)
+}
+
+// GetRestaurant godoc
+// @Router /restaurant/{id} [get]
+// @Summary Get a restaurant by ID
+// @Description Get a restaurant by ID
+// @Tags restaurant
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Restaurant ID"
+// @Success 200 {object} entity.Restaurant
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) GetRestaurant(ctx *gin.Context) {
+	restaurant, err := h.UseCase.RestaurantRepo.GetSingle(ctx, entity.Id{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting restaurant") {
+		return
+	}
+
+	ctx.JSON(200, restaurant)
+}
+
+// GetRestaurants godoc
+// @Router /restaurant/list [get]
+// @Summary Get a list of restaurants
+// @Description By name. With latitude and longitude only the active restaurants delivering there are listed,
+// @Description nearest first, with their nearest branch and its distance in meters. Only admins see inactive ones.
+// @Tags restaurant
+// @Accept  json
+// @Produce  json
+// @Param page query number true "page"
+// @Param limit query number true "limit"
+// @Param search query string false "search"
+// @Param latitude query number false "Delivery latitude"
+// @Param longitude query number false "Delivery longitude"
+// @Success 200 {object} entity.RestaurantList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetRestaurants(ctx *gin.Context) {
+	var (
+		req entity.RestaurantRequest
+	)
+
+	req.Page, _ = strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	req.Limit, _ = strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	req.Search = ctx.Query("search")
+	req.ActiveOnly = !isAdmin(ctx)
+
+	latitude, longitude := ctx.Query("latitude"), ctx.Query("longitude")
+	if latitude != "" || longitude != "" {
+		lat, err := strconv.ParseFloat(latitude, 64)
+		if err != nil || lat < -90 || lat > 90 {
+			h.ReturnError(ctx, config.ErrorBadRequest, "Invalid latitude", 400)
+			return
+		}
+
+		lon, err := strconv.ParseFloat(longitude, 64)
+		if err != nil || lon < -180 || lon > 180 {
+			h.ReturnError(ctx, config.ErrorBadRequest, "Invalid longitude", 400)
+			return
+		}
+
+		req.Latitude, req.Longitude = &lat, &lon
+	}
+
+	restaurants, err := h.UseCase.RestaurantRepo.GetList(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting restaurants") {
+		return
+	}
+
+	ctx.JSON(200, restaurants)
+}
+
+// UpdateRestaurant godoc
+// @Router /restaurant [put]
+// @Summary Update a restaurant
+// @Description Restaurant admins may update their own restaurant but not whether it is active.
+// @Security BearerAuth
+// @Tags restaurant
+// @Accept  json
+// @Produce  json
+// @Param restaurant body entity.Restaurant true "Restaurant"
+// @Success 200 {object} entity.Restaurant
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) UpdateRestaurant(ctx *gin.Context) {
+	var (
+		body entity.Restaurant
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil || body.Name == "" || !validSlug(body.Slug) {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	if isRestaurantAdmin(ctx) {
+		restaurantID, ok := h.callerRestaurant(ctx)
+		if !ok {
+			return
+		}
+		if body.ID != restaurantID {
+			h.notFound(ctx)
+			return
+		}
+		body.IsActive = nil
+	}
+
+	restaurant, err := h.UseCase.RestaurantRepo.Update(ctx, body)
+	if h.HandleDbError(ctx, err, "Error updating restaurant") {
+		return
+	}
+
+	ctx.JSON(200, restaurant)
+}
+
+// DeleteRestaurant godoc
+// @Router /restaurant/{id} [delete]
+// @Summary Delete a restaurant
+// @Description Admins only. Only a restaurant without branches, categories, products and banners can be deleted,
+// @Description its admins become plain users. The default restaurant can not be deleted.
+// @Security BearerAuth
+// @Tags restaurant
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Restaurant ID"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) DeleteRestaurant(ctx *gin.Context) {
+	if ctx.Param("id") == config.DefaultRestaurantID {
+		h.ReturnError(ctx, config.ErrorBadRequest, "The default restaurant can not be deleted", 400)
+		return
+	}
+
+	err := h.UseCase.RestaurantRepo.Delete(ctx, entity.Id{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error deleting restaurant") {
+		return
+	}
+
+	ctx.JSON(200, entity.SuccessResponse{
+		Message: "Restaurant deleted successfully",
+	})
+}
+
+// SaveRestaurantAdmin godoc
+// @Router /restaurant-admin [put]
+// @Summary Make a user the admin of a restaurant
+// @Description Superadmin only. The user manages the branches, catalog and banners of the restaurant, replacing
+// @Description the restaurant they had. Only plain users can be made restaurant admins. The user is logged out so
+// @Description the role applies from their next login.
+// @Security BearerAuth
+// @Tags restaurant
+// @Accept  json
+// @Produce  json
+// @Param admin body entity.RestaurantAdmin true "Restaurant admin"
+// @Success 200 {object} entity.RestaurantAdmin
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) SaveRestaurantAdmin(ctx *gin.Context) {
+	var (
+		body entity.RestaurantAdmin
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil || body.UserID == "" || body.RestaurantID == "" {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	admin, err := h.UseCase.RestaurantRepo.SaveAdmin(ctx, body)
+	if h.HandleDbError(ctx, err, "Error saving restaurant admin") {
+		return
+	}
+
+	ctx.JSON(200, admin)
+}
+
+// GetRestaurantAdmin godoc
+// @Router /restaurant-admin/{user_id} [get]
+// @Summary Get the restaurant of a restaurant admin
+// @Description Superadmin only.
+// @Security BearerAuth
+// @Tags restaurant
+// @Accept  json
+// @Produce  json
+// @Param user_id path string true "User ID"
+// @Success 200 {object} entity.RestaurantAdmin
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) GetRestaurantAdmin(ctx *gin.Context) {
+	admin, err := h.UseCase.RestaurantRepo.GetAdmin(ctx, entity.Id{ID: ctx.Param("user_id")})
+	if h.HandleDbError(ctx, err, "Error getting restaurant admin") {
+		return
+	}
+
+	ctx.JSON(200, admin)
+}
+
+// GetRestaurantAdmins godoc
+// @Router /restaurant-admin/list [get]
+// @Summary Get a list of restaurant admins
+// @Description Superadmin only.
+// @Security BearerAuth
+// @Tags restaurant
+// @Accept  json
+// @Produce  json
+// @Param page query number true "page"
+// @Param limit query number true "limit"
+// @Param restaurant_id query string false "Only the admins of this restaurant"
+// @Success 200 {object} entity.RestaurantAdminList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetRestaurantAdmins(ctx *gin.Context) {
+	var (
+		req entity.RestaurantAdminRequest
+	)
+
+	req.Page, _ = strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	req.Limit, _ = strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	req.RestaurantID = ctx.Query("restaurant_id")
+
+	admins, err := h.UseCase.RestaurantRepo.GetAdmins(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting restaurant admins") {
+		return
+	}
+
+	ctx.JSON(200, admins)
+}
+
+// DeleteRestaurantAdmin godoc
+// @Router /restaurant-admin/{user_id} [delete]
+// @Summary Remove a restaurant admin
+// @Description Superadmin only. The user becomes a plain user again and is logged out.
+// @Security BearerAuth
+// @Tags restaurant
+// @Accept  json
+// @Produce  json
+// @Param user_id path string true "User ID"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) DeleteRestaurantAdmin(ctx *gin.Context) {
+	err := h.UseCase.RestaurantRepo.DeleteAdmin(ctx, entity.Id{ID: ctx.Param("user_id")})
+	if h.HandleDbError(ctx, err, "Error deleting restaurant admin") {
+		return
+	}
+
+	ctx.JSON(200, entity.SuccessResponse{
+		Message: "Restaurant admin deleted successfully",
+	})
+}
+
+// validSlug allows lowercase latin letters, digits and inner hyphens.
+func validSlug(slug string) bool {
+	if slug == "" || strings.HasPrefix(slug, "-") || strings.HasSuffix(slug, "-") {
+		return false
+	}
+
+	for _, r := range slug {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,238 @@
+package handler
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// SaveBranchHours godoc
+// @Router /branch/hours [put]
+// @Summary Set the opening hours of a branch
+// @Description Replaces the week of the branch, times are local HH:MM and closes_at may be 24:00. A branch without
+// @Description hours is open around the clock, one with hours is closed on the weekdays it has none for. Scheduled
+// @Description orders are only taken for slots within the opening hours. Branch managers may set the hours of their
+// @Description own branches.
+// @Security BearerAuth
+// @Tags branch
+// @Accept  json
+// @Produce  json
+// @Param hours body entity.BranchHoursList true "Opening hours"
+// @Success 200 {object} entity.BranchHoursList
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) SaveBranchHours(ctx *gin.Context) {
+	var (
+		body entity.BranchHoursList
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil || body.BranchID == "" {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	weekdays := make(map[int]bool)
+	for _, hours := range body.Hours {
+		opens, okOpens := parseClock(hours.OpensAt)
+		closes, okCloses := parseClock(hours.ClosesAt)
+		if hours.Weekday < 0 || hours.Weekday > 6 || weekdays[hours.Weekday] || !okOpens || !okCloses || opens >= closes {
+			h.ReturnError(ctx, config.ErrorBadRequest, "Invalid hours", 400)
+			return
+		}
+		weekdays[hours.Weekday] = true
+	}
+
+	if !h.checkBranchAccess(ctx, body.BranchID) || !h.checkRestaurantRows(ctx, "branch", body.BranchID) {
+		return
+	}
+
+	hours, err := h.UseCase.BranchRepo.SaveHours(ctx, body)
+	if h.HandleDbError(ctx, err, "Error saving branch hours") {
+		return
+	}
+
+	ctx.JSON(200, hours)
+}
+
+// GetBranchHours godoc
+// @Router /branch/{id}/hours [get]
+// @Summary Get the opening hours of a branch
+// @Description By weekday, 0 is Sunday. Empty when the branch is open around the clock.
+// @Security BearerAuth
+// @Tags branch
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Branch ID"
+// @Success 200 {object} entity.BranchHoursList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetBranchHours(ctx *gin.Context) {
+	hours, err := h.UseCase.BranchRepo.GetHours(ctx, entity.Id{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting branch hours") {
+		return
+	}
+
+	ctx.JSON(200, hours)
+}
+
+// GetDeliverySlots godoc
+// @Router /branch/{id}/slots [get]
+// @Summary Get the delivery slots of a branch
+// @Description The 30 minute slots of a day a scheduled order can still be placed for at the branch, within its
+// @Description opening hours, from an hour up to a week ahead. Full slots are left out, remaining is set when the
+// @Description branch limits the orders per slot. Pass a slot's starts_at as scheduled_at when creating the order.
+// @Tags branch
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Branch ID"
+// @Param date query string false "Local date, YYYY-MM-DD, today when empty"
+// @Success 200 {object} entity.DeliverySlotList
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) GetDeliverySlots(ctx *gin.Context) {
+	day := time.Now().In(config.LocalTime)
+	if date := ctx.Query("date"); date != "" {
+		var err error
+		day, err = time.ParseInLocation(time.DateOnly, date, config.LocalTime)
+		if err != nil {
+			h.ReturnError(ctx, config.ErrorBadRequest, "Invalid date", 400)
+			return
+		}
+	}
+	day = startOfDay(day)
+
+	slots, err := h.branchSlots(ctx, ctx.Param("id"), day)
+	if h.HandleDbError(ctx, err, "Error getting delivery slots") {
+		return
+	}
+
+	ctx.JSON(200, entity.DeliverySlotList{
+		BranchID: ctx.Param("id"),
+		Date:     day.Format(time.DateOnly),
+		Items:    slots,
+	})
+}
+
+// scheduleBranch picks the nearest of branches that has the slot of the
+// scheduled order free, and stores scheduled_at in UTC. It writes the error
+// response when there is none. OrderRepo.Create checks the slot again under a
+// lock, as it may fill up in between.
+func (h *Handler) scheduleBranch(ctx *gin.Context, order *entity.Order, branches []entity.Branch) (entity.Branch, bool) {
+	scheduledAt, err := time.Parse(time.RFC3339, order.ScheduledAt)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid scheduled_at", 400)
+		return entity.Branch{}, false
+	}
+
+	local := scheduledAt.In(config.LocalTime)
+	for _, branch := range branches {
+		slots, err := h.branchSlots(ctx, branch.Id, startOfDay(local))
+		if h.HandleDbError(ctx, err, "Error getting delivery slots") {
+			return entity.Branch{}, false
+		}
+
+		if slices.ContainsFunc(slots, func(slot entity.DeliverySlot) bool {
+			return slot.StartsAt == local.Format(time.RFC3339)
+		}) {
+			order.ScheduledAt = scheduledAt.UTC().Format(time.RFC3339)
+			return branch, true
+		}
+	}
+
+	h.ReturnError(ctx, config.ErrorBadRequest, "The delivery slot is not available", 400)
+	return entity.Branch{}, false
+}
+
+// branchSlots lists the slots of a branch on day that can still be booked,
+// pgx.ErrNoRows when the branch does not exist.
+func (h *Handler) branchSlots(ctx context.Context, branchID string, day time.Time) ([]entity.DeliverySlot, error) {
+	load, err := h.UseCase.BranchCapacityRepo.GetLoad(ctx, entity.Id{ID: branchID})
+	if err != nil {
+		return nil, err
+	}
+
+	hours, err := h.UseCase.BranchRepo.GetHours(ctx, entity.Id{ID: branchID})
+	if err != nil {
+		return nil, err
+	}
+
+	booked, err := h.UseCase.OrderRepo.CountScheduled(ctx, branchID, day, day.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	return deliverySlots(day, hours.Hours, booked, load.Capacity.MaxOrdersPerSlot, time.Now()), nil
+}
+
+// deliverySlots lays the slots of day out over the opening hours of its
+// weekday and keeps those that are far enough ahead and not full. booked is
+// keyed by the Unix time of a slot, a zero limit is no limit.
+func deliverySlots(day time.Time, hours []entity.BranchHours, booked map[int64]int, limit int, now time.Time) []entity.DeliverySlot {
+	slots := []entity.DeliverySlot{}
+
+	opens, closes := 0, 24*60
+	if len(hours) > 0 {
+		i := slices.IndexFunc(hours, func(weekday entity.BranchHours) bool {
+			return weekday.Weekday == int(day.Weekday())
+		})
+		if i < 0 {
+			return slots
+		}
+
+		opens, _ = parseClock(hours[i].OpensAt)
+		closes, _ = parseClock(hours[i].ClosesAt)
+	}
+
+	slotMinutes := int(config.SlotLength / time.Minute)
+	for minute := opens; minute+slotMinutes <= closes; minute += slotMinutes {
+		start := time.Date(day.Year(), day.Month(), day.Day(), 0, minute, 0, 0, day.Location())
+		if start.Before(now.Add(config.ScheduleMinLead)) || start.After(now.Add(config.ScheduleMaxAhead)) {
+			continue
+		}
+
+		slot := entity.DeliverySlot{
+			StartsAt: start.Format(time.RFC3339),
+			EndsAt:   start.Add(config.SlotLength).Format(time.RFC3339),
+		}
+		if limit > 0 {
+			remaining := limit - booked[start.Unix()]
+			if remaining <= 0 {
+				continue
+			}
+			slot.Remaining = &remaining
+		}
+
+		slots = append(slots, slot)
+	}
+
+	return slots
+}
+
+// parseClock parses HH:MM into minutes since midnight, up to 24:00.
+func parseClock(clock string) (int, bool) {
+	hh, mm, ok := strings.Cut(clock, ":")
+	if !ok || len(hh) != 2 || len(mm) != 2 {
+		return 0, false
+	}
+
+	hour, err := strconv.Atoi(hh)
+	if err != nil {
+		return 0, false
+	}
+	minute, err := strconv.Atoi(mm)
+	if err != nil || hour < 0 || minute < 0 || minute > 59 || hour*60+minute > 24*60 {
+		return 0, false
+	}
+
+	return hour*60 + minute, true
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -31,6 +31,10 @@ func (h *Handler) GetSession(ctx *gin.Context) {
 		return
 	}
 
+	if !h.checkOwner(ctx, session.UserID) {
+		return
+	}
+
 	ctx.JSON(200, session)
 }
 
@@ -56,8 +60,8 @@ func (h *Handler) GetSessions(ctx *gin.Context) {
 	limit := ctx.DefaultQuery("limit", "10")
 	userId := ctx.DefaultQuery("user_id", "")
 
-	if ctx.GetHeader("user_type") == "user" {
-		userId = ctx.GetHeader("sub")
+	if scope := ownerScope(ctx); scope != "" {
+		userId = scope
 	}
 
 	req.Page, _ = strconv.Atoi(page)
@@ -105,6 +109,17 @@ func (h *Handler) UpdateSession(ctx *gin.Context) {
 		return
 	}
 
+	current, err := h.UseCase.SessionRepo.GetSingle(ctx, entity.Id{ID: body.ID})
+	if h.HandleDbError(ctx, err, "Error getting session") {
+		return
+	}
+
+	if !h.checkOwner(ctx, current.UserID) {
+		return
+	}
+
+	body.UserID = current.UserID
+
 	session, err := h.UseCase.SessionRepo.Update(ctx, body)
 	if h.HandleDbError(ctx, err, "Error updating session") {
 		return
@@ -131,7 +146,16 @@ func (h *Handler) DeleteSession(ctx *gin.Context) {
 
 	req.ID = ctx.Param("id")
 
-	err := h.UseCase.SessionRepo.Delete(ctx, req)
+	session, err := h.UseCase.SessionRepo.GetSingle(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting session") {
+		return
+	}
+
+	if !h.checkOwner(ctx, session.UserID) {
+		return
+	}
+
+	err = h.UseCase.SessionRepo.Delete(ctx, req)
 	if h.HandleDbError(ctx, err, "Error deleting session") {
 		return
 	}
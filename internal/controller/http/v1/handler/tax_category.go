@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateTaxCategory godoc
+// @Router /tax-category [post]
+// @Summary Create a tax category
+// @Description A VAT rate in percent, products point to it with tax_category_id. Prices include VAT.
+// @Security BearerAuth
+// @Tags tax-category
+// @Accept  json
+// @Produce  json
+// @Param category body entity.TaxCategory true "Tax category"
+// @Success 201 {object} entity.TaxCategory
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) CreateTaxCategory(ctx *gin.Context) {
+	var (
+		body entity.TaxCategory
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil || !validTaxCategory(body) {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	category, err := h.UseCase.TaxCategoryRepo.Create(ctx, body)
+	if h.HandleDbError(ctx, err, "Error creating tax category") {
+		return
+	}
+
+	ctx.JSON(201, category)
+}
+
+// GetTaxCategory godoc
+// @Router /tax-category/{id} [get]
+// @Summary Get a tax category by ID
+// @Description Get a tax category by ID
+// @Security BearerAuth
+// @Tags tax-category
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Tax category ID"
+// @Success 200 {object} entity.TaxCategory
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetTaxCategory(ctx *gin.Context) {
+	category, err := h.UseCase.TaxCategoryRepo.GetSingle(ctx, entity.Id{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting tax category") {
+		return
+	}
+
+	ctx.JSON(200, category)
+}
+
+// GetTaxCategories godoc
+// @Router /tax-category/list [get]
+// @Summary Get a list of tax categories
+// @Description Get a list of tax categories
+// @Security BearerAuth
+// @Tags tax-category
+// @Accept  json
+// @Produce  json
+// @Param page query number true "page"
+// @Param limit query number true "limit"
+// @Success 200 {object} entity.TaxCategoryList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetTaxCategories(ctx *gin.Context) {
+	var (
+		req entity.GetListFilter
+	)
+
+	req.Page, _ = strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	req.Limit, _ = strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+
+	req.OrderBy = append(req.OrderBy, entity.OrderBy{
+		Column: "name",
+		Order:  "asc",
+	})
+
+	categories, err := h.UseCase.TaxCategoryRepo.GetList(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting tax categories") {
+		return
+	}
+
+	ctx.JSON(200, categories)
+}
+
+// UpdateTaxCategory godoc
+// @Router /tax-category [put]
+// @Summary Update a tax category
+// @Description A new rate applies to orders placed from now on, existing orders keep theirs.
+// @Security BearerAuth
+// @Tags tax-category
+// @Accept  json
+// @Produce  json
+// @Param category body entity.TaxCategory true "Tax category"
+// @Success 200 {object} entity.TaxCategory
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) UpdateTaxCategory(ctx *gin.Context) {
+	var (
+		body entity.TaxCategory
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil || body.ID == "" || !validTaxCategory(body) {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	category, err := h.UseCase.TaxCategoryRepo.Update(ctx, body)
+	if h.HandleDbError(ctx, err, "Error updating tax category") {
+		return
+	}
+
+	ctx.JSON(200, category)
+}
+
+// DeleteTaxCategory godoc
+// @Router /tax-category/{id} [delete]
+// @Summary Delete a tax category
+// @Description Categories used by products cannot be deleted.
+// @Security BearerAuth
+// @Tags tax-category
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Tax category ID"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) DeleteTaxCategory(ctx *gin.Context) {
+	err := h.UseCase.TaxCategoryRepo.Delete(ctx, entity.Id{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error deleting tax category") {
+		return
+	}
+
+	ctx.JSON(200, entity.SuccessResponse{
+		Message: "Tax category deleted successfully",
+	})
+}
+
+func validTaxCategory(category entity.TaxCategory) bool {
+	return category.Name != "" && category.Rate >= 0 && category.Rate < 100
+}
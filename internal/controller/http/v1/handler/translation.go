@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/Akrom0181/Food-Delivery/internal/usecase/repo"
+	"github.com/gin-gonic/gin"
+)
+
+var translatableEntities = map[string]bool{
+	repo.TranslationEntityProduct:  true,
+	repo.TranslationEntityCategory: true,
+	repo.TranslationEntityBanner:   true,
+}
+
+// GetTranslations godoc
+// @Router /translation/{entity_type}/{id} [get]
+// @Summary Get the translations of a product, category or banner
+// @Description Get the translations of a product, category or banner
+// @Security BearerAuth
+// @Tags translation
+// @Accept  json
+// @Produce  json
+// @Param entity_type path string true "product, category or banner"
+// @Param id path string true "Entity ID"
+// @Success 200 {object} entity.TranslationList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetTranslations(ctx *gin.Context) {
+	entityType := ctx.Param("entity_type")
+	if !translatableEntities[entityType] {
+		h.notFound(ctx)
+		return
+	}
+
+	translations, err := h.UseCase.TranslationRepo.GetList(ctx, entityType, entity.Id{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting translations") {
+		return
+	}
+
+	ctx.JSON(200, translations)
+}
+
+// UpsertTranslation godoc
+// @Router /translation/{entity_type}/{id} [put]
+// @Summary Create or replace a translation
+// @Description Products take name and description, categories name and banners title.
+// @Description The default locale (uz) is edited on the entity itself.
+// @Security BearerAuth
+// @Tags translation
+// @Accept  json
+// @Produce  json
+// @Param entity_type path string true "product, category or banner"
+// @Param id path string true "Entity ID"
+// @Param translation body entity.Translation true "Translation"
+// @Success 200 {object} entity.TranslationList
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) UpsertTranslation(ctx *gin.Context) {
+	var (
+		body entity.Translation
+		req  = entity.Id{ID: ctx.Param("id")}
+	)
+
+	entityType := ctx.Param("entity_type")
+	if !translatableEntities[entityType] {
+		h.notFound(ctx)
+		return
+	}
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	if !validTranslationLocale(body.Locale) {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Unsupported locale", 400)
+		return
+	}
+
+	err = h.UseCase.TranslationRepo.Upsert(ctx, entityType, req, body)
+	if h.HandleDbError(ctx, err, "Error saving translation") {
+		return
+	}
+
+	translations, err := h.UseCase.TranslationRepo.GetList(ctx, entityType, req)
+	if h.HandleDbError(ctx, err, "Error getting translations") {
+		return
+	}
+
+	ctx.JSON(200, translations)
+}
+
+// DeleteTranslation godoc
+// @Router /translation/{entity_type}/{id}/{locale} [delete]
+// @Summary Delete a translation
+// @Description Delete a translation
+// @Security BearerAuth
+// @Tags translation
+// @Accept  json
+// @Produce  json
+// @Param entity_type path string true "product, category or banner"
+// @Param id path string true "Entity ID"
+// @Param locale path string true "Locale"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) DeleteTranslation(ctx *gin.Context) {
+	entityType := ctx.Param("entity_type")
+	if !translatableEntities[entityType] {
+		h.notFound(ctx)
+		return
+	}
+
+	err := h.UseCase.TranslationRepo.Delete(ctx, entityType, entity.Id{ID: ctx.Param("id")}, ctx.Param("locale"))
+	if h.HandleDbError(ctx, err, "Error deleting translation") {
+		return
+	}
+
+	ctx.JSON(200, entity.SuccessResponse{
+		Message: "Translation deleted successfully",
+	})
+}
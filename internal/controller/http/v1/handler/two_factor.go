@@ -0,0 +1,617 @@
+package handler
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/Akrom0181/Food-Delivery/pkg/hash"
+	"github.com/Akrom0181/Food-Delivery/pkg/totp"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4"
+)
+
+// pendingLogin is kept in redis between the password step and the second factor step.
+type pendingLogin struct {
+	UserID   string `json:"user_id"`
+	Platform string `json:"platform"`
+}
+
+// requireTwoFactor decides whether the login has to pass a second factor. When it
+// does, a pending login is stored and the challenge to return to the client is built.
+func (h *Handler) requireTwoFactor(ctx *gin.Context, user entity.User, platform string) (entity.TwoFactorChallenge, bool, bool) {
+	var challenge entity.TwoFactorChallenge
+
+	twoFactor, err := h.UseCase.TwoFactorRepo.GetSingle(ctx, entity.Id{ID: user.ID})
+	if err != nil && err != pgx.ErrNoRows {
+		h.HandleDbError(ctx, err, "Error getting two factor settings")
+		return challenge, false, false
+	}
+
+	enabled := err == nil && twoFactor.IsEnabled
+	enforced := platform == "admin" && slices.Contains(h.Config.TwoFactor.EnforceRoles, user.UserRole)
+	if !enabled && !enforced {
+		return challenge, false, true
+	}
+
+	token, err := randomToken()
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorInternalServer, "Oops, something went wrong!!!", http.StatusInternalServerError)
+		return challenge, false, false
+	}
+
+	if !h.savePendingLogin(ctx, token, pendingLogin{UserID: user.ID, Platform: platform}) {
+		return challenge, false, false
+	}
+
+	challenge = entity.TwoFactorChallenge{
+		TwoFactorRequired: true,
+		SetupRequired:     !enabled,
+		Token:             token,
+		ExpiresIn:         int(config.TwoFactorTokenExpireTime.Seconds()),
+	}
+
+	return challenge, true, true
+}
+
+// TwoFactorSetup godoc
+// @Router /auth/2fa/setup [post]
+// @Summary Start two factor enrollment during login
+// @Description Generates a TOTP secret for a user whose role requires two factor authentication but who has not enrolled yet
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Param body body entity.TwoFactorSetupRequest true "Two factor token from login"
+// @Success 200 {object} entity.TwoFactorSetupResponse
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) TwoFactorSetup(ctx *gin.Context) {
+	var (
+		body entity.TwoFactorSetupRequest
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	pending, ok := h.getPendingLogin(ctx, body.Token)
+	if !ok {
+		return
+	}
+
+	twoFactor, err := h.UseCase.TwoFactorRepo.GetSingle(ctx, entity.Id{ID: pending.UserID})
+	if err == nil && twoFactor.IsEnabled {
+		h.ReturnError(ctx, config.ErrorConflict, "Two factor authentication is already enabled", 400)
+		return
+	}
+
+	user, err := h.UseCase.UserRepo.GetSingle(ctx, entity.UserSingleRequest{ID: pending.UserID})
+	if h.HandleDbError(ctx, err, "Error getting user") {
+		return
+	}
+
+	resp, ok := h.enrollTwoFactor(ctx, user)
+	if !ok {
+		return
+	}
+
+	ctx.JSON(200, resp)
+}
+
+// TwoFactorVerify godoc
+// @Router /auth/2fa/verify [post]
+// @Summary Complete login with a second factor
+// @Description Checks a TOTP or recovery code and issues the access token. The first successful code after enrollment enables two factor authentication and returns recovery codes.
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Param body body entity.TwoFactorVerifyRequest true "Code"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 429 {object} entity.ErrorResponse
+func (h *Handler) TwoFactorVerify(ctx *gin.Context) {
+	var (
+		body          entity.TwoFactorVerifyRequest
+		recoveryCodes []string
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	pending, ok := h.getPendingLogin(ctx, body.Token)
+	if !ok {
+		return
+	}
+
+	twoFactor, err := h.UseCase.TwoFactorRepo.GetSingle(ctx, entity.Id{ID: pending.UserID})
+	if err == pgx.ErrNoRows {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Two factor setup is required", 400)
+		return
+	}
+	if h.HandleDbError(ctx, err, "Error getting two factor settings") {
+		return
+	}
+
+	// the attempts are counted per user, a new login does not reset them
+	if !h.takeCodeAttempt(ctx, pending.UserID) {
+		return
+	}
+
+	switch {
+	case body.RecoveryCode != "" && twoFactor.IsEnabled:
+		ok = h.useRecoveryCode(ctx, pending.UserID, body.RecoveryCode)
+	case body.Code != "":
+		ok = h.checkTotp(ctx, twoFactor, body.Code)
+	default:
+		h.ReturnError(ctx, config.ErrorBadRequest, "Code is required", 400)
+		return
+	}
+	if ctx.IsAborted() || ctx.Writer.Written() {
+		return
+	}
+
+	if !ok {
+		h.ReturnError(ctx, config.ErrorInvalidOtp, "Incorrect code", http.StatusBadRequest)
+		return
+	}
+	h.resetCodeAttempts(ctx, pending.UserID)
+
+	if !twoFactor.IsEnabled {
+		err = h.UseCase.TwoFactorRepo.Enable(ctx, entity.Id{ID: pending.UserID})
+		if h.HandleDbError(ctx, err, "Error enabling two factor") {
+			return
+		}
+
+		recoveryCodes, ok = h.issueRecoveryCodes(ctx, pending.UserID)
+		if !ok {
+			return
+		}
+	}
+
+	_ = h.Redis.Del(ctx, pendingLoginKey(body.Token))
+
+	user, err := h.UseCase.UserRepo.GetSingle(ctx, entity.UserSingleRequest{ID: pending.UserID})
+	if h.HandleDbError(ctx, err, "Error getting user") {
+		return
+	}
+
+	user, session, ok := h.openSession(ctx, user, pending.Platform)
+	if !ok {
+		return
+	}
+
+	resp := gin.H{
+		"user":    user,
+		"session": session,
+	}
+	if len(recoveryCodes) > 0 {
+		resp["recovery_codes"] = recoveryCodes
+	}
+
+	ctx.JSON(200, resp)
+}
+
+// SetupMyTwoFactor godoc
+// @Router /user/2fa/setup [post]
+// @Summary Start two factor enrollment
+// @Description Generates a new TOTP secret for the current user. It becomes active after /user/2fa/activate.
+// @Security BearerAuth
+// @Tags user
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} entity.TwoFactorSetupResponse
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) SetupMyTwoFactor(ctx *gin.Context) {
+	userID := ctx.GetHeader("sub")
+
+	twoFactor, err := h.UseCase.TwoFactorRepo.GetSingle(ctx, entity.Id{ID: userID})
+	if err == nil && twoFactor.IsEnabled {
+		h.ReturnError(ctx, config.ErrorConflict, "Two factor authentication is already enabled", 400)
+		return
+	}
+
+	user, err := h.UseCase.UserRepo.GetSingle(ctx, entity.UserSingleRequest{ID: userID})
+	if h.HandleDbError(ctx, err, "Error getting user") {
+		return
+	}
+
+	resp, ok := h.enrollTwoFactor(ctx, user)
+	if !ok {
+		return
+	}
+
+	ctx.JSON(200, resp)
+}
+
+// ActivateMyTwoFactor godoc
+// @Router /user/2fa/activate [post]
+// @Summary Activate two factor authentication
+// @Description Confirms the enrollment with a code from the authenticator app and returns recovery codes
+// @Security BearerAuth
+// @Tags user
+// @Accept  json
+// @Produce  json
+// @Param body body entity.TwoFactorCodeRequest true "Code"
+// @Success 200 {object} entity.RecoveryCodesResponse
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 429 {object} entity.ErrorResponse
+func (h *Handler) ActivateMyTwoFactor(ctx *gin.Context) {
+	var (
+		body   entity.TwoFactorCodeRequest
+		userID = ctx.GetHeader("sub")
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	twoFactor, err := h.UseCase.TwoFactorRepo.GetSingle(ctx, entity.Id{ID: userID})
+	if h.HandleDbError(ctx, err, "Error getting two factor settings") {
+		return
+	}
+
+	if twoFactor.IsEnabled {
+		h.ReturnError(ctx, config.ErrorConflict, "Two factor authentication is already enabled", 400)
+		return
+	}
+
+	if !h.checkMyTotp(ctx, twoFactor, body.Code) {
+		return
+	}
+
+	err = h.UseCase.TwoFactorRepo.Enable(ctx, entity.Id{ID: userID})
+	if h.HandleDbError(ctx, err, "Error enabling two factor") {
+		return
+	}
+
+	codes, ok := h.issueRecoveryCodes(ctx, userID)
+	if !ok {
+		return
+	}
+
+	ctx.JSON(200, entity.RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// DisableMyTwoFactor godoc
+// @Router /user/2fa/disable [post]
+// @Summary Disable two factor authentication
+// @Description Disables two factor authentication for the current user. Not allowed for roles where it is enforced.
+// @Security BearerAuth
+// @Tags user
+// @Accept  json
+// @Produce  json
+// @Param body body entity.TwoFactorCodeRequest true "Code"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 429 {object} entity.ErrorResponse
+func (h *Handler) DisableMyTwoFactor(ctx *gin.Context) {
+	var (
+		body   entity.TwoFactorCodeRequest
+		userID = ctx.GetHeader("sub")
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	if slices.Contains(h.Config.TwoFactor.EnforceRoles, ctx.GetHeader("user_role")) {
+		h.ReturnError(ctx, config.ErrorForbidden, "Two factor authentication is required for your role", http.StatusForbidden)
+		return
+	}
+
+	twoFactor, err := h.UseCase.TwoFactorRepo.GetSingle(ctx, entity.Id{ID: userID})
+	if h.HandleDbError(ctx, err, "Error getting two factor settings") {
+		return
+	}
+
+	if !h.checkMyTotp(ctx, twoFactor, body.Code) {
+		return
+	}
+
+	err = h.UseCase.TwoFactorRepo.Delete(ctx, entity.Id{ID: userID})
+	if h.HandleDbError(ctx, err, "Error disabling two factor") {
+		return
+	}
+
+	ctx.JSON(200, entity.SuccessResponse{
+		Message: "Two factor authentication disabled",
+	})
+}
+
+// RegenerateMyRecoveryCodes godoc
+// @Router /user/2fa/recovery-codes [post]
+// @Summary Regenerate recovery codes
+// @Description Replaces all recovery codes of the current user
+// @Security BearerAuth
+// @Tags user
+// @Accept  json
+// @Produce  json
+// @Param body body entity.TwoFactorCodeRequest true "Code"
+// @Success 200 {object} entity.RecoveryCodesResponse
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 429 {object} entity.ErrorResponse
+func (h *Handler) RegenerateMyRecoveryCodes(ctx *gin.Context) {
+	var (
+		body   entity.TwoFactorCodeRequest
+		userID = ctx.GetHeader("sub")
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	twoFactor, err := h.UseCase.TwoFactorRepo.GetSingle(ctx, entity.Id{ID: userID})
+	if h.HandleDbError(ctx, err, "Error getting two factor settings") {
+		return
+	}
+
+	if !twoFactor.IsEnabled {
+		h.ReturnError(ctx, config.ErrorInvalidOtp, "Incorrect code", http.StatusBadRequest)
+		return
+	}
+
+	if !h.checkMyTotp(ctx, twoFactor, body.Code) {
+		return
+	}
+
+	codes, ok := h.issueRecoveryCodes(ctx, userID)
+	if !ok {
+		return
+	}
+
+	ctx.JSON(200, entity.RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// ResetUserTwoFactor godoc
+// @Router /user/2fa/{id} [delete]
+// @Summary Reset a user's two factor authentication
+// @Description Removes the TOTP secret and recovery codes of a user, e.g. after a lost phone. Users with an enforced role have to enroll again on next login.
+// @Security BearerAuth
+// @Tags user
+// @Accept  json
+// @Produce  json
+// @Param id path string true "User ID"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) ResetUserTwoFactor(ctx *gin.Context) {
+	var (
+		req  entity.Id
+		role = ctx.GetHeader("user_role")
+	)
+
+	req.ID = ctx.Param("id")
+
+	if role != "admin" && role != "superadmin" {
+		h.ReturnError(ctx, config.ErrorForbidden, "Only admins can reset two factor authentication", http.StatusForbidden)
+		return
+	}
+
+	user, err := h.UseCase.UserRepo.GetSingle(ctx, entity.UserSingleRequest{ID: req.ID})
+	if h.HandleDbError(ctx, err, "Error getting user") {
+		return
+	}
+
+	if user.UserRole == "superadmin" && role != "superadmin" {
+		h.ReturnError(ctx, config.ErrorForbidden, "Only superadmins can reset a superadmin", http.StatusForbidden)
+		return
+	}
+
+	err = h.UseCase.TwoFactorRepo.Delete(ctx, req)
+	if h.HandleDbError(ctx, err, "Error resetting two factor") {
+		return
+	}
+
+	ctx.JSON(200, entity.SuccessResponse{
+		Message: "Two factor authentication reset successfully",
+	})
+}
+
+func (h *Handler) enrollTwoFactor(ctx *gin.Context, user entity.User) (entity.TwoFactorSetupResponse, bool) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorInternalServer, "Oops, something went wrong!!!", http.StatusInternalServerError)
+		return entity.TwoFactorSetupResponse{}, false
+	}
+
+	_, err = h.UseCase.TwoFactorRepo.Upsert(ctx, entity.UserTwoFactor{
+		UserID: user.ID,
+		Secret: secret,
+	})
+	if h.HandleDbError(ctx, err, "Error saving two factor secret") {
+		return entity.TwoFactorSetupResponse{}, false
+	}
+
+	return entity.TwoFactorSetupResponse{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(h.Config.TwoFactor.Issuer, user.Email, secret),
+	}, true
+}
+
+// checkTotp validates the code and makes sure it is not used twice.
+func (h *Handler) checkTotp(ctx *gin.Context, twoFactor entity.UserTwoFactor, code string) bool {
+	step, ok := totp.Validate(code, twoFactor.Secret, time.Now())
+	if !ok {
+		return false
+	}
+
+	// a code stays valid for at most three periods because of the allowed
+	// skew; the first request to claim it wins
+	claimed, err := h.keys.Claim(ctx, fmt.Sprintf("2fa-used-%s-%d", twoFactor.UserID, step), 90*time.Second)
+	if err != nil {
+		h.Logger.Error(err, "Error claiming two factor code")
+		return false
+	}
+
+	return claimed
+}
+
+// checkMyTotp checks a code of the signed in user within the attempts of
+// the user. It writes the error response when the code is not accepted.
+func (h *Handler) checkMyTotp(ctx *gin.Context, twoFactor entity.UserTwoFactor, code string) bool {
+	if !h.takeCodeAttempt(ctx, twoFactor.UserID) {
+		return false
+	}
+
+	if !h.checkTotp(ctx, twoFactor, code) {
+		h.ReturnError(ctx, config.ErrorInvalidOtp, "Incorrect code", http.StatusBadRequest)
+		return false
+	}
+	h.resetCodeAttempts(ctx, twoFactor.UserID)
+
+	return true
+}
+
+// takeCodeAttempt counts a code attempt of the user before the code is
+// checked, so concurrent requests can not get past the limit. A user gets
+// TwoFactorMaxAttempts tries within TwoFactorLockout, whatever the login. It
+// writes the error response when none is left.
+func (h *Handler) takeCodeAttempt(ctx *gin.Context, userID string) bool {
+	attempts, err := h.keys.Count(ctx, codeAttemptsKey(userID), config.TwoFactorLockout)
+	if err != nil {
+		h.Logger.Error(err, "Error counting two factor attempt")
+		h.ReturnError(ctx, config.ErrorInternalServer, "Oops, something went wrong!!!", http.StatusInternalServerError)
+		return false
+	}
+
+	if attempts > int64(config.TwoFactorMaxAttempts) {
+		ctx.Header("Retry-After", strconv.Itoa(int(config.TwoFactorLockout.Seconds())))
+		h.ReturnError(ctx, config.ErrorInvalidOtp, "Too many attempts, try again later", http.StatusTooManyRequests)
+		return false
+	}
+
+	return true
+}
+
+// resetCodeAttempts gives the user all attempts back after a right code.
+func (h *Handler) resetCodeAttempts(ctx *gin.Context, userID string) {
+	if err := h.keys.Del(ctx, codeAttemptsKey(userID)); err != nil {
+		h.Logger.Error(err, "Error resetting two factor attempts")
+	}
+}
+
+func codeAttemptsKey(userID string) string {
+	return "2fa-attempts-" + userID
+}
+
+func (h *Handler) useRecoveryCode(ctx *gin.Context, userID, code string) bool {
+	codes, err := h.UseCase.TwoFactorRepo.GetRecoveryCodes(ctx, entity.Id{ID: userID})
+	if h.HandleDbError(ctx, err, "Error getting recovery codes") {
+		return false
+	}
+
+	code = strings.ToLower(strings.TrimSpace(code))
+	for _, item := range codes {
+		if !hash.CheckPasswordHash(code, item.CodeHash) {
+			continue
+		}
+
+		err = h.UseCase.TwoFactorRepo.UseRecoveryCode(ctx, entity.Id{ID: item.ID})
+		return err == nil
+	}
+
+	return false
+}
+
+func (h *Handler) issueRecoveryCodes(ctx *gin.Context, userID string) ([]string, bool) {
+	var (
+		codes  = make([]string, 0, config.RecoveryCodesCount)
+		hashes = make([]string, 0, config.RecoveryCodesCount)
+	)
+
+	for i := 0; i < config.RecoveryCodesCount; i++ {
+		buf := make([]byte, 6)
+		if _, err := rand.Read(buf); err != nil {
+			h.ReturnError(ctx, config.ErrorInternalServer, "Oops, something went wrong!!!", http.StatusInternalServerError)
+			return nil, false
+		}
+
+		raw := strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(buf))
+		code := raw[:5] + "-" + raw[5:]
+
+		hashed, err := hash.HashPassword(code)
+		if err != nil {
+			h.ReturnError(ctx, config.ErrorInternalServer, "Oops, something went wrong!!!", http.StatusInternalServerError)
+			return nil, false
+		}
+
+		codes = append(codes, code)
+		hashes = append(hashes, hashed)
+	}
+
+	err := h.UseCase.TwoFactorRepo.ReplaceRecoveryCodes(ctx, userID, hashes)
+	if h.HandleDbError(ctx, err, "Error saving recovery codes") {
+		return nil, false
+	}
+
+	return codes, true
+}
+
+func (h *Handler) savePendingLogin(ctx *gin.Context, token string, pending pendingLogin) bool {
+	value, err := json.Marshal(pending)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorInternalServer, "Oops, something went wrong!!!", http.StatusInternalServerError)
+		return false
+	}
+
+	err = h.Redis.Set(ctx, pendingLoginKey(token), string(value), int(config.TwoFactorTokenExpireTime.Seconds()))
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorInternalServer, "Oops, something went wrong!!!", http.StatusInternalServerError)
+		return false
+	}
+
+	return true
+}
+
+func (h *Handler) getPendingLogin(ctx *gin.Context, token string) (pendingLogin, bool) {
+	var pending pendingLogin
+
+	if token == "" {
+		h.ReturnError(ctx, config.ErrorInvalidToken, "Two factor token is required", http.StatusBadRequest)
+		return pending, false
+	}
+
+	value, err := h.Redis.Get(ctx, pendingLoginKey(token))
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorSessionExpired, "Two factor token is invalid or expired", http.StatusUnauthorized)
+		return pending, false
+	}
+
+	if err = json.Unmarshal([]byte(value), &pending); err != nil {
+		h.ReturnError(ctx, config.ErrorInternalServer, "Oops, something went wrong!!!", http.StatusInternalServerError)
+		return pending, false
+	}
+
+	return pending, true
+}
+
+func pendingLoginKey(token string) string {
+	return "2fa-login-" + token
+}
+
+func randomToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(buf), nil
+}
@@ -1,12 +1,151 @@
 package handler
 
 import (
+	"errors"
+	"mime/multipart"
+	"net/http"
+
 	"github.com/Akrom0181/Food-Delivery/config"
 	"github.com/Akrom0181/Food-Delivery/internal/entity"
-	"github.com/Akrom0181/Food-Delivery/pkg/firebase"
+	"github.com/Akrom0181/Food-Delivery/pkg/imageproc"
+	"github.com/Akrom0181/Food-Delivery/pkg/storage"
 	"github.com/gin-gonic/gin"
 )
 
+// uploadFiles stores the "file" parts of form. The object key is the content
+// hash of the file, the client file name is not used.
+func (h *Handler) uploadFiles(ctx *gin.Context, form *multipart.Form) (*entity.MultipleFileUploadResponse, bool) {
+	var resp entity.MultipleFileUploadResponse
+
+	files := form.File["file"]
+	if len(files) == 0 {
+		h.ReturnError(ctx, config.ErrorBadRequest, "No file uploaded", 400)
+		return nil, false
+	}
+
+	limits := storage.Limits{
+		MaxSize:      h.Config.Storage.MaxUploadSize,
+		AllowedTypes: h.Config.Storage.AllowedTypes,
+	}
+
+	for _, header := range files {
+		if header.Size > limits.MaxSize {
+			h.ReturnError(ctx, config.ErrorBadRequest, "File "+header.Filename+" is too large", http.StatusRequestEntityTooLarge)
+			return nil, false
+		}
+
+		file, err := header.Open()
+		if err != nil {
+			h.ReturnError(ctx, config.ErrorBadRequest, "Invalid file upload request", 400)
+			return nil, false
+		}
+
+		object, err := storage.Upload(ctx, h.Storage, file, limits)
+		file.Close()
+
+		switch {
+		case errors.Is(err, storage.ErrTooLarge):
+			h.ReturnError(ctx, config.ErrorBadRequest, "File "+header.Filename+" is too large", http.StatusRequestEntityTooLarge)
+			return nil, false
+		case errors.Is(err, storage.ErrTypeNotAllowed):
+			h.ReturnError(ctx, config.ErrorBadRequest, "File type of "+header.Filename+" is not allowed", http.StatusUnsupportedMediaType)
+			return nil, false
+		case err != nil:
+			h.Logger.Error(err, "Error uploading file")
+			h.ReturnError(ctx, config.ErrorInternalServer, "Error uploading files", http.StatusInternalServerError)
+			return nil, false
+		}
+
+		if !h.trackUpload(ctx, object) {
+			return nil, false
+		}
+
+		resp.Url = append(resp.Url, &entity.Url{
+			Id:  object.Key,
+			Url: object.URL,
+		})
+	}
+
+	return &resp, true
+}
+
+// uploadImage resizes the first "file" part of form into the image variants
+// and stores them. Metadata such as the EXIF location of a photo is dropped.
+func (h *Handler) uploadImage(ctx *gin.Context, form *multipart.Form) (entity.ImageVariants, bool) {
+	files := form.File["file"]
+	if len(files) == 0 {
+		h.ReturnError(ctx, config.ErrorBadRequest, "No file uploaded", 400)
+		return nil, false
+	}
+
+	header := files[0]
+	if header.Size > h.Config.Storage.MaxUploadSize {
+		h.ReturnError(ctx, config.ErrorBadRequest, "File "+header.Filename+" is too large", http.StatusRequestEntityTooLarge)
+		return nil, false
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid file upload request", 400)
+		return nil, false
+	}
+	defer file.Close()
+
+	data, _, err := storage.Read(file, storage.Limits{
+		MaxSize:      h.Config.Storage.MaxUploadSize,
+		AllowedTypes: imageTypes,
+	})
+	if errors.Is(err, storage.ErrTooLarge) {
+		h.ReturnError(ctx, config.ErrorBadRequest, "File "+header.Filename+" is too large", http.StatusRequestEntityTooLarge)
+		return nil, false
+	}
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "File "+header.Filename+" is not a supported image", http.StatusUnsupportedMediaType)
+		return nil, false
+	}
+
+	outputs, err := imageproc.Process(data, imageproc.Variants)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "File "+header.Filename+" is not a supported image", http.StatusUnsupportedMediaType)
+		return nil, false
+	}
+
+	variants := entity.ImageVariants{}
+	for _, output := range outputs {
+		object, err := storage.Store(ctx, h.Storage, output.Data, output.ContentType)
+		if err != nil {
+			h.Logger.Error(err, "Error uploading image")
+			h.ReturnError(ctx, config.ErrorInternalServer, "Error uploading files", http.StatusInternalServerError)
+			return nil, false
+		}
+
+		if !h.trackUpload(ctx, object) {
+			return nil, false
+		}
+
+		variants[output.Name] = object.URL
+	}
+
+	return variants, true
+}
+
+// trackUpload records a stored object. It stays an orphan until an entity
+// references its url and is deleted by the sweeper if that never happens.
+func (h *Handler) trackUpload(ctx *gin.Context, object storage.Object) bool {
+	_, err := h.UseCase.UploadRepo.Create(ctx, entity.Upload{
+		Key:         object.Key,
+		URL:         object.URL,
+		ContentType: object.ContentType,
+		Size:        object.Size,
+		OwnerID:     ctx.GetHeader("sub"),
+	})
+
+	return !h.HandleDbError(ctx, err, "Error saving upload")
+}
+
+// imageTypes are the formats imageproc can decode.
+var imageTypes = []string{"image/jpeg", "image/png", "image/gif", "image/webp"}
+
 // UploadFiles godoc
 // @ID upload_multiple_files
 // @Router /firebase [post]
@@ -27,8 +166,8 @@ func (h *Handler) UploadFiles(ctx *gin.Context) {
 		return
 	}
 
-	resp, err := firebase.UploadFiles(form)
-	if h.HandleDbError(ctx, err, "Error uploading files") {
+	resp, ok := h.uploadFiles(ctx, form)
+	if !ok {
 		return
 	}
 
@@ -44,21 +183,51 @@ func (h *Handler) UploadFiles(ctx *gin.Context) {
 // @Tags Upload File
 // @Accept json
 // @Produce json
-// @Param id query string true "ID of the file to delete"
+// @Param id path string true "ID of the file to delete"
 // @Success 204 {string} string "Success Request"
 // @Failure 400 {object} entity.ErrorResponse "Bad Request"
 // @Failure 500 {object} entity.ErrorResponse "Server error"
 func (h *Handler) DeleteFile(ctx *gin.Context) {
-	fileID := ctx.Query("id")
-	if fileID == "" {
-		h.ReturnError(ctx, config.ErrorBadRequest, "Missing file ID", 400)
+	fileID := ctx.Param("id")
+	if !storage.ValidKey(fileID) {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid file ID", 400)
 		return
 	}
 
-	err := firebase.DeleteFile(fileID)
-	if h.HandleDbError(ctx, err, "Error deleting file") {
+	err := h.Storage.Delete(ctx, fileID)
+	if errors.Is(err, storage.ErrNotFound) {
+		h.notFound(ctx)
+		return
+	}
+	if err != nil {
+		h.Logger.Error(err, "Error deleting file")
+		h.ReturnError(ctx, config.ErrorInternalServer, "Error deleting file", http.StatusInternalServerError)
+		return
+	}
+
+	err = h.UseCase.UploadRepo.Delete(ctx, entity.Id{ID: fileID})
+	if h.HandleDbError(ctx, err, "Error deleting upload") {
 		return
 	}
 
 	ctx.JSON(204, entity.SuccessResponse{Message: "File deleted successfully"})
 }
+
+// GetStorageReport godoc
+// @Router /storage/report [get]
+// @Summary Storage usage report
+// @Description Number of stored objects and bytes per entity type referencing them. Objects nothing references are reported as "unreferenced" and deleted by the sweeper after a grace period.
+// @Security BearerAuth
+// @Tags Upload File
+// @Accept json
+// @Produce json
+// @Success 200 {object} entity.StorageReport
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetStorageReport(ctx *gin.Context) {
+	report, err := h.UseCase.UploadRepo.GetReport(ctx)
+	if h.HandleDbError(ctx, err, "Error getting storage report") {
+		return
+	}
+
+	ctx.JSON(200, report)
+}
@@ -63,6 +63,10 @@ func (h *Handler) GetUserLocation(ctx *gin.Context) {
 		return
 	}
 
+	if !h.checkOwner(ctx, userlocation.UserId) {
+		return
+	}
+
 	ctx.JSON(200, userlocation)
 }
 
@@ -88,8 +92,8 @@ func (h *Handler) GetUserLocations(ctx *gin.Context) {
 	limit := ctx.DefaultQuery("limit", "10")
 	userId := ctx.DefaultQuery("user_id", "")
 
-	if ctx.GetHeader("user_type") == "user" {
-		userId = ctx.GetHeader("sub")
+	if scope := ownerScope(ctx); scope != "" {
+		userId = scope
 	}
 
 	req.Page, _ = strconv.Atoi(page)
@@ -137,10 +141,17 @@ func (h *Handler) UpdateUserLocation(ctx *gin.Context) {
 		return
 	}
 
-	if ctx.GetHeader("user_type") == "user" {
-		body.UserId = ctx.GetHeader("sub")
+	current, err := h.UseCase.UserLocationRepo.GetSingle(ctx, entity.Id{ID: body.Id})
+	if h.HandleDbError(ctx, err, "Error getting userlocation") {
+		return
 	}
 
+	if !h.checkOwner(ctx, current.UserId) {
+		return
+	}
+
+	body.UserId = current.UserId
+
 	userlocation, err := h.UseCase.UserLocationRepo.Update(ctx, body)
 	if h.HandleDbError(ctx, err, "Error updating userlocation") {
 		return
@@ -167,7 +178,16 @@ func (h *Handler) DeleteUserLocation(ctx *gin.Context) {
 
 	req.ID = ctx.Param("id")
 
-	err := h.UseCase.UserLocationRepo.Delete(ctx, req)
+	userlocation, err := h.UseCase.UserLocationRepo.GetSingle(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting userlocation") {
+		return
+	}
+
+	if !h.checkOwner(ctx, userlocation.UserId) {
+		return
+	}
+
+	err = h.UseCase.UserLocationRepo.Delete(ctx, req)
 	if h.HandleDbError(ctx, err, "Error deleting user location") {
 		return
 	}
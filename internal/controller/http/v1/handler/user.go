@@ -5,7 +5,6 @@ import (
 
 	"github.com/Akrom0181/Food-Delivery/config"
 	"github.com/Akrom0181/Food-Delivery/internal/entity"
-	"github.com/Akrom0181/Food-Delivery/pkg/firebase"
 	"github.com/Akrom0181/Food-Delivery/pkg/hash"
 	"github.com/gin-gonic/gin"
 )
@@ -64,6 +63,10 @@ func (h *Handler) GetUser(ctx *gin.Context) {
 
 	req.ID = ctx.Param("id")
 
+	if !h.checkOwner(ctx, req.ID) {
+		return
+	}
+
 	user, err := h.UseCase.UserRepo.GetSingle(ctx, req)
 	if h.HandleDbError(ctx, err, "Error getting user") {
 		return
@@ -146,7 +149,7 @@ func (h *Handler) UpdateUser(ctx *gin.Context) {
 	)
 
 	err := ctx.ShouldBindJSON(&body)
-	if err != nil {
+	if err != nil || !validValues(body.ExcludedAllergens, config.Allergens) {
 		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
 		return
 	}
@@ -155,6 +158,16 @@ func (h *Handler) UpdateUser(ctx *gin.Context) {
 		body.ID = ctx.GetHeader("sub")
 	}
 
+	// only admins change roles, branch staff and restaurant admin roles only
+	// through /branch-staff and /restaurant-admin
+	current, err := h.UseCase.UserRepo.GetSingle(ctx, entity.UserSingleRequest{ID: body.ID})
+	if h.HandleDbError(ctx, err, "Error getting user") {
+		return
+	}
+	if !isAdmin(ctx) || assignedRole(body.UserRole) || assignedRole(current.UserRole) {
+		body.UserRole = current.UserRole
+	}
+
 	if body.Password != "" {
 		body.Password, err = hash.HashPassword(body.Password)
 		if err != nil {
@@ -212,8 +225,8 @@ func (h *Handler) DeleteUser(ctx *gin.Context) {
 // @Tags user
 // @Accept multipart/form-data
 // @Produce json
-// @Param file formData []file true "File to upload"
-// @Success 200 {object} entity.MultipleFileUploadResponse "Success Request"
+// @Param file formData file true "Profile picture"
+// @Success 200 {object} entity.ImageVariants "Success Request"
 // @Failure 400 {object} entity.ErrorResponse "Bad Request"
 // @Failure 500 {object} entity.ErrorResponse "Server error"
 func (h *Handler) UploadProfilePic(ctx *gin.Context) {
@@ -236,27 +249,27 @@ func (h *Handler) UploadProfilePic(ctx *gin.Context) {
 		return
 	}
 
-	resp, err := firebase.UploadFiles(form)
-	if h.HandleDbError(ctx, err, "Error uploading files") {
+	images, ok := h.uploadImage(ctx, form)
+	if !ok {
 		return
 	}
 
 	_, err = h.UseCase.UserRepo.Update(ctx, entity.User{
-		ID:         id.ID,
-		FullName:   user.FullName,
-		UserName:   user.UserName,
-		Email:      user.Email,
-		Gender:     user.Gender,
-		ProfilePic: resp.Url[0].Url,
-		Bio:        user.Bio,
-		Status:     user.Status,
-		UserType:   user.UserType,
-		UserRole:   user.UserRole,
+		ID:       id.ID,
+		FullName: user.FullName,
+		UserName: user.UserName,
+		Email:    user.Email,
+		Gender:   user.Gender,
+		Images:   images,
+		Bio:      user.Bio,
+		Status:   user.Status,
+		UserType: user.UserType,
+		UserRole: user.UserRole,
 	})
 
 	if h.HandleDbError(ctx, err, "Error updating user") {
 		return
 	}
 
-	ctx.JSON(200, resp)
+	ctx.JSON(200, images)
 }
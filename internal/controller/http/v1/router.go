@@ -3,8 +3,8 @@ package v1
 
 import (
 	"net/http"
+	"strings"
 
-	"github.com/casbin/casbin"
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	swaggerFiles "github.com/swaggo/files"
@@ -14,8 +14,12 @@ import (
 	"github.com/Akrom0181/Food-Delivery/config"
 	_ "github.com/Akrom0181/Food-Delivery/docs"
 	"github.com/Akrom0181/Food-Delivery/internal/controller/http/v1/handler"
+	"github.com/Akrom0181/Food-Delivery/internal/kitchen"
 	"github.com/Akrom0181/Food-Delivery/internal/usecase"
 	"github.com/Akrom0181/Food-Delivery/pkg/logger"
+	"github.com/Akrom0181/Food-Delivery/pkg/oauth"
+	"github.com/Akrom0181/Food-Delivery/pkg/rbac"
+	"github.com/Akrom0181/Food-Delivery/pkg/storage"
 	rediscache "github.com/golanguzb70/redis-cache"
 )
 
@@ -28,16 +32,17 @@ import (
 // @securityDefinitions.apikey BearerAuth
 // @in header
 // @name Authorization
-func NewRouter(engine *gin.Engine, l *logger.Logger, config *config.Config, useCase *usecase.UseCase, redis rediscache.RedisCache) {
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+func NewRouter(engine *gin.Engine, l *logger.Logger, config *config.Config, useCase *usecase.UseCase, redis rediscache.RedisCache, providers map[string]oauth.Provider, enforcer *rbac.Enforcer, store storage.Storage, kitchenBroker *kitchen.Broker) {
 	// Options
 	engine.Use(gin.Logger())
 	engine.Use(gin.Recovery())
 
-	handlerV1 := handler.NewHandler(l, config, useCase, redis)
+	handlerV1 := handler.NewHandler(l, config, useCase, redis, providers, enforcer, store, kitchenBroker)
 
-	// Initialize Casbin enforcer
-	e := casbin.NewEnforcer("config/rbac.conf", "config/policy.csv")
-	engine.Use(handlerV1.AuthMiddleware(e))
+	engine.Use(handlerV1.AuthMiddleware(enforcer))
 
 	// Swagger
 	url := ginSwagger.URL("swagger/doc.json") // The url pointing to API definition
@@ -49,6 +54,11 @@ func NewRouter(engine *gin.Engine, l *logger.Logger, config *config.Config, useC
 	// Prometheus metrics
 	engine.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
+	// Uploaded files when they are kept on the local disk
+	if config.Storage.Driver == "local" {
+		engine.Static("/files", config.Storage.LocalDir)
+	}
+
 	// Routes
 	v1 := engine.Group("/v1")
 
@@ -60,6 +70,11 @@ func NewRouter(engine *gin.Engine, l *logger.Logger, config *config.Config, useC
 		user.PUT("/", handlerV1.UpdateUser)
 		user.DELETE("/:id", handlerV1.DeleteUser)
 		user.POST("/upload", handlerV1.UploadProfilePic)
+		user.POST("/2fa/setup", handlerV1.SetupMyTwoFactor)
+		user.POST("/2fa/activate", handlerV1.ActivateMyTwoFactor)
+		user.POST("/2fa/disable", handlerV1.DisableMyTwoFactor)
+		user.POST("/2fa/recovery-codes", handlerV1.RegenerateMyRecoveryCodes)
+		user.DELETE("/2fa/:id", handlerV1.ResetUserTwoFactor)
 	}
 
 	session := v1.Group("/session")
@@ -76,6 +91,12 @@ func NewRouter(engine *gin.Engine, l *logger.Logger, config *config.Config, useC
 		auth.POST("/register", handlerV1.Register)
 		auth.POST("/verify-email", handlerV1.VerifyEmail)
 		auth.POST("/login", handlerV1.Login)
+		auth.POST("/2fa/setup", handlerV1.TwoFactorSetup)
+		auth.POST("/2fa/verify", handlerV1.TwoFactorVerify)
+		auth.GET("/oauth/:provider", handlerV1.OAuthStart)
+		auth.GET("/oauth/:provider/callback", handlerV1.OAuthCallback)
+		auth.POST("/oauth/:provider/callback", handlerV1.OAuthCallback)
+		auth.POST("/oauth/telegram", handlerV1.TelegramLogin)
 	}
 
 	report := v1.Group("/report")
@@ -103,6 +124,11 @@ func NewRouter(engine *gin.Engine, l *logger.Logger, config *config.Config, useC
 		firebase.DELETE("/:id", handlerV1.DeleteFile)
 	}
 
+	storageGroup := v1.Group("/storage")
+	{
+		storageGroup.GET("/report", handlerV1.GetStorageReport)
+	}
+
 	category := v1.Group("/category")
 	{
 		category.POST("/", handlerV1.CreateCategory)
@@ -110,16 +136,30 @@ func NewRouter(engine *gin.Engine, l *logger.Logger, config *config.Config, useC
 		category.GET("/:id", handlerV1.GetCategory)
 		category.PUT("/", handlerV1.UpdateCategory)
 		category.DELETE("/:id", handlerV1.DeleteCategory)
+		category.PUT("/sort", handlerV1.SortCategories)
+		category.PUT("/upload/:id", handlerV1.UploadCategoryPic)
 	}
 
+	v1.GET("/menu", handlerV1.GetMenu)
+
 	product := v1.Group("/product")
 	{
 		product.POST("/", handlerV1.CreateProduct)
 		product.GET("/list", handlerV1.GetProducts)
+		product.GET("/search", handlerV1.SearchProducts)
+		product.GET("/suggest", handlerV1.SuggestProducts)
+		product.PUT("/availability", handlerV1.SetProductAvailability)
 		product.GET("/:id", handlerV1.GetProduct)
 		product.PUT("/", handlerV1.UpdateProduct)
 		product.DELETE("/:id", handlerV1.DeleteProduct)
 		product.PUT("/upload/:id", handlerV1.UploadProductPic)
+		product.PUT("/sort", handlerV1.SortProducts)
+		product.GET("/:id/bundle", handlerV1.GetBundle)
+		product.PUT("/:id/bundle", handlerV1.SaveBundle)
+		product.DELETE("/:id/bundle", handlerV1.DeleteBundle)
+		product.GET("/:id/prices", handlerV1.GetPriceHistory)
+		product.POST("/:id/prices", handlerV1.SchedulePrice)
+		product.DELETE("/:id/prices/:price_id", handlerV1.CancelScheduledPrice)
 	}
 
 	banner := v1.Group("/banner")
@@ -131,15 +171,97 @@ func NewRouter(engine *gin.Engine, l *logger.Logger, config *config.Config, useC
 		banner.DELETE("/:id", handlerV1.DeleteBanner)
 	}
 
+	translation := v1.Group("/translation")
+	{
+		translation.GET("/:entity_type/:id", handlerV1.GetTranslations)
+		translation.PUT("/:entity_type/:id", handlerV1.UpsertTranslation)
+		translation.DELETE("/:entity_type/:id/:locale", handlerV1.DeleteTranslation)
+	}
+
+	pricingRule := v1.Group("/pricing-rule")
+	{
+		pricingRule.POST("/", handlerV1.CreatePricingRule)
+		pricingRule.GET("/list", handlerV1.GetPricingRules)
+		pricingRule.GET("/:id", handlerV1.GetPricingRule)
+		pricingRule.PUT("/", handlerV1.UpdatePricingRule)
+		pricingRule.DELETE("/:id", handlerV1.DeletePricingRule)
+	}
+
+	taxCategory := v1.Group("/tax-category")
+	{
+		taxCategory.POST("/", handlerV1.CreateTaxCategory)
+		taxCategory.GET("/list", handlerV1.GetTaxCategories)
+		taxCategory.GET("/:id", handlerV1.GetTaxCategory)
+		taxCategory.PUT("/", handlerV1.UpdateTaxCategory)
+		taxCategory.DELETE("/:id", handlerV1.DeleteTaxCategory)
+	}
+
+	kitchenStation := v1.Group("/kitchen-station")
+	{
+		kitchenStation.POST("/", handlerV1.CreateKitchenStation)
+		kitchenStation.GET("/list", handlerV1.GetKitchenStations)
+		kitchenStation.GET("/:id", handlerV1.GetKitchenStation)
+		kitchenStation.PUT("/", handlerV1.UpdateKitchenStation)
+		kitchenStation.DELETE("/:id", handlerV1.DeleteKitchenStation)
+	}
+
+	kitchenDisplay := v1.Group("/kitchen")
+	{
+		kitchenDisplay.GET("/tickets", handlerV1.GetKitchenTickets)
+		kitchenDisplay.GET("/stream", handlerV1.StreamKitchen)
+		kitchenDisplay.PUT("/item/status", handlerV1.SetKitchenItemStatus)
+		kitchenDisplay.POST("/order/:id/bump", handlerV1.BumpKitchenOrder)
+		kitchenDisplay.POST("/order/:id/recall", handlerV1.RecallKitchenOrder)
+	}
+
+	printing := v1.Group("/print")
+	{
+		printing.GET("/agent/next", handlerV1.NextPrintJob)
+		printing.POST("/agent/:id/ack", handlerV1.AckPrintJob)
+		printing.GET("/jobs/list", handlerV1.GetPrintJobs)
+		printing.POST("/jobs/:id/retry", handlerV1.RetryPrintJob)
+		printing.POST("/order/:id", handlerV1.PrintOrder)
+	}
+
 	branch := v1.Group("/branch")
 	{
 		branch.POST("/", handlerV1.CreateBranch)
 		branch.GET("/list", handlerV1.GetBranches)
+		branch.GET("/load/list", handlerV1.GetBranchLoads)
 		branch.GET("/:id", handlerV1.GetBranch)
+		branch.GET("/:id/hours", handlerV1.GetBranchHours)
+		branch.GET("/:id/slots", handlerV1.GetDeliverySlots)
 		branch.PUT("/", handlerV1.UpdateBranch)
+		branch.PUT("/capacity", handlerV1.SaveBranchCapacity)
+		branch.PUT("/hours", handlerV1.SaveBranchHours)
 		branch.DELETE("/:id", handlerV1.DeleteBranch)
 	}
 
+	branchStaff := v1.Group("/branch-staff")
+	{
+		branchStaff.PUT("/", handlerV1.SaveBranchStaff)
+		branchStaff.GET("/list", handlerV1.GetBranchStaffList)
+		branchStaff.GET("/:user_id", handlerV1.GetBranchStaff)
+		branchStaff.DELETE("/:user_id", handlerV1.DeleteBranchStaff)
+	}
+
+	restaurant := v1.Group("/restaurant")
+	{
+		restaurant.POST("/", handlerV1.CreateRestaurant)
+		restaurant.GET("/list", handlerV1.GetRestaurants)
+		restaurant.GET("/:id", handlerV1.GetRestaurant)
+		restaurant.PUT("/", handlerV1.UpdateRestaurant)
+		restaurant.DELETE("/:id", handlerV1.DeleteRestaurant)
+	}
+
+	restaurantAdmin := v1.Group("/restaurant-admin")
+	{
+		restaurantAdmin.PUT("/", handlerV1.SaveRestaurantAdmin)
+		restaurantAdmin.GET("/list", handlerV1.GetRestaurantAdmins)
+		restaurantAdmin.GET("/:user_id", handlerV1.GetRestaurantAdmin)
+		restaurantAdmin.DELETE("/:user_id", handlerV1.DeleteRestaurantAdmin)
+	}
+
 	user_location := v1.Group("/user/location")
 	{
 		user_location.POST("/", handlerV1.CreateUserLocation)
@@ -152,15 +274,53 @@ func NewRouter(engine *gin.Engine, l *logger.Logger, config *config.Config, useC
 	order := v1.Group("/order")
 	{
 		order.POST("/", handlerV1.CreateOrder)
+		order.POST("/check", handlerV1.CheckCart)
 		order.GET("/list", handlerV1.GetOrders)
 		order.GET("/:id", handlerV1.GetOrder)
 		order.PUT("/", handlerV1.UpdateOrder)
 		order.DELETE("/:id", handlerV1.DeleteOrder)
 		order.GET("/bybranch", handlerV1.GetBranchOrders)
+		order.GET("/:id/receipt", handlerV1.GetReceipt)
+		order.GET("/:id/receipt.pdf", handlerV1.GetReceiptPDF)
+		order.POST("/:id/receipt", handlerV1.IssueReceipt)
 	}
 
+	policy := v1.Group("/policy")
+	{
+		policy.POST("/", handlerV1.CreatePolicy)
+		policy.GET("/list", handlerV1.GetPolicies)
+		policy.DELETE("/:id", handlerV1.DeletePolicy)
+		policy.POST("/role", handlerV1.CreateRoleInheritance)
+		policy.GET("/role/list", handlerV1.GetRoleInheritances)
+		policy.DELETE("/role/:id", handlerV1.DeleteRoleInheritance)
+	}
+
+	apiKey := v1.Group("/api-key")
+	{
+		apiKey.POST("/", handlerV1.CreateAPIKey)
+		apiKey.GET("/list", handlerV1.GetAPIKeys)
+		apiKey.GET("/:id", handlerV1.GetAPIKey)
+		apiKey.PUT("/", handlerV1.UpdateAPIKey)
+		apiKey.POST("/:id/rotate", handlerV1.RotateAPIKey)
+		apiKey.DELETE("/:id", handlerV1.RevokeAPIKey)
+	}
+
+	warnUnmatchedPolicies(engine, l, enforcer)
+
 	// courier := v1.Group("/courier")
 	// {
 	// 	courier.POST("/", handlerV1.AssignCourierToOrder)
 	// }
 }
+
+// warnUnmatchedPolicies logs the policies that point at routes which are not registered.
+func warnUnmatchedPolicies(engine *gin.Engine, l *logger.Logger, enforcer *rbac.Enforcer) {
+	var routes []rbac.Route
+	for _, route := range engine.Routes() {
+		routes = append(routes, rbac.Route{Method: route.Method, Path: route.Path})
+	}
+
+	for _, rule := range enforcer.Unmatched(routes) {
+		l.Warn("router - policy %s does not match any registered route", strings.Join(rule, ", "))
+	}
+}
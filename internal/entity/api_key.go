@@ -0,0 +1,40 @@
+package entity
+
+// APIKey authenticates a partner or service account (an aggregator, the POS)
+// without a JWT. It acts as UserID and may do what its Scopes (casbin roles) allow.
+type APIKey struct {
+	ID                string   `json:"id"`
+	Name              string   `json:"name"`
+	UserID            string   `json:"user_id"`
+	Prefix            string   `json:"prefix"`
+	KeyHash           string   `json:"-"`
+	PreviousKeyHash   string   `json:"-"`
+	PreviousExpiresAt string   `json:"previous_expires_at"`
+	Scopes            []string `json:"scopes"`
+	// BranchIDs are the branches the key may act for, a printer key needs at least one.
+	BranchIDs []string `json:"branch_ids"`
+	// RateLimit is the number of requests allowed per minute.
+	RateLimit  int    `json:"rate_limit"`
+	IsActive   bool   `json:"is_active"`
+	ExpiresAt  string `json:"expires_at"`
+	LastUsedAt string `json:"last_used_at"`
+	CreatedBy  string `json:"created_by"`
+	CreatedAt  string `json:"created_at"`
+	UpdatedAt  string `json:"updated_at"`
+}
+
+type APIKeyList struct {
+	Items []APIKey `json:"api_keys"`
+	Count int      `json:"count"`
+}
+
+// APIKeySecret is returned once, when a key is created or rotated. Only its hash is stored.
+type APIKeySecret struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+type APIKeyRotateRequest struct {
+	// GraceMinutes keeps the old key working for a while so the partner can switch without downtime.
+	GraceMinutes int `json:"grace_minutes"`
+}
@@ -1,11 +1,12 @@
 package entity
 
 type Banner struct {
-	Id        string `json:"id"`
-	Title     string `json:"title"`
-	ImageUrl  string `json:"image_url"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
+	Id           string        `json:"id"`
+	RestaurantID string        `json:"restaurant_id"`
+	Title        string        `json:"title"`
+	Images       ImageVariants `json:"images"`
+	CreatedAt    string        `json:"created_at"`
+	UpdatedAt    string        `json:"updated_at"`
 }
 
 type BannerList struct {
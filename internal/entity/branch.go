@@ -1,14 +1,17 @@
 package entity
 
 type Branch struct {
-	Id        string `json:"id"`
-	Name      string `json:"name"`
-	Address   string `json:"address"`
-	Latitude  string `json:"latitude"`
-	Longitude string `json:"longitude"`
-	Phone     string `json:"phone"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
+	Id string `json:"id"`
+	// RestaurantID is the default restaurant when empty on create and can not
+	// be changed later.
+	RestaurantID string `json:"restaurant_id"`
+	Name         string `json:"name"`
+	Address      string `json:"address"`
+	Latitude     string `json:"latitude"`
+	Longitude    string `json:"longitude"`
+	Phone        string `json:"phone"`
+	CreatedAt    string `json:"created_at"`
+	UpdatedAt    string `json:"updated_at"`
 }
 
 type BranchSingleRequest struct {
@@ -0,0 +1,32 @@
+package entity
+
+// BranchCapacity is how much a branch can cook, a zero limit is no limit.
+// Active orders are confirmed or preparing, items are the cooked lines of the
+// orders taken in the last capacity window. A new order for a full branch is
+// handled by Overflow.
+type BranchCapacity struct {
+	BranchID          string `json:"branch_id"`
+	MaxActiveOrders   int    `json:"max_active_orders"`
+	MaxItemsPerWindow int    `json:"max_items_per_window"`
+	// MaxOrdersPerSlot limits the scheduled orders of a delivery slot.
+	MaxOrdersPerSlot int    `json:"max_orders_per_slot"`
+	Overflow         string `json:"overflow" enums:"extend_eta,reroute,reject" example:"extend_eta"`
+	UpdatedAt        string `json:"updated_at,omitempty"`
+}
+
+// BranchLoad is what a branch is cooking against its capacity.
+type BranchLoad struct {
+	BranchID     string         `json:"branch_id"`
+	BranchName   string         `json:"branch_name"`
+	RestaurantID string         `json:"restaurant_id"`
+	ActiveOrders int            `json:"active_orders"`
+	WindowItems  int            `json:"window_items"`
+	Capacity     BranchCapacity `json:"capacity"`
+	// Delay is how many minutes later a new order of one item would be ready.
+	Delay int `json:"delay"`
+}
+
+type BranchLoadList struct {
+	Items []BranchLoad `json:"items"`
+	Count int          `json:"count"`
+}
@@ -0,0 +1,22 @@
+package entity
+
+// BranchStaff is a user who works at branches. Managers also set product
+// availability and branch details, operators run orders, kitchen and printers.
+type BranchStaff struct {
+	UserID    string   `json:"user_id"`
+	FullName  string   `json:"full_name"`
+	Role      string   `json:"role" enums:"branch_manager,branch_operator"`
+	BranchIDs []string `json:"branch_ids"`
+}
+
+type BranchStaffList struct {
+	Items []BranchStaff `json:"items"`
+	Count int           `json:"count"`
+}
+
+// BranchStaffRequest lists the staff, only those of BranchID when it is set.
+type BranchStaffRequest struct {
+	BranchID string
+	Page     int
+	Limit    int
+}
@@ -0,0 +1,35 @@
+package entity
+
+import "github.com/Akrom0181/Food-Delivery/pkg/money"
+
+// Bundle is a combo product made of slots. A slot with one option is a fixed
+// item, with more options the customer chooses one. The price of the bundle
+// product is the bundle price, the price delta of a chosen option is added.
+type Bundle struct {
+	ProductID string       `json:"product_id"`
+	Slots     []BundleSlot `json:"slots"`
+}
+
+type BundleSlot struct {
+	ID        string             `json:"id"`
+	Name      string             `json:"name"`
+	Quantity  int                `json:"quantity"`
+	SortOrder int                `json:"sort_order"`
+	Options   []BundleSlotOption `json:"options"`
+}
+
+type BundleSlotOption struct {
+	ProductID  string       `json:"product_id"`
+	Name       string       `json:"name"`
+	PriceDelta money.Amount `json:"price_delta" swaggertype:"number"`
+	// IsDefault is chosen when the customer does not choose.
+	IsDefault bool `json:"is_default"`
+	// IsAvailable is false when the product is inactive or sold out at the branch.
+	IsAvailable bool `json:"is_available"`
+}
+
+type BundleRequest struct {
+	ProductID string
+	BranchID  string
+	Locales   []string
+}
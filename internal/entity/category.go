@@ -1,18 +1,45 @@
 package entity
 
 type Category struct {
-	Id        string `json:"id"`
-	Name      string `json:"name"`
+	Id string `json:"id"`
+	// RestaurantID is the default restaurant when empty on create and can not
+	// be changed later, subcategories are in the restaurant of their parent.
+	RestaurantID string `json:"restaurant_id"`
+	// ParentID is empty for top level categories.
+	ParentID string        `json:"parent_id"`
+	Name     string        `json:"name"`
+	Images   ImageVariants `json:"images"`
+	// KitchenStationID routes the products to a kitchen station, subcategories
+	// without one use the station of their parent.
+	KitchenStationID string `json:"kitchen_station_id"`
+	// SortOrder positions the category among its siblings. It and the flags
+	// are left unchanged when missing from an update. Hidden categories are
+	// left out of the menu along with everything under them, inactive ones
+	// out of search too.
+	SortOrder *int   `json:"sort_order,omitempty"`
+	IsActive  *bool  `json:"is_active,omitempty"`
+	IsHidden  *bool  `json:"is_hidden,omitempty"`
 	CreatedAt string `json:"created_at"`
 	UpdatedAt string `json:"updated_at"`
 }
 
 type CategorySingleRequest struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Locales []string `json:"-"`
 }
 
 type CategoryList struct {
 	Items []Category `json:"items"`
 	Count int        `json:"count"`
 }
+
+type SortItem struct {
+	ID        string `json:"id"`
+	SortOrder int    `json:"sort_order"`
+}
+
+// SortRequest sets the position of several categories or products at once.
+type SortRequest struct {
+	Items []SortItem `json:"items"`
+}
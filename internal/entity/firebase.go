@@ -9,6 +9,9 @@ func (u Url) QueryEscape(filename string) any {
 	panic("unimplemented")
 }
 
+// ImageVariants maps a variant name (thumbnail, card, full) to the url of the image.
+type ImageVariants map[string]string
+
 type MultipleFileUploadResponse struct {
 	Url []*Url `json:"url"`
 }
@@ -0,0 +1,69 @@
+package entity
+
+// KitchenStation is where a kind of product is made, e.g. grill, fryer or
+// drinks. Categories route their products to a station.
+type KitchenStation struct {
+	ID        string `json:"id"`
+	Name      string `json:"name" example:"grill"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type KitchenStationList struct {
+	Items []KitchenStation `json:"items"`
+	Count int              `json:"count"`
+}
+
+// KitchenTicket is an order on the kitchen display of its branch. ReadyETA is
+// when the kitchen expects to have all of its items ready.
+type KitchenTicket struct {
+	OrderID        string        `json:"order_id"`
+	BranchID       string        `json:"branch_id"`
+	Status         string        `json:"status"`
+	DeliveryStatus string        `json:"delivery_status"`
+	Items          []KitchenItem `json:"items"`
+	ReadyETA       string        `json:"ready_eta,omitempty"`
+	BumpedAt       string        `json:"bumped_at,omitempty"`
+	CreatedAt      string        `json:"created_at"`
+}
+
+type KitchenTicketList struct {
+	Items []KitchenTicket `json:"items"`
+	Count int             `json:"count"`
+}
+
+// KitchenItem is an order line the kitchen makes. ParentItemID is the line of
+// the bundle it is a component of.
+type KitchenItem struct {
+	ID           string `json:"id"`
+	ProductID    string `json:"product_id"`
+	Name         string `json:"name"`
+	Quantity     int    `json:"quantity"`
+	ParentItemID string `json:"parent_item_id,omitempty"`
+	StationID    string `json:"station_id,omitempty"`
+	StationName  string `json:"station_name,omitempty"`
+	Status       string `json:"status" enums:"queued,cooking,ready"`
+	CookingAt    string `json:"cooking_at,omitempty"`
+	ReadyAt      string `json:"ready_at,omitempty"`
+}
+
+// KitchenTicketsRequest selects the tickets of a branch, only the items of
+// StationID when it is set. Bumped lists the latest bumped orders instead of
+// the open ones.
+type KitchenTicketsRequest struct {
+	BranchID  string
+	StationID string
+	OrderID   string
+	Bumped    bool
+	Limit     int
+	// Locales translate the product names, most preferred first.
+	Locales []string
+}
+
+// KitchenItemStatus moves an order line to Status. Moving it back recalls it.
+type KitchenItemStatus struct {
+	ID     string `json:"id"`
+	Status string `json:"status" enums:"queued,cooking,ready"`
+	// Locales translate the product names of the ticket returned.
+	Locales []string `json:"-"`
+}
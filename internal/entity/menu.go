@@ -0,0 +1,80 @@
+package entity
+
+import "github.com/Akrom0181/Food-Delivery/pkg/money"
+
+type MenuRequest struct {
+	RestaurantID string
+	BranchID     string
+	Locales      []string
+	// Dietary comes from the query or, for signed in users, from the allergens
+	// excluded in their profile.
+	Dietary DietaryFilter
+	// ChangedSince asks for the changes after a catalog version instead of the whole menu.
+	ChangedSince int64
+}
+
+// Menu is the tree of active, visible categories with their products in
+// display order, and the banners. Version is the catalog version it was built
+// at, pass it as changed_since to get what changed later.
+type Menu struct {
+	Version      int64          `json:"version"`
+	RestaurantID string         `json:"restaurant_id"`
+	BranchID     string         `json:"branch_id,omitempty"`
+	Categories   []MenuCategory `json:"categories"`
+	Banners      []MenuBanner   `json:"banners"`
+}
+
+type MenuCategory struct {
+	Id            string         `json:"id"`
+	ParentID      string         `json:"parent_id"`
+	Name          string         `json:"name"`
+	Images        ImageVariants  `json:"images"`
+	SortOrder     int            `json:"sort_order"`
+	Products      []MenuProduct  `json:"products,omitempty"`
+	Subcategories []MenuCategory `json:"subcategories,omitempty"`
+}
+
+type MenuProduct struct {
+	Id          string        `json:"id"`
+	CategoryID  string        `json:"category_id"`
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	Price       money.Amount  `json:"price" swaggertype:"number"`
+	Images      ImageVariants `json:"images"`
+	SortOrder   int           `json:"sort_order"`
+	Nutrition   *Nutrition    `json:"nutrition,omitempty"`
+	Allergens   []string      `json:"allergens"`
+	DietaryTags []string      `json:"dietary_tags"`
+	SpicyLevel  int           `json:"spicy_level"`
+	// IsBundle tells to get the slots of the product from /product/{id}/bundle.
+	IsBundle bool `json:"is_bundle"`
+	// IsAvailable is false when the product, or every option of a bundle
+	// slot, is sold out at the branch.
+	IsAvailable bool `json:"is_available"`
+}
+
+type MenuBanner struct {
+	Id     string        `json:"id"`
+	Title  string        `json:"title"`
+	Images ImageVariants `json:"images"`
+}
+
+// MenuChanges lists what changed after a catalog version. Categories and
+// products are flat, without their children. Removed holds the ids of what
+// was deleted or is no longer shown, it may name rows of other restaurants
+// the client never had.
+type MenuChanges struct {
+	Version      int64          `json:"version"`
+	RestaurantID string         `json:"restaurant_id"`
+	BranchID     string         `json:"branch_id,omitempty"`
+	Categories   []MenuCategory `json:"categories"`
+	Products     []MenuProduct  `json:"products"`
+	Banners      []MenuBanner   `json:"banners"`
+	Removed      MenuRemoved    `json:"removed"`
+}
+
+type MenuRemoved struct {
+	Categories []string `json:"categories"`
+	Products   []string `json:"products"`
+	Banners    []string `json:"banners"`
+}
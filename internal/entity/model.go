@@ -3,6 +3,8 @@ package entity
 type Id struct {
 	ID   string `json:"id"`
 	Slug string `json:"slug"`
+	// Locales translate catalog content, most preferred first.
+	Locales []string `json:"-"`
 }
 
 type OrderBy struct {
@@ -12,7 +14,7 @@ type OrderBy struct {
 
 type Filter struct {
 	Column string `json:"column"`
-	Type   string `json:"type"` // eq, ne, gt, gte, lt, lte, search
+	Type   string `json:"type"` // eq, ne, gt, gte, lt, lte, search, contains, excludes
 	Value  string `json:"value"`
 }
 
@@ -21,6 +23,7 @@ type GetListFilter struct {
 	Limit   int       `json:"limit"`
 	Filters []Filter  `json:"filters"`
 	OrderBy []OrderBy `json:"order_by"`
+	Locales []string  `json:"-"`
 }
 
 type UpdateFieldItem struct {
@@ -1,10 +1,14 @@
 package entity
 
+import "github.com/Akrom0181/Food-Delivery/pkg/money"
+
 type Order struct {
 	ID             string       `json:"id"`
 	UserID         string       `json:"user_id"`
-	TotalPrice     float64      `json:"total_price"`
-	Status         string       `json:"status" enums:"pending, confirmed, cancelled, preparing, picked_up, delivered" example:"pending"`
+	TotalPrice     money.Amount `json:"total_price" swaggertype:"number"`
+	Currency       string       `json:"currency" example:"UZS"`
+	Tax            money.Amount `json:"tax" swaggertype:"number"`
+	Status         string       `json:"status" enums:"scheduled, pending, confirmed, cancelled, preparing, picked_up, delivered" example:"pending"`
 	DeliveryStatus string       `json:"delivery_status" enums:"olib ketish,yetkazib berish" example:"yetkazib berish"`
 	Address        string       `json:"address"`
 	Floor          int          `json:"floor"`
@@ -15,8 +19,12 @@ type Order struct {
 	OrderItems     []OrderItems `json:"order_items"`
 	BranchId       string       `json:"branch_id"`
 	CourierId      string       `json:"courier_id"`
-	CreatedAt      string       `json:"created_at"`
-	UpdatedAt      string       `json:"updated_at"`
+	ReadyETA       string       `json:"ready_eta,omitempty"`
+	// ScheduledAt is the start of the slot a scheduled order is delivered or
+	// picked up in, the order is ASAP when it is empty.
+	ScheduledAt string `json:"scheduled_at,omitempty" example:"2026-10-20T13:00:00+05:00"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
 }
 
 type OrderList struct {
@@ -25,12 +33,55 @@ type OrderList struct {
 }
 
 type OrderItems struct {
-	Id         string  `json:"id"`
-	OrderId    string  `json:"order_id"`
-	ProductId  string  `json:"product_id"`
-	TotalPrice float64 `json:"total_price"`
-	Quantity   int     `json:"quantity"`
-	Price      float64 `json:"price"`
-	CreatedAt  string  `json:"created_at"`
-	UpdatedAt  string  `json:"updated_at"`
+	Id         string       `json:"id"`
+	OrderId    string       `json:"order_id"`
+	ProductId  string       `json:"product_id"`
+	TotalPrice money.Amount `json:"total_price" swaggertype:"number"`
+	Quantity   int          `json:"quantity"`
+	Price      money.Amount `json:"price" swaggertype:"number"`
+	// PriceVersionID is the entry of the price history Price was taken from.
+	PriceVersionID string `json:"price_version_id,omitempty"`
+	// Discount is taken off Price * Quantity by AppliedRules, TotalPrice is net of it.
+	Discount     money.Amount  `json:"discount" swaggertype:"number"`
+	AppliedRules []AppliedRule `json:"applied_rules"`
+	// MxikCode is the product code on the receipt, Tax the VAT at TaxRate included in TotalPrice.
+	MxikCode string       `json:"mxik_code"`
+	TaxRate  float64      `json:"tax_rate"`
+	Tax      money.Amount `json:"tax" swaggertype:"number"`
+	// ProductName and PackageCode are kept as ordered for the receipt.
+	ProductName string `json:"-"`
+	PackageCode string `json:"-"`
+	// ParentItemID is the line of the bundle a component line belongs to.
+	// Component lines go to the kitchen, the bundle line carries the price.
+	ParentItemID string `json:"parent_item_id,omitempty"`
+	BundleSlotID string `json:"bundle_slot_id,omitempty"`
+	// Selections choose the options of the slots when the product is a bundle.
+	Selections []OrderItemSelection `json:"selections,omitempty"`
+	CreatedAt  string               `json:"created_at"`
+	UpdatedAt  string               `json:"updated_at"`
+}
+
+type OrderItemSelection struct {
+	SlotID    string `json:"slot_id"`
+	ProductID string `json:"product_id"`
+}
+
+// CartCheckRequest asks which items of a cart have ExcludeAllergens, the
+// allergens excluded in the profile of the user when empty.
+type CartCheckRequest struct {
+	Items            []OrderItems `json:"items"`
+	ExcludeAllergens []string     `json:"exclude_allergens"`
+}
+
+type CartCheck struct {
+	Warnings []AllergenWarning `json:"warnings"`
+}
+
+// AllergenWarning names the excluded allergens of a cart item. BundleID is
+// set when the product is a component of a bundle in the cart.
+type AllergenWarning struct {
+	ProductID string   `json:"product_id"`
+	Name      string   `json:"name"`
+	Allergens []string `json:"allergens"`
+	BundleID  string   `json:"bundle_id,omitempty"`
 }
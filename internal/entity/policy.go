@@ -0,0 +1,28 @@
+package entity
+
+// Policy is a casbin p rule: Subject (role) may call Action (methods regex) on Object (path pattern).
+type Policy struct {
+	ID        string `json:"id"`
+	Subject   string `json:"subject"`
+	Object    string `json:"object"`
+	Action    string `json:"action"`
+	CreatedAt string `json:"created_at"`
+}
+
+type PolicyList struct {
+	Items []Policy `json:"policies"`
+	Count int      `json:"count"`
+}
+
+// RoleInheritance is a casbin g rule: Role gets every permission of Parent.
+type RoleInheritance struct {
+	ID        string `json:"id"`
+	Role      string `json:"role"`
+	Parent    string `json:"parent"`
+	CreatedAt string `json:"created_at"`
+}
+
+type RoleInheritanceList struct {
+	Items []RoleInheritance `json:"roles"`
+	Count int               `json:"count"`
+}
@@ -0,0 +1,50 @@
+package entity
+
+import "github.com/Akrom0181/Food-Delivery/pkg/money"
+
+// PricingRule is a discount on order lines. Kind is percent (Percent off the
+// line), amount (Amount off every unit) or buy_get (of every BuyQuantity +
+// GetQuantity units GetQuantity are free). Empty ProductID, CategoryID and
+// BranchID match everything. DaysOfWeek (0 is Sunday) and the StartTime to
+// EndTime window ("15:04", local time) limit when it applies, a window ending
+// before it starts crosses midnight and a missing bound is midnight.
+//
+// Rules with a higher Priority come first, on a tie more specific ones: a
+// product before a category before everything, a branch before all branches.
+// The first matching rule applies. Only when it is Stackable the following
+// stackable rules apply too, each to what is left of the line.
+type PricingRule struct {
+	ID             string       `json:"id"`
+	Name           string       `json:"name"`
+	Kind           string       `json:"kind" enums:"percent,amount,buy_get"`
+	Percent        float64      `json:"percent"`
+	Amount         money.Amount `json:"amount" swaggertype:"number"`
+	BuyQuantity    int          `json:"buy_quantity"`
+	GetQuantity    int          `json:"get_quantity"`
+	ProductID      string       `json:"product_id"`
+	CategoryID     string       `json:"category_id"`
+	BranchID       string       `json:"branch_id"`
+	DaysOfWeek     []int        `json:"days_of_week"`
+	StartTime      string       `json:"start_time" example:"14:00"`
+	EndTime        string       `json:"end_time" example:"17:00"`
+	StartsAt       string       `json:"starts_at"`
+	EndsAt         string       `json:"ends_at"`
+	FirstOrderOnly bool         `json:"first_order_only"`
+	Priority       int          `json:"priority"`
+	Stackable      bool         `json:"stackable"`
+	IsActive       bool         `json:"is_active"`
+	CreatedAt      string       `json:"created_at"`
+	UpdatedAt      string       `json:"updated_at"`
+}
+
+type PricingRuleList struct {
+	Items []PricingRule `json:"items"`
+	Count int           `json:"count"`
+}
+
+// AppliedRule is a rule that took Discount off an order line.
+type AppliedRule struct {
+	RuleID   string       `json:"rule_id"`
+	Name     string       `json:"name"`
+	Discount money.Amount `json:"discount" swaggertype:"number"`
+}
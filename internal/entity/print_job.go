@@ -0,0 +1,46 @@
+package entity
+
+// PrintJob is an ESC/POS ticket for a printer of a branch: the customer copy
+// of an order or its kitchen copy for StationID. Data is only returned to the
+// printer agent that claims the job.
+type PrintJob struct {
+	ID        string `json:"id"`
+	BranchID  string `json:"branch_id"`
+	OrderID   string `json:"order_id"`
+	Kind      string `json:"kind" enums:"customer,kitchen"`
+	StationID string `json:"station_id,omitempty"`
+	Data      []byte `json:"data,omitempty" swaggertype:"string" format:"base64"`
+	Status    string `json:"status" enums:"pending,printing,printed,failed"`
+	Attempts  int    `json:"attempts"`
+	Error     string `json:"error"`
+	CreatedAt string `json:"created_at"`
+	PrintedAt string `json:"printed_at,omitempty"`
+}
+
+type PrintJobList struct {
+	Items []PrintJob `json:"items"`
+	Count int        `json:"count"`
+}
+
+// PrintJobClaim selects the jobs a printer agent prints: those of Kind and
+// StationID when they are set.
+type PrintJobClaim struct {
+	BranchID  string
+	Kind      string
+	StationID string
+}
+
+// PrintJobAck reports whether the claimed job was printed, Error says why not.
+type PrintJobAck struct {
+	ID      string `json:"-"`
+	Printed bool   `json:"printed"`
+	Error   string `json:"error"`
+}
+
+// PrintRequest prints the tickets of an order again: the customer or kitchen
+// copies, both when Kind is empty. Only the kitchen copy of StationID is
+// printed when it is set.
+type PrintRequest struct {
+	Kind      string `json:"kind" enums:"customer,kitchen"`
+	StationID string `json:"station_id"`
+}
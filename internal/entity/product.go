@@ -1,14 +1,58 @@
 package entity
 
+import "github.com/Akrom0181/Food-Delivery/pkg/money"
+
 type Product struct {
-	Id          string  `json:"id"`
-	CategoryId  string  `json:"category_id"`
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
-	ImageUrl    string  `json:"image_url"`
-	CreatedAt   string  `json:"created_at"`
-	UpdatedAt   string  `json:"updated_at"`
+	Id         string `json:"id"`
+	CategoryId string `json:"category_id"`
+	// RestaurantID is read only, it is the restaurant of the category.
+	RestaurantID string        `json:"restaurant_id"`
+	Name         string        `json:"name"`
+	Description  string        `json:"description"`
+	Price        money.Amount  `json:"price" swaggertype:"number"`
+	Images       ImageVariants `json:"images"`
+	// SortOrder positions the product within its category. It and the flags
+	// are left unchanged when missing from an update. Hidden products are
+	// left out of the menu, inactive ones out of search too.
+	SortOrder *int  `json:"sort_order,omitempty"`
+	IsActive  *bool `json:"is_active,omitempty"`
+	IsHidden  *bool `json:"is_hidden,omitempty"`
+	// IsBundle is set by saving the slots of the bundle.
+	IsBundle bool `json:"is_bundle"`
+	// PriceVersionID is the entry of the price history the price comes from.
+	PriceVersionID string `json:"price_version_id"`
+	// Nutrition, allergens, dietary tags and the spicy level are left
+	// unchanged when missing from an update too.
+	Nutrition   *Nutrition `json:"nutrition,omitempty"`
+	Allergens   []string   `json:"allergens"`
+	DietaryTags []string   `json:"dietary_tags"`
+	SpicyLevel  *int       `json:"spicy_level,omitempty"`
+	// TaxCategoryID, MxikCode and PackageCode go on the fiscal receipt, they
+	// are left unchanged when empty in an update. TaxRate is read only.
+	TaxCategoryID string  `json:"tax_category_id"`
+	TaxRate       float64 `json:"tax_rate"`
+	MxikCode      string  `json:"mxik_code" example:"10202001001000000"`
+	PackageCode   string  `json:"package_code"`
+	CreatedAt     string  `json:"created_at"`
+	UpdatedAt     string  `json:"updated_at"`
+}
+
+// Nutrition is per portion.
+type Nutrition struct {
+	Kcal          float64 `json:"kcal"`
+	Protein       float64 `json:"protein"`
+	Fat           float64 `json:"fat"`
+	Carbohydrates float64 `json:"carbohydrates"`
+	// PortionWeight is in grams.
+	PortionWeight int `json:"portion_weight"`
+}
+
+// DietaryFilter leaves out products with any of ExcludeAllergens, without all
+// of DietaryTags or spicier than MaxSpicyLevel.
+type DietaryFilter struct {
+	ExcludeAllergens []string
+	DietaryTags      []string
+	MaxSpicyLevel    *int
 }
 
 type ProductSingleRequest struct {
@@ -20,3 +64,69 @@ type ProductList struct {
 	Items []Product `json:"items"`
 	Count int       `json:"count"`
 }
+
+// ProductSearchRequest -. Terms are the folded words of the query.
+type ProductSearchRequest struct {
+	Terms        []string
+	CategoryID   string
+	RestaurantID string
+	BranchID     string
+	PriceMin     *money.Amount
+	PriceMax     *money.Amount
+	Dietary      DietaryFilter
+	Page         int
+	Limit        int
+	Locales      []string
+}
+
+type ProductSearchHit struct {
+	Product
+	CategoryName string  `json:"category_name"`
+	Rank         float64 `json:"rank"`
+	// Highlight has name and description HTML escaped, with the matched words wrapped in <mark>.
+	Highlight ProductHighlight `json:"highlight"`
+}
+
+type ProductHighlight struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type ProductSearchResult struct {
+	Items []ProductSearchHit `json:"items"`
+	Count int                `json:"count"`
+}
+
+// Suggestion is an autocomplete entry, Type is product or category.
+type Suggestion struct {
+	ID   string `json:"id"`
+	Text string `json:"text"`
+	Type string `json:"type"`
+}
+
+type SuggestionList struct {
+	Items []Suggestion `json:"items"`
+}
+
+type ProductAvailability struct {
+	ProductID   string `json:"product_id"`
+	BranchID    string `json:"branch_id"`
+	IsAvailable bool   `json:"is_available"`
+}
+
+// PriceVersion is an entry of the price history of a product. Status is
+// scheduled until EffectiveAt, then current until the next price, then past.
+type PriceVersion struct {
+	ID          string       `json:"id"`
+	ProductID   string       `json:"product_id"`
+	Price       money.Amount `json:"price" swaggertype:"number"`
+	EffectiveAt string       `json:"effective_at"`
+	AppliedAt   string       `json:"applied_at,omitempty"`
+	Status      string       `json:"status" enums:"scheduled,current,past"`
+	CreatedBy   string       `json:"created_by,omitempty"`
+	CreatedAt   string       `json:"created_at"`
+}
+
+type PriceHistory struct {
+	Items []PriceVersion `json:"items"`
+}
@@ -0,0 +1,53 @@
+package entity
+
+// Restaurant is a brand on the marketplace, it owns its branches, categories,
+// products and banners. Inactive restaurants are left out of the listing.
+type Restaurant struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Slug        string `json:"slug" example:"plov-house"`
+	Description string `json:"description"`
+	IsActive    *bool  `json:"is_active,omitempty"`
+	// NearestBranchID and Distance, in meters, are set when the list is
+	// filtered by a location.
+	NearestBranchID string  `json:"nearest_branch_id,omitempty"`
+	Distance        float64 `json:"distance,omitempty"`
+	CreatedAt       string  `json:"created_at"`
+	UpdatedAt       string  `json:"updated_at"`
+}
+
+type RestaurantList struct {
+	Items []Restaurant `json:"items"`
+	Count int          `json:"count"`
+}
+
+// RestaurantRequest lists restaurants matching Search. With a location only
+// active restaurants with a branch delivering there are listed, nearest first.
+type RestaurantRequest struct {
+	Search     string
+	Latitude   *float64
+	Longitude  *float64
+	ActiveOnly bool
+	Page       int
+	Limit      int
+}
+
+// RestaurantAdmin is a user who manages the catalog of one restaurant.
+type RestaurantAdmin struct {
+	UserID       string `json:"user_id"`
+	FullName     string `json:"full_name"`
+	RestaurantID string `json:"restaurant_id"`
+}
+
+type RestaurantAdminList struct {
+	Items []RestaurantAdmin `json:"items"`
+	Count int               `json:"count"`
+}
+
+// RestaurantAdminRequest lists the restaurant admins, only those of
+// RestaurantID when it is set.
+type RestaurantAdminRequest struct {
+	RestaurantID string
+	Page         int
+	Limit        int
+}
@@ -0,0 +1,30 @@
+package entity
+
+// BranchHours is when a branch is open on a weekday, 0 is Sunday, in local
+// time. ClosesAt may be 24:00.
+type BranchHours struct {
+	Weekday  int    `json:"weekday" example:"1"`
+	OpensAt  string `json:"opens_at" example:"09:00"`
+	ClosesAt string `json:"closes_at" example:"23:00"`
+}
+
+// BranchHoursList is the week of a branch. A branch without hours is open
+// around the clock, one with hours is closed on the weekdays it has none for.
+type BranchHoursList struct {
+	BranchID string        `json:"branch_id"`
+	Hours    []BranchHours `json:"hours"`
+}
+
+// DeliverySlot is a time a scheduled order can be delivered or picked up in.
+type DeliverySlot struct {
+	StartsAt string `json:"starts_at" example:"2026-10-20T13:00:00+05:00"`
+	EndsAt   string `json:"ends_at" example:"2026-10-20T13:30:00+05:00"`
+	// Remaining is how many more orders the slot takes, unset when unlimited.
+	Remaining *int `json:"remaining,omitempty"`
+}
+
+type DeliverySlotList struct {
+	BranchID string         `json:"branch_id"`
+	Date     string         `json:"date" example:"2026-10-20"`
+	Items    []DeliverySlot `json:"items"`
+}
@@ -0,0 +1,53 @@
+package entity
+
+import "github.com/Akrom0181/Food-Delivery/pkg/money"
+
+// TaxCategory is a VAT rate products are sold at. Prices include the tax.
+type TaxCategory struct {
+	ID        string  `json:"id"`
+	Name      string  `json:"name"`
+	Rate      float64 `json:"rate" example:"12"`
+	CreatedAt string  `json:"created_at"`
+	UpdatedAt string  `json:"updated_at"`
+}
+
+type TaxCategoryList struct {
+	Items []TaxCategory `json:"items"`
+	Count int           `json:"count"`
+}
+
+// Receipt is the fiscal receipt of a delivered order. Number counts the
+// receipts of the branch. FiscalSign, TerminalID and QRURL are set once the
+// fiscal provider registered it.
+type Receipt struct {
+	ID           string        `json:"id"`
+	OrderID      string        `json:"order_id"`
+	BranchID     string        `json:"branch_id"`
+	Number       int           `json:"number"`
+	Items        []ReceiptItem `json:"items"`
+	Total        money.Amount  `json:"total" swaggertype:"number"`
+	Tax          money.Amount  `json:"tax" swaggertype:"number"`
+	Currency     string        `json:"currency"`
+	Status       string        `json:"status" enums:"pending,registered,failed"`
+	TerminalID   string        `json:"terminal_id"`
+	FiscalSign   string        `json:"fiscal_sign"`
+	QRURL        string        `json:"qr_url"`
+	Error        string        `json:"error,omitempty"`
+	Attempts     int           `json:"attempts"`
+	CreatedAt    string        `json:"created_at"`
+	RegisteredAt string        `json:"registered_at,omitempty"`
+}
+
+// ReceiptItem is an order line as printed on the receipt.
+type ReceiptItem struct {
+	ProductID   string       `json:"product_id"`
+	Name        string       `json:"name"`
+	MxikCode    string       `json:"mxik_code"`
+	PackageCode string       `json:"package_code"`
+	Quantity    int          `json:"quantity"`
+	Price       money.Amount `json:"price" swaggertype:"number"`
+	Discount    money.Amount `json:"discount" swaggertype:"number"`
+	Total       money.Amount `json:"total" swaggertype:"number"`
+	TaxRate     float64      `json:"tax_rate"`
+	Tax         money.Amount `json:"tax" swaggertype:"number"`
+}
@@ -0,0 +1,16 @@
+package entity
+
+// Translation is catalog content in a locale other than the default one.
+// Products have a name and description, categories a name and banners a title.
+type Translation struct {
+	Locale      string `json:"locale"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Title       string `json:"title,omitempty"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+type TranslationList struct {
+	Items []Translation `json:"items"`
+}
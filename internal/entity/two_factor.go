@@ -0,0 +1,49 @@
+package entity
+
+type UserTwoFactor struct {
+	UserID    string `json:"user_id"`
+	Secret    string `json:"-"`
+	IsEnabled bool   `json:"is_enabled"`
+	EnabledAt string `json:"enabled_at"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type RecoveryCode struct {
+	ID        string `json:"id"`
+	UserID    string `json:"user_id"`
+	CodeHash  string `json:"-"`
+	UsedAt    string `json:"used_at"`
+	CreatedAt string `json:"created_at"`
+}
+
+// TwoFactorChallenge is returned by login instead of a token when a second step is needed.
+type TwoFactorChallenge struct {
+	TwoFactorRequired bool   `json:"two_factor_required"`
+	SetupRequired     bool   `json:"setup_required"`
+	Token             string `json:"two_factor_token"`
+	ExpiresIn         int    `json:"expires_in"`
+}
+
+type TwoFactorSetupRequest struct {
+	Token string `json:"two_factor_token"`
+}
+
+type TwoFactorSetupResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type TwoFactorVerifyRequest struct {
+	Token        string `json:"two_factor_token"`
+	Code         string `json:"code"`
+	RecoveryCode string `json:"recovery_code"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
@@ -0,0 +1,26 @@
+package entity
+
+// Upload is an object in file storage.
+type Upload struct {
+	Key         string `json:"key"`
+	URL         string `json:"url"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	OwnerID     string `json:"owner_id"`
+	OrphanedAt  string `json:"orphaned_at"`
+	CreatedAt   string `json:"created_at"`
+}
+
+// StorageUsage is the storage used by the objects referenced by one entity
+// type. Objects nothing references are reported as "unreferenced".
+type StorageUsage struct {
+	EntityType string `json:"entity_type"`
+	Objects    int    `json:"objects"`
+	Bytes      int64  `json:"bytes"`
+}
+
+type StorageReport struct {
+	Items        []StorageUsage `json:"items"`
+	TotalObjects int            `json:"total_objects"`
+	TotalBytes   int64          `json:"total_bytes"`
+}
@@ -0,0 +1,42 @@
+package entity
+
+// UserIdentity links an external (social login) account to a user.
+type UserIdentity struct {
+	ID        string `json:"id"`
+	UserID    string `json:"user_id"`
+	Provider  string `json:"provider"`
+	Subject   string `json:"subject"`
+	Email     string `json:"email"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type UserIdentitySingleRequest struct {
+	Provider string `json:"provider"`
+	Subject  string `json:"subject"`
+}
+
+type OAuthStartResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+	State            string `json:"state"`
+}
+
+// OAuthCallbackRequest is what the provider sends back to the redirect URL.
+type OAuthCallbackRequest struct {
+	Code             string `json:"code" form:"code"`
+	State            string `json:"state" form:"state"`
+	Error            string `json:"error" form:"error"`
+	ErrorDescription string `json:"error_description" form:"error_description"`
+}
+
+// TelegramLoginRequest is the payload of the Telegram login widget.
+type TelegramLoginRequest struct {
+	ID        int64  `json:"id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Username  string `json:"username"`
+	PhotoURL  string `json:"photo_url"`
+	AuthDate  int64  `json:"auth_date"`
+	Hash      string `json:"hash"`
+	Platform  string `json:"platform"`
+}
@@ -0,0 +1,245 @@
+// Package kitchen tells the kitchen displays and printer agents connected to
+// this instance which orders of their branch changed. Changes come from
+// postgres NOTIFY, so they see changes made through any replica.
+package kitchen
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// Channels the broker listens on.
+const (
+	// ChannelOrders has the orders on the kitchen display that changed.
+	ChannelOrders = "kitchen"
+	// ChannelPrintJobs has the orders with print jobs waiting.
+	ChannelPrintJobs = "print_job"
+)
+
+var channels = []string{ChannelOrders, ChannelPrintJobs}
+
+const (
+	_reconnectDelay = 5 * time.Second
+	_listenTimeout  = 10 * time.Second
+)
+
+type event struct {
+	BranchID string `json:"branch_id"`
+	OrderID  string `json:"order_id"`
+}
+
+// Broker listens on a dedicated connection and hands the changed orders to
+// the subscriptions of their channel and branch.
+type Broker struct {
+	url     string
+	onError func(error)
+
+	mu   sync.Mutex
+	subs map[subKey]map[*Subscription]struct{}
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewBroker starts listening.
+func NewBroker(url string, onError func(error)) *Broker {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	b := &Broker{
+		url:     url,
+		onError: onError,
+		subs:    map[subKey]map[*Subscription]struct{}{},
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+
+	go b.listen(ctx)
+
+	return b
+}
+
+type subKey struct {
+	channel  string
+	branchID string
+}
+
+// Subscribe returns the changes of the orders of a branch on channel until it
+// is closed.
+func (b *Broker) Subscribe(channel, branchID string) *Subscription {
+	s := &Subscription{
+		broker:  b,
+		key:     subKey{channel: channel, branchID: branchID},
+		pending: map[string]struct{}{},
+		ready:   make(chan struct{}, 1),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.subs[s.key] == nil {
+		b.subs[s.key] = map[*Subscription]struct{}{}
+	}
+	b.subs[s.key][s] = struct{}{}
+
+	return s
+}
+
+// Close stops listening.
+func (b *Broker) Close() {
+	b.cancel()
+	<-b.done
+}
+
+func (b *Broker) unsubscribe(s *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.subs[s.key], s)
+	if len(b.subs[s.key]) == 0 {
+		delete(b.subs, s.key)
+	}
+}
+
+func (b *Broker) listen(ctx context.Context) {
+	defer close(b.done)
+
+	for {
+		err := b.listenOnce(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err != nil && b.onError != nil {
+			b.onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(_reconnectDelay):
+		}
+
+		// changes made while the connection was down were missed
+		b.resyncAll()
+	}
+}
+
+func (b *Broker) listenOnce(ctx context.Context) error {
+	connectCtx, cancel := context.WithTimeout(ctx, _listenTimeout)
+	defer cancel()
+
+	conn, err := pgx.Connect(connectCtx, b.url)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	for _, channel := range channels {
+		if _, err = conn.Exec(connectCtx, "LISTEN "+channel); err != nil {
+			return err
+		}
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var e event
+		if err = json.Unmarshal([]byte(notification.Payload), &e); err != nil {
+			if b.onError != nil {
+				b.onError(err)
+			}
+			continue
+		}
+
+		b.publish(notification.Channel, e)
+	}
+}
+
+func (b *Broker) publish(channel string, e event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for s := range b.subs[subKey{channel: channel, branchID: e.BranchID}] {
+		s.add(e.OrderID)
+	}
+}
+
+func (b *Broker) resyncAll() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, subs := range b.subs {
+		for s := range subs {
+			s.setResync()
+		}
+	}
+}
+
+// Subscription collects the orders of a branch that changed since they were
+// last taken. Changes of the same order are merged, so a slow reader never
+// blocks the broker and never misses an order.
+type Subscription struct {
+	broker *Broker
+	key    subKey
+
+	mu      sync.Mutex
+	pending map[string]struct{}
+	resync  bool
+	ready   chan struct{}
+}
+
+// Ready receives when there is something to take.
+func (s *Subscription) Ready() <-chan struct{} {
+	return s.ready
+}
+
+// Take returns the ids of the orders that changed. Resync is set when changes
+// may have been missed and the whole display has to be loaded again.
+func (s *Subscription) Take() (orderIDs []string, resync bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id := range s.pending {
+		orderIDs = append(orderIDs, id)
+	}
+	s.pending = map[string]struct{}{}
+
+	resync, s.resync = s.resync, false
+
+	return orderIDs, resync
+}
+
+// Close stops the subscription.
+func (s *Subscription) Close() {
+	s.broker.unsubscribe(s)
+}
+
+func (s *Subscription) add(orderID string) {
+	s.mu.Lock()
+	s.pending[orderID] = struct{}{}
+	s.mu.Unlock()
+
+	s.signal()
+}
+
+func (s *Subscription) setResync() {
+	s.mu.Lock()
+	s.resync = true
+	s.mu.Unlock()
+
+	s.signal()
+}
+
+func (s *Subscription) signal() {
+	select {
+	case s.ready <- struct{}{}:
+	default:
+	}
+}
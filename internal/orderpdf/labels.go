@@ -0,0 +1,93 @@
+package orderpdf
+
+import (
+	"fmt"
+	"html"
+
+	"github.com/Akrom0181/Food-Delivery/config"
+)
+
+var translations = map[string]map[string]string{
+	"uz": {
+		"receipt":       "Chek",
+		"order":         "Buyurtma",
+		"date":          "Sana",
+		"address":       "Manzil",
+		"item":          "Mahsulot",
+		"quantity":      "Soni",
+		"price":         "Narxi",
+		"discount":      "Chegirma",
+		"total":         "Jami",
+		"subtotal":      "Oraliq jami",
+		"vat":           "QQS",
+		"terminal":      "Fiskal modul",
+		"fiscal_sign":   "Fiskal belgi",
+		"email_subject": "Buyurtmangiz cheki",
+		"email_body":    "Buyurtmangiz uchun rahmat! Chek ilova qilingan.",
+	},
+	"ru": {
+		"receipt":       "Чек",
+		"order":         "Заказ",
+		"date":          "Дата",
+		"address":       "Адрес",
+		"item":          "Товар",
+		"quantity":      "Кол-во",
+		"price":         "Цена",
+		"discount":      "Скидка",
+		"total":         "Итого",
+		"subtotal":      "Подытог",
+		"vat":           "НДС",
+		"terminal":      "Фискальный модуль",
+		"fiscal_sign":   "Фискальный признак",
+		"email_subject": "Чек вашего заказа",
+		"email_body":    "Спасибо за заказ! Чек во вложении.",
+	},
+	"en": {
+		"receipt":       "Receipt",
+		"order":         "Order",
+		"date":          "Date",
+		"address":       "Address",
+		"item":          "Item",
+		"quantity":      "Qty",
+		"price":         "Price",
+		"discount":      "Discount",
+		"total":         "Total",
+		"subtotal":      "Subtotal",
+		"vat":           "VAT",
+		"terminal":      "Fiscal module",
+		"fiscal_sign":   "Fiscal sign",
+		"email_subject": "Receipt for your order",
+		"email_body":    "Thank you for your order! The receipt is attached.",
+	},
+}
+
+// labels returns the labels in locale, or in the default locale when there
+// is no translation.
+func labels(locale string) map[string]string {
+	if l, ok := translations[locale]; ok {
+		return l
+	}
+	return translations[config.DefaultLocale]
+}
+
+// Email returns the subject and HTML body of the email the receipt of doc is
+// attached to.
+func Email(doc Document) (subject, body string) {
+	l := labels(doc.Locale)
+
+	subject = l["email_subject"]
+	if doc.Receipt != nil {
+		subject = fmt.Sprintf("%s № %d", subject, doc.Receipt.Number)
+	}
+	body = fmt.Sprintf("<!DOCTYPE html>\n<html>\n<body>\n    <p>%s</p>\n</body>\n</html>\n", html.EscapeString(l["email_body"]))
+
+	return subject, body
+}
+
+// Filename is the name the PDF of doc is downloaded as.
+func Filename(doc Document) string {
+	if doc.Receipt != nil {
+		return fmt.Sprintf("receipt-%d.pdf", doc.Receipt.Number)
+	}
+	return "receipt-" + doc.Order.ID + ".pdf"
+}
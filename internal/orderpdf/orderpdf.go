@@ -0,0 +1,305 @@
+// Package orderpdf renders the receipt of an order as a printable A4 PDF in
+// the language of the customer.
+package orderpdf
+
+import (
+	"bytes"
+	"fmt"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/Akrom0181/Food-Delivery/pkg/money"
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/qr"
+	"github.com/go-pdf/fpdf"
+)
+
+// Document is what goes on the receipt. Names are the product names in
+// Locale by product id. Receipt is the fiscal receipt when there is one.
+type Document struct {
+	Order   entity.Order
+	Branch  entity.Branch
+	Receipt *entity.Receipt
+	Names   map[string]string
+	Locale  string
+}
+
+// Renderer draws documents with the DejaVu Sans fonts, which cover Latin and
+// Cyrillic. The fonts are read on the first render.
+type Renderer struct {
+	fontDir string
+
+	once    sync.Once
+	regular []byte
+	bold    []byte
+	err     error
+}
+
+// New -.
+func New(fontDir string) *Renderer {
+	return &Renderer{fontDir: fontDir}
+}
+
+func (r *Renderer) loadFonts() error {
+	r.once.Do(func() {
+		r.regular, r.err = os.ReadFile(filepath.Join(r.fontDir, "DejaVuSans.ttf"))
+		if r.err != nil {
+			return
+		}
+		r.bold, r.err = os.ReadFile(filepath.Join(r.fontDir, "DejaVuSans-Bold.ttf"))
+	})
+
+	return r.err
+}
+
+const (
+	margin     = 15.0
+	lineHeight = 6.0
+)
+
+// column widths of the item table: name, quantity, price, discount, total
+var columns = []float64{84, 16, 27, 27, 26}
+
+// Render writes the PDF of the document to w.
+func (r *Renderer) Render(w io.Writer, doc Document) error {
+	if err := r.loadFonts(); err != nil {
+		return fmt.Errorf("orderpdf - fonts: %w", err)
+	}
+
+	l := labels(doc.Locale)
+
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(margin, margin, margin)
+	pdf.SetAutoPageBreak(true, margin)
+	pdf.AddUTF8FontFromBytes("DejaVu", "", r.regular)
+	pdf.AddUTF8FontFromBytes("DejaVu", "B", r.bold)
+	pdf.SetTitle(l["receipt"], true)
+	pdf.AddPage()
+
+	// branch
+	pdf.SetFont("DejaVu", "B", 16)
+	pdf.CellFormat(0, 8, doc.Branch.Name, "", 1, "L", false, 0, "")
+	pdf.SetFont("DejaVu", "", 10)
+	for _, line := range []string{doc.Branch.Address, doc.Branch.Phone} {
+		if line != "" {
+			pdf.CellFormat(0, 5, line, "", 1, "L", false, 0, "")
+		}
+	}
+	pdf.Ln(4)
+
+	// title
+	title := l["receipt"]
+	if doc.Receipt != nil {
+		title = fmt.Sprintf("%s № %d", l["receipt"], doc.Receipt.Number)
+	}
+	pdf.SetFont("DejaVu", "B", 14)
+	pdf.CellFormat(0, 8, title, "", 1, "L", false, 0, "")
+
+	pdf.SetFont("DejaVu", "", 10)
+	field(pdf, l["order"], doc.Order.ID)
+	field(pdf, l["date"], localTime(doc.Order.CreatedAt))
+	if doc.Order.Address != "" {
+		field(pdf, l["address"], doc.Order.Address)
+	}
+	pdf.Ln(4)
+
+	// items
+	pdf.SetFont("DejaVu", "B", 10)
+	pdf.SetFillColor(238, 238, 238)
+	for i, header := range []string{l["item"], l["quantity"], l["price"], l["discount"], l["total"]} {
+		align := "R"
+		if i == 0 {
+			align = "L"
+		}
+		pdf.CellFormat(columns[i], 7, header, "B", 0, align, true, 0, "")
+	}
+	pdf.Ln(-1)
+
+	var subtotal, discount money.Amount
+	components := map[string][]entity.OrderItems{}
+	for _, item := range doc.Order.OrderItems {
+		if item.ParentItemID != "" {
+			components[item.ParentItemID] = append(components[item.ParentItemID], item)
+		}
+	}
+
+	for _, item := range doc.Order.OrderItems {
+		if item.ParentItemID != "" {
+			continue
+		}
+
+		subtotal += item.Price.Mul(item.Quantity)
+		discount += item.Discount
+
+		pdf.SetFont("DejaVu", "", 10)
+		pdf.SetTextColor(0, 0, 0)
+		pdf.CellFormat(columns[0], lineHeight, fit(pdf, doc.name(item.ProductId), columns[0]), "", 0, "L", false, 0, "")
+		pdf.CellFormat(columns[1], lineHeight, fmt.Sprint(item.Quantity), "", 0, "R", false, 0, "")
+		pdf.CellFormat(columns[2], lineHeight, amount(item.Price), "", 0, "R", false, 0, "")
+		pdf.CellFormat(columns[3], lineHeight, discountText(item.Discount), "", 0, "R", false, 0, "")
+		pdf.CellFormat(columns[4], lineHeight, amount(item.TotalPrice), "", 1, "R", false, 0, "")
+
+		// bundle components and the rules behind the discount
+		pdf.SetFont("DejaVu", "", 8)
+		pdf.SetTextColor(100, 100, 100)
+		for _, component := range components[item.Id] {
+			text := fmt.Sprintf("    + %s × %d", doc.name(component.ProductId), component.Quantity)
+			pdf.CellFormat(0, 4.5, fit(pdf, text, columns[0]), "", 1, "L", false, 0, "")
+		}
+		for _, rule := range item.AppliedRules {
+			text := fmt.Sprintf("    %s: −%s", rule.Name, amount(rule.Discount))
+			pdf.CellFormat(0, 4.5, fit(pdf, text, columns[0]), "", 1, "L", false, 0, "")
+		}
+	}
+
+	pdf.SetTextColor(0, 0, 0)
+	x, y := pdf.GetXY()
+	pdf.Line(x, y+1, x+sum(columns), y+1)
+	pdf.Ln(3)
+
+	// totals
+	pdf.SetFont("DejaVu", "", 10)
+	total(pdf, l["subtotal"], amount(subtotal))
+	if discount > 0 {
+		total(pdf, l["discount"], discountText(discount))
+	}
+	for _, tax := range taxes(doc.Order.OrderItems) {
+		total(pdf, fmt.Sprintf("%s %s%%", l["vat"], rate(tax.rate)), amount(tax.amount))
+	}
+	pdf.SetFont("DejaVu", "B", 12)
+	total(pdf, l["total"], amount(doc.Order.TotalPrice)+" "+doc.Order.Currency)
+	pdf.Ln(6)
+
+	// fiscal data
+	if receipt := doc.Receipt; receipt != nil && receipt.Status == "registered" {
+		pdf.SetFont("DejaVu", "", 9)
+		field(pdf, l["terminal"], receipt.TerminalID)
+		field(pdf, l["fiscal_sign"], receipt.FiscalSign)
+
+		if err := qrCode(pdf, receipt.QRURL); err != nil {
+			return err
+		}
+	}
+
+	return pdf.Output(w)
+}
+
+func (d Document) name(productID string) string {
+	if name, ok := d.Names[productID]; ok {
+		return name
+	}
+	return productID
+}
+
+func field(pdf *fpdf.Fpdf, label, value string) {
+	pdf.CellFormat(40, 5, label+":", "", 0, "L", false, 0, "")
+	pdf.CellFormat(0, 5, value, "", 1, "L", false, 0, "")
+}
+
+func total(pdf *fpdf.Fpdf, label, value string) {
+	width := sum(columns)
+	pdf.CellFormat(width-50, lineHeight, label, "", 0, "R", false, 0, "")
+	pdf.CellFormat(50, lineHeight, value, "", 1, "R", false, 0, "")
+}
+
+func qrCode(pdf *fpdf.Fpdf, url string) error {
+	if url == "" {
+		return nil
+	}
+
+	code, err := qr.Encode(url, qr.M, qr.Auto)
+	if err != nil {
+		return fmt.Errorf("orderpdf - qr: %w", err)
+	}
+	code, err = barcode.Scale(code, 300, 300)
+	if err != nil {
+		return fmt.Errorf("orderpdf - qr: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err = png.Encode(&buf, code); err != nil {
+		return fmt.Errorf("orderpdf - qr: %w", err)
+	}
+
+	options := fpdf.ImageOptions{ImageType: "PNG"}
+	pdf.RegisterImageOptionsReader("qr", options, &buf)
+	pdf.ImageOptions("qr", pdf.GetX(), pdf.GetY()+2, 35, 35, true, options, 0, url)
+
+	return pdf.Error()
+}
+
+// fit shortens text to the width of a column.
+func fit(pdf *fpdf.Fpdf, text string, width float64) string {
+	if pdf.GetStringWidth(text) <= width-2 {
+		return text
+	}
+
+	runes := []rune(text)
+	for len(runes) > 0 && pdf.GetStringWidth(string(runes)+"…") > width-2 {
+		runes = runes[:len(runes)-1]
+	}
+
+	return string(runes) + "…"
+}
+
+type taxLine struct {
+	rate   float64
+	amount money.Amount
+}
+
+// taxes sums the VAT of the lines by rate, lowest rate first.
+func taxes(items []entity.OrderItems) []taxLine {
+	byRate := map[float64]money.Amount{}
+	for _, item := range items {
+		if item.TaxRate > 0 {
+			byRate[item.TaxRate] += item.Tax
+		}
+	}
+
+	var lines []taxLine
+	for r, a := range byRate {
+		lines = append(lines, taxLine{rate: r, amount: a})
+	}
+	sort.Slice(lines, func(i, j int) bool { return lines[i].rate < lines[j].rate })
+
+	return lines
+}
+
+func amount(a money.Amount) string {
+	return a.Grouped()
+}
+
+func discountText(a money.Amount) string {
+	if a == 0 {
+		return ""
+	}
+	return "−" + amount(a)
+}
+
+func rate(r float64) string {
+	return strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%.2f", r), "0"), ".")
+}
+
+func localTime(value string) string {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return value
+	}
+	return t.In(config.LocalTime).Format("02.01.2006 15:04")
+}
+
+func sum(values []float64) float64 {
+	var s float64
+	for _, v := range values {
+		s += v
+	}
+	return s
+}
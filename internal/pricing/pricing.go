@@ -0,0 +1,214 @@
+// Package pricing applies the pricing rules to order lines.
+package pricing
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/Akrom0181/Food-Delivery/pkg/money"
+)
+
+// Line is an order line to be priced.
+type Line struct {
+	ProductID  string
+	CategoryID string
+	UnitPrice  money.Amount
+	Quantity   int
+}
+
+// Order is what rules match besides the line. Time is in local time,
+// discounts computed from a rate are rounded for Currency.
+type Order struct {
+	BranchID   string
+	Time       time.Time
+	FirstOrder bool
+	Currency   money.Currency
+}
+
+// Result is the discount of a line and the rules it comes from.
+type Result struct {
+	Discount money.Amount
+	Applied  []entity.AppliedRule
+}
+
+// Apply prices every line with the rules in the order described on
+// entity.PricingRule. A rule applies when it matches and takes something off.
+// The result only depends on the arguments, the order of rules does not matter.
+func Apply(rules []entity.PricingRule, order Order, lines []Line) []Result {
+	rules = Sort(rules)
+	results := make([]Result, len(lines))
+
+	for i, line := range lines {
+		remaining := line.UnitPrice.Mul(line.Quantity)
+
+		for _, rule := range rules {
+			if len(results[i].Applied) > 0 && !rule.Stackable {
+				continue
+			}
+
+			if !Matches(rule, order, line) {
+				continue
+			}
+
+			discount := money.Min(discountOf(rule, order, line, remaining), remaining)
+			if discount <= 0 {
+				continue
+			}
+
+			remaining -= discount
+			results[i].Discount += discount
+			results[i].Applied = append(results[i].Applied, entity.AppliedRule{
+				RuleID:   rule.ID,
+				Name:     rule.Name,
+				Discount: discount,
+			})
+
+			if !rule.Stackable {
+				break
+			}
+		}
+	}
+
+	return results
+}
+
+// Sort returns the rules in precedence order: higher priority first, then
+// more specific, then by id.
+func Sort(rules []entity.PricingRule) []entity.PricingRule {
+	sorted := append([]entity.PricingRule(nil), rules...)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.Priority != b.Priority {
+			return a.Priority > b.Priority
+		}
+		if specificity(a) != specificity(b) {
+			return specificity(a) > specificity(b)
+		}
+		return a.ID < b.ID
+	})
+
+	return sorted
+}
+
+// Matches reports whether the rule is active for the line of the order.
+func Matches(rule entity.PricingRule, order Order, line Line) bool {
+	switch {
+	case !rule.IsActive:
+		return false
+	case rule.BranchID != "" && rule.BranchID != order.BranchID:
+		return false
+	case rule.ProductID != "" && rule.ProductID != line.ProductID:
+		return false
+	case rule.CategoryID != "" && rule.CategoryID != line.CategoryID:
+		return false
+	case rule.FirstOrderOnly && !order.FirstOrder:
+		return false
+	}
+
+	if rule.StartsAt != "" {
+		startsAt, err := time.Parse(time.RFC3339, rule.StartsAt)
+		if err != nil || order.Time.Before(startsAt) {
+			return false
+		}
+	}
+
+	if rule.EndsAt != "" {
+		endsAt, err := time.Parse(time.RFC3339, rule.EndsAt)
+		if err != nil || !order.Time.Before(endsAt) {
+			return false
+		}
+	}
+
+	if len(rule.DaysOfWeek) > 0 && !containsDay(rule.DaysOfWeek, int(order.Time.Weekday())) {
+		return false
+	}
+
+	if rule.StartTime != "" || rule.EndTime != "" {
+		// a window without a start starts at midnight, one without an end
+		// lasts until midnight
+		start, end := 0, 24*60
+		var ok bool
+		if rule.StartTime != "" {
+			if start, ok = minuteOfDay(rule.StartTime); !ok {
+				return false
+			}
+		}
+		if rule.EndTime != "" {
+			if end, ok = minuteOfDay(rule.EndTime); !ok {
+				return false
+			}
+		}
+
+		now := order.Time.Hour()*60 + order.Time.Minute()
+		if start <= end {
+			return start <= now && now < end
+		}
+		return now >= start || now < end
+	}
+
+	return true
+}
+
+// ValidTime reports whether value is a time of day as "15:04".
+func ValidTime(value string) bool {
+	_, ok := minuteOfDay(value)
+	return ok
+}
+
+func discountOf(rule entity.PricingRule, order Order, line Line, remaining money.Amount) money.Amount {
+	switch rule.Kind {
+	case "percent":
+		return remaining.Percent(rule.Percent, order.Currency)
+	case "amount":
+		return rule.Amount.Mul(line.Quantity)
+	case "buy_get":
+		group := rule.BuyQuantity + rule.GetQuantity
+		if rule.GetQuantity <= 0 || group <= 0 || line.Quantity <= 0 {
+			return 0
+		}
+
+		free := line.Quantity / group * rule.GetQuantity
+		return remaining.Share(free, line.Quantity, order.Currency)
+	}
+
+	return 0
+}
+
+// specificity ranks a product rule over a category rule over a rule for
+// everything, and a branch rule over the same rule for all branches.
+func specificity(rule entity.PricingRule) int {
+	score := 0
+	switch {
+	case rule.ProductID != "":
+		score = 4
+	case rule.CategoryID != "":
+		score = 2
+	}
+
+	if rule.BranchID != "" {
+		score++
+	}
+
+	return score
+}
+
+func minuteOfDay(value string) (int, bool) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, false
+	}
+
+	return t.Hour()*60 + t.Minute(), true
+}
+
+func containsDay(days []int, day int) bool {
+	for _, item := range days {
+		if item == day {
+			return true
+		}
+	}
+
+	return false
+}
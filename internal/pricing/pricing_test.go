@@ -0,0 +1,336 @@
+package pricing
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/Akrom0181/Food-Delivery/pkg/money"
+)
+
+var (
+	usd, _ = money.LookupCurrency("USD")
+	uzs, _ = money.LookupCurrency("UZS")
+
+	// a Monday afternoon
+	afternoon = time.Date(2026, 10, 19, 15, 0, 0, 0, time.UTC)
+)
+
+func TestSort(t *testing.T) {
+	rules := []entity.PricingRule{
+		{ID: "e", CategoryID: "c1"},
+		{ID: "d", ProductID: "p1"},
+		{ID: "b", CategoryID: "c1", BranchID: "b1"},
+		{ID: "c", Priority: 1},
+		{ID: "a", ProductID: "p2"},
+		{ID: "f", ProductID: "p1", BranchID: "b1"},
+	}
+
+	got := ids(Sort(rules))
+
+	// priority, then product over category over all products with a branch
+	// rule before the same rule for all branches, then id
+	want := []string{"c", "f", "a", "d", "b", "e"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Sort() = %v, want %v", got, want)
+	}
+
+	if rules[0].ID != "e" {
+		t.Errorf("Sort() reordered its argument")
+	}
+}
+
+func TestApply(t *testing.T) {
+	line := Line{ProductID: "p1", CategoryID: "c1", UnitPrice: 10000, Quantity: 2}
+
+	tests := []struct {
+		name         string
+		rules        []entity.PricingRule
+		at           time.Time
+		firstOrder   bool
+		line         Line
+		currency     money.Currency
+		wantDiscount money.Amount
+		wantApplied  []string
+	}{
+		{
+			name: "higher priority wins over a more specific rule",
+			rules: []entity.PricingRule{
+				{ID: "a", Kind: "percent", Percent: 10, ProductID: "p1", IsActive: true},
+				{ID: "b", Kind: "percent", Percent: 20, Priority: 1, IsActive: true},
+			},
+			wantDiscount: 4000,
+			wantApplied:  []string{"b"},
+		},
+		{
+			name: "more specific rule wins a priority tie",
+			rules: []entity.PricingRule{
+				{ID: "a", Kind: "percent", Percent: 50, IsActive: true},
+				{ID: "b", Kind: "percent", Percent: 10, CategoryID: "c1", IsActive: true},
+			},
+			wantDiscount: 2000,
+			wantApplied:  []string{"b"},
+		},
+		{
+			name: "branch rule wins over the same rule for all branches",
+			rules: []entity.PricingRule{
+				{ID: "a", Kind: "percent", Percent: 50, ProductID: "p1", IsActive: true},
+				{ID: "b", Kind: "percent", Percent: 10, ProductID: "p1", BranchID: "b1", IsActive: true},
+			},
+			wantDiscount: 2000,
+			wantApplied:  []string{"b"},
+		},
+		{
+			name: "lower id wins a full tie",
+			rules: []entity.PricingRule{
+				{ID: "b", Kind: "percent", Percent: 30, ProductID: "p1", IsActive: true},
+				{ID: "a", Kind: "percent", Percent: 10, ProductID: "p1", IsActive: true},
+			},
+			wantDiscount: 2000,
+			wantApplied:  []string{"a"},
+		},
+		{
+			name: "stackable rules apply to what is left",
+			rules: []entity.PricingRule{
+				{ID: "a", Kind: "percent", Percent: 10, Priority: 2, Stackable: true, IsActive: true},
+				{ID: "b", Kind: "amount", Amount: 500, Priority: 1, Stackable: true, IsActive: true},
+				{ID: "c", Kind: "percent", Percent: 50, Stackable: true, IsActive: true},
+			},
+			// 2000 off 20000, 2 × 500 off 18000, half of 17000
+			wantDiscount: 11500,
+			wantApplied:  []string{"a", "b", "c"},
+		},
+		{
+			name: "non-stackable rule is skipped after a stackable one",
+			rules: []entity.PricingRule{
+				{ID: "a", Kind: "percent", Percent: 10, Priority: 1, Stackable: true, IsActive: true},
+				{ID: "b", Kind: "percent", Percent: 50, IsActive: true},
+				{ID: "c", Kind: "amount", Amount: 100, Stackable: true, IsActive: true},
+			},
+			wantDiscount: 2200,
+			wantApplied:  []string{"a", "c"},
+		},
+		{
+			name: "nothing stacks on a non-stackable rule",
+			rules: []entity.PricingRule{
+				{ID: "a", Kind: "percent", Percent: 10, Priority: 1, IsActive: true},
+				{ID: "b", Kind: "percent", Percent: 10, Stackable: true, IsActive: true},
+			},
+			wantDiscount: 2000,
+			wantApplied:  []string{"a"},
+		},
+		{
+			name: "rule that does not match does not block the next",
+			rules: []entity.PricingRule{
+				{ID: "a", Kind: "percent", Percent: 50, Priority: 2, BranchID: "b2", IsActive: true},
+				{ID: "b", Kind: "percent", Percent: 50, Priority: 1, IsActive: false},
+				{ID: "c", Kind: "percent", Percent: 10, IsActive: true},
+			},
+			wantDiscount: 2000,
+			wantApplied:  []string{"c"},
+		},
+		{
+			name: "discount is capped at the line total",
+			rules: []entity.PricingRule{
+				{ID: "a", Kind: "amount", Amount: 15000, IsActive: true},
+				{ID: "b", Kind: "percent", Percent: 10, Stackable: true, IsActive: true},
+			},
+			wantDiscount: 20000,
+			wantApplied:  []string{"a"},
+		},
+		{
+			name: "first order rule only applies to a first order",
+			rules: []entity.PricingRule{
+				{ID: "a", Kind: "percent", Percent: 10, FirstOrderOnly: true, IsActive: true},
+			},
+			wantDiscount: 0,
+		},
+		{
+			name: "first order rule applies to a first order",
+			rules: []entity.PricingRule{
+				{ID: "a", Kind: "percent", Percent: 10, FirstOrderOnly: true, IsActive: true},
+			},
+			firstOrder:   true,
+			wantDiscount: 2000,
+			wantApplied:  []string{"a"},
+		},
+		{
+			name: "rule applies on its days of week",
+			rules: []entity.PricingRule{
+				{ID: "a", Kind: "percent", Percent: 10, DaysOfWeek: []int{0, 6}, IsActive: true},
+				{ID: "b", Kind: "percent", Percent: 20, DaysOfWeek: []int{1}, IsActive: true},
+			},
+			wantDiscount: 4000,
+			wantApplied:  []string{"b"},
+		},
+		{
+			name: "rule does not apply after it ended",
+			rules: []entity.PricingRule{
+				{ID: "a", Kind: "percent", Percent: 10, StartsAt: "2026-10-01T00:00:00Z", EndsAt: "2026-10-19T15:00:00Z", IsActive: true},
+			},
+			wantDiscount: 0,
+		},
+		{
+			name: "time window end is exclusive",
+			rules: []entity.PricingRule{
+				{ID: "a", Kind: "percent", Percent: 10, StartTime: "14:00", EndTime: "15:00", IsActive: true},
+			},
+			wantDiscount: 0,
+		},
+		{
+			name: "overnight window applies before midnight",
+			rules: []entity.PricingRule{
+				{ID: "a", Kind: "percent", Percent: 10, StartTime: "22:00", EndTime: "02:00", IsActive: true},
+			},
+			at:           time.Date(2026, 10, 19, 23, 30, 0, 0, time.UTC),
+			wantDiscount: 2000,
+			wantApplied:  []string{"a"},
+		},
+		{
+			name: "overnight window applies after midnight",
+			rules: []entity.PricingRule{
+				{ID: "a", Kind: "percent", Percent: 10, StartTime: "22:00", EndTime: "02:00", IsActive: true},
+			},
+			at:           time.Date(2026, 10, 20, 1, 59, 0, 0, time.UTC),
+			wantDiscount: 2000,
+			wantApplied:  []string{"a"},
+		},
+		{
+			name: "overnight window does not apply during the day",
+			rules: []entity.PricingRule{
+				{ID: "a", Kind: "percent", Percent: 10, StartTime: "22:00", EndTime: "02:00", IsActive: true},
+			},
+			wantDiscount: 0,
+		},
+		{
+			name: "window without an end lasts until midnight",
+			rules: []entity.PricingRule{
+				{ID: "a", Kind: "percent", Percent: 10, StartTime: "14:00", IsActive: true},
+			},
+			at:           time.Date(2026, 10, 19, 23, 59, 0, 0, time.UTC),
+			wantDiscount: 2000,
+			wantApplied:  []string{"a"},
+		},
+		{
+			name: "window without an end does not apply before its start",
+			rules: []entity.PricingRule{
+				{ID: "a", Kind: "percent", Percent: 10, StartTime: "16:00", IsActive: true},
+			},
+			wantDiscount: 0,
+		},
+		{
+			name: "window without a start applies from midnight",
+			rules: []entity.PricingRule{
+				{ID: "a", Kind: "percent", Percent: 10, EndTime: "02:00", IsActive: true},
+			},
+			at:           time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+			wantDiscount: 2000,
+			wantApplied:  []string{"a"},
+		},
+		{
+			name: "window without a start ends at its end",
+			rules: []entity.PricingRule{
+				{ID: "a", Kind: "percent", Percent: 10, EndTime: "15:00", IsActive: true},
+			},
+			wantDiscount: 0,
+		},
+		{
+			name: "buy two get one free",
+			rules: []entity.PricingRule{
+				{ID: "a", Kind: "buy_get", BuyQuantity: 2, GetQuantity: 1, IsActive: true},
+			},
+			// two of seven are free
+			line:         Line{ProductID: "p1", CategoryID: "c1", UnitPrice: 10000, Quantity: 7},
+			wantDiscount: 20000,
+			wantApplied:  []string{"a"},
+		},
+		{
+			name: "buy get takes nothing off an incomplete group",
+			rules: []entity.PricingRule{
+				{ID: "a", Kind: "buy_get", BuyQuantity: 2, GetQuantity: 1, IsActive: true},
+				{ID: "b", Kind: "percent", Percent: 10, Priority: -1, IsActive: true},
+			},
+			wantDiscount: 2000,
+			wantApplied:  []string{"b"},
+		},
+		{
+			name: "buy get share is rounded for the currency",
+			rules: []entity.PricingRule{
+				{ID: "a", Kind: "buy_get", BuyQuantity: 1, GetQuantity: 1, IsActive: true},
+			},
+			// one of 3 × 12 900.10 is free, in whole so'm
+			line:         Line{ProductID: "p1", CategoryID: "c1", UnitPrice: 1290010, Quantity: 3},
+			currency:     uzs,
+			wantDiscount: 1290000,
+			wantApplied:  []string{"a"},
+		},
+		{
+			name: "percent is rounded for the currency",
+			rules: []entity.PricingRule{
+				{ID: "a", Kind: "percent", Percent: 15, IsActive: true},
+			},
+			// 15% of 24 690.00 is 3 703.50, rounded half away from zero
+			line:         Line{ProductID: "p1", CategoryID: "c1", UnitPrice: 1234500, Quantity: 2},
+			currency:     uzs,
+			wantDiscount: 370400,
+			wantApplied:  []string{"a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := Order{BranchID: "b1", Time: afternoon, FirstOrder: tt.firstOrder, Currency: usd}
+			if !tt.at.IsZero() {
+				order.Time = tt.at
+			}
+			if tt.currency.Code != "" {
+				order.Currency = tt.currency
+			}
+
+			l := line
+			if tt.line.Quantity != 0 {
+				l = tt.line
+			}
+
+			results := Apply(tt.rules, order, []Line{l})
+			if len(results) != 1 {
+				t.Fatalf("Apply() returned %d results, want 1", len(results))
+			}
+
+			got := results[0]
+			if got.Discount != tt.wantDiscount {
+				t.Errorf("Discount = %s, want %s", got.Discount, tt.wantDiscount)
+			}
+
+			var applied []string
+			var sum money.Amount
+			for _, rule := range got.Applied {
+				applied = append(applied, rule.RuleID)
+				sum += rule.Discount
+			}
+			if !slices.Equal(applied, tt.wantApplied) {
+				t.Errorf("Applied = %v, want %v", applied, tt.wantApplied)
+			}
+			if sum != got.Discount {
+				t.Errorf("applied discounts add up to %s, want %s", sum, got.Discount)
+			}
+
+			// the order of the rules given does not matter
+			reversed := slices.Clone(tt.rules)
+			slices.Reverse(reversed)
+			if again := Apply(reversed, order, []Line{l}); again[0].Discount != got.Discount {
+				t.Errorf("Apply() of reversed rules = %s, want %s", again[0].Discount, got.Discount)
+			}
+		})
+	}
+}
+
+func ids(rules []entity.PricingRule) []string {
+	var response []string
+	for _, rule := range rules {
+		response = append(response, rule.ID)
+	}
+
+	return response
+}
@@ -0,0 +1,58 @@
+package ticket
+
+import "github.com/Akrom0181/Food-Delivery/config"
+
+// translations are plain text, the printer code page has no Uzbek ʻ.
+var translations = map[string]map[string]string{
+	"uz": {
+		"date":     "Sana",
+		"address":  "Manzil",
+		"entrance": "podyezd",
+		"floor":    "qavat",
+		"door":     "xonadon",
+		"pickup":   "Olib ketish",
+		"delivery": "Yetkazib berish",
+		"subtotal": "Oraliq jami",
+		"discount": "Chegirma",
+		"vat":      "Shu jumladan QQS",
+		"total":    "Jami",
+		"kitchen":  "Oshxona",
+	},
+	"ru": {
+		"date":     "Дата",
+		"address":  "Адрес",
+		"entrance": "подъезд",
+		"floor":    "этаж",
+		"door":     "кв.",
+		"pickup":   "Самовывоз",
+		"delivery": "Доставка",
+		"subtotal": "Подытог",
+		"discount": "Скидка",
+		"vat":      "В т.ч. НДС",
+		"total":    "Итого",
+		"kitchen":  "Кухня",
+	},
+	"en": {
+		"date":     "Date",
+		"address":  "Address",
+		"entrance": "entrance",
+		"floor":    "floor",
+		"door":     "apt.",
+		"pickup":   "Pickup",
+		"delivery": "Delivery",
+		"subtotal": "Subtotal",
+		"discount": "Discount",
+		"vat":      "Incl. VAT",
+		"total":    "Total",
+		"kitchen":  "Kitchen",
+	},
+}
+
+// labels returns the labels in locale, or in the default locale when there
+// is no translation.
+func labels(locale string) map[string]string {
+	if l, ok := translations[locale]; ok {
+		return l
+	}
+	return translations[config.DefaultLocale]
+}
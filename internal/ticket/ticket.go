@@ -0,0 +1,266 @@
+// Package ticket lays out the tickets of an order for receipt printers in
+// ESC/POS: the customer copy and a kitchen copy for every kitchen station.
+package ticket
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/Akrom0181/Food-Delivery/pkg/escpos"
+	"github.com/Akrom0181/Food-Delivery/pkg/money"
+)
+
+// Document is what goes on the tickets. Names are the product names in
+// Locale by product id, Kitchen has the lines the kitchen makes.
+type Document struct {
+	Order   entity.Order
+	Branch  entity.Branch
+	Kitchen entity.KitchenTicket
+	Names   map[string]string
+	Locale  string
+}
+
+// Copy is the kitchen copy of the lines of one station, StationID is empty
+// for the lines of products without a station.
+type Copy struct {
+	StationID string
+	Data      []byte
+}
+
+var width = config.PrintTicketWidth
+
+// Customer returns the customer copy: the lines with their bundle
+// components, discounts, VAT and total.
+func Customer(doc Document) []byte {
+	l := labels(doc.Locale)
+	w := escpos.New()
+
+	// branch
+	w.Align(escpos.Center)
+	w.Bold(true)
+	w.Size(2, 2)
+	wrap(w, doc.Branch.Name, width/2)
+	w.Size(1, 1)
+	w.Bold(false)
+	for _, line := range []string{doc.Branch.Address, doc.Branch.Phone} {
+		if line != "" {
+			wrap(w, line, width)
+		}
+	}
+	w.Feed(1)
+
+	header(w, doc, l)
+	if doc.Order.DeliveryStatus != "" {
+		w.Line(deliveryType(doc.Order.DeliveryStatus, l))
+	}
+
+	w.Align(escpos.Left)
+	if doc.Order.Address != "" {
+		wrap(w, l["address"]+": "+address(doc.Order, l), width)
+	}
+	w.Line(strings.Repeat("-", width))
+
+	// items
+	components := map[string][]entity.OrderItems{}
+	for _, item := range doc.Order.OrderItems {
+		if item.ParentItemID != "" {
+			components[item.ParentItemID] = append(components[item.ParentItemID], item)
+		}
+	}
+
+	var subtotal, discount money.Amount
+	for _, item := range doc.Order.OrderItems {
+		if item.ParentItemID != "" {
+			continue
+		}
+
+		subtotal += item.Price.Mul(item.Quantity)
+		discount += item.Discount
+
+		wrap(w, doc.name(item.ProductId), width)
+		w.Line(escpos.Columns(fmt.Sprintf("  %d x %s", item.Quantity, item.Price.Grouped()),
+			item.Price.Mul(item.Quantity).Grouped(), width))
+
+		for _, component := range components[item.Id] {
+			indent(w, "  + ", fmt.Sprintf("%s x %d", doc.name(component.ProductId), component.Quantity))
+		}
+		for _, rule := range item.AppliedRules {
+			w.Line(escpos.Columns("  "+rule.Name, "-"+rule.Discount.Grouped(), width))
+		}
+	}
+	w.Line(strings.Repeat("-", width))
+
+	// totals
+	w.Line(escpos.Columns(l["subtotal"], subtotal.Grouped(), width))
+	if discount > 0 {
+		w.Line(escpos.Columns(l["discount"], "-"+discount.Grouped(), width))
+	}
+	if doc.Order.Tax > 0 {
+		w.Line(escpos.Columns(l["vat"], doc.Order.Tax.Grouped(), width))
+	}
+	w.Bold(true)
+	w.Size(1, 2)
+	w.Line(escpos.Columns(l["total"], doc.Order.TotalPrice.Grouped()+" "+doc.Order.Currency, width))
+	w.Size(1, 1)
+	w.Bold(false)
+	w.Feed(1)
+
+	footer(w, doc)
+
+	return w.Bytes()
+}
+
+// Kitchen returns a copy for every station with lines in doc.Kitchen, in the
+// order the stations first appear. Components show the bundle they are in.
+func Kitchen(doc Document) []Copy {
+	l := labels(doc.Locale)
+
+	var (
+		stations []string
+		names    = map[string]string{}
+		items    = map[string][]entity.KitchenItem{}
+	)
+	for _, item := range doc.Kitchen.Items {
+		if _, ok := items[item.StationID]; !ok {
+			stations = append(stations, item.StationID)
+			names[item.StationID] = item.StationName
+		}
+		items[item.StationID] = append(items[item.StationID], item)
+	}
+
+	bundles := map[string]string{}
+	for _, item := range doc.Order.OrderItems {
+		bundles[item.Id] = item.ProductId
+	}
+
+	copies := make([]Copy, 0, len(stations))
+	for _, stationID := range stations {
+		w := escpos.New()
+
+		w.Align(escpos.Center)
+		w.Bold(true)
+		w.Size(2, 2)
+		station := names[stationID]
+		if station == "" {
+			station = l["kitchen"]
+		}
+		wrap(w, station, width/2)
+		w.Size(1, 1)
+		w.Bold(false)
+
+		header(w, doc, l)
+		if doc.Order.DeliveryStatus != "" {
+			w.Line(deliveryType(doc.Order.DeliveryStatus, l))
+		}
+		w.Align(escpos.Left)
+		w.Line(strings.Repeat("-", width))
+
+		w.Size(1, 2)
+		for _, item := range items[stationID] {
+			w.Bold(true)
+			wrap(w, fmt.Sprintf("%d x %s", item.Quantity, item.Name), width)
+			w.Bold(false)
+			if productID, ok := bundles[item.ParentItemID]; ok {
+				indent(w, "   ", "("+doc.name(productID)+")")
+			}
+		}
+		w.Size(1, 1)
+		w.Line(strings.Repeat("-", width))
+		w.Feed(1)
+
+		footer(w, doc)
+
+		copies = append(copies, Copy{StationID: stationID, Data: w.Bytes()})
+	}
+
+	return copies
+}
+
+// Number is the short number staff call an order by.
+func Number(orderID string) string {
+	if len(orderID) > 8 {
+		orderID = orderID[:8]
+	}
+	return strings.ToUpper(orderID)
+}
+
+// header writes the order number and date centered.
+func header(w *escpos.Writer, doc Document, l map[string]string) {
+	w.Align(escpos.Center)
+	w.Bold(true)
+	w.Size(2, 2)
+	w.Line("#" + Number(doc.Order.ID))
+	w.Size(1, 1)
+	w.Bold(false)
+	w.Line(l["date"] + ": " + localTime(doc.Order.CreatedAt))
+}
+
+// footer writes the QR code of the order id, feeds and cuts.
+func footer(w *escpos.Writer, doc Document) {
+	w.Align(escpos.Center)
+	w.QR(doc.Order.ID, 6)
+	w.Line(doc.Order.ID)
+	w.Feed(3)
+	w.Cut()
+}
+
+func wrap(w *escpos.Writer, text string, width int) {
+	for _, line := range escpos.Wrap(text, width) {
+		w.Line(line)
+	}
+}
+
+// indent wraps text under prefix, the lines after the first indented as far.
+func indent(w *escpos.Writer, prefix, text string) {
+	pad := strings.Repeat(" ", len(prefix))
+	for i, line := range escpos.Wrap(text, width-len(prefix)) {
+		if i == 0 {
+			w.Line(prefix + line)
+		} else {
+			w.Line(pad + line)
+		}
+	}
+}
+
+func (d Document) name(productID string) string {
+	if name, ok := d.Names[productID]; ok {
+		return name
+	}
+	return productID
+}
+
+func address(order entity.Order, l map[string]string) string {
+	parts := []string{order.Address}
+	if order.Entrance > 0 {
+		parts = append(parts, fmt.Sprintf("%s %d", l["entrance"], order.Entrance))
+	}
+	if order.Floor != 0 {
+		parts = append(parts, fmt.Sprintf("%s %d", l["floor"], order.Floor))
+	}
+	if order.DoorNumber > 0 {
+		parts = append(parts, fmt.Sprintf("%s %d", l["door"], order.DoorNumber))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func deliveryType(status string, l map[string]string) string {
+	switch status {
+	case "olib ketish":
+		return l["pickup"]
+	case "yetkazib berish":
+		return l["delivery"]
+	default:
+		return status
+	}
+}
+
+func localTime(value string) string {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return value
+	}
+	return t.In(config.LocalTime).Format("02.01.2006 15:04")
+}
@@ -3,6 +3,7 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/Akrom0181/Food-Delivery/internal/entity"
 )
@@ -57,6 +58,7 @@ type (
 		Update(ctx context.Context, req entity.Category) (entity.Category, error)
 		Delete(ctx context.Context, req entity.Id) error
 		UpdateField(ctx context.Context, req entity.UpdateFieldRequest) (entity.RowsEffected, error)
+		Sort(ctx context.Context, req entity.SortRequest) error
 	}
 
 	ProductRepoI interface {
@@ -65,6 +67,14 @@ type (
 		GetList(ctx context.Context, req entity.GetListFilter) (entity.ProductList, error)
 		Update(ctx context.Context, req entity.Product) (entity.Product, error)
 		Delete(ctx context.Context, req entity.Id) error
+		Search(ctx context.Context, req entity.ProductSearchRequest) (entity.ProductSearchResult, error)
+		Suggest(ctx context.Context, restaurantID string, terms []string, limit int) (entity.SuggestionList, error)
+		SetAvailability(ctx context.Context, req entity.ProductAvailability) (entity.ProductAvailability, error)
+		Sort(ctx context.Context, req entity.SortRequest) error
+		GetPriceHistory(ctx context.Context, req entity.Id) (entity.PriceHistory, error)
+		SchedulePrice(ctx context.Context, req entity.PriceVersion) (entity.PriceVersion, error)
+		CancelScheduledPrice(ctx context.Context, req entity.PriceVersion) error
+		ApplyScheduledPrices(ctx context.Context) (int64, error)
 	}
 
 	// BannerRepo -.
@@ -84,7 +94,9 @@ type (
 		Update(ctx context.Context, req entity.Branch) (entity.Branch, error)
 		Delete(ctx context.Context, req entity.Id) error
 		UpdateField(ctx context.Context, req entity.UpdateFieldRequest) (entity.RowsEffected, error)
-		GetNearestBranch(ctx context.Context, lat, lon float64) (entity.Branch, error)
+		GetNearestBranches(ctx context.Context, restaurantID string, lat, lon float64) ([]entity.Branch, error)
+		SaveHours(ctx context.Context, req entity.BranchHoursList) (entity.BranchHoursList, error)
+		GetHours(ctx context.Context, req entity.Id) (entity.BranchHoursList, error)
 	}
 
 	// UserLocationRepo -.
@@ -104,10 +116,172 @@ type (
 		Delete(ctx context.Context, req entity.Id) error
 		UpdateField(ctx context.Context, req entity.UpdateFieldRequest) (entity.RowsEffected, error)
 		GetOrdersByBranch(ctx context.Context, req entity.GetListFilter) (entity.OrderList, error)
+		HasOrders(ctx context.Context, userID string) (bool, error)
+		CountScheduled(ctx context.Context, branchID string, from, to time.Time) (map[int64]int, error)
+		ReleaseScheduled(ctx context.Context) (int, error)
 	}
 
 	CourierRepoI interface {
 		GetNearbyCouriers(ctx context.Context, lat, lng float64, radius float64) ([]entity.Courier, error)
 		AssignOrderToCourier(ctx context.Context, orderID, courierID string) error
+		GetByUserID(ctx context.Context, userID string) (entity.Courier, error)
+	}
+
+	// TwoFactorRepo -.
+	TwoFactorRepoI interface {
+		Upsert(ctx context.Context, req entity.UserTwoFactor) (entity.UserTwoFactor, error)
+		GetSingle(ctx context.Context, req entity.Id) (entity.UserTwoFactor, error)
+		Enable(ctx context.Context, req entity.Id) error
+		Delete(ctx context.Context, req entity.Id) error
+		ReplaceRecoveryCodes(ctx context.Context, userID string, hashes []string) error
+		GetRecoveryCodes(ctx context.Context, req entity.Id) ([]entity.RecoveryCode, error)
+		UseRecoveryCode(ctx context.Context, req entity.Id) error
+	}
+
+	// UserIdentityRepo -.
+	UserIdentityRepoI interface {
+		Create(ctx context.Context, req entity.UserIdentity) (entity.UserIdentity, error)
+		GetSingle(ctx context.Context, req entity.UserIdentitySingleRequest) (entity.UserIdentity, error)
+	}
+
+	// PolicyRepo -.
+	PolicyRepoI interface {
+		CreatePolicy(ctx context.Context, req entity.Policy) (entity.Policy, error)
+		GetPolicies(ctx context.Context, req entity.GetListFilter) (entity.PolicyList, error)
+		DeletePolicy(ctx context.Context, req entity.Id) error
+		CreateRoleInheritance(ctx context.Context, req entity.RoleInheritance) (entity.RoleInheritance, error)
+		GetRoleInheritances(ctx context.Context, req entity.GetListFilter) (entity.RoleInheritanceList, error)
+		DeleteRoleInheritance(ctx context.Context, req entity.Id) error
+	}
+
+	// APIKeyRepo -.
+	APIKeyRepoI interface {
+		Create(ctx context.Context, req entity.APIKey) (entity.APIKey, error)
+		GetSingle(ctx context.Context, req entity.Id) (entity.APIKey, error)
+		GetByPrefix(ctx context.Context, prefix string) (entity.APIKey, error)
+		GetList(ctx context.Context, req entity.GetListFilter) (entity.APIKeyList, error)
+		Update(ctx context.Context, req entity.APIKey) (entity.APIKey, error)
+		Rotate(ctx context.Context, req entity.Id, keyHash string, graceUntil time.Time) error
+		Revoke(ctx context.Context, req entity.Id) error
+		UpdateLastUsed(ctx context.Context, req entity.Id) error
+	}
+
+	// UploadRepo -.
+	UploadRepoI interface {
+		Create(ctx context.Context, req entity.Upload) (entity.Upload, error)
+		Delete(ctx context.Context, req entity.Id) error
+		GetOrphans(ctx context.Context, before time.Time, limit int) ([]entity.Upload, error)
+		DeleteOrphan(ctx context.Context, key string, before time.Time, deleteObject func(ctx context.Context) error) (bool, error)
+		PruneReferences(ctx context.Context) (int64, error)
+		GetReport(ctx context.Context) (entity.StorageReport, error)
+	}
+
+	// MenuRepo -.
+	MenuRepoI interface {
+		GetVersion(ctx context.Context) (int64, error)
+		Get(ctx context.Context, req entity.MenuRequest) (entity.Menu, error)
+		GetChanges(ctx context.Context, req entity.MenuRequest) (entity.MenuChanges, error)
+	}
+
+	// BundleRepo -.
+	BundleRepoI interface {
+		Get(ctx context.Context, req entity.BundleRequest) (entity.Bundle, error)
+		Save(ctx context.Context, req entity.Bundle) (entity.Bundle, error)
+		Delete(ctx context.Context, req entity.Id) error
+	}
+
+	// PricingRuleRepo -.
+	PricingRuleRepoI interface {
+		Create(ctx context.Context, req entity.PricingRule) (entity.PricingRule, error)
+		GetSingle(ctx context.Context, req entity.Id) (entity.PricingRule, error)
+		GetList(ctx context.Context, req entity.GetListFilter) (entity.PricingRuleList, error)
+		GetActive(ctx context.Context, branchID string) ([]entity.PricingRule, error)
+		Update(ctx context.Context, req entity.PricingRule) (entity.PricingRule, error)
+		Delete(ctx context.Context, req entity.Id) error
+	}
+
+	// TaxCategoryRepo -.
+	TaxCategoryRepoI interface {
+		Create(ctx context.Context, req entity.TaxCategory) (entity.TaxCategory, error)
+		GetSingle(ctx context.Context, req entity.Id) (entity.TaxCategory, error)
+		GetList(ctx context.Context, req entity.GetListFilter) (entity.TaxCategoryList, error)
+		Update(ctx context.Context, req entity.TaxCategory) (entity.TaxCategory, error)
+		Delete(ctx context.Context, req entity.Id) error
+	}
+
+	// ReceiptRepo -.
+	ReceiptRepoI interface {
+		Create(ctx context.Context, req entity.Id) (entity.Receipt, bool, error)
+		GetByOrder(ctx context.Context, req entity.Id) (entity.Receipt, error)
+		ClaimPending(ctx context.Context, limit int, retryDelay time.Duration) ([]entity.Receipt, error)
+		SetRegistered(ctx context.Context, req entity.Receipt) error
+		SetError(ctx context.Context, req entity.Receipt, final bool) error
+		Retry(ctx context.Context, req entity.Id) error
+	}
+
+	// TranslationRepo -.
+	TranslationRepoI interface {
+		GetList(ctx context.Context, entityType string, req entity.Id) (entity.TranslationList, error)
+		Upsert(ctx context.Context, entityType string, req entity.Id, translation entity.Translation) error
+		Delete(ctx context.Context, entityType string, req entity.Id, locale string) error
+	}
+
+	// KitchenStationRepo -.
+	KitchenStationRepoI interface {
+		Create(ctx context.Context, req entity.KitchenStation) (entity.KitchenStation, error)
+		GetSingle(ctx context.Context, req entity.Id) (entity.KitchenStation, error)
+		GetList(ctx context.Context, req entity.GetListFilter) (entity.KitchenStationList, error)
+		Update(ctx context.Context, req entity.KitchenStation) (entity.KitchenStation, error)
+		Delete(ctx context.Context, req entity.Id) error
+	}
+
+	// KitchenRepo -.
+	KitchenRepoI interface {
+		GetTickets(ctx context.Context, req entity.KitchenTicketsRequest) (entity.KitchenTicketList, error)
+		GetTicket(ctx context.Context, req entity.KitchenTicketsRequest) (entity.KitchenTicket, error)
+		SetItemStatus(ctx context.Context, req entity.KitchenItemStatus) (entity.KitchenTicket, error)
+		Bump(ctx context.Context, req entity.Id) (entity.KitchenTicket, error)
+		Recall(ctx context.Context, req entity.Id) (entity.KitchenTicket, error)
+		GetItemBranch(ctx context.Context, req entity.Id) (string, error)
+	}
+
+	// PrintJobRepo -.
+	PrintJobRepoI interface {
+		Create(ctx context.Context, req []entity.PrintJob) (entity.PrintJobList, error)
+		Claim(ctx context.Context, req entity.PrintJobClaim, lease time.Duration) (entity.PrintJob, error)
+		Ack(ctx context.Context, req entity.PrintJobAck) (entity.PrintJob, error)
+		Retry(ctx context.Context, req entity.Id) (entity.PrintJob, error)
+		GetSingle(ctx context.Context, req entity.Id) (entity.PrintJob, error)
+		GetList(ctx context.Context, req entity.GetListFilter) (entity.PrintJobList, error)
+	}
+
+	// BranchStaffRepo -.
+	BranchStaffRepoI interface {
+		Save(ctx context.Context, req entity.BranchStaff) (entity.BranchStaff, error)
+		GetSingle(ctx context.Context, req entity.Id) (entity.BranchStaff, error)
+		GetList(ctx context.Context, req entity.BranchStaffRequest) (entity.BranchStaffList, error)
+		Delete(ctx context.Context, req entity.Id) error
+		GetBranches(ctx context.Context, req entity.Id) ([]string, error)
+	}
+
+	RestaurantRepoI interface {
+		Create(ctx context.Context, req entity.Restaurant) (entity.Restaurant, error)
+		GetSingle(ctx context.Context, req entity.Id) (entity.Restaurant, error)
+		GetList(ctx context.Context, req entity.RestaurantRequest) (entity.RestaurantList, error)
+		Update(ctx context.Context, req entity.Restaurant) (entity.Restaurant, error)
+		Delete(ctx context.Context, req entity.Id) error
+		Owns(ctx context.Context, table, restaurantID string, ids []string) (bool, error)
+		GetProductRestaurants(ctx context.Context, productIDs []string) ([]string, error)
+		SaveAdmin(ctx context.Context, req entity.RestaurantAdmin) (entity.RestaurantAdmin, error)
+		GetAdmin(ctx context.Context, req entity.Id) (entity.RestaurantAdmin, error)
+		GetAdmins(ctx context.Context, req entity.RestaurantAdminRequest) (entity.RestaurantAdminList, error)
+		DeleteAdmin(ctx context.Context, req entity.Id) error
+	}
+
+	// BranchCapacityRepo -.
+	BranchCapacityRepoI interface {
+		Save(ctx context.Context, req entity.BranchCapacity) (entity.BranchCapacity, error)
+		GetLoad(ctx context.Context, req entity.Id) (entity.BranchLoad, error)
+		GetLoads(ctx context.Context, req entity.GetListFilter) (entity.BranchLoadList, error)
 	}
 )
@@ -9,31 +9,66 @@ import (
 
 // UseCase -.
 type UseCase struct {
-	UserRepo         UserRepoI
-	SessionRepo      SessionRepoI
-	ReportRepo       ReportRepoI
-	NotificationRepo NotificationRepoI
-	CategoryRepo     CategoryRepoI
-	ProductRepo      ProductRepoI
-	BannerRepo       BannerRepoI
-	BranchRepo       BranchRepoI
-	UserLocationRepo UserLocationRepoI
-	OrderRepo        OrderRepoI
-	CourierRepo      CourierRepoI
+	UserRepo           UserRepoI
+	SessionRepo        SessionRepoI
+	ReportRepo         ReportRepoI
+	NotificationRepo   NotificationRepoI
+	CategoryRepo       CategoryRepoI
+	ProductRepo        ProductRepoI
+	BannerRepo         BannerRepoI
+	BranchRepo         BranchRepoI
+	UserLocationRepo   UserLocationRepoI
+	OrderRepo          OrderRepoI
+	CourierRepo        CourierRepoI
+	TwoFactorRepo      TwoFactorRepoI
+	UserIdentityRepo   UserIdentityRepoI
+	PolicyRepo         PolicyRepoI
+	APIKeyRepo         APIKeyRepoI
+	UploadRepo         UploadRepoI
+	TranslationRepo    TranslationRepoI
+	MenuRepo           MenuRepoI
+	BundleRepo         BundleRepoI
+	PricingRuleRepo    PricingRuleRepoI
+	TaxCategoryRepo    TaxCategoryRepoI
+	ReceiptRepo        ReceiptRepoI
+	KitchenStationRepo KitchenStationRepoI
+	KitchenRepo        KitchenRepoI
+	PrintJobRepo       PrintJobRepoI
+	BranchStaffRepo    BranchStaffRepoI
+	RestaurantRepo     RestaurantRepoI
+	BranchCapacityRepo BranchCapacityRepoI
 }
 
 // New -.
 func New(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *UseCase {
 	return &UseCase{
-		UserRepo:         repo.NewUserRepo(pg, config, logger),
-		SessionRepo:      repo.NewSessionRepo(pg, config, logger),
-		ReportRepo:       repo.NewReportRepo(pg, config, logger),
-		NotificationRepo: repo.NewNotificationRepo(pg, config, logger),
-		CategoryRepo:     repo.NewCategoryRepo(pg, config, logger),
-		ProductRepo:      repo.NewProductRepo(pg, config, logger),
-		BannerRepo:       repo.NewBannerRepo(pg, config, logger),
-		BranchRepo:       repo.NewBranchRepo(pg, config, logger),
-		UserLocationRepo: repo.NewUserLocationRepo(pg, config, logger),
-		OrderRepo:        repo.NewOrderRepo(pg, config, logger),
+		UserRepo:           repo.NewUserRepo(pg, config, logger),
+		SessionRepo:        repo.NewSessionRepo(pg, config, logger),
+		ReportRepo:         repo.NewReportRepo(pg, config, logger),
+		NotificationRepo:   repo.NewNotificationRepo(pg, config, logger),
+		CategoryRepo:       repo.NewCategoryRepo(pg, config, logger),
+		ProductRepo:        repo.NewProductRepo(pg, config, logger),
+		BannerRepo:         repo.NewBannerRepo(pg, config, logger),
+		BranchRepo:         repo.NewBranchRepo(pg, config, logger),
+		UserLocationRepo:   repo.NewUserLocationRepo(pg, config, logger),
+		OrderRepo:          repo.NewOrderRepo(pg, config, logger),
+		CourierRepo:        repo.NewCourierRepo(pg, config, logger),
+		TwoFactorRepo:      repo.NewTwoFactorRepo(pg, config, logger),
+		UserIdentityRepo:   repo.NewUserIdentityRepo(pg, config, logger),
+		PolicyRepo:         repo.NewPolicyRepo(pg, config, logger),
+		APIKeyRepo:         repo.NewAPIKeyRepo(pg, config, logger),
+		UploadRepo:         repo.NewUploadRepo(pg, config, logger),
+		TranslationRepo:    repo.NewTranslationRepo(pg, config, logger),
+		MenuRepo:           repo.NewMenuRepo(pg, config, logger),
+		BundleRepo:         repo.NewBundleRepo(pg, config, logger),
+		PricingRuleRepo:    repo.NewPricingRuleRepo(pg, config, logger),
+		TaxCategoryRepo:    repo.NewTaxCategoryRepo(pg, config, logger),
+		ReceiptRepo:        repo.NewReceiptRepo(pg, config, logger),
+		KitchenStationRepo: repo.NewKitchenStationRepo(pg, config, logger),
+		KitchenRepo:        repo.NewKitchenRepo(pg, config, logger),
+		PrintJobRepo:       repo.NewPrintJobRepo(pg, config, logger),
+		BranchStaffRepo:    repo.NewBranchStaffRepo(pg, config, logger),
+		RestaurantRepo:     repo.NewRestaurantRepo(pg, config, logger),
+		BranchCapacityRepo: repo.NewBranchCapacityRepo(pg, config, logger),
 	}
 }
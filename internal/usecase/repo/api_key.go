@@ -0,0 +1,305 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/Akrom0181/Food-Delivery/pkg/apikey"
+	"github.com/Akrom0181/Food-Delivery/pkg/logger"
+	"github.com/Akrom0181/Food-Delivery/pkg/postgres"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+const apiKeyColumns = `id, name, user_id, prefix, key_hash, COALESCE(previous_key_hash, ''), previous_expires_at,
+	scopes, branch_ids::text[], rate_limit, is_active, expires_at, last_used_at, COALESCE(created_by::text, ''), created_at, updated_at`
+
+// APIKeyRepo stores API keys. The scopes of a key are kept as casbin g rules
+// of its subject and written in the same transaction as the key.
+type APIKeyRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+// New -.
+func NewAPIKeyRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *APIKeyRepo {
+	return &APIKeyRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+func (r *APIKeyRepo) Create(ctx context.Context, req entity.APIKey) (entity.APIKey, error) {
+	req.ID = uuid.NewString()
+	req.IsActive = true
+
+	query, args, err := r.pg.Builder.Insert("api_key").
+		Columns(`id, name, user_id, prefix, key_hash, scopes, branch_ids, rate_limit, expires_at, created_by`).
+		Values(req.ID, req.Name, req.UserID, req.Prefix, req.KeyHash, req.Scopes, req.BranchIDs, req.RateLimit, squirrel.Expr("NULLIF(?, '')::timestamp", req.ExpiresAt), squirrel.Expr("NULLIF(?, '')::uuid", req.CreatedBy)).ToSql()
+	if err != nil {
+		return entity.APIKey{}, err
+	}
+
+	tx, err := r.pg.Pool.Begin(ctx)
+	if err != nil {
+		return entity.APIKey{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, query, args...)
+	if err != nil {
+		return entity.APIKey{}, err
+	}
+
+	err = r.replaceScopes(ctx, tx, req.ID, req.Scopes)
+	if err != nil {
+		return entity.APIKey{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return entity.APIKey{}, err
+	}
+
+	return r.GetSingle(ctx, entity.Id{ID: req.ID})
+}
+
+func (r *APIKeyRepo) GetSingle(ctx context.Context, req entity.Id) (entity.APIKey, error) {
+	query, args, err := r.pg.Builder.Select(apiKeyColumns).From("api_key").Where("id = ?", req.ID).ToSql()
+	if err != nil {
+		return entity.APIKey{}, err
+	}
+
+	return scanAPIKey(r.pg.Pool.QueryRow(ctx, query, args...))
+}
+
+// GetByPrefix looks a key up by the public part of the key.
+func (r *APIKeyRepo) GetByPrefix(ctx context.Context, prefix string) (entity.APIKey, error) {
+	query, args, err := r.pg.Builder.Select(apiKeyColumns).From("api_key").Where("prefix = ?", prefix).ToSql()
+	if err != nil {
+		return entity.APIKey{}, err
+	}
+
+	return scanAPIKey(r.pg.Pool.QueryRow(ctx, query, args...))
+}
+
+func (r *APIKeyRepo) GetList(ctx context.Context, req entity.GetListFilter) (entity.APIKeyList, error) {
+	var (
+		response = entity.APIKeyList{}
+	)
+
+	queryBuilder := r.pg.Builder.Select(apiKeyColumns).From("api_key")
+
+	queryBuilder, where := PrepareGetListQuery(queryBuilder, req)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item, err := scanAPIKey(rows)
+		if err != nil {
+			return response, err
+		}
+
+		response.Items = append(response.Items, item)
+	}
+
+	countQuery, args, err := r.pg.Builder.Select("COUNT(1)").From("api_key").Where(where).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(&response.Count)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// Update changes the name, scopes, branches, rate limit and expiry of an active key.
+func (r *APIKeyRepo) Update(ctx context.Context, req entity.APIKey) (entity.APIKey, error) {
+	query, args, err := r.pg.Builder.Update("api_key").
+		SetMap(map[string]interface{}{
+			"name":       req.Name,
+			"scopes":     req.Scopes,
+			"branch_ids": req.BranchIDs,
+			"rate_limit": req.RateLimit,
+			"expires_at": squirrel.Expr("NULLIF(?, '')::timestamp", req.ExpiresAt),
+			"updated_at": "now()",
+		}).
+		Where("id = ? AND is_active", req.ID).ToSql()
+	if err != nil {
+		return entity.APIKey{}, err
+	}
+
+	tx, err := r.pg.Pool.Begin(ctx)
+	if err != nil {
+		return entity.APIKey{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	n, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return entity.APIKey{}, err
+	}
+
+	if n.RowsAffected() == 0 {
+		return entity.APIKey{}, pgx.ErrNoRows
+	}
+
+	err = r.replaceScopes(ctx, tx, req.ID, req.Scopes)
+	if err != nil {
+		return entity.APIKey{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return entity.APIKey{}, err
+	}
+
+	return r.GetSingle(ctx, entity.Id{ID: req.ID})
+}
+
+// Rotate replaces the hash of an active key. The old hash keeps working until graceUntil.
+func (r *APIKeyRepo) Rotate(ctx context.Context, req entity.Id, keyHash string, graceUntil time.Time) error {
+	query, args, err := r.pg.Builder.Update("api_key").
+		Set("previous_key_hash", squirrel.Expr("key_hash")).
+		Set("previous_expires_at", graceUntil).
+		Set("key_hash", keyHash).
+		Set("updated_at", "now()").
+		Where("id = ? AND is_active", req.ID).ToSql()
+	if err != nil {
+		return err
+	}
+
+	n, err := r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if n.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// Revoke deactivates the key and drops its scopes.
+func (r *APIKeyRepo) Revoke(ctx context.Context, req entity.Id) error {
+	query, args, err := r.pg.Builder.Update("api_key").
+		Set("is_active", false).
+		Set("updated_at", "now()").
+		Where("id = ? AND is_active", req.ID).ToSql()
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.pg.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	n, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if n.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	err = r.replaceScopes(ctx, tx, req.ID, nil)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *APIKeyRepo) UpdateLastUsed(ctx context.Context, req entity.Id) error {
+	query, args, err := r.pg.Builder.Update("api_key").
+		Set("last_used_at", "now()").
+		Where("id = ?", req.ID).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	return err
+}
+
+func (r *APIKeyRepo) replaceScopes(ctx context.Context, tx pgx.Tx, id string, scopes []string) error {
+	subject := apikey.Subject(id)
+
+	query, args, err := r.pg.Builder.Delete("casbin_rule").Where("ptype = 'g' AND v0 = ?", subject).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if len(scopes) == 0 {
+		return nil
+	}
+
+	insert := r.pg.Builder.Insert("casbin_rule").Columns(`id, ptype, v0, v1`)
+	for _, scope := range scopes {
+		insert = insert.Values(uuid.NewString(), "g", subject, scope)
+	}
+
+	query, args, err = insert.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+	return err
+}
+
+func scanAPIKey(row pgx.Row) (entity.APIKey, error) {
+	var (
+		response                                 entity.APIKey
+		previousExpiresAt, expiresAt, lastUsedAt sql.NullTime
+		createdAt, updatedAt                     time.Time
+	)
+
+	err := row.Scan(&response.ID, &response.Name, &response.UserID, &response.Prefix, &response.KeyHash,
+		&response.PreviousKeyHash, &previousExpiresAt, &response.Scopes, &response.BranchIDs, &response.RateLimit, &response.IsActive,
+		&expiresAt, &lastUsedAt, &response.CreatedBy, &createdAt, &updatedAt)
+	if err != nil {
+		return entity.APIKey{}, err
+	}
+
+	if previousExpiresAt.Valid {
+		response.PreviousExpiresAt = previousExpiresAt.Time.Format(time.RFC3339)
+	}
+	if expiresAt.Valid {
+		response.ExpiresAt = expiresAt.Time.Format(time.RFC3339)
+	}
+	if lastUsedAt.Valid {
+		response.LastUsedAt = lastUsedAt.Time.Format(time.RFC3339)
+	}
+	response.CreatedAt = createdAt.Format(time.RFC3339)
+	response.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+	return response, nil
+}
@@ -29,15 +29,22 @@ func NewBannerRepo(pg *postgres.Postgres, config *config.Config, logger *logger.
 
 func (r *BannerRepo) Create(ctx context.Context, req entity.Banner) (entity.Banner, error) {
 	req.Id = uuid.NewString()
+	if req.Images == nil {
+		req.Images = entity.ImageVariants{}
+	}
+
+	if req.RestaurantID == "" {
+		req.RestaurantID = config.DefaultRestaurantID
+	}
 
 	query, args, err := r.pg.Builder.Insert("banner").
-		Columns(`id, title, image_url`).
-		Values(req.Id, req.Title, req.ImageUrl).ToSql()
+		Columns(`id, restaurant_id, title, images`).
+		Values(req.Id, req.RestaurantID, req.Title, req.Images).ToSql()
 	if err != nil {
 		return entity.Banner{}, err
 	}
 
-	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	err = execLinkingUploads(ctx, r.pg, query, args, UploadEntityBanner, req.Id, imageURLs(req.Images))
 	if err != nil {
 		return entity.Banner{}, err
 	}
@@ -52,7 +59,9 @@ func (r *BannerRepo) GetSingle(ctx context.Context, req entity.Id) (entity.Banne
 	)
 
 	queryBuilder := r.pg.Builder.
-		Select(`id, banner, created_at, updated_at`).
+		Select(`id, restaurant_id`).
+		Column(translatedColumn(TranslationEntityBanner, "banner", "title", req.Locales)).
+		Columns(`images, created_at, updated_at`).
 		From("banner")
 
 	switch {
@@ -68,7 +77,7 @@ func (r *BannerRepo) GetSingle(ctx context.Context, req entity.Id) (entity.Banne
 	}
 
 	err = r.pg.Pool.QueryRow(ctx, query, args...).
-		Scan(&response.Id, &response.ImageUrl, &createdAt, &updatedAt)
+		Scan(&response.Id, &response.RestaurantID, &response.Title, &response.Images, &createdAt, &updatedAt)
 	if err != nil {
 		return entity.Banner{}, err
 	}
@@ -86,7 +95,9 @@ func (r *BannerRepo) GetList(ctx context.Context, req entity.GetListFilter) (ent
 	)
 
 	queryBuilder := r.pg.Builder.
-		Select(`id, title, image_url, created_at, updated_at`).
+		Select(`id, restaurant_id`).
+		Column(translatedColumn(TranslationEntityBanner, "banner", "title", req.Locales)).
+		Columns(`images, created_at, updated_at`).
 		From("banner")
 
 	queryBuilder, where := PrepareGetListQuery(queryBuilder, req)
@@ -104,7 +115,7 @@ func (r *BannerRepo) GetList(ctx context.Context, req entity.GetListFilter) (ent
 
 	for rows.Next() {
 		var item entity.Banner
-		err = rows.Scan(&item.Id, &item.ImageUrl, &createdAt, &updatedAt)
+		err = rows.Scan(&item.Id, &item.RestaurantID, &item.Title, &item.Images, &createdAt, &updatedAt)
 		if err != nil {
 			return response, err
 		}
@@ -131,16 +142,23 @@ func (r *BannerRepo) GetList(ctx context.Context, req entity.GetListFilter) (ent
 func (r *BannerRepo) Update(ctx context.Context, req entity.Banner) (entity.Banner, error) {
 	mp := map[string]interface{}{
 		"title":      req.Title,
-		"image_url":  req.ImageUrl,
 		"updated_at": "now()",
 	}
 
+	if req.Images != nil {
+		mp["images"] = req.Images
+	}
+
 	query, args, err := r.pg.Builder.Update("banner").SetMap(mp).Where("id = ?", req.Id).ToSql()
 	if err != nil {
 		return entity.Banner{}, err
 	}
 
-	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	if req.Images != nil {
+		err = execLinkingUploads(ctx, r.pg, query, args, UploadEntityBanner, req.Id, imageURLs(req.Images))
+	} else {
+		_, err = r.pg.Pool.Exec(ctx, query, args...)
+	}
 	if err != nil {
 		return entity.Banner{}, err
 	}
@@ -154,7 +172,8 @@ func (r *BannerRepo) Delete(ctx context.Context, req entity.Id) error {
 		return err
 	}
 
-	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	// the images of the entity become orphans and are swept later
+	err = execLinkingUploads(ctx, r.pg, query, args, UploadEntityBanner, req.ID, nil)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,206 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/Akrom0181/Food-Delivery/pkg/logger"
+	"github.com/Akrom0181/Food-Delivery/pkg/postgres"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+)
+
+// BranchFullError is returned by Order Create when the branch of an order
+// has no room for it and its overflow does not extend the ETA.
+type BranchFullError struct {
+	Overflow string
+}
+
+func (e *BranchFullError) Error() string {
+	return fmt.Sprintf("repo: branch is full, overflow %s", e.Overflow)
+}
+
+type BranchCapacityRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+// New -.
+func NewBranchCapacityRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *BranchCapacityRepo {
+	return &BranchCapacityRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+// Save sets the capacity of a branch.
+func (r *BranchCapacityRepo) Save(ctx context.Context, req entity.BranchCapacity) (entity.BranchCapacity, error) {
+	var updatedAt time.Time
+
+	query, args, err := r.pg.Builder.Insert("branch_capacity").
+		Columns(`branch_id, max_active_orders, max_items_per_window, max_orders_per_slot, overflow`).
+		Values(req.BranchID, req.MaxActiveOrders, req.MaxItemsPerWindow, req.MaxOrdersPerSlot, req.Overflow).
+		Suffix(`ON CONFLICT (branch_id) DO UPDATE SET max_active_orders = EXCLUDED.max_active_orders,
+			max_items_per_window = EXCLUDED.max_items_per_window, max_orders_per_slot = EXCLUDED.max_orders_per_slot,
+			overflow = EXCLUDED.overflow, updated_at = now()
+			RETURNING updated_at`).ToSql()
+	if err != nil {
+		return entity.BranchCapacity{}, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, query, args...).Scan(&updatedAt)
+	if err != nil {
+		return entity.BranchCapacity{}, err
+	}
+
+	req.UpdatedAt = updatedAt.Format(time.RFC3339)
+	return req, nil
+}
+
+// GetLoad returns the load of a branch, pgx.ErrNoRows when it does not exist.
+// A branch without capacity has no limits.
+func (r *BranchCapacityRepo) GetLoad(ctx context.Context, req entity.Id) (entity.BranchLoad, error) {
+	query, args, err := loadQuery(r.pg.Builder).Where("b.id = ?", req.ID).ToSql()
+	if err != nil {
+		return entity.BranchLoad{}, err
+	}
+
+	return scanBranchLoad(r.pg.Pool.QueryRow(ctx, query, args...))
+}
+
+// GetLoads lists the load of branches, columns of req are of branch b.
+func (r *BranchCapacityRepo) GetLoads(ctx context.Context, req entity.GetListFilter) (entity.BranchLoadList, error) {
+	response := entity.BranchLoadList{Items: []entity.BranchLoad{}}
+
+	queryBuilder, where := PrepareGetListQuery(loadQuery(r.pg.Builder), req)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item, err := scanBranchLoad(rows)
+		if err != nil {
+			return response, err
+		}
+
+		response.Items = append(response.Items, item)
+	}
+	if err = rows.Err(); err != nil {
+		return response, err
+	}
+
+	countQuery, args, err := r.pg.Builder.Select("COUNT(1)").From("branch b").Where(where).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(&response.Count)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// loadQuery counts the active orders of a branch and the cooked lines of the
+// orders it took, or released from schedule, within the capacity window.
+func loadQuery(builder squirrel.StatementBuilderType) squirrel.SelectBuilder {
+	return builder.
+		Select(`b.id, b.name, b.restaurant_id`).
+		Column(`(SELECT COUNT(1) FROM orders o WHERE o.branch_id = b.id AND o.status IN ('confirmed', 'preparing'))`).
+		Column(squirrel.Expr(`(SELECT COALESCE(SUM(oi.quantity), 0) FROM orders o
+			JOIN orderitems oi ON oi.order_id = o.id
+			JOIN product p ON p.id = oi.product_id AND NOT p.is_bundle
+			WHERE o.branch_id = b.id AND o.status NOT IN ('cancelled', 'scheduled')
+				AND CASE WHEN o.scheduled_at IS NULL THEN o.created_at ELSE o.start_after END > now() - ? * interval '1 second')`,
+			config.CapacityWindow.Seconds())).
+		Columns(`COALESCE(c.max_active_orders, 0), COALESCE(c.max_items_per_window, 0),
+			COALESCE(c.max_orders_per_slot, 0), COALESCE(c.overflow, 'extend_eta'), c.updated_at`).
+		From("branch b").
+		LeftJoin("branch_capacity c ON c.branch_id = b.id")
+}
+
+func scanBranchLoad(row pgx.Row) (entity.BranchLoad, error) {
+	var (
+		item      entity.BranchLoad
+		updatedAt sql.NullTime
+	)
+
+	err := row.Scan(&item.BranchID, &item.BranchName, &item.RestaurantID, &item.ActiveOrders, &item.WindowItems,
+		&item.Capacity.MaxActiveOrders, &item.Capacity.MaxItemsPerWindow, &item.Capacity.MaxOrdersPerSlot,
+		&item.Capacity.Overflow, &updatedAt)
+	if err != nil {
+		return entity.BranchLoad{}, err
+	}
+
+	item.Capacity.BranchID = item.BranchID
+	item.Capacity.UpdatedAt = formatNullTime(updatedAt)
+	return item, nil
+}
+
+// lockBranchLoad locks the capacity row of a branch until tx ends and counts
+// its load within tx, so orders placed at the same time are counted one after
+// the other. A branch without capacity has nothing to lock.
+func lockBranchLoad(ctx context.Context, tx pgx.Tx, builder squirrel.StatementBuilderType, branchID string) (entity.BranchLoad, error) {
+	var locked int
+	err := tx.QueryRow(ctx, `SELECT 1 FROM branch_capacity WHERE branch_id = $1 FOR UPDATE`, branchID).Scan(&locked)
+	if err != nil && err != pgx.ErrNoRows {
+		return entity.BranchLoad{}, err
+	}
+
+	query, args, err := loadQuery(builder).Where("b.id = ?", branchID).ToSql()
+	if err != nil {
+		return entity.BranchLoad{}, err
+	}
+
+	return scanBranchLoad(tx.QueryRow(ctx, query, args...))
+}
+
+// CapacityDelay is how long a new order of items cooked items waits at a
+// branch with load: a capacity window for every window of work ahead of it,
+// zero while the branch has room.
+func CapacityDelay(load entity.BranchLoad, items int) time.Duration {
+	windows := 0
+	if limit := load.Capacity.MaxActiveOrders; limit > 0 {
+		windows = load.ActiveOrders / limit
+	}
+	if limit := load.Capacity.MaxItemsPerWindow; limit > 0 {
+		windows = max(windows, (load.WindowItems+items-1)/limit)
+	}
+
+	return time.Duration(windows) * config.CapacityWindow
+}
+
+// CookedItems counts the items the kitchen cooks, a bundle line is cooked as
+// its component lines.
+func CookedItems(items []entity.OrderItems) int {
+	bundles := make(map[string]bool)
+	for _, item := range items {
+		if item.ParentItemID != "" {
+			bundles[item.ParentItemID] = true
+		}
+	}
+
+	count := 0
+	for _, item := range items {
+		if !bundles[item.Id] {
+			count += item.Quantity
+		}
+	}
+
+	return count
+}
@@ -0,0 +1,258 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/Akrom0181/Food-Delivery/pkg/logger"
+	"github.com/Akrom0181/Food-Delivery/pkg/postgres"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+)
+
+type BranchStaffRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+// New -.
+func NewBranchStaffRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *BranchStaffRepo {
+	return &BranchStaffRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+// Save gives the user the staff role and replaces their branches. Only users
+// and staff can be made staff, for anyone else it returns pgx.ErrNoRows. The
+// sessions of the user are ended so the role is picked up at the next login.
+func (r *BranchStaffRepo) Save(ctx context.Context, req entity.BranchStaff) (entity.BranchStaff, error) {
+	tx, err := r.pg.Pool.Begin(ctx)
+	if err != nil {
+		return entity.BranchStaff{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	query, args, err := r.pg.Builder.Update("users").
+		Set("user_role", req.Role).
+		Set("updated_at", squirrel.Expr("now()")).
+		Where(squirrel.Eq{"id": req.UserID, "user_role::text": append([]string{"user"}, config.BranchStaffRoles...)}).
+		ToSql()
+	if err != nil {
+		return entity.BranchStaff{}, err
+	}
+
+	tag, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return entity.BranchStaff{}, err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.BranchStaff{}, pgx.ErrNoRows
+	}
+
+	if err = r.setBranches(ctx, tx, req.UserID, req.BranchIDs); err != nil {
+		return entity.BranchStaff{}, err
+	}
+
+	if err = endSessions(ctx, tx, r.pg.Builder, req.UserID); err != nil {
+		return entity.BranchStaff{}, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return entity.BranchStaff{}, err
+	}
+
+	return r.GetSingle(ctx, entity.Id{ID: req.UserID})
+}
+
+// GetSingle returns pgx.ErrNoRows when the user is not staff.
+func (r *BranchStaffRepo) GetSingle(ctx context.Context, req entity.Id) (entity.BranchStaff, error) {
+	var item entity.BranchStaff
+
+	query, args, err := r.selectQuery().
+		Where(squirrel.Eq{"u.id": req.ID, "u.user_role::text": config.BranchStaffRoles}).
+		GroupBy("u.id").ToSql()
+	if err != nil {
+		return entity.BranchStaff{}, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, query, args...).Scan(&item.UserID, &item.FullName, &item.Role, &item.BranchIDs)
+	if err != nil {
+		return entity.BranchStaff{}, err
+	}
+
+	return item, nil
+}
+
+func (r *BranchStaffRepo) GetList(ctx context.Context, req entity.BranchStaffRequest) (entity.BranchStaffList, error) {
+	response := entity.BranchStaffList{Items: []entity.BranchStaff{}}
+
+	if req.Limit <= 0 {
+		req.Limit = 10
+	}
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+
+	where := squirrel.And{squirrel.Eq{"u.user_role::text": config.BranchStaffRoles}}
+	if req.BranchID != "" {
+		where = append(where, squirrel.Expr("u.id IN (SELECT user_id FROM branch_staff WHERE branch_id = ?)", req.BranchID))
+	}
+
+	query, args, err := r.selectQuery().Where(where).
+		GroupBy("u.id").OrderBy("u.full_name", "u.id").
+		Limit(uint64(req.Limit)).Offset(uint64((req.Page - 1) * req.Limit)).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item entity.BranchStaff
+		if err = rows.Scan(&item.UserID, &item.FullName, &item.Role, &item.BranchIDs); err != nil {
+			return response, err
+		}
+
+		response.Items = append(response.Items, item)
+	}
+	if err = rows.Err(); err != nil {
+		return response, err
+	}
+
+	countQuery, args, err := r.pg.Builder.Select("COUNT(1)").From("users u").Where(where).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(&response.Count)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// Delete makes the staff member a plain user again and ends their sessions.
+// It returns pgx.ErrNoRows when the user is not staff.
+func (r *BranchStaffRepo) Delete(ctx context.Context, req entity.Id) error {
+	tx, err := r.pg.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query, args, err := r.pg.Builder.Update("users").
+		Set("user_role", "user").
+		Set("updated_at", squirrel.Expr("now()")).
+		Where(squirrel.Eq{"id": req.ID, "user_role::text": config.BranchStaffRoles}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	tag, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	if err = r.setBranches(ctx, tx, req.ID, nil); err != nil {
+		return err
+	}
+
+	if err = endSessions(ctx, tx, r.pg.Builder, req.ID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// GetBranches returns the ids of the branches of a staff member.
+func (r *BranchStaffRepo) GetBranches(ctx context.Context, req entity.Id) ([]string, error) {
+	branches := []string{}
+
+	query, args, err := r.pg.Builder.Select("branch_id::text").From("branch_staff").Where("user_id = ?", req.ID).ToSql()
+	if err != nil {
+		return branches, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return branches, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		if err = rows.Scan(&id); err != nil {
+			return branches, err
+		}
+
+		branches = append(branches, id)
+	}
+
+	return branches, rows.Err()
+}
+
+// selectQuery selects a user with the ids of their branches, in the order
+// they were given.
+func (r *BranchStaffRepo) selectQuery() squirrel.SelectBuilder {
+	return r.pg.Builder.
+		Select(`u.id, u.full_name, u.user_role::text,
+			COALESCE(array_agg(bs.branch_id::text ORDER BY bs.created_at) FILTER (WHERE bs.branch_id IS NOT NULL), '{}')`).
+		From("users u").
+		LeftJoin("branch_staff bs ON bs.user_id = u.id")
+}
+
+// setBranches replaces the branches of a staff member.
+func (r *BranchStaffRepo) setBranches(ctx context.Context, tx pgx.Tx, userID string, branchIDs []string) error {
+	query, args, err := r.pg.Builder.Delete("branch_staff").Where("user_id = ?", userID).ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec(ctx, query, args...); err != nil {
+		return err
+	}
+
+	if len(branchIDs) == 0 {
+		return nil
+	}
+
+	insert := r.pg.Builder.Insert("branch_staff").Columns("user_id, branch_id")
+	for _, branchID := range branchIDs {
+		insert = insert.Values(userID, branchID)
+	}
+
+	query, args, err = insert.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+	return err
+}
+
+// endSessions logs the user out everywhere.
+func endSessions(ctx context.Context, tx pgx.Tx, builder squirrel.StatementBuilderType, userID string) error {
+	query, args, err := builder.Update("session").
+		Set("is_active", false).
+		Set("updated_at", squirrel.Expr("now()")).
+		Where("user_id = ? AND is_active", userID).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+	return err
+}
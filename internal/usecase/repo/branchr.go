@@ -2,7 +2,6 @@ package repo
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"time"
 
@@ -11,7 +10,6 @@ import (
 	"github.com/Akrom0181/Food-Delivery/pkg/logger"
 	"github.com/Akrom0181/Food-Delivery/pkg/postgres"
 	"github.com/google/uuid"
-	"github.com/jackc/pgx"
 )
 
 type BranchRepo struct {
@@ -31,10 +29,13 @@ func NewBranchRepo(pg *postgres.Postgres, config *config.Config, logger *logger.
 
 func (r *BranchRepo) Create(ctx context.Context, req entity.Branch) (entity.Branch, error) {
 	req.Id = uuid.NewString()
+	if req.RestaurantID == "" {
+		req.RestaurantID = config.DefaultRestaurantID
+	}
 
 	query, args, err := r.pg.Builder.Insert("branch").
-		Columns(`id, name, address, latitude, longitude, phone_number`).
-		Values(req.Id, req.Name, req.Address, req.Latitude, req.Longitude, req.Phone).ToSql()
+		Columns(`id, restaurant_id, name, address, latitude, longitude, phone_number`).
+		Values(req.Id, req.RestaurantID, req.Name, req.Address, req.Latitude, req.Longitude, req.Phone).ToSql()
 	if err != nil {
 		return entity.Branch{}, err
 	}
@@ -54,7 +55,7 @@ func (r *BranchRepo) GetSingle(ctx context.Context, req entity.Id) (entity.Branc
 	)
 
 	queryBuilder := r.pg.Builder.
-		Select(`id, name, address, latitude, longitude, phone_number, created_at, updated_at`).
+		Select(`id, restaurant_id, name, address, latitude, longitude, phone_number, created_at, updated_at`).
 		From("branch")
 
 	switch {
@@ -70,7 +71,7 @@ func (r *BranchRepo) GetSingle(ctx context.Context, req entity.Id) (entity.Branc
 	}
 
 	err = r.pg.Pool.QueryRow(ctx, query, args...).
-		Scan(&response.Id, &response.Name, &response.Address, &response.Latitude, &response.Longitude, &response.Phone, &createdAt, &updatedAt)
+		Scan(&response.Id, &response.RestaurantID, &response.Name, &response.Address, &response.Latitude, &response.Longitude, &response.Phone, &createdAt, &updatedAt)
 	if err != nil {
 		return entity.Branch{}, err
 	}
@@ -88,7 +89,7 @@ func (r *BranchRepo) GetList(ctx context.Context, req entity.GetListFilter) (ent
 	)
 
 	queryBuilder := r.pg.Builder.
-		Select(`id, name, address, latitude, longitude, phone_number, created_at, updated_at`).
+		Select(`id, restaurant_id, name, address, latitude, longitude, phone_number, created_at, updated_at`).
 		From("branch")
 
 	queryBuilder, where := PrepareGetListQuery(queryBuilder, req)
@@ -106,7 +107,7 @@ func (r *BranchRepo) GetList(ctx context.Context, req entity.GetListFilter) (ent
 
 	for rows.Next() {
 		var item entity.Branch
-		err = rows.Scan(&item.Id, &item.Name, &item.Address, &item.Latitude, &item.Longitude, &item.Phone, &createdAt, &updatedAt)
+		err = rows.Scan(&item.Id, &item.RestaurantID, &item.Name, &item.Address, &item.Latitude, &item.Longitude, &item.Phone, &createdAt, &updatedAt)
 		if err != nil {
 			return response, err
 		}
@@ -190,33 +191,94 @@ func (r *BranchRepo) UpdateField(ctx context.Context, req entity.UpdateFieldRequ
 	return response, nil
 }
 
-func (r *BranchRepo) GetNearestBranch(ctx context.Context, lat, lon float64) (entity.Branch, error) {
-	const searchRadius = 10000.0 // 10 km radius ichidagi filiallarni qidiramiz
+// GetNearestBranches returns the branches of the restaurant within the
+// delivery radius of the location, nearest first.
+func (r *BranchRepo) GetNearestBranches(ctx context.Context, restaurantID string, lat, lon float64) ([]entity.Branch, error) {
 	var (
+		response               []entity.Branch
 		created_at, updated_at time.Time
 	)
-	var branch entity.Branch
 	query := `
-		SELECT id, name, address, latitude, longitude, phone_number, created_at, updated_at
+		SELECT id, restaurant_id, name, address, latitude, longitude, phone_number, created_at, updated_at
 		FROM branch
-		WHERE earth_distance(ll_to_earth($1, $2), ll_to_earth(latitude, longitude)) < $3
+		WHERE restaurant_id = $4 AND earth_distance(ll_to_earth($1, $2), ll_to_earth(latitude, longitude)) < $3
 		ORDER BY earth_distance(ll_to_earth($1, $2), ll_to_earth(latitude, longitude))
-		LIMIT 1
 	`
 
-	err := r.pg.Pool.QueryRow(ctx, query, lat, lon, searchRadius).Scan(
-		&branch.Id, &branch.Name, &branch.Address, &branch.Latitude, &branch.Longitude,
-		&branch.Phone, &created_at, &updated_at,
-	)
+	rows, err := r.pg.Pool.Query(ctx, query, lat, lon, config.DeliveryRadius, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
+	for rows.Next() {
+		var branch entity.Branch
+		err = rows.Scan(
+			&branch.Id, &branch.RestaurantID, &branch.Name, &branch.Address, &branch.Latitude, &branch.Longitude,
+			&branch.Phone, &created_at, &updated_at,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		branch.CreatedAt = created_at.Format(time.RFC3339)
+		branch.UpdatedAt = updated_at.Format(time.RFC3339)
+		response = append(response, branch)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// SaveHours replaces the opening hours of a branch.
+func (r *BranchRepo) SaveHours(ctx context.Context, req entity.BranchHoursList) (entity.BranchHoursList, error) {
+	tx, err := r.pg.Pool.Begin(ctx)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return entity.Branch{}, errors.New("there is no branch near you in 10 km radius")
+		return entity.BranchHoursList{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err = tx.Exec(ctx, `DELETE FROM branch_hours WHERE branch_id = $1`, req.BranchID); err != nil {
+		return entity.BranchHoursList{}, err
+	}
+
+	for _, hours := range req.Hours {
+		_, err = tx.Exec(ctx, `INSERT INTO branch_hours (branch_id, weekday, opens_at, closes_at)
+			VALUES ($1, $2, $3::text::time, $4::text::time)`, req.BranchID, hours.Weekday, hours.OpensAt, hours.ClosesAt)
+		if err != nil {
+			return entity.BranchHoursList{}, err
 		}
-		return entity.Branch{}, err
 	}
 
-	branch.CreatedAt = created_at.Format(time.RFC3339)
-	branch.UpdatedAt = updated_at.Format(time.RFC3339)
-	return branch, nil
+	if err = tx.Commit(ctx); err != nil {
+		return entity.BranchHoursList{}, err
+	}
+
+	return r.GetHours(ctx, entity.Id{ID: req.BranchID})
+}
+
+// GetHours returns the opening hours of a branch by weekday.
+func (r *BranchRepo) GetHours(ctx context.Context, req entity.Id) (entity.BranchHoursList, error) {
+	response := entity.BranchHoursList{BranchID: req.ID, Hours: []entity.BranchHours{}}
+
+	rows, err := r.pg.Pool.Query(ctx, `SELECT weekday, to_char(opens_at, 'HH24:MI'),
+			CASE WHEN closes_at = '24:00' THEN '24:00' ELSE to_char(closes_at, 'HH24:MI') END
+		FROM branch_hours WHERE branch_id = $1 ORDER BY weekday`, req.ID)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var hours entity.BranchHours
+		if err = rows.Scan(&hours.Weekday, &hours.OpensAt, &hours.ClosesAt); err != nil {
+			return response, err
+		}
+
+		response.Hours = append(response.Hours, hours)
+	}
+
+	return response, rows.Err()
 }
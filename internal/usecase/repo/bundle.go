@@ -0,0 +1,186 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/Akrom0181/Food-Delivery/pkg/logger"
+	"github.com/Akrom0181/Food-Delivery/pkg/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+type BundleRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+// New -.
+func NewBundleRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *BundleRepo {
+	return &BundleRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+// Get returns the slots of a bundle with their options in display order. It
+// returns pgx.ErrNoRows when the product does not exist or is not a bundle.
+// With a branch, options sold out there are marked unavailable.
+func (r *BundleRepo) Get(ctx context.Context, req entity.BundleRequest) (entity.Bundle, error) {
+	var (
+		response = entity.Bundle{ProductID: req.ProductID, Slots: []entity.BundleSlot{}}
+		isBundle bool
+	)
+
+	err := r.pg.Pool.QueryRow(ctx, `SELECT is_bundle FROM product WHERE id = $1`, req.ProductID).Scan(&isBundle)
+	if err != nil {
+		return response, err
+	}
+
+	if !isBundle {
+		return response, pgx.ErrNoRows
+	}
+
+	queryBuilder := r.pg.Builder.
+		Select(`s.id, s.name, s.quantity, s.sort_order, o.product_id`).
+		Column(translatedColumn(TranslationEntityProduct, "c", "name", req.Locales)).
+		Columns(`o.price_delta, o.is_default`).
+		From("bundle_slot s").
+		Join("bundle_slot_option o ON o.slot_id = s.id").
+		Join("product c ON c.id = o.product_id").
+		Where("s.bundle_id = ?", req.ProductID).
+		OrderBy("s.sort_order", "s.created_at", "o.sort_order")
+
+	if req.BranchID != "" {
+		queryBuilder = queryBuilder.Column("c.is_active AND product_available(c.id, ?::uuid)", req.BranchID)
+	} else {
+		queryBuilder = queryBuilder.Column("c.is_active")
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			slot   entity.BundleSlot
+			option entity.BundleSlotOption
+		)
+
+		err = rows.Scan(&slot.ID, &slot.Name, &slot.Quantity, &slot.SortOrder, &option.ProductID,
+			&option.Name, &option.PriceDelta, &option.IsDefault, &option.IsAvailable)
+		if err != nil {
+			return response, err
+		}
+
+		if n := len(response.Slots); n > 0 && response.Slots[n-1].ID == slot.ID {
+			response.Slots[n-1].Options = append(response.Slots[n-1].Options, option)
+			continue
+		}
+
+		slot.Options = []entity.BundleSlotOption{option}
+		response.Slots = append(response.Slots, slot)
+	}
+
+	return response, rows.Err()
+}
+
+// Save makes the product a bundle and replaces its slots. It returns
+// pgx.ErrNoRows when the product does not exist.
+func (r *BundleRepo) Save(ctx context.Context, req entity.Bundle) (entity.Bundle, error) {
+	tx, err := r.pg.Pool.Begin(ctx)
+	if err != nil {
+		return req, err
+	}
+	defer tx.Rollback(ctx)
+
+	query, args, err := r.pg.Builder.Update("product").
+		Set("is_bundle", true).
+		Set("updated_at", "now()").
+		Where("id = ?", req.ProductID).ToSql()
+	if err != nil {
+		return req, err
+	}
+
+	n, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return req, err
+	}
+
+	if n.RowsAffected() == 0 {
+		return req, pgx.ErrNoRows
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM bundle_slot WHERE bundle_id = $1`, req.ProductID)
+	if err != nil {
+		return req, err
+	}
+
+	for i := range req.Slots {
+		slot := &req.Slots[i]
+		slot.ID = uuid.NewString()
+
+		query, args, err := r.pg.Builder.Insert("bundle_slot").
+			Columns(`id, bundle_id, name, quantity, sort_order`).
+			Values(slot.ID, req.ProductID, slot.Name, slot.Quantity, slot.SortOrder).ToSql()
+		if err != nil {
+			return req, err
+		}
+
+		_, err = tx.Exec(ctx, query, args...)
+		if err != nil {
+			return req, err
+		}
+
+		for j, option := range slot.Options {
+			query, args, err := r.pg.Builder.Insert("bundle_slot_option").
+				Columns(`slot_id, product_id, price_delta, is_default, sort_order`).
+				Values(slot.ID, option.ProductID, option.PriceDelta, option.IsDefault, j).ToSql()
+			if err != nil {
+				return req, err
+			}
+
+			_, err = tx.Exec(ctx, query, args...)
+			if err != nil {
+				return req, err
+			}
+		}
+	}
+
+	return req, tx.Commit(ctx)
+}
+
+// Delete removes the slots of a bundle, the product stays as a plain one.
+func (r *BundleRepo) Delete(ctx context.Context, req entity.Id) error {
+	tx, err := r.pg.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	n, err := tx.Exec(ctx, `UPDATE product SET is_bundle = false, updated_at = now() WHERE id = $1 AND is_bundle`, req.ID)
+	if err != nil {
+		return err
+	}
+
+	if n.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM bundle_slot WHERE bundle_id = $1`, req.ID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/Akrom0181/Food-Delivery/internal/entity"
 	"github.com/Akrom0181/Food-Delivery/pkg/logger"
 	"github.com/Akrom0181/Food-Delivery/pkg/postgres"
+	"github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 )
 
@@ -29,20 +30,32 @@ func NewCategoryRepo(pg *postgres.Postgres, config *config.Config, logger *logge
 
 func (r *CategoryRepo) Create(ctx context.Context, req entity.Category) (entity.Category, error) {
 	req.Id = uuid.NewString()
+	if req.Images == nil {
+		req.Images = entity.ImageVariants{}
+	}
 
+	// without a position the category goes after its siblings, without a
+	// restaurant it is in the one of its parent
 	query, args, err := r.pg.Builder.Insert("category").
-		Columns(`id, name`).
-		Values(req.Id, req.Name).ToSql()
+		Columns(`id, restaurant_id, parent_id, name, images, kitchen_station_id, sort_order, is_active, is_hidden`).
+		Values(req.Id,
+			squirrel.Expr("COALESCE(NULLIF(?, '')::uuid, (SELECT restaurant_id FROM category WHERE id = NULLIF(?, '')::uuid), ?)",
+				req.RestaurantID, req.ParentID, config.DefaultRestaurantID),
+			squirrel.Expr("NULLIF(?, '')::uuid", req.ParentID), req.Name, req.Images,
+			squirrel.Expr("NULLIF(?, '')::uuid", req.KitchenStationID),
+			squirrel.Expr(`COALESCE(?::int, (SELECT COALESCE(MAX(sort_order), 0) + 10 FROM category WHERE parent_id IS NOT DISTINCT FROM NULLIF(?, '')::uuid))`, req.SortOrder, req.ParentID),
+			squirrel.Expr("COALESCE(?::boolean, true)", req.IsActive),
+			squirrel.Expr("COALESCE(?::boolean, false)", req.IsHidden)).ToSql()
 	if err != nil {
 		return entity.Category{}, err
 	}
 
-	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	err = execLinkingUploads(ctx, r.pg, query, args, UploadEntityCategory, req.Id, imageURLs(req.Images))
 	if err != nil {
 		return entity.Category{}, err
 	}
 
-	return req, nil
+	return r.GetSingle(ctx, entity.CategorySingleRequest{ID: req.Id})
 }
 
 func (r *CategoryRepo) GetSingle(ctx context.Context, req entity.CategorySingleRequest) (entity.Category, error) {
@@ -52,7 +65,9 @@ func (r *CategoryRepo) GetSingle(ctx context.Context, req entity.CategorySingleR
 	)
 
 	queryBuilder := r.pg.Builder.
-		Select(`id, name, created_at, updated_at`).
+		Select(`id, restaurant_id, COALESCE(parent_id::text, '')`).
+		Column(translatedColumn(TranslationEntityCategory, "category", "name", req.Locales)).
+		Columns(`images, COALESCE(kitchen_station_id::text, ''), sort_order, is_active, is_hidden, created_at, updated_at`).
 		From("category")
 
 	switch {
@@ -70,7 +85,8 @@ func (r *CategoryRepo) GetSingle(ctx context.Context, req entity.CategorySingleR
 	}
 
 	err = r.pg.Pool.QueryRow(ctx, query, args...).
-		Scan(&response.Id, &response.Name, &createdAt, &updatedAt)
+		Scan(&response.Id, &response.RestaurantID, &response.ParentID, &response.Name, &response.Images, &response.KitchenStationID, &response.SortOrder, &response.IsActive,
+			&response.IsHidden, &createdAt, &updatedAt)
 	if err != nil {
 		return entity.Category{}, err
 	}
@@ -88,7 +104,9 @@ func (r *CategoryRepo) GetList(ctx context.Context, req entity.GetListFilter) (e
 	)
 
 	queryBuilder := r.pg.Builder.
-		Select(`id, name, created_at, updated_at`).
+		Select(`id, restaurant_id, COALESCE(parent_id::text, '')`).
+		Column(translatedColumn(TranslationEntityCategory, "category", "name", req.Locales)).
+		Columns(`images, COALESCE(kitchen_station_id::text, ''), sort_order, is_active, is_hidden, created_at, updated_at`).
 		From("category")
 
 	queryBuilder, where := PrepareGetListQuery(queryBuilder, req)
@@ -106,7 +124,8 @@ func (r *CategoryRepo) GetList(ctx context.Context, req entity.GetListFilter) (e
 
 	for rows.Next() {
 		var item entity.Category
-		err = rows.Scan(&item.Id, &item.Name, &createdAt, &updatedAt)
+		err = rows.Scan(&item.Id, &item.RestaurantID, &item.ParentID, &item.Name, &item.Images, &item.KitchenStationID, &item.SortOrder, &item.IsActive, &item.IsHidden,
+			&createdAt, &updatedAt)
 		if err != nil {
 			return response, err
 		}
@@ -132,8 +151,24 @@ func (r *CategoryRepo) GetList(ctx context.Context, req entity.GetListFilter) (e
 
 func (r *CategoryRepo) Update(ctx context.Context, req entity.Category) (entity.Category, error) {
 	mp := map[string]interface{}{
-		"name":       req.Name,
-		"updated_at": "now()",
+		"name":               req.Name,
+		"parent_id":          squirrel.Expr("NULLIF(?, '')::uuid", req.ParentID),
+		"kitchen_station_id": squirrel.Expr("NULLIF(?, '')::uuid", req.KitchenStationID),
+		"updated_at":         "now()",
+	}
+
+	// the position, flags and images are only replaced when given
+	if req.SortOrder != nil {
+		mp["sort_order"] = *req.SortOrder
+	}
+	if req.IsActive != nil {
+		mp["is_active"] = *req.IsActive
+	}
+	if req.IsHidden != nil {
+		mp["is_hidden"] = *req.IsHidden
+	}
+	if req.Images != nil {
+		mp["images"] = req.Images
 	}
 
 	query, args, err := r.pg.Builder.Update("category").SetMap(mp).Where("id = ?", req.Id).ToSql()
@@ -141,12 +176,16 @@ func (r *CategoryRepo) Update(ctx context.Context, req entity.Category) (entity.
 		return entity.Category{}, err
 	}
 
-	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	if req.Images != nil {
+		err = execLinkingUploads(ctx, r.pg, query, args, UploadEntityCategory, req.Id, imageURLs(req.Images))
+	} else {
+		_, err = r.pg.Pool.Exec(ctx, query, args...)
+	}
 	if err != nil {
 		return entity.Category{}, err
 	}
 
-	return req, nil
+	return r.GetSingle(ctx, entity.CategorySingleRequest{ID: req.Id})
 }
 
 func (r *CategoryRepo) Delete(ctx context.Context, req entity.Id) error {
@@ -155,7 +194,8 @@ func (r *CategoryRepo) Delete(ctx context.Context, req entity.Id) error {
 		return err
 	}
 
-	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	// subcategories and products go with it, their uploads are pruned by the sweeper
+	err = execLinkingUploads(ctx, r.pg, query, args, UploadEntityCategory, req.ID, nil)
 	if err != nil {
 		return err
 	}
@@ -163,6 +203,11 @@ func (r *CategoryRepo) Delete(ctx context.Context, req entity.Id) error {
 	return nil
 }
 
+// Sort sets the position of several categories among their siblings.
+func (r *CategoryRepo) Sort(ctx context.Context, req entity.SortRequest) error {
+	return sortRows(ctx, r.pg, "category", req)
+}
+
 func (r *CategoryRepo) UpdateField(ctx context.Context, req entity.UpdateFieldRequest) (entity.RowsEffected, error) {
 	mp := map[string]interface{}{}
 	response := entity.RowsEffected{}
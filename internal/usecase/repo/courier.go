@@ -2,6 +2,8 @@ package repo
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/Akrom0181/Food-Delivery/config"
 	"github.com/Akrom0181/Food-Delivery/internal/entity"
@@ -68,3 +70,40 @@ func (r *CourierRepo) AssignOrderToCourier(ctx context.Context, orderID, courier
 	}
 	return nil
 }
+
+func (r *CourierRepo) GetByUserID(ctx context.Context, userID string) (entity.Courier, error) {
+	var (
+		response             entity.Courier
+		lastUpdated          sql.NullTime
+		createdAt, updatedAt sql.NullTime
+		latitude, longitude  sql.NullFloat64
+	)
+
+	query, args, err := r.pg.Builder.
+		Select(`id, user_id, status, latitude, longitude, last_updated, created_at, updated_at`).
+		From("couriers").
+		Where("user_id = ?", userID).ToSql()
+	if err != nil {
+		return entity.Courier{}, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, query, args...).
+		Scan(&response.ID, &response.UserID, &response.Status, &latitude, &longitude, &lastUpdated, &createdAt, &updatedAt)
+	if err != nil {
+		return entity.Courier{}, err
+	}
+
+	response.Latitude = latitude.Float64
+	response.Longitude = longitude.Float64
+	if lastUpdated.Valid {
+		response.LastUpdated = lastUpdated.Time.Format(time.RFC3339)
+	}
+	if createdAt.Valid {
+		response.CreatedAt = createdAt.Time.Format(time.RFC3339)
+	}
+	if updatedAt.Valid {
+		response.UpdatedAt = updatedAt.Time.Format(time.RFC3339)
+	}
+
+	return response, nil
+}
@@ -1,8 +1,13 @@
 package repo
 
 import (
+	"context"
+	"strings"
+
 	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/Akrom0181/Food-Delivery/pkg/postgres"
 	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
 )
 
 func PrepareFilter(filters []entity.Filter) squirrel.And {
@@ -23,8 +28,16 @@ func PrepareFilter(filters []entity.Filter) squirrel.And {
 			where = append(where, squirrel.Lt{e.Column: e.Value})
 		case "lte":
 			where = append(where, squirrel.LtOrEq{e.Column: e.Value})
+		// the column is one of the comma separated values
+		case "in":
+			where = append(where, squirrel.Eq{e.Column: strings.Split(e.Value, ",")})
 		case "search":
 			or = append(or, squirrel.ILike{e.Column: "%" + e.Value + "%"})
+		// the array column has all or none of the comma separated values
+		case "contains":
+			where = append(where, squirrel.Expr(e.Column+" @> ?::text[]", strings.Split(e.Value, ",")))
+		case "excludes":
+			where = append(where, squirrel.Expr("NOT "+e.Column+" && ?::text[]", strings.Split(e.Value, ",")))
 		}
 	}
 
@@ -56,3 +69,34 @@ func PrepareGetListQuery(selectQuery squirrel.SelectBuilder, filterRequest entit
 
 	return selectQuery, where
 }
+
+// sortRows sets the sort_order of the rows of table in one transaction. It
+// returns pgx.ErrNoRows when one of them does not exist.
+func sortRows(ctx context.Context, pg *postgres.Postgres, table string, req entity.SortRequest) error {
+	tx, err := pg.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	for _, item := range req.Items {
+		query, args, err := pg.Builder.Update(table).
+			Set("sort_order", item.SortOrder).
+			Set("updated_at", "now()").
+			Where("id = ?", item.ID).ToSql()
+		if err != nil {
+			return err
+		}
+
+		n, err := tx.Exec(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+
+		if n.RowsAffected() == 0 {
+			return pgx.ErrNoRows
+		}
+	}
+
+	return tx.Commit(ctx)
+}
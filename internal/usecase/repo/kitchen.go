@@ -0,0 +1,355 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/Akrom0181/Food-Delivery/pkg/logger"
+	"github.com/Akrom0181/Food-Delivery/pkg/postgres"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+)
+
+// kitchenOpen are the statuses of the orders the kitchen works on.
+var kitchenOpen = []string{"pending", "confirmed", "preparing"}
+
+type KitchenRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+// New -.
+func NewKitchenRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *KitchenRepo {
+	return &KitchenRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+// GetTickets returns the open orders of a branch oldest first, or the latest
+// bumped ones when req.Bumped is set. Only lines the kitchen makes are listed,
+// orders with none of them at req.StationID are left out.
+func (r *KitchenRepo) GetTickets(ctx context.Context, req entity.KitchenTicketsRequest) (entity.KitchenTicketList, error) {
+	response := entity.KitchenTicketList{Items: []entity.KitchenTicket{}}
+
+	queryBuilder := r.pg.Builder.
+		Select(`o.id, o.branch_id::text, o.status, o.delivery_status, o.ready_eta, o.bumped_at, o.created_at,
+			oi.id, oi.product_id`).
+		Column(translatedColumn(TranslationEntityProduct, "p", "name", req.Locales)).
+		Columns(`oi.quantity, COALESCE(oi.parent_item_id::text, ''), COALESCE(ks.id::text, ''), COALESCE(ks.name, ''),
+			oi.kitchen_status, oi.cooking_at, oi.ready_at`).
+		From("orders o").
+		Join("orderitems oi ON oi.order_id = o.id").
+		Join("product p ON p.id = oi.product_id AND NOT p.is_bundle").
+		LeftJoin("category c ON c.id = p.category_id").
+		LeftJoin("category pc ON pc.id = c.parent_id").
+		LeftJoin("kitchen_station ks ON ks.id = COALESCE(c.kitchen_station_id, pc.kitchen_station_id)").
+		Where(squirrel.Eq{"o.branch_id": req.BranchID, "o.status": kitchenOpen})
+
+	switch {
+	case req.OrderID != "":
+		queryBuilder = queryBuilder.Where("o.id = ?", req.OrderID).OrderBy("oi.created_at", "oi.id")
+	case req.Bumped:
+		queryBuilder = queryBuilder.
+			Where(`o.id IN (SELECT id FROM orders WHERE branch_id = ? AND status = ANY(?) AND bumped_at IS NOT NULL
+				ORDER BY bumped_at DESC LIMIT ?)`, req.BranchID, kitchenOpen, req.Limit).
+			OrderBy("o.bumped_at DESC", "o.id", "oi.created_at", "oi.id")
+	default:
+		queryBuilder = queryBuilder.Where("o.bumped_at IS NULL").OrderBy("o.created_at", "o.id", "oi.created_at", "oi.id")
+	}
+
+	if req.StationID != "" {
+		queryBuilder = queryBuilder.Where("ks.id = ?", req.StationID)
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			ticket                 entity.KitchenTicket
+			item                   entity.KitchenItem
+			readyETA, bumpedAt     sql.NullTime
+			createdAt              time.Time
+			cookingAt, itemReadyAt sql.NullTime
+		)
+
+		err = rows.Scan(&ticket.OrderID, &ticket.BranchID, &ticket.Status, &ticket.DeliveryStatus, &readyETA, &bumpedAt,
+			&createdAt, &item.ID, &item.ProductID, &item.Name, &item.Quantity, &item.ParentItemID, &item.StationID,
+			&item.StationName, &item.Status, &cookingAt, &itemReadyAt)
+		if err != nil {
+			return response, err
+		}
+
+		item.CookingAt = formatNullTime(cookingAt)
+		item.ReadyAt = formatNullTime(itemReadyAt)
+
+		// rows of an order are next to each other
+		if n := len(response.Items); n > 0 && response.Items[n-1].OrderID == ticket.OrderID {
+			response.Items[n-1].Items = append(response.Items[n-1].Items, item)
+			continue
+		}
+
+		ticket.ReadyETA = formatNullTime(readyETA)
+		ticket.BumpedAt = formatNullTime(bumpedAt)
+		ticket.CreatedAt = createdAt.Format(time.RFC3339)
+		ticket.Items = []entity.KitchenItem{item}
+		response.Items = append(response.Items, ticket)
+	}
+	if err = rows.Err(); err != nil {
+		return response, err
+	}
+
+	response.Count = len(response.Items)
+
+	return response, nil
+}
+
+// GetTicket returns an open order of a branch, bumped or not, or pgx.ErrNoRows.
+func (r *KitchenRepo) GetTicket(ctx context.Context, req entity.KitchenTicketsRequest) (entity.KitchenTicket, error) {
+	if req.OrderID == "" {
+		return entity.KitchenTicket{}, fmt.Errorf("GetTicket - invalid request")
+	}
+
+	tickets, err := r.GetTickets(ctx, req)
+	if err != nil {
+		return entity.KitchenTicket{}, err
+	}
+
+	if len(tickets.Items) == 0 {
+		return entity.KitchenTicket{}, pgx.ErrNoRows
+	}
+
+	return tickets.Items[0], nil
+}
+
+// GetItemBranch returns the branch of the order of a line.
+func (r *KitchenRepo) GetItemBranch(ctx context.Context, req entity.Id) (string, error) {
+	var branchID string
+
+	query, args, err := r.pg.Builder.Select(`COALESCE(o.branch_id::text, '')`).
+		From("orderitems oi").
+		Join("orders o ON o.id = oi.order_id").
+		Where("oi.id = ?", req.ID).ToSql()
+	if err != nil {
+		return "", err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, query, args...).Scan(&branchID)
+	return branchID, err
+}
+
+// SetItemStatus moves a line of an open order. Cooking the first line moves
+// the order to preparing, a line that was cooking and is ready teaches the
+// prep time of its product.
+func (r *KitchenRepo) SetItemStatus(ctx context.Context, req entity.KitchenItemStatus) (entity.KitchenTicket, error) {
+	tx, err := r.pg.Pool.Begin(ctx)
+	if err != nil {
+		return entity.KitchenTicket{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	var orderID, branchID string
+
+	query, args, err := r.pg.Builder.Select("o.id, o.branch_id::text").
+		From("orderitems oi").
+		Join("orders o ON o.id = oi.order_id").
+		Join("product p ON p.id = oi.product_id").
+		Where(squirrel.Eq{"oi.id": req.ID, "o.status": kitchenOpen}).
+		Where("NOT p.is_bundle AND o.branch_id IS NOT NULL").
+		Suffix("FOR UPDATE OF o").ToSql()
+	if err != nil {
+		return entity.KitchenTicket{}, err
+	}
+
+	err = tx.QueryRow(ctx, query, args...).Scan(&orderID, &branchID)
+	if err != nil {
+		return entity.KitchenTicket{}, err
+	}
+
+	switch req.Status {
+	case "ready":
+		err = markReady(ctx, tx, branchID, "oi.id", req.ID)
+	case "cooking":
+		err = r.exec(ctx, tx, r.pg.Builder.Update("orderitems").
+			Set("kitchen_status", "cooking").
+			Set("cooking_at", squirrel.Expr("now()")).
+			Set("ready_at", nil).
+			Where("id = ? AND kitchen_status <> 'cooking'", req.ID))
+		if err == nil {
+			err = r.exec(ctx, tx, r.pg.Builder.Update("orders").
+				Set("status", "preparing").
+				Set("updated_at", squirrel.Expr("now()")).
+				Where(squirrel.Eq{"id": orderID, "status": []string{"pending", "confirmed"}}))
+		}
+	case "queued":
+		err = r.exec(ctx, tx, r.pg.Builder.Update("orderitems").
+			Set("kitchen_status", "queued").
+			Set("cooking_at", nil).
+			Set("ready_at", nil).
+			Where("id = ?", req.ID))
+	default:
+		err = fmt.Errorf("SetItemStatus - invalid status %q", req.Status)
+	}
+	if err != nil {
+		return entity.KitchenTicket{}, err
+	}
+
+	if err = refreshReadyETA(ctx, tx, r.pg.Builder, orderID); err != nil {
+		return entity.KitchenTicket{}, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return entity.KitchenTicket{}, err
+	}
+
+	return r.GetTicket(ctx, entity.KitchenTicketsRequest{BranchID: branchID, OrderID: orderID, Locales: req.Locales})
+}
+
+// Bump marks the lines of an open order ready and takes it off the kitchen
+// display. It returns pgx.ErrNoRows when the order is not on the display.
+func (r *KitchenRepo) Bump(ctx context.Context, req entity.Id) (entity.KitchenTicket, error) {
+	tx, err := r.pg.Pool.Begin(ctx)
+	if err != nil {
+		return entity.KitchenTicket{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	var branchID string
+
+	query, args, err := r.pg.Builder.Select("branch_id::text").
+		From("orders").
+		Where(squirrel.Eq{"id": req.ID, "status": kitchenOpen}).
+		Where("branch_id IS NOT NULL AND bumped_at IS NULL").
+		Suffix("FOR UPDATE").ToSql()
+	if err != nil {
+		return entity.KitchenTicket{}, err
+	}
+
+	err = tx.QueryRow(ctx, query, args...).Scan(&branchID)
+	if err != nil {
+		return entity.KitchenTicket{}, err
+	}
+
+	if err = markReady(ctx, tx, branchID, "oi.order_id", req.ID); err != nil {
+		return entity.KitchenTicket{}, err
+	}
+
+	err = r.exec(ctx, tx, r.pg.Builder.Update("orders").Set("bumped_at", squirrel.Expr("now()")).Where("id = ?", req.ID))
+	if err != nil {
+		return entity.KitchenTicket{}, err
+	}
+
+	if err = refreshReadyETA(ctx, tx, r.pg.Builder, req.ID); err != nil {
+		return entity.KitchenTicket{}, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return entity.KitchenTicket{}, err
+	}
+
+	return r.GetTicket(ctx, entity.KitchenTicketsRequest{BranchID: branchID, OrderID: req.ID, Locales: req.Locales})
+}
+
+// Recall puts a bumped order that is still open back on the kitchen display.
+// It returns pgx.ErrNoRows when there is no such order.
+func (r *KitchenRepo) Recall(ctx context.Context, req entity.Id) (entity.KitchenTicket, error) {
+	var branchID string
+
+	query, args, err := r.pg.Builder.Update("orders").
+		Set("bumped_at", nil).
+		Where(squirrel.Eq{"id": req.ID, "status": kitchenOpen}).
+		Where("branch_id IS NOT NULL AND bumped_at IS NOT NULL").
+		Suffix("RETURNING branch_id::text").ToSql()
+	if err != nil {
+		return entity.KitchenTicket{}, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, query, args...).Scan(&branchID)
+	if err != nil {
+		return entity.KitchenTicket{}, err
+	}
+
+	return r.GetTicket(ctx, entity.KitchenTicketsRequest{BranchID: branchID, OrderID: req.ID, Locales: req.Locales})
+}
+
+// exec runs a statement built with the query builder in tx.
+func (r *KitchenRepo) exec(ctx context.Context, tx pgx.Tx, builder squirrel.Sqlizer) error {
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+	return err
+}
+
+// markReady marks the kitchen lines where column is id ready. The lines that
+// were cooking are samples of the prep time of their product at the branch,
+// averaged over the last config.PrepTimeSamples. It is a data-modifying CTE,
+// which the query builder does not build.
+func markReady(ctx context.Context, tx pgx.Tx, branchID, column, id string) error {
+	_, err := tx.Exec(ctx, `WITH done AS (
+			UPDATE orderitems oi SET kitchen_status = 'ready', ready_at = now()
+			FROM product p
+			WHERE p.id = oi.product_id AND NOT p.is_bundle AND oi.kitchen_status <> 'ready' AND `+column+` = $1
+			RETURNING oi.product_id, EXTRACT(EPOCH FROM oi.ready_at - oi.cooking_at) AS seconds
+		)
+		INSERT INTO prep_time AS pt (branch_id, product_id, seconds, samples)
+		SELECT $2::uuid, product_id, avg(seconds), count(*) FROM done WHERE seconds > 0 GROUP BY product_id
+		ON CONFLICT (branch_id, product_id) DO UPDATE SET
+			seconds = pt.seconds + (EXCLUDED.seconds - pt.seconds) * EXCLUDED.samples / LEAST(pt.samples + EXCLUDED.samples, $3)::float8,
+			samples = pt.samples + EXCLUDED.samples,
+			updated_at = now()`, id, branchID, config.PrepTimeSamples)
+
+	return err
+}
+
+// refreshReadyETA sets when the order is expected to be ready: queued lines
+// take the prep time of their product from now, or from when a full branch
+// gets to the order, cooking ones from when they started, and the order is
+// ready with its last line.
+func refreshReadyETA(ctx context.Context, tx pgx.Tx, builder squirrel.StatementBuilderType, orderID string) error {
+	prepTime := config.DefaultPrepTime.Seconds()
+
+	query, args, err := builder.Update("orders o").
+		Set("ready_eta", squirrel.Expr(`(
+			SELECT max(CASE oi.kitchen_status
+				WHEN 'ready' THEN oi.ready_at
+				WHEN 'cooking' THEN GREATEST(now(), oi.cooking_at + COALESCE(pt.seconds, ?) * interval '1 second')
+				ELSE GREATEST(now(), o.start_after) + COALESCE(pt.seconds, ?) * interval '1 second'
+			END)
+			FROM orderitems oi
+			JOIN product p ON p.id = oi.product_id AND NOT p.is_bundle
+			LEFT JOIN prep_time pt ON pt.branch_id = o.branch_id AND pt.product_id = oi.product_id
+			WHERE oi.order_id = o.id
+		)`, prepTime, prepTime)).
+		Where("o.id = ?", orderID).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+	return err
+}
+
+func formatNullTime(t sql.NullTime) string {
+	if !t.Valid {
+		return ""
+	}
+	return t.Time.Format(time.RFC3339)
+}
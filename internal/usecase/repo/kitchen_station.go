@@ -0,0 +1,157 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/Akrom0181/Food-Delivery/pkg/logger"
+	"github.com/Akrom0181/Food-Delivery/pkg/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+type KitchenStationRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+// New -.
+func NewKitchenStationRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *KitchenStationRepo {
+	return &KitchenStationRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+func (r *KitchenStationRepo) Create(ctx context.Context, req entity.KitchenStation) (entity.KitchenStation, error) {
+	req.ID = uuid.NewString()
+
+	query, args, err := r.pg.Builder.Insert("kitchen_station").
+		Columns(`id, name`).
+		Values(req.ID, req.Name).ToSql()
+	if err != nil {
+		return entity.KitchenStation{}, err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return entity.KitchenStation{}, err
+	}
+
+	return r.GetSingle(ctx, entity.Id{ID: req.ID})
+}
+
+func (r *KitchenStationRepo) GetSingle(ctx context.Context, req entity.Id) (entity.KitchenStation, error) {
+	if req.ID == "" {
+		return entity.KitchenStation{}, fmt.Errorf("GetSingle - invalid request")
+	}
+
+	query, args, err := r.pg.Builder.Select(`id, name, created_at, updated_at`).
+		From("kitchen_station").Where("id = ?", req.ID).ToSql()
+	if err != nil {
+		return entity.KitchenStation{}, err
+	}
+
+	return scanKitchenStation(r.pg.Pool.QueryRow(ctx, query, args...))
+}
+
+func (r *KitchenStationRepo) GetList(ctx context.Context, req entity.GetListFilter) (entity.KitchenStationList, error) {
+	response := entity.KitchenStationList{Items: []entity.KitchenStation{}}
+
+	queryBuilder, where := PrepareGetListQuery(r.pg.Builder.Select(`id, name, created_at, updated_at`).From("kitchen_station"), req)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item, err := scanKitchenStation(rows)
+		if err != nil {
+			return response, err
+		}
+
+		response.Items = append(response.Items, item)
+	}
+
+	countQuery, args, err := r.pg.Builder.Select("COUNT(1)").From("kitchen_station").Where(where).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(&response.Count)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+func (r *KitchenStationRepo) Update(ctx context.Context, req entity.KitchenStation) (entity.KitchenStation, error) {
+	query, args, err := r.pg.Builder.Update("kitchen_station").
+		SetMap(map[string]interface{}{
+			"name":       req.Name,
+			"updated_at": "now()",
+		}).Where("id = ?", req.ID).ToSql()
+	if err != nil {
+		return entity.KitchenStation{}, err
+	}
+
+	n, err := r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return entity.KitchenStation{}, err
+	}
+
+	if n.RowsAffected() == 0 {
+		return entity.KitchenStation{}, pgx.ErrNoRows
+	}
+
+	return r.GetSingle(ctx, entity.Id{ID: req.ID})
+}
+
+// Delete leaves the categories of the station without one.
+func (r *KitchenStationRepo) Delete(ctx context.Context, req entity.Id) error {
+	query, args, err := r.pg.Builder.Delete("kitchen_station").Where("id = ?", req.ID).ToSql()
+	if err != nil {
+		return err
+	}
+
+	n, err := r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if n.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+func scanKitchenStation(row pgx.Row) (entity.KitchenStation, error) {
+	var (
+		item                 entity.KitchenStation
+		createdAt, updatedAt time.Time
+	)
+
+	err := row.Scan(&item.ID, &item.Name, &createdAt, &updatedAt)
+	if err != nil {
+		return entity.KitchenStation{}, err
+	}
+
+	item.CreatedAt = createdAt.Format(time.RFC3339)
+	item.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+	return item, nil
+}
@@ -0,0 +1,337 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/Akrom0181/Food-Delivery/pkg/logger"
+	"github.com/Akrom0181/Food-Delivery/pkg/postgres"
+	"github.com/Masterminds/squirrel"
+)
+
+// MenuRepo reads the catalog the way customers see it. Writes to the catalog
+// are logged in catalog_change by triggers, the highest logged version is the
+// version of the catalog. The triggers log one transaction at a time, so no
+// write below a version read here can commit after it.
+type MenuRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+// New -.
+func NewMenuRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *MenuRepo {
+	return &MenuRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+// GetVersion returns the current catalog version, 0 before the first write.
+func (r *MenuRepo) GetVersion(ctx context.Context) (int64, error) {
+	var version int64
+
+	err := r.pg.Pool.QueryRow(ctx, `SELECT COALESCE(MAX(version), 0) FROM catalog_change`).Scan(&version)
+
+	return version, err
+}
+
+// Get returns the tree of active, visible categories of the restaurant with
+// their active, visible products, both in display order, and the banners. Categories without
+// any products below them are left out. The version is read first, so a write
+// racing with the reads is synced again by the next changed_since request.
+func (r *MenuRepo) Get(ctx context.Context, req entity.MenuRequest) (entity.Menu, error) {
+	var (
+		response = entity.Menu{RestaurantID: req.RestaurantID, BranchID: req.BranchID}
+		err      error
+	)
+
+	response.Version, err = r.GetVersion(ctx)
+	if err != nil {
+		return response, err
+	}
+
+	categories, err := r.categories(ctx, req, nil)
+	if err != nil {
+		return response, err
+	}
+
+	products, err := r.products(ctx, req, nil)
+	if err != nil {
+		return response, err
+	}
+
+	response.Banners, err = r.banners(ctx, req, nil)
+	if err != nil {
+		return response, err
+	}
+
+	var (
+		children        = map[string][]entity.MenuCategory{}
+		productsByGroup = map[string][]entity.MenuProduct{}
+	)
+
+	for _, item := range categories {
+		children[item.ParentID] = append(children[item.ParentID], item)
+	}
+	for _, item := range products {
+		productsByGroup[item.CategoryID] = append(productsByGroup[item.CategoryID], item)
+	}
+
+	response.Categories = menuTree(children, productsByGroup, "")
+	if response.Categories == nil {
+		response.Categories = []entity.MenuCategory{}
+	}
+
+	return response, nil
+}
+
+// GetChanges returns the categories, products and banners written after
+// req.ChangedSince as they are now, and the ids of those that were deleted or
+// are no longer shown.
+func (r *MenuRepo) GetChanges(ctx context.Context, req entity.MenuRequest) (entity.MenuChanges, error) {
+	var (
+		response = entity.MenuChanges{
+			RestaurantID: req.RestaurantID,
+			BranchID:     req.BranchID,
+			Categories:   []entity.MenuCategory{},
+			Products:     []entity.MenuProduct{},
+			Banners:      []entity.MenuBanner{},
+			Removed: entity.MenuRemoved{
+				Categories: []string{},
+				Products:   []string{},
+				Banners:    []string{},
+			},
+		}
+		changed = map[string][]string{}
+		err     error
+	)
+
+	response.Version, err = r.GetVersion(ctx)
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, `SELECT DISTINCT entity_type, entity_id::text FROM catalog_change
+		WHERE version > $1 AND version <= $2`, req.ChangedSince, response.Version)
+	if err != nil {
+		return response, err
+	}
+
+	for rows.Next() {
+		var entityType, id string
+		if err = rows.Scan(&entityType, &id); err != nil {
+			rows.Close()
+			return response, err
+		}
+
+		changed[entityType] = append(changed[entityType], id)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return response, err
+	}
+
+	if ids := changed[TranslationEntityCategory]; len(ids) > 0 {
+		response.Categories, err = r.categories(ctx, req, ids)
+		if err != nil {
+			return response, err
+		}
+
+		for _, item := range response.Categories {
+			ids = without(ids, item.Id)
+		}
+		response.Removed.Categories = append(response.Removed.Categories, ids...)
+	}
+
+	if ids := changed[TranslationEntityProduct]; len(ids) > 0 {
+		response.Products, err = r.products(ctx, req, ids)
+		if err != nil {
+			return response, err
+		}
+
+		for _, item := range response.Products {
+			ids = without(ids, item.Id)
+		}
+		response.Removed.Products = append(response.Removed.Products, ids...)
+	}
+
+	if ids := changed[TranslationEntityBanner]; len(ids) > 0 {
+		response.Banners, err = r.banners(ctx, req, ids)
+		if err != nil {
+			return response, err
+		}
+
+		for _, item := range response.Banners {
+			ids = without(ids, item.Id)
+		}
+		response.Removed.Banners = append(response.Removed.Banners, ids...)
+	}
+
+	return response, nil
+}
+
+// categories returns the active, visible categories, only those in ids unless ids is nil.
+func (r *MenuRepo) categories(ctx context.Context, req entity.MenuRequest, ids []string) ([]entity.MenuCategory, error) {
+	response := []entity.MenuCategory{}
+
+	queryBuilder := r.pg.Builder.
+		Select(`id, COALESCE(parent_id::text, '')`).
+		Column(translatedColumn(TranslationEntityCategory, "category", "name", req.Locales)).
+		Columns(`images, sort_order`).
+		From("category").
+		Where("restaurant_id = ? AND is_active AND NOT is_hidden", req.RestaurantID).
+		OrderBy("sort_order", "name")
+
+	if ids != nil {
+		queryBuilder = queryBuilder.Where("id = ANY(?::uuid[])", ids)
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item entity.MenuCategory
+
+		err = rows.Scan(&item.Id, &item.ParentID, &item.Name, &item.Images, &item.SortOrder)
+		if err != nil {
+			return nil, err
+		}
+
+		response = append(response, item)
+	}
+
+	return response, rows.Err()
+}
+
+// products returns the active, visible products of active, visible
+// categories passing the dietary filter, only those in ids unless ids is nil.
+func (r *MenuRepo) products(ctx context.Context, req entity.MenuRequest, ids []string) ([]entity.MenuProduct, error) {
+	response := []entity.MenuProduct{}
+
+	queryBuilder := r.pg.Builder.
+		Select(`p.id, p.category_id`).
+		Column(translatedColumn(TranslationEntityProduct, "p", "name", req.Locales)).
+		Column(squirrel.Expr("COALESCE(?, '')", translatedColumn(TranslationEntityProduct, "p", "description", req.Locales))).
+		Columns(`p.price, p.images, p.sort_order, p.nutrition, p.allergens, p.dietary_tags, p.spicy_level, p.is_bundle`).
+		From("product p").
+		Join("category c ON c.id = p.category_id").
+		Where("p.restaurant_id = ? AND p.is_active AND NOT p.is_hidden AND c.is_active AND NOT c.is_hidden", req.RestaurantID).
+		Where(dietaryConditions("p", req.Dietary)).
+		OrderBy("p.sort_order", "p.name")
+
+	if req.BranchID != "" {
+		queryBuilder = queryBuilder.Column("product_available(p.id, ?::uuid)", req.BranchID)
+	} else {
+		queryBuilder = queryBuilder.Column("true")
+	}
+
+	if ids != nil {
+		queryBuilder = queryBuilder.Where("p.id = ANY(?::uuid[])", ids)
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item entity.MenuProduct
+
+		err = rows.Scan(&item.Id, &item.CategoryID, &item.Name, &item.Description, &item.Price, &item.Images,
+			&item.SortOrder, &item.Nutrition, &item.Allergens, &item.DietaryTags, &item.SpicyLevel, &item.IsBundle, &item.IsAvailable)
+		if err != nil {
+			return nil, err
+		}
+
+		response = append(response, item)
+	}
+
+	return response, rows.Err()
+}
+
+// banners returns the banners, newest first, only those in ids unless ids is nil.
+func (r *MenuRepo) banners(ctx context.Context, req entity.MenuRequest, ids []string) ([]entity.MenuBanner, error) {
+	response := []entity.MenuBanner{}
+
+	queryBuilder := r.pg.Builder.
+		Select(`id`).
+		Column(translatedColumn(TranslationEntityBanner, "banner", "title", req.Locales)).
+		Columns(`images`).
+		From("banner").
+		Where("restaurant_id = ?", req.RestaurantID).
+		OrderBy("created_at DESC")
+
+	if ids != nil {
+		queryBuilder = queryBuilder.Where("id = ANY(?::uuid[])", ids)
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item entity.MenuBanner
+
+		err = rows.Scan(&item.Id, &item.Title, &item.Images)
+		if err != nil {
+			return nil, err
+		}
+
+		response = append(response, item)
+	}
+
+	return response, rows.Err()
+}
+
+// menuTree builds the categories under parentID, dropping the empty ones.
+// Categories under a hidden or inactive one never become reachable.
+func menuTree(children map[string][]entity.MenuCategory, products map[string][]entity.MenuProduct, parentID string) []entity.MenuCategory {
+	var tree []entity.MenuCategory
+
+	for _, item := range children[parentID] {
+		item.Products = products[item.Id]
+		item.Subcategories = menuTree(children, products, item.Id)
+
+		if len(item.Products) == 0 && len(item.Subcategories) == 0 {
+			continue
+		}
+
+		tree = append(tree, item)
+	}
+
+	return tree
+}
+
+func without(ids []string, id string) []string {
+	for i, item := range ids {
+		if item == id {
+			return append(ids[:i], ids[i+1:]...)
+		}
+	}
+
+	return ids
+}
@@ -50,7 +50,7 @@ func (r *NotificationRepo) GetSingle(ctx context.Context, req entity.Id) (entity
 	var createdAt time.Time
 
 	queryBuilder := r.pg.Builder.
-		Select(`id, user_id, message, status, created_at`).
+		Select(`id, COALESCE(owner_id::text, ''), user_id, message, status, created_at`).
 		From("notifications")
 
 	switch {
@@ -67,7 +67,7 @@ func (r *NotificationRepo) GetSingle(ctx context.Context, req entity.Id) (entity
 	}
 
 	err = r.pg.Pool.QueryRow(ctx, query, args...).
-		Scan(&response.ID, &response.UserID, &response.Message, &response.Status, &createdAt)
+		Scan(&response.ID, &response.OwnerId, &response.UserID, &response.Message, &response.Status, &createdAt)
 	if err != nil {
 		return entity.Notification{}, err
 	}
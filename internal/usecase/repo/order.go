@@ -3,15 +3,21 @@ package repo
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"time"
 
 	"github.com/Akrom0181/Food-Delivery/config"
 	"github.com/Akrom0181/Food-Delivery/internal/entity"
 	"github.com/Akrom0181/Food-Delivery/pkg/logger"
 	"github.com/Akrom0181/Food-Delivery/pkg/postgres"
+	"github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 )
 
+// ErrSlotFull is returned by Create when the delivery slot of a scheduled
+// order was taken while it was placed.
+var ErrSlotFull = errors.New("repo: delivery slot is full")
+
 type OrderRepo struct {
 	pg     *postgres.Postgres
 	config *config.Config
@@ -35,10 +41,40 @@ func (r *OrderRepo) Create(ctx context.Context, order entity.Order) (entity.Orde
 	}
 	defer tx.Rollback(ctx)
 
+	// the capacity row of the branch is locked until the order is in, so two
+	// orders can not both take the last place of a slot or of the kitchen
+	load, err := lockBranchLoad(ctx, tx, r.pg.Builder, order.BranchId)
+	if err != nil {
+		return entity.Order{}, err
+	}
+
+	// the kitchen load of now does not matter for a scheduled order
+	var delay time.Duration
+	if order.ScheduledAt != "" {
+		if limit := load.Capacity.MaxOrdersPerSlot; limit > 0 {
+			var booked int
+			err = tx.QueryRow(ctx, `SELECT COUNT(1) FROM orders
+				WHERE branch_id = $1 AND scheduled_at = $2::timestamp AND status <> 'cancelled'`,
+				order.BranchId, order.ScheduledAt).Scan(&booked)
+			if err != nil {
+				return entity.Order{}, err
+			}
+			if booked >= limit {
+				return entity.Order{}, ErrSlotFull
+			}
+		}
+	} else {
+		delay = CapacityDelay(load, CookedItems(order.OrderItems))
+		if delay > 0 && load.Capacity.Overflow != "extend_eta" {
+			return entity.Order{}, &BranchFullError{Overflow: load.Capacity.Overflow}
+		}
+	}
+
 	order.ID = uuid.NewString()
 	orderQuery, orderArgs, err := r.pg.Builder.Insert("orders").
-		Columns(`id, user_id, total_price, status, delivery_status, address, floor, door_number, entrance, latitude, longitude, branch_id`).
-		Values(order.ID, order.UserID, order.TotalPrice, order.Status, order.DeliveryStatus, order.Address, order.Floor, order.DoorNumber, order.Entrance, order.Latitude, order.Longitude, order.BranchId).ToSql()
+		Columns(`id, user_id, total_price, currency, tax, status, delivery_status, address, floor, door_number, entrance, latitude, longitude, branch_id, scheduled_at`).
+		Values(order.ID, order.UserID, order.TotalPrice, order.Currency, order.Tax, order.Status, order.DeliveryStatus, order.Address, order.Floor, order.DoorNumber, order.Entrance, order.Latitude, order.Longitude, order.BranchId,
+			squirrel.Expr("NULLIF(?, '')::timestamp", order.ScheduledAt)).ToSql()
 	if err != nil {
 		return entity.Order{}, err
 	}
@@ -48,12 +84,24 @@ func (r *OrderRepo) Create(ctx context.Context, order entity.Order) (entity.Orde
 		return entity.Order{}, err
 	}
 
-	for _, item := range order.OrderItems {
-		item.Id = uuid.NewString()
+	// lines of bundles come with ids for their component lines to point to
+	for i := range order.OrderItems {
+		item := &order.OrderItems[i]
+		if item.Id == "" {
+			item.Id = uuid.NewString()
+		}
 		item.OrderId = order.ID
+		if item.AppliedRules == nil {
+			item.AppliedRules = []entity.AppliedRule{}
+		}
 		itemQuery, itemArgs, err := r.pg.Builder.Insert("orderitems").
-			Columns(`id, order_id, product_id, total_price, quantity, price`).
-			Values(item.Id, item.OrderId, item.ProductId, item.TotalPrice, item.Quantity, item.Price).ToSql()
+			Columns(`id, order_id, product_id, total_price, quantity, price, price_version_id, discount, applied_rules,
+				mxik_code, tax_rate, tax, product_name, package_code, parent_item_id, bundle_slot_id`).
+			Values(item.Id, item.OrderId, item.ProductId, item.TotalPrice, item.Quantity, item.Price,
+				squirrel.Expr("NULLIF(?, '')::uuid", item.PriceVersionID), item.Discount, item.AppliedRules,
+				item.MxikCode, item.TaxRate, item.Tax, item.ProductName, item.PackageCode,
+				squirrel.Expr("NULLIF(?, '')::uuid", item.ParentItemID),
+				squirrel.Expr("NULLIF(?, '')::uuid", item.BundleSlotID)).ToSql()
 		if err != nil {
 			return entity.Order{}, err
 		}
@@ -64,6 +112,47 @@ func (r *OrderRepo) Create(ctx context.Context, order entity.Order) (entity.Orde
 		}
 	}
 
+	switch {
+	case order.ScheduledAt != "":
+		// a scheduled order is started its prep time, and the ride for a
+		// delivery, before its slot
+		lead := time.Duration(0)
+		if order.DeliveryStatus == "yetkazib berish" {
+			lead = config.DeliveryLeadTime
+		}
+
+		_, err = tx.Exec(ctx, `UPDATE orders o SET start_after = o.scheduled_at - ($2 + COALESCE((
+				SELECT max(COALESCE(pt.seconds, $3))
+				FROM orderitems oi
+				JOIN product p ON p.id = oi.product_id AND NOT p.is_bundle
+				LEFT JOIN prep_time pt ON pt.branch_id = o.branch_id AND pt.product_id = oi.product_id
+				WHERE oi.order_id = o.id
+			), 0)) * interval '1 second'
+			WHERE o.id = $1`, order.ID, lead.Seconds(), config.DefaultPrepTime.Seconds())
+		if err != nil {
+			return entity.Order{}, err
+		}
+	case delay > 0:
+		// a full branch starts on the order once the work ahead of it is done
+		_, err = tx.Exec(ctx, `UPDATE orders SET start_after = now() + $2 * interval '1 second' WHERE id = $1`,
+			order.ID, delay.Seconds())
+		if err != nil {
+			return entity.Order{}, err
+		}
+	}
+
+	err = refreshReadyETA(ctx, tx, r.pg.Builder, order.ID)
+	if err != nil {
+		return entity.Order{}, err
+	}
+
+	var readyETA sql.NullTime
+	err = tx.QueryRow(ctx, `SELECT ready_eta FROM orders WHERE id = $1`, order.ID).Scan(&readyETA)
+	if err != nil {
+		return entity.Order{}, err
+	}
+	order.ReadyETA = formatNullTime(readyETA)
+
 	err = tx.Commit(ctx)
 	if err != nil {
 		return entity.Order{}, err
@@ -75,15 +164,16 @@ func (r *OrderRepo) Create(ctx context.Context, order entity.Order) (entity.Orde
 func (r *OrderRepo) GetSingle(ctx context.Context, req entity.Id) (entity.Order, error) {
 	response := entity.Order{}
 	var (
-		createdAt, updatedAt time.Time
-		courier_id           sql.NullString
+		createdAt, updatedAt  time.Time
+		courier_id            sql.NullString
+		readyETA, scheduledAt sql.NullTime
 	)
 
 	// Query for the order details
 	queryBuilder := r.pg.Builder.
-		Select(`o.id, o.user_id, o.total_price, o.status, o.delivery_status, 
+		Select(`o.id, o.user_id, o.total_price, o.currency, o.tax, o.status, o.delivery_status, 
 			o.address, o.floor, o.door_number, o.entrance, o.latitude, o.longitude, o.branch_id, o.courier_id, 
-			o.created_at, o.updated_at`).
+			o.ready_eta, o.scheduled_at, o.created_at, o.updated_at`).
 		From("orders AS o").
 		Where("o.id = ?", req.ID)
 
@@ -93,9 +183,9 @@ func (r *OrderRepo) GetSingle(ctx context.Context, req entity.Id) (entity.Order,
 	}
 
 	err = r.pg.Pool.QueryRow(ctx, query, args...).Scan(
-		&response.ID, &response.UserID, &response.TotalPrice, &response.Status, &response.DeliveryStatus,
+		&response.ID, &response.UserID, &response.TotalPrice, &response.Currency, &response.Tax, &response.Status, &response.DeliveryStatus,
 		&response.Address, &response.Floor, &response.DoorNumber, &response.Entrance,
-		&response.Latitude, &response.Longitude, &response.BranchId, &courier_id, &createdAt, &updatedAt,
+		&response.Latitude, &response.Longitude, &response.BranchId, &courier_id, &readyETA, &scheduledAt, &createdAt, &updatedAt,
 	)
 	if err != nil {
 		return entity.Order{}, err
@@ -107,12 +197,15 @@ func (r *OrderRepo) GetSingle(ctx context.Context, req entity.Id) (entity.Order,
 		courier_id.String = ""
 	}
 
+	response.ReadyETA = formatNullTime(readyETA)
+	response.ScheduledAt = formatNullTime(scheduledAt)
 	response.CreatedAt = createdAt.Format(time.RFC3339)
 	response.UpdatedAt = updatedAt.Format(time.RFC3339)
 
 	// Query for order items
 	itemsQuery, itemsArgs, err := r.pg.Builder.
-		Select(`id, order_id, product_id, total_price, quantity, price`).
+		Select(`id, order_id, COALESCE(product_id::text, ''), total_price, quantity, price, COALESCE(price_version_id::text, ''), discount, applied_rules,
+			mxik_code, tax_rate, tax, COALESCE(parent_item_id::text, ''), COALESCE(bundle_slot_id::text, '')`).
 		From("orderitems").
 		Where("order_id = ?", req.ID).
 		ToSql()
@@ -128,7 +221,9 @@ func (r *OrderRepo) GetSingle(ctx context.Context, req entity.Id) (entity.Order,
 
 	for rows.Next() {
 		var item entity.OrderItems
-		err := rows.Scan(&item.Id, &item.OrderId, &item.ProductId, &item.TotalPrice, &item.Quantity, &item.Price)
+		err := rows.Scan(&item.Id, &item.OrderId, &item.ProductId, &item.TotalPrice, &item.Quantity, &item.Price,
+			&item.PriceVersionID, &item.Discount, &item.AppliedRules,
+			&item.MxikCode, &item.TaxRate, &item.Tax, &item.ParentItemID, &item.BundleSlotID)
 		if err != nil {
 			return entity.Order{}, err
 		}
@@ -140,14 +235,18 @@ func (r *OrderRepo) GetSingle(ctx context.Context, req entity.Id) (entity.Order,
 
 func (r *OrderRepo) GetList(ctx context.Context, req entity.GetListFilter) (entity.OrderList, error) {
 	var (
-		response             = entity.OrderList{}
-		createdAt, updatedAt time.Time
-		courier_id           sql.NullString
+		response              = entity.OrderList{}
+		createdAt, updatedAt  time.Time
+		courier_id            sql.NullString
+		readyETA, scheduledAt sql.NullTime
 	)
 
 	queryBuilder := r.pg.Builder.
-		Select(`o.id, o.user_id, o.total_price, o.status, o.delivery_status, o.address, o.floor, o.door_number, o.entrance, o.latitude, o.longitude, o.branch_id, o.courier_id, o.created_at, o.updated_at,
-				oi.id, oi.order_id, oi.product_id, oi.total_price, oi.quantity, oi.price`).
+		Select(`o.id, o.user_id, o.total_price, o.currency, o.tax, o.status, o.delivery_status, o.address, o.floor, o.door_number, o.entrance, o.latitude, o.longitude, o.branch_id, o.courier_id, o.ready_eta, o.scheduled_at, o.created_at, o.updated_at,
+				oi.id, oi.order_id, COALESCE(oi.product_id::text, ''), oi.total_price, oi.quantity, oi.price, COALESCE(oi.price_version_id::text, ''),
+				COALESCE(oi.discount, 0), COALESCE(oi.applied_rules, '[]'),
+				COALESCE(oi.mxik_code, ''), COALESCE(oi.tax_rate, 0), COALESCE(oi.tax, 0),
+				COALESCE(oi.parent_item_id::text, ''), COALESCE(oi.bundle_slot_id::text, '')`).
 		From("orders o").
 		LeftJoin("orderitems oi ON o.id = oi.order_id")
 
@@ -172,11 +271,12 @@ func (r *OrderRepo) GetList(ctx context.Context, req entity.GetListFilter) (enti
 			order     entity.Order
 		)
 		err = rows.Scan(
-			&order.ID, &order.UserID, &order.TotalPrice, &order.Status, &order.DeliveryStatus,
+			&order.ID, &order.UserID, &order.TotalPrice, &order.Currency, &order.Tax, &order.Status, &order.DeliveryStatus,
 			&order.Address, &order.Floor, &order.DoorNumber, &order.Entrance,
-			&order.Latitude, &order.Longitude, &order.BranchId, &courier_id, &createdAt, &updatedAt,
+			&order.Latitude, &order.Longitude, &order.BranchId, &courier_id, &readyETA, &scheduledAt, &createdAt, &updatedAt,
 			&orderItem.Id, &orderItem.OrderId, &orderItem.ProductId, &orderItem.TotalPrice,
-			&orderItem.Quantity, &orderItem.Price,
+			&orderItem.Quantity, &orderItem.Price, &orderItem.PriceVersionID, &orderItem.Discount, &orderItem.AppliedRules,
+			&orderItem.MxikCode, &orderItem.TaxRate, &orderItem.Tax, &orderItem.ParentItemID, &orderItem.BundleSlotID,
 		)
 		if err != nil {
 			return response, err
@@ -188,6 +288,8 @@ func (r *OrderRepo) GetList(ctx context.Context, req entity.GetListFilter) (enti
 			courier_id.String = ""
 		}
 
+		order.ReadyETA = formatNullTime(readyETA)
+		order.ScheduledAt = formatNullTime(scheduledAt)
 		order.CreatedAt = createdAt.Format(time.RFC3339)
 		order.UpdatedAt = updatedAt.Format(time.RFC3339)
 
@@ -220,7 +322,6 @@ func (r *OrderRepo) Update(ctx context.Context, req entity.Order) (entity.Order,
 	mp := map[string]interface{}{
 		"id":              req.ID,
 		"user_id":         req.UserID,
-		"total_price":     req.TotalPrice,
 		"status":          req.Status,
 		"delivery_status": req.DeliveryStatus,
 		"address":         req.Address,
@@ -243,7 +344,8 @@ func (r *OrderRepo) Update(ctx context.Context, req entity.Order) (entity.Order,
 		return entity.Order{}, err
 	}
 
-	return req, nil
+	// the price, tax and currency are fixed when the order is placed
+	return r.GetSingle(ctx, entity.Id{ID: req.ID})
 }
 
 func (r *OrderRepo) Delete(ctx context.Context, req entity.Id) error {
@@ -310,17 +412,21 @@ func (r *OrderRepo) UpdateField(ctx context.Context, req entity.UpdateFieldReque
 
 func (r *OrderRepo) GetOrdersByBranch(ctx context.Context, req entity.GetListFilter) (entity.OrderList, error) {
 	var (
-		response             = entity.OrderList{}
-		createdAt, updatedAt time.Time
-		courier_id           sql.NullString
+		response              = entity.OrderList{}
+		createdAt, updatedAt  time.Time
+		courier_id            sql.NullString
+		readyETA, scheduledAt sql.NullTime
 	)
 
 	// Build base query
 	queryBuilder := r.pg.Builder.
-		Select(`o.id, o.user_id, o.total_price, o.status, o.delivery_status, o.address, 
+		Select(`o.id, o.user_id, o.total_price, o.currency, o.tax, o.status, o.delivery_status, o.address, 
 				o.floor, o.door_number, o.entrance, o.latitude, o.longitude, o.branch_id, 
-				o.courier_id, o.created_at, o.updated_at,
-				oi.id, oi.order_id, oi.product_id, oi.total_price, oi.quantity, oi.price`).
+				o.courier_id, o.ready_eta, o.scheduled_at, o.created_at, o.updated_at,
+				oi.id, oi.order_id, COALESCE(oi.product_id::text, ''), oi.total_price, oi.quantity, oi.price, COALESCE(oi.price_version_id::text, ''),
+				COALESCE(oi.discount, 0), COALESCE(oi.applied_rules, '[]'),
+				COALESCE(oi.mxik_code, ''), COALESCE(oi.tax_rate, 0), COALESCE(oi.tax, 0),
+				COALESCE(oi.parent_item_id::text, ''), COALESCE(oi.bundle_slot_id::text, '')`).
 		From("orders o").
 		LeftJoin("orderitems oi ON o.id = oi.order_id")
 
@@ -346,11 +452,12 @@ func (r *OrderRepo) GetOrdersByBranch(ctx context.Context, req entity.GetListFil
 			order     entity.Order
 		)
 		err = rows.Scan(
-			&order.ID, &order.UserID, &order.TotalPrice, &order.Status, &order.DeliveryStatus,
+			&order.ID, &order.UserID, &order.TotalPrice, &order.Currency, &order.Tax, &order.Status, &order.DeliveryStatus,
 			&order.Address, &order.Floor, &order.DoorNumber, &order.Entrance,
-			&order.Latitude, &order.Longitude, &order.BranchId, &courier_id, &createdAt, &updatedAt,
+			&order.Latitude, &order.Longitude, &order.BranchId, &courier_id, &readyETA, &scheduledAt, &createdAt, &updatedAt,
 			&orderItem.Id, &orderItem.OrderId, &orderItem.ProductId, &orderItem.TotalPrice,
-			&orderItem.Quantity, &orderItem.Price,
+			&orderItem.Quantity, &orderItem.Price, &orderItem.PriceVersionID, &orderItem.Discount, &orderItem.AppliedRules,
+			&orderItem.MxikCode, &orderItem.TaxRate, &orderItem.Tax, &orderItem.ParentItemID, &orderItem.BundleSlotID,
 		)
 		if err != nil {
 			return response, err
@@ -362,6 +469,8 @@ func (r *OrderRepo) GetOrdersByBranch(ctx context.Context, req entity.GetListFil
 			courier_id.String = ""
 		}
 
+		order.ReadyETA = formatNullTime(readyETA)
+		order.ScheduledAt = formatNullTime(scheduledAt)
 		order.CreatedAt = createdAt.Format(time.RFC3339)
 		order.UpdatedAt = updatedAt.Format(time.RFC3339)
 
@@ -396,3 +505,84 @@ func (r *OrderRepo) GetOrdersByBranch(ctx context.Context, req entity.GetListFil
 
 	return response, nil
 }
+
+// HasOrders reports whether the user placed an order that was not cancelled.
+func (r *OrderRepo) HasOrders(ctx context.Context, userID string) (bool, error) {
+	var exists bool
+
+	err := r.pg.Pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM orders WHERE user_id = $1 AND status <> 'cancelled')`, userID).Scan(&exists)
+
+	return exists, err
+}
+
+// CountScheduled counts the scheduled orders of a branch by their slot, keyed
+// by the Unix time of the slot, for the slots starting within [from, to).
+func (r *OrderRepo) CountScheduled(ctx context.Context, branchID string, from, to time.Time) (map[int64]int, error) {
+	response := make(map[int64]int)
+
+	rows, err := r.pg.Pool.Query(ctx, `SELECT scheduled_at, COUNT(1) FROM orders
+		WHERE branch_id = $1 AND scheduled_at >= $2 AND scheduled_at < $3 AND status <> 'cancelled'
+		GROUP BY scheduled_at`, branchID, from.UTC(), to.UTC())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			slot  time.Time
+			count int
+		)
+		if err = rows.Scan(&slot, &count); err != nil {
+			return nil, err
+		}
+
+		response[slot.Unix()] = count
+	}
+
+	return response, rows.Err()
+}
+
+// ReleaseScheduled hands the scheduled orders that are due to the kitchen as
+// pending and returns how many it released. Every replica may run it, an
+// order is released only once.
+func (r *OrderRepo) ReleaseScheduled(ctx context.Context) (int, error) {
+	tx, err := r.pg.Pool.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `UPDATE orders SET status = 'pending', updated_at = now()
+		WHERE status = 'scheduled' AND start_after <= now()
+		RETURNING id`)
+	if err != nil {
+		return 0, err
+	}
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err = rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for _, id := range ids {
+		if err = refreshReadyETA(ctx, tx, r.pg.Builder, id); err != nil {
+			return 0, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+
+	return len(ids), nil
+}
@@ -0,0 +1,191 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/Akrom0181/Food-Delivery/pkg/logger"
+	"github.com/Akrom0181/Food-Delivery/pkg/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+// PolicyRepo manages the rows of casbin_rule. The enforcer itself reads them through pkg/rbac.
+type PolicyRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+// New -.
+func NewPolicyRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *PolicyRepo {
+	return &PolicyRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+func (r *PolicyRepo) CreatePolicy(ctx context.Context, req entity.Policy) (entity.Policy, error) {
+	req.ID = uuid.NewString()
+
+	query, args, err := r.pg.Builder.Insert("casbin_rule").
+		Columns(`id, ptype, v0, v1, v2`).
+		Values(req.ID, "p", req.Subject, req.Object, req.Action).ToSql()
+	if err != nil {
+		return entity.Policy{}, err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return entity.Policy{}, err
+	}
+
+	return req, nil
+}
+
+func (r *PolicyRepo) GetPolicies(ctx context.Context, req entity.GetListFilter) (entity.PolicyList, error) {
+	var (
+		response  = entity.PolicyList{}
+		createdAt time.Time
+	)
+
+	req.Filters = append(req.Filters, entity.Filter{Column: "ptype", Type: "eq", Value: "p"})
+
+	queryBuilder := r.pg.Builder.
+		Select(`id, v0, v1, v2, created_at`).
+		From("casbin_rule")
+
+	queryBuilder, where := PrepareGetListQuery(queryBuilder, req)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item entity.Policy
+		err = rows.Scan(&item.ID, &item.Subject, &item.Object, &item.Action, &createdAt)
+		if err != nil {
+			return response, err
+		}
+
+		item.CreatedAt = createdAt.Format(time.RFC3339)
+
+		response.Items = append(response.Items, item)
+	}
+
+	countQuery, args, err := r.pg.Builder.Select("COUNT(1)").From("casbin_rule").Where(where).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(&response.Count)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+func (r *PolicyRepo) DeletePolicy(ctx context.Context, req entity.Id) error {
+	return r.delete(ctx, "p", req.ID)
+}
+
+func (r *PolicyRepo) CreateRoleInheritance(ctx context.Context, req entity.RoleInheritance) (entity.RoleInheritance, error) {
+	req.ID = uuid.NewString()
+
+	query, args, err := r.pg.Builder.Insert("casbin_rule").
+		Columns(`id, ptype, v0, v1`).
+		Values(req.ID, "g", req.Role, req.Parent).ToSql()
+	if err != nil {
+		return entity.RoleInheritance{}, err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return entity.RoleInheritance{}, err
+	}
+
+	return req, nil
+}
+
+func (r *PolicyRepo) GetRoleInheritances(ctx context.Context, req entity.GetListFilter) (entity.RoleInheritanceList, error) {
+	var (
+		response  = entity.RoleInheritanceList{}
+		createdAt time.Time
+	)
+
+	req.Filters = append(req.Filters, entity.Filter{Column: "ptype", Type: "eq", Value: "g"})
+
+	queryBuilder := r.pg.Builder.
+		Select(`id, v0, v1, created_at`).
+		From("casbin_rule")
+
+	queryBuilder, where := PrepareGetListQuery(queryBuilder, req)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item entity.RoleInheritance
+		err = rows.Scan(&item.ID, &item.Role, &item.Parent, &createdAt)
+		if err != nil {
+			return response, err
+		}
+
+		item.CreatedAt = createdAt.Format(time.RFC3339)
+
+		response.Items = append(response.Items, item)
+	}
+
+	countQuery, args, err := r.pg.Builder.Select("COUNT(1)").From("casbin_rule").Where(where).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(&response.Count)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+func (r *PolicyRepo) DeleteRoleInheritance(ctx context.Context, req entity.Id) error {
+	return r.delete(ctx, "g", req.ID)
+}
+
+func (r *PolicyRepo) delete(ctx context.Context, ptype, id string) error {
+	query, args, err := r.pg.Builder.Delete("casbin_rule").Where("id = ? AND ptype = ?", id, ptype).ToSql()
+	if err != nil {
+		return err
+	}
+
+	n, err := r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if n.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
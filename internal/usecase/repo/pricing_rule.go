@@ -0,0 +1,229 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/Akrom0181/Food-Delivery/pkg/logger"
+	"github.com/Akrom0181/Food-Delivery/pkg/postgres"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+const pricingRuleColumns = `id, name, kind, percent, amount, buy_quantity, get_quantity,
+	COALESCE(product_id::text, ''), COALESCE(category_id::text, ''), COALESCE(branch_id::text, ''),
+	days_of_week, COALESCE(to_char(start_time, 'HH24:MI'), ''), COALESCE(to_char(end_time, 'HH24:MI'), ''),
+	starts_at, ends_at, first_order_only, priority, stackable, is_active, created_at, updated_at`
+
+type PricingRuleRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+// New -.
+func NewPricingRuleRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *PricingRuleRepo {
+	return &PricingRuleRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+func (r *PricingRuleRepo) Create(ctx context.Context, req entity.PricingRule) (entity.PricingRule, error) {
+	req.ID = uuid.NewString()
+
+	query, args, err := r.pg.Builder.Insert("pricing_rule").
+		Columns(`id, name, kind, percent, amount, buy_quantity, get_quantity, product_id, category_id, branch_id,
+			days_of_week, start_time, end_time, starts_at, ends_at, first_order_only, priority, stackable, is_active`).
+		Values(req.ID, req.Name, req.Kind, req.Percent, req.Amount, req.BuyQuantity, req.GetQuantity,
+			squirrel.Expr("NULLIF(?, '')::uuid", req.ProductID),
+			squirrel.Expr("NULLIF(?, '')::uuid", req.CategoryID),
+			squirrel.Expr("NULLIF(?, '')::uuid", req.BranchID),
+			squirrel.Expr("COALESCE(?::int[], '{}')", req.DaysOfWeek),
+			squirrel.Expr("NULLIF(?, '')::time", req.StartTime),
+			squirrel.Expr("NULLIF(?, '')::time", req.EndTime),
+			squirrel.Expr("NULLIF(?, '')::timestamp", req.StartsAt),
+			squirrel.Expr("NULLIF(?, '')::timestamp", req.EndsAt),
+			req.FirstOrderOnly, req.Priority, req.Stackable, req.IsActive).ToSql()
+	if err != nil {
+		return entity.PricingRule{}, err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return entity.PricingRule{}, err
+	}
+
+	return r.GetSingle(ctx, entity.Id{ID: req.ID})
+}
+
+func (r *PricingRuleRepo) GetSingle(ctx context.Context, req entity.Id) (entity.PricingRule, error) {
+	if req.ID == "" {
+		return entity.PricingRule{}, fmt.Errorf("GetSingle - invalid request")
+	}
+
+	query, args, err := r.pg.Builder.Select(pricingRuleColumns).From("pricing_rule").Where("id = ?", req.ID).ToSql()
+	if err != nil {
+		return entity.PricingRule{}, err
+	}
+
+	return scanPricingRule(r.pg.Pool.QueryRow(ctx, query, args...))
+}
+
+func (r *PricingRuleRepo) GetList(ctx context.Context, req entity.GetListFilter) (entity.PricingRuleList, error) {
+	response := entity.PricingRuleList{Items: []entity.PricingRule{}}
+
+	queryBuilder, where := PrepareGetListQuery(r.pg.Builder.Select(pricingRuleColumns).From("pricing_rule"), req)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item, err := scanPricingRule(rows)
+		if err != nil {
+			return response, err
+		}
+
+		response.Items = append(response.Items, item)
+	}
+
+	countQuery, args, err := r.pg.Builder.Select("COUNT(1)").From("pricing_rule").Where(where).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(&response.Count)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// GetActive returns the active rules of a branch and of all branches.
+func (r *PricingRuleRepo) GetActive(ctx context.Context, branchID string) ([]entity.PricingRule, error) {
+	var response []entity.PricingRule
+
+	query, args, err := r.pg.Builder.Select(pricingRuleColumns).From("pricing_rule").
+		Where("is_active AND (branch_id IS NULL OR branch_id = NULLIF(?, '')::uuid)", branchID).
+		Where("(ends_at IS NULL OR ends_at > now())").ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item, err := scanPricingRule(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		response = append(response, item)
+	}
+
+	return response, rows.Err()
+}
+
+// Update replaces the rule. It returns pgx.ErrNoRows when the rule does not exist.
+func (r *PricingRuleRepo) Update(ctx context.Context, req entity.PricingRule) (entity.PricingRule, error) {
+	mp := map[string]interface{}{
+		"name":             req.Name,
+		"kind":             req.Kind,
+		"percent":          req.Percent,
+		"amount":           req.Amount,
+		"buy_quantity":     req.BuyQuantity,
+		"get_quantity":     req.GetQuantity,
+		"product_id":       squirrel.Expr("NULLIF(?, '')::uuid", req.ProductID),
+		"category_id":      squirrel.Expr("NULLIF(?, '')::uuid", req.CategoryID),
+		"branch_id":        squirrel.Expr("NULLIF(?, '')::uuid", req.BranchID),
+		"days_of_week":     squirrel.Expr("COALESCE(?::int[], '{}')", req.DaysOfWeek),
+		"start_time":       squirrel.Expr("NULLIF(?, '')::time", req.StartTime),
+		"end_time":         squirrel.Expr("NULLIF(?, '')::time", req.EndTime),
+		"starts_at":        squirrel.Expr("NULLIF(?, '')::timestamp", req.StartsAt),
+		"ends_at":          squirrel.Expr("NULLIF(?, '')::timestamp", req.EndsAt),
+		"first_order_only": req.FirstOrderOnly,
+		"priority":         req.Priority,
+		"stackable":        req.Stackable,
+		"is_active":        req.IsActive,
+		"updated_at":       "now()",
+	}
+
+	query, args, err := r.pg.Builder.Update("pricing_rule").SetMap(mp).Where("id = ?", req.ID).ToSql()
+	if err != nil {
+		return entity.PricingRule{}, err
+	}
+
+	n, err := r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return entity.PricingRule{}, err
+	}
+
+	if n.RowsAffected() == 0 {
+		return entity.PricingRule{}, pgx.ErrNoRows
+	}
+
+	return r.GetSingle(ctx, entity.Id{ID: req.ID})
+}
+
+func (r *PricingRuleRepo) Delete(ctx context.Context, req entity.Id) error {
+	query, args, err := r.pg.Builder.Delete("pricing_rule").Where("id = ?", req.ID).ToSql()
+	if err != nil {
+		return err
+	}
+
+	n, err := r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if n.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+func scanPricingRule(row pgx.Row) (entity.PricingRule, error) {
+	var (
+		item                 entity.PricingRule
+		startsAt, endsAt     sql.NullTime
+		createdAt, updatedAt time.Time
+	)
+
+	err := row.Scan(&item.ID, &item.Name, &item.Kind, &item.Percent, &item.Amount, &item.BuyQuantity, &item.GetQuantity,
+		&item.ProductID, &item.CategoryID, &item.BranchID, &item.DaysOfWeek, &item.StartTime, &item.EndTime,
+		&startsAt, &endsAt, &item.FirstOrderOnly, &item.Priority, &item.Stackable, &item.IsActive, &createdAt, &updatedAt)
+	if err != nil {
+		return entity.PricingRule{}, err
+	}
+
+	if startsAt.Valid {
+		item.StartsAt = startsAt.Time.Format(time.RFC3339)
+	}
+	if endsAt.Valid {
+		item.EndsAt = endsAt.Time.Format(time.RFC3339)
+	}
+	item.CreatedAt = createdAt.Format(time.RFC3339)
+	item.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+	return item, nil
+}
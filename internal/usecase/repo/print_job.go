@@ -0,0 +1,215 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/Akrom0181/Food-Delivery/pkg/logger"
+	"github.com/Akrom0181/Food-Delivery/pkg/postgres"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+const printJobColumns = `id, branch_id, order_id, kind, COALESCE(station_id::text, ''), status, attempts, error,
+	created_at, printed_at`
+
+type PrintJobRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+// New -.
+func NewPrintJobRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *PrintJobRepo {
+	return &PrintJobRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+// Create queues the jobs. They are returned without their data.
+func (r *PrintJobRepo) Create(ctx context.Context, req []entity.PrintJob) (entity.PrintJobList, error) {
+	response := entity.PrintJobList{Items: []entity.PrintJob{}}
+
+	tx, err := r.pg.Pool.Begin(ctx)
+	if err != nil {
+		return response, err
+	}
+	defer tx.Rollback(ctx)
+
+	for _, job := range req {
+		query, args, err := r.pg.Builder.Insert("print_job").
+			Columns(`id, branch_id, order_id, kind, station_id, data`).
+			Values(uuid.NewString(), job.BranchID, job.OrderID, job.Kind,
+				squirrel.Expr("NULLIF(?, '')::uuid", job.StationID), job.Data).
+			Suffix("RETURNING " + printJobColumns).ToSql()
+		if err != nil {
+			return response, err
+		}
+
+		item, err := scanPrintJob(tx.QueryRow(ctx, query, args...))
+		if err != nil {
+			return response, err
+		}
+
+		response.Items = append(response.Items, item)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return response, err
+	}
+
+	response.Count = len(response.Items)
+
+	return response, nil
+}
+
+// Claim leases the oldest job of a branch the agent prints for lease, with its
+// data. A job whose lease ran out without an ack is claimed again until it is
+// out of attempts. It returns pgx.ErrNoRows when there is nothing to print.
+func (r *PrintJobRepo) Claim(ctx context.Context, req entity.PrintJobClaim, lease time.Duration) (entity.PrintJob, error) {
+	query, args, err := r.pg.Builder.Update("print_job").
+		Set("status", "failed").
+		Set("error", "not acknowledged").
+		Set("lease_until", nil).
+		Where("branch_id = ? AND status = 'printing' AND lease_until < now() AND attempts >= ?",
+			req.BranchID, config.PrintJobMaxAttempts).ToSql()
+	if err != nil {
+		return entity.PrintJob{}, err
+	}
+
+	if _, err = r.pg.Pool.Exec(ctx, query, args...); err != nil {
+		return entity.PrintJob{}, err
+	}
+
+	// the subquery keeps ? placeholders, the update numbers them all
+	next := squirrel.Select("id").From("print_job").
+		Where("branch_id = ?", req.BranchID).
+		Where("(status = 'pending' OR (status = 'printing' AND lease_until < now()))")
+	if req.Kind != "" {
+		next = next.Where("kind = ?", req.Kind)
+	}
+	if req.StationID != "" {
+		next = next.Where("station_id = ?", req.StationID)
+	}
+
+	query, args, err = r.pg.Builder.Update("print_job").
+		Set("status", "printing").
+		Set("attempts", squirrel.Expr("attempts + 1")).
+		Set("lease_until", squirrel.Expr("now() + ? * interval '1 second'", int(lease.Seconds()))).
+		Where(squirrel.Expr("id = (?)", next.OrderBy("created_at").Limit(1).Suffix("FOR UPDATE SKIP LOCKED"))).
+		Suffix("RETURNING " + printJobColumns + ", data").ToSql()
+	if err != nil {
+		return entity.PrintJob{}, err
+	}
+
+	var data []byte
+	job, err := scanPrintJob(r.pg.Pool.QueryRow(ctx, query, args...), &data)
+	job.Data = data
+
+	return job, err
+}
+
+// Ack records the outcome of a claimed job. A job that did not print is
+// claimed again until it is out of attempts. It returns pgx.ErrNoRows when the
+// job is not claimed.
+func (r *PrintJobRepo) Ack(ctx context.Context, req entity.PrintJobAck) (entity.PrintJob, error) {
+	return r.queryRow(ctx, r.pg.Builder.Update("print_job").
+		Set("status", squirrel.Expr("CASE WHEN ? THEN 'printed' WHEN attempts >= ? THEN 'failed' ELSE 'pending' END",
+			req.Printed, config.PrintJobMaxAttempts)).
+		Set("error", req.Error).
+		Set("printed_at", squirrel.Expr("CASE WHEN ? THEN now() END", req.Printed)).
+		Set("lease_until", nil).
+		Where("id = ? AND status = 'printing'", req.ID).
+		Suffix("RETURNING "+printJobColumns))
+}
+
+// Retry queues a failed job again. It returns pgx.ErrNoRows when the job did
+// not fail.
+func (r *PrintJobRepo) Retry(ctx context.Context, req entity.Id) (entity.PrintJob, error) {
+	return r.queryRow(ctx, r.pg.Builder.Update("print_job").
+		Set("status", "pending").
+		Set("attempts", 0).
+		Set("error", "").
+		Where("id = ? AND status = 'failed'", req.ID).
+		Suffix("RETURNING "+printJobColumns))
+}
+
+// GetSingle returns the job without its data.
+func (r *PrintJobRepo) GetSingle(ctx context.Context, req entity.Id) (entity.PrintJob, error) {
+	return r.queryRow(ctx, r.pg.Builder.Select(printJobColumns).From("print_job").Where("id = ?", req.ID))
+}
+
+// GetList returns jobs without their data.
+func (r *PrintJobRepo) GetList(ctx context.Context, req entity.GetListFilter) (entity.PrintJobList, error) {
+	response := entity.PrintJobList{Items: []entity.PrintJob{}}
+
+	queryBuilder, where := PrepareGetListQuery(r.pg.Builder.Select(printJobColumns).From("print_job"), req)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item, err := scanPrintJob(rows)
+		if err != nil {
+			return response, err
+		}
+
+		response.Items = append(response.Items, item)
+	}
+
+	countQuery, args, err := r.pg.Builder.Select("COUNT(1)").From("print_job").Where(where).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(&response.Count)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// queryRow runs a statement that returns one job, without its data.
+func (r *PrintJobRepo) queryRow(ctx context.Context, builder squirrel.Sqlizer) (entity.PrintJob, error) {
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return entity.PrintJob{}, err
+	}
+
+	return scanPrintJob(r.pg.Pool.QueryRow(ctx, query, args...))
+}
+
+// scanPrintJob scans printJobColumns and then the extra destinations.
+func scanPrintJob(row pgx.Row, extra ...interface{}) (entity.PrintJob, error) {
+	var (
+		item      entity.PrintJob
+		createdAt time.Time
+		printedAt sql.NullTime
+	)
+
+	dest := append([]interface{}{&item.ID, &item.BranchID, &item.OrderID, &item.Kind, &item.StationID, &item.Status,
+		&item.Attempts, &item.Error, &createdAt, &printedAt}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return entity.PrintJob{}, err
+	}
+
+	item.CreatedAt = createdAt.Format(time.RFC3339)
+	item.PrintedAt = formatNullTime(printedAt)
+
+	return item, nil
+}
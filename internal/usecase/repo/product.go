@@ -9,6 +9,7 @@ import (
 	"github.com/Akrom0181/Food-Delivery/internal/entity"
 	"github.com/Akrom0181/Food-Delivery/pkg/logger"
 	"github.com/Akrom0181/Food-Delivery/pkg/postgres"
+	"github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 )
 
@@ -29,20 +30,34 @@ func NewProductRepo(pg *postgres.Postgres, config *config.Config, logger *logger
 
 func (r *ProductRepo) Create(ctx context.Context, req entity.Product) (entity.Product, error) {
 	req.Id = uuid.NewString()
+	if req.Images == nil {
+		req.Images = entity.ImageVariants{}
+	}
 
+	// without a position the product goes after the others in its category,
+	// it is put in the restaurant of the category by a trigger
 	query, args, err := r.pg.Builder.Insert("product").
-		Columns(`id, category_id, name, description, price, image_url`).
-		Values(req.Id, req.CategoryId, req.Name, req.Description, req.Price, req.ImageUrl).ToSql()
+		Columns(`id, category_id, name, description, price, images, sort_order, is_active, is_hidden,
+			nutrition, allergens, dietary_tags, spicy_level, tax_category_id, mxik_code, package_code`).
+		Values(req.Id, req.CategoryId, req.Name, req.Description, req.Price, req.Images,
+			squirrel.Expr(`COALESCE(?::int, (SELECT COALESCE(MAX(sort_order), 0) + 10 FROM product WHERE category_id = ?))`, req.SortOrder, req.CategoryId),
+			squirrel.Expr("COALESCE(?::boolean, true)", req.IsActive),
+			squirrel.Expr("COALESCE(?::boolean, false)", req.IsHidden),
+			req.Nutrition,
+			squirrel.Expr("COALESCE(?::text[], '{}')", req.Allergens),
+			squirrel.Expr("COALESCE(?::text[], '{}')", req.DietaryTags),
+			squirrel.Expr("COALESCE(?::smallint, 0)", req.SpicyLevel),
+			squirrel.Expr("NULLIF(?, '')::uuid", req.TaxCategoryID), req.MxikCode, req.PackageCode).ToSql()
 	if err != nil {
 		return entity.Product{}, err
 	}
 
-	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	err = execLinkingUploads(ctx, r.pg, query, args, UploadEntityProduct, req.Id, imageURLs(req.Images))
 	if err != nil {
 		return entity.Product{}, err
 	}
 
-	return req, nil
+	return r.GetSingle(ctx, entity.Id{ID: req.Id})
 }
 
 func (r *ProductRepo) GetSingle(ctx context.Context, req entity.Id) (entity.Product, error) {
@@ -52,7 +67,13 @@ func (r *ProductRepo) GetSingle(ctx context.Context, req entity.Id) (entity.Prod
 	)
 
 	queryBuilder := r.pg.Builder.
-		Select(`id, category_id, name, description, price, image_url, created_at, updated_at`).
+		Select(`id, category_id, restaurant_id`).
+		Column(translatedColumn(TranslationEntityProduct, "product", "name", req.Locales)).
+		Column(translatedColumn(TranslationEntityProduct, "product", "description", req.Locales)).
+		Columns(`price, COALESCE(price_version_id::text, ''), images, sort_order, is_active, is_hidden, is_bundle,
+			nutrition, allergens, dietary_tags, spicy_level, COALESCE(tax_category_id::text, ''),
+			COALESCE((SELECT rate FROM tax_category WHERE id = product.tax_category_id), 0), mxik_code, package_code,
+			created_at, updated_at`).
 		From("product")
 
 	switch {
@@ -68,7 +89,10 @@ func (r *ProductRepo) GetSingle(ctx context.Context, req entity.Id) (entity.Prod
 	}
 
 	err = r.pg.Pool.QueryRow(ctx, query, args...).
-		Scan(&response.Id, &response.CategoryId, &response.Name, &response.Description, &response.Price, &response.ImageUrl, &createdAt, &updatedAt)
+		Scan(&response.Id, &response.CategoryId, &response.RestaurantID, &response.Name, &response.Description, &response.Price, &response.PriceVersionID, &response.Images,
+			&response.SortOrder, &response.IsActive, &response.IsHidden, &response.IsBundle,
+			&response.Nutrition, &response.Allergens, &response.DietaryTags, &response.SpicyLevel,
+			&response.TaxCategoryID, &response.TaxRate, &response.MxikCode, &response.PackageCode, &createdAt, &updatedAt)
 	if err != nil {
 		return entity.Product{}, err
 	}
@@ -86,7 +110,13 @@ func (r *ProductRepo) GetList(ctx context.Context, req entity.GetListFilter) (en
 	)
 
 	queryBuilder := r.pg.Builder.
-		Select(`id, category_id, name, description, price, image_url, created_at, updated_at`).
+		Select(`id, category_id, restaurant_id`).
+		Column(translatedColumn(TranslationEntityProduct, "product", "name", req.Locales)).
+		Column(translatedColumn(TranslationEntityProduct, "product", "description", req.Locales)).
+		Columns(`price, COALESCE(price_version_id::text, ''), images, sort_order, is_active, is_hidden, is_bundle,
+			nutrition, allergens, dietary_tags, spicy_level, COALESCE(tax_category_id::text, ''),
+			COALESCE((SELECT rate FROM tax_category WHERE id = product.tax_category_id), 0), mxik_code, package_code,
+			created_at, updated_at`).
 		From("product")
 
 	queryBuilder, where := PrepareGetListQuery(queryBuilder, req)
@@ -104,7 +134,10 @@ func (r *ProductRepo) GetList(ctx context.Context, req entity.GetListFilter) (en
 
 	for rows.Next() {
 		var item entity.Product
-		err = rows.Scan(&item.Id, &item.CategoryId, &item.Name, &item.Description, &item.Price, &item.ImageUrl, &createdAt, &updatedAt)
+		err = rows.Scan(&item.Id, &item.CategoryId, &item.RestaurantID, &item.Name, &item.Description, &item.Price, &item.PriceVersionID, &item.Images,
+			&item.SortOrder, &item.IsActive, &item.IsHidden, &item.IsBundle,
+			&item.Nutrition, &item.Allergens, &item.DietaryTags, &item.SpicyLevel,
+			&item.TaxCategoryID, &item.TaxRate, &item.MxikCode, &item.PackageCode, &createdAt, &updatedAt)
 		if err != nil {
 			return response, err
 		}
@@ -133,16 +166,54 @@ func (r *ProductRepo) Update(ctx context.Context, req entity.Product) (entity.Pr
 		"name":        req.Name,
 		"description": req.Description,
 		"price":       req.Price,
-		"image_url":   req.ImageUrl,
 		"updated_at":  "now()",
 	}
 
+	// the position, flags and images are only replaced when given, an upload sets the images
+	if req.SortOrder != nil {
+		mp["sort_order"] = *req.SortOrder
+	}
+	if req.IsActive != nil {
+		mp["is_active"] = *req.IsActive
+	}
+	if req.IsHidden != nil {
+		mp["is_hidden"] = *req.IsHidden
+	}
+	if req.Images != nil {
+		mp["images"] = req.Images
+	}
+	if req.Nutrition != nil {
+		mp["nutrition"] = req.Nutrition
+	}
+	if req.Allergens != nil {
+		mp["allergens"] = req.Allergens
+	}
+	if req.DietaryTags != nil {
+		mp["dietary_tags"] = req.DietaryTags
+	}
+	if req.SpicyLevel != nil {
+		mp["spicy_level"] = *req.SpicyLevel
+	}
+	if req.TaxCategoryID != "" {
+		mp["tax_category_id"] = req.TaxCategoryID
+	}
+	if req.MxikCode != "" {
+		mp["mxik_code"] = req.MxikCode
+	}
+	if req.PackageCode != "" {
+		mp["package_code"] = req.PackageCode
+	}
+
 	query, args, err := r.pg.Builder.Update("product").SetMap(mp).Where("id = ?", req.Id).ToSql()
 	if err != nil {
 		return entity.Product{}, err
 	}
 
-	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	if req.Images != nil {
+		err = execLinkingUploads(ctx, r.pg, query, args, UploadEntityProduct, req.Id, imageURLs(req.Images))
+	} else {
+		_, err = r.pg.Pool.Exec(ctx, query, args...)
+	}
 	if err != nil {
 		return entity.Product{}, err
 	}
@@ -156,7 +227,8 @@ func (r *ProductRepo) Delete(ctx context.Context, req entity.Id) error {
 		return err
 	}
 
-	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	// the images of the entity become orphans and are swept later
+	err = execLinkingUploads(ctx, r.pg, query, args, UploadEntityProduct, req.ID, nil)
 	if err != nil {
 		return err
 	}
@@ -164,6 +236,11 @@ func (r *ProductRepo) Delete(ctx context.Context, req entity.Id) error {
 	return nil
 }
 
+// Sort sets the position of several products within their categories.
+func (r *ProductRepo) Sort(ctx context.Context, req entity.SortRequest) error {
+	return sortRows(ctx, r.pg, "product", req)
+}
+
 func (r *ProductRepo) UpdateField(ctx context.Context, req entity.UpdateFieldRequest) (entity.RowsEffected, error) {
 	mp := map[string]interface{}{}
 	response := entity.RowsEffected{}
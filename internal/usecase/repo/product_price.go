@@ -0,0 +1,127 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/jackc/pgx/v4"
+)
+
+// GetPriceHistory returns the scheduled, current and past prices of a
+// product, latest effective first. It returns pgx.ErrNoRows when the product
+// does not exist.
+func (r *ProductRepo) GetPriceHistory(ctx context.Context, req entity.Id) (entity.PriceHistory, error) {
+	response := entity.PriceHistory{Items: []entity.PriceVersion{}}
+
+	var exists bool
+	err := r.pg.Pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM product WHERE id = $1)`, req.ID).Scan(&exists)
+	if err != nil {
+		return response, err
+	}
+
+	if !exists {
+		return response, pgx.ErrNoRows
+	}
+
+	query, args, err := r.pg.Builder.
+		Select(`h.id, h.product_id, h.price, h.effective_at, h.applied_at,
+			CASE WHEN h.applied_at IS NULL THEN 'scheduled' WHEN h.id = p.price_version_id THEN 'current' ELSE 'past' END,
+			COALESCE(h.created_by::text, ''), h.created_at`).
+		From("product_price_history h").
+		Join("product p ON p.id = h.product_id").
+		Where("h.product_id = ?", req.ID).
+		OrderBy("h.effective_at DESC", "h.created_at DESC").ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			item                   entity.PriceVersion
+			effectiveAt, createdAt time.Time
+			appliedAt              sql.NullTime
+		)
+
+		err = rows.Scan(&item.ID, &item.ProductID, &item.Price, &effectiveAt, &appliedAt, &item.Status,
+			&item.CreatedBy, &createdAt)
+		if err != nil {
+			return response, err
+		}
+
+		item.EffectiveAt = effectiveAt.Format(time.RFC3339)
+		item.CreatedAt = createdAt.Format(time.RFC3339)
+		if appliedAt.Valid {
+			item.AppliedAt = appliedAt.Time.Format(time.RFC3339)
+		}
+
+		response.Items = append(response.Items, item)
+	}
+
+	return response, rows.Err()
+}
+
+// SchedulePrice adds a price that becomes the price of the product at
+// req.EffectiveAt. It returns pgx.ErrNoRows when the product does not exist.
+func (r *ProductRepo) SchedulePrice(ctx context.Context, req entity.PriceVersion) (entity.PriceVersion, error) {
+	var createdAt time.Time
+
+	err := r.pg.Pool.QueryRow(ctx, `INSERT INTO product_price_history (product_id, price, effective_at, created_by)
+		SELECT id, $2::decimal, $3::timestamp, NULLIF($4, '')::uuid FROM product WHERE id = $1
+		RETURNING id, created_at`, req.ProductID, req.Price, req.EffectiveAt, req.CreatedBy).Scan(&req.ID, &createdAt)
+	if err != nil {
+		return req, err
+	}
+
+	req.Status = "scheduled"
+	req.CreatedAt = createdAt.Format(time.RFC3339)
+
+	return req, nil
+}
+
+// CancelScheduledPrice deletes a price that is not applied yet. It returns
+// pgx.ErrNoRows when there is no such scheduled price for the product.
+func (r *ProductRepo) CancelScheduledPrice(ctx context.Context, req entity.PriceVersion) error {
+	n, err := r.pg.Pool.Exec(ctx, `DELETE FROM product_price_history
+		WHERE id = $1 AND product_id = $2 AND applied_at IS NULL`, req.ID, req.ProductID)
+	if err != nil {
+		return err
+	}
+
+	if n.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// ApplyScheduledPrices makes the scheduled prices that are due the prices of
+// their products. When several are due for a product the latest effective
+// wins and the others are marked applied as well. Rows another replica is
+// applying are skipped. It returns the number of products repriced.
+func (r *ProductRepo) ApplyScheduledPrices(ctx context.Context) (int64, error) {
+	n, err := r.pg.Pool.Exec(ctx, `WITH due AS (
+			SELECT id, product_id, price, effective_at FROM product_price_history
+			WHERE applied_at IS NULL AND effective_at <= now()
+			FOR UPDATE SKIP LOCKED
+		), applied AS (
+			UPDATE product_price_history h SET applied_at = now() FROM due WHERE h.id = due.id
+		), latest AS (
+			SELECT DISTINCT ON (product_id) id, product_id, price FROM due
+			ORDER BY product_id, effective_at DESC
+		)
+		UPDATE product p SET price = latest.price, price_version_id = latest.id, updated_at = now()
+		FROM latest WHERE p.id = latest.product_id`)
+	if err != nil {
+		return 0, err
+	}
+
+	return n.RowsAffected(), nil
+}
@@ -0,0 +1,234 @@
+package repo
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+)
+
+// wordSimilarityThreshold is how close a typo has to be, pg_trgm's default of 0.6 misses most of them.
+const wordSimilarityThreshold = "0.4"
+
+// Search finds products by the full text document (name, category name and
+// description) with prefix matching, or by trigram similarity of the name to
+// catch typos, in the default locale and in req.Locales. The better the match
+// the higher the rank.
+func (r *ProductRepo) Search(ctx context.Context, req entity.ProductSearchRequest) (entity.ProductSearchResult, error) {
+	var response = entity.ProductSearchResult{}
+
+	queryBuilder := r.pg.Builder.
+		Select(`p.id, p.category_id, p.restaurant_id`).
+		Column(translatedColumn(TranslationEntityProduct, "p", "name", req.Locales)).
+		Column(squirrel.Expr("COALESCE(?, '')", translatedColumn(TranslationEntityProduct, "p", "description", req.Locales))).
+		Columns(`p.price, p.images, p.nutrition, p.allergens, p.dietary_tags, p.spicy_level, p.created_at, p.updated_at`).
+		Column(squirrel.Expr("COALESCE(?, '')", translatedColumn(TranslationEntityCategory, "c", "name", req.Locales))).
+		From("product p").
+		LeftJoin("category c ON c.id = p.category_id").
+		Where("p.is_active AND COALESCE(c.is_active, true)")
+
+	if len(req.Terms) > 0 {
+		tsQuery, term := tsPrefixQuery(req.Terms), strings.Join(req.Terms, " ")
+
+		rank := squirrel.Expr("ts_rank_cd(p.search_document, to_tsquery('simple', ?)) + word_similarity(?, p.search_name)", tsQuery, term)
+		matches := squirrel.Or{
+			squirrel.Expr("p.search_document @@ to_tsquery('simple', ?)", tsQuery),
+			squirrel.Expr("? <% p.search_name", term),
+		}
+
+		// translations in the requested locales are indexed on their own
+		if len(req.Locales) > 0 {
+			translated := squirrel.Expr(`(SELECT MAX(ts_rank_cd(t.search_document, to_tsquery('simple', ?)) + word_similarity(?, t.search_name))
+				FROM product_translation t WHERE t.product_id = p.id AND t.locale = ANY(?::text[])
+				AND (t.search_document @@ to_tsquery('simple', ?) OR ? <% t.search_name))`, tsQuery, term, req.Locales, tsQuery, term)
+
+			rank = squirrel.Expr("GREATEST(?, COALESCE(?, 0))", rank, translated)
+			matches = append(matches, squirrel.Expr("? IS NOT NULL", translated))
+		}
+
+		queryBuilder = queryBuilder.
+			Column(squirrel.Expr("? AS rank", rank)).
+			Where(matches).
+			OrderBy("rank DESC", "p.name")
+	} else {
+		queryBuilder = queryBuilder.
+			Column("0::float8 AS rank").
+			OrderBy("p.created_at DESC")
+	}
+
+	queryBuilder = queryBuilder.Column("COUNT(1) OVER ()")
+
+	if req.RestaurantID != "" {
+		queryBuilder = queryBuilder.Where("p.restaurant_id = ?", req.RestaurantID)
+	}
+	if req.CategoryID != "" {
+		queryBuilder = queryBuilder.Where("p.category_id = ?", req.CategoryID)
+	}
+	if req.PriceMin != nil {
+		queryBuilder = queryBuilder.Where("p.price >= ?", *req.PriceMin)
+	}
+	if req.PriceMax != nil {
+		queryBuilder = queryBuilder.Where("p.price <= ?", *req.PriceMax)
+	}
+	if req.BranchID != "" {
+		queryBuilder = queryBuilder.Where("product_available(p.id, ?::uuid)", req.BranchID)
+	}
+	queryBuilder = queryBuilder.Where(dietaryConditions("p", req.Dietary))
+
+	if req.Limit <= 0 {
+		req.Limit = 10
+	}
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	queryBuilder = queryBuilder.Limit(uint64(req.Limit)).Offset(uint64((req.Page - 1) * req.Limit))
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	err = r.withSimilarityThreshold(ctx, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var (
+				item                 entity.ProductSearchHit
+				createdAt, updatedAt time.Time
+			)
+
+			err = rows.Scan(&item.Id, &item.CategoryId, &item.RestaurantID, &item.Name, &item.Description, &item.Price, &item.Images,
+				&item.Nutrition, &item.Allergens, &item.DietaryTags, &item.SpicyLevel, &createdAt, &updatedAt, &item.CategoryName, &item.Rank, &response.Count)
+			if err != nil {
+				return err
+			}
+
+			item.CreatedAt = createdAt.Format(time.RFC3339)
+			item.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+			response.Items = append(response.Items, item)
+		}
+
+		return rows.Err()
+	})
+
+	return response, err
+}
+
+// Suggest completes the last words typed into the search box with product and
+// category names of the restaurant.
+func (r *ProductRepo) Suggest(ctx context.Context, restaurantID string, terms []string, limit int) (entity.SuggestionList, error) {
+	var response = entity.SuggestionList{}
+
+	if len(terms) == 0 {
+		return response, nil
+	}
+
+	// names starting with the input come first, then names with a word
+	// starting with it, then anything similar enough
+	query := `SELECT id, text, type FROM (
+			SELECT p.id::text AS id, p.name AS text, 'product' AS type, p.search_name AS search_name
+			FROM product p JOIN category pc ON pc.id = p.category_id
+			WHERE p.restaurant_id = $3 AND p.is_active AND pc.is_active AND (p.search_name LIKE '%' || $1 || '%' OR $1 <% p.search_name)
+			UNION ALL
+			SELECT c.id::text, c.name, 'category', c.search_name
+			FROM category c WHERE c.restaurant_id = $3 AND c.is_active AND (c.search_name LIKE '%' || $1 || '%' OR $1 <% c.search_name)
+		) s ORDER BY CASE WHEN search_name LIKE $1 || '%' THEN 2
+			WHEN search_name LIKE '% ' || $1 || '%' THEN 1.5
+			ELSE word_similarity($1, search_name) END DESC, text
+		LIMIT $2`
+
+	err := r.withSimilarityThreshold(ctx, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, query, strings.Join(terms, " "), limit, restaurantID)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var item entity.Suggestion
+			if err = rows.Scan(&item.ID, &item.Text, &item.Type); err != nil {
+				return err
+			}
+
+			response.Items = append(response.Items, item)
+		}
+
+		return rows.Err()
+	})
+
+	return response, err
+}
+
+// SetAvailability marks a product as available or not at a branch.
+func (r *ProductRepo) SetAvailability(ctx context.Context, req entity.ProductAvailability) (entity.ProductAvailability, error) {
+	query, args, err := r.pg.Builder.Insert("product_availability").
+		Columns(`product_id, branch_id, is_available`).
+		Values(req.ProductID, req.BranchID, req.IsAvailable).
+		Suffix(`ON CONFLICT (product_id, branch_id) DO UPDATE SET is_available = EXCLUDED.is_available, updated_at = now()`).ToSql()
+	if err != nil {
+		return entity.ProductAvailability{}, err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return entity.ProductAvailability{}, err
+	}
+
+	return req, nil
+}
+
+// withSimilarityThreshold runs fn in a transaction with the lowered word similarity threshold.
+func (r *ProductRepo) withSimilarityThreshold(ctx context.Context, fn func(tx pgx.Tx) error) error {
+	tx, err := r.pg.Pool.BeginTx(ctx, pgx.TxOptions{AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, "SET LOCAL pg_trgm.word_similarity_threshold = "+wordSimilarityThreshold)
+	if err != nil {
+		return err
+	}
+
+	if err = fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// tsPrefixQuery builds a to_tsquery expression matching words that start with every term.
+// Terms only hold letters and digits, so they need no quoting.
+func tsPrefixQuery(terms []string) string {
+	parts := make([]string, len(terms))
+	for i, term := range terms {
+		parts[i] = term + ":*"
+	}
+
+	return strings.Join(parts, " & ")
+}
+
+// dietaryConditions keeps the products aliased as alias that pass f.
+func dietaryConditions(alias string, f entity.DietaryFilter) squirrel.And {
+	where := squirrel.And{}
+
+	if len(f.ExcludeAllergens) > 0 {
+		where = append(where, squirrel.Expr("NOT "+alias+".allergens && ?::text[]", f.ExcludeAllergens))
+	}
+	if len(f.DietaryTags) > 0 {
+		where = append(where, squirrel.Expr(alias+".dietary_tags @> ?::text[]", f.DietaryTags))
+	}
+	if f.MaxSpicyLevel != nil {
+		where = append(where, squirrel.Expr(alias+".spicy_level <= ?", *f.MaxSpicyLevel))
+	}
+
+	return where
+}
@@ -0,0 +1,227 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/Akrom0181/Food-Delivery/pkg/logger"
+	"github.com/Akrom0181/Food-Delivery/pkg/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+const receiptColumns = `id, order_id, branch_id, number, items, total, tax, currency, status,
+	terminal_id, fiscal_sign, qr_url, error, attempts, created_at, registered_at`
+
+type ReceiptRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+// New -.
+func NewReceiptRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *ReceiptRepo {
+	return &ReceiptRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+// Create makes the pending receipt of an order with the next number of its
+// branch and reports whether it did. When the order has a receipt already
+// that one is returned.
+func (r *ReceiptRepo) Create(ctx context.Context, req entity.Id) (entity.Receipt, bool, error) {
+	tx, err := r.pg.Pool.Begin(ctx)
+	if err != nil {
+		return entity.Receipt{}, false, err
+	}
+	defer tx.Rollback(ctx)
+
+	receipt := entity.Receipt{ID: uuid.NewString(), OrderID: req.ID}
+
+	// the lock keeps two requests from numbering the same order
+	err = tx.QueryRow(ctx, `SELECT COALESCE(branch_id::text, ''), currency FROM orders WHERE id = $1 FOR UPDATE`,
+		req.ID).Scan(&receipt.BranchID, &receipt.Currency)
+	if err != nil {
+		return entity.Receipt{}, false, err
+	}
+
+	existing, err := scanReceipt(tx.QueryRow(ctx, `SELECT `+receiptColumns+` FROM receipt WHERE order_id = $1`, req.ID))
+	if err == nil {
+		return existing, false, nil
+	}
+	if err != pgx.ErrNoRows {
+		return entity.Receipt{}, false, err
+	}
+
+	if receipt.BranchID == "" {
+		return entity.Receipt{}, false, fmt.Errorf("Create - order has no branch")
+	}
+
+	// component lines of bundles cost nothing and are left out; the total and
+	// VAT are those of the lines, as priced and named when the order was placed
+	rows, err := tx.Query(ctx, `SELECT COALESCE(product_id::text, ''), product_name, mxik_code, package_code, quantity,
+			price, discount, total_price, tax_rate, tax
+		FROM orderitems
+		WHERE order_id = $1 AND parent_item_id IS NULL
+		ORDER BY created_at, id`, req.ID)
+	if err != nil {
+		return entity.Receipt{}, false, err
+	}
+	defer rows.Close()
+
+	receipt.Items = []entity.ReceiptItem{}
+	for rows.Next() {
+		var item entity.ReceiptItem
+		err = rows.Scan(&item.ProductID, &item.Name, &item.MxikCode, &item.PackageCode, &item.Quantity,
+			&item.Price, &item.Discount, &item.Total, &item.TaxRate, &item.Tax)
+		if err != nil {
+			return entity.Receipt{}, false, err
+		}
+
+		receipt.Items = append(receipt.Items, item)
+		receipt.Total += item.Total
+		receipt.Tax += item.Tax
+	}
+	if err = rows.Err(); err != nil {
+		return entity.Receipt{}, false, err
+	}
+
+	// the counter row stays locked until commit, so numbers have no gaps
+	err = tx.QueryRow(ctx, `INSERT INTO receipt_counter (branch_id, last_number) VALUES ($1, 1)
+		ON CONFLICT (branch_id) DO UPDATE SET last_number = receipt_counter.last_number + 1
+		RETURNING last_number`, receipt.BranchID).Scan(&receipt.Number)
+	if err != nil {
+		return entity.Receipt{}, false, err
+	}
+
+	query, args, err := r.pg.Builder.Insert("receipt").
+		Columns(`id, order_id, branch_id, number, items, total, tax, currency`).
+		Values(receipt.ID, receipt.OrderID, receipt.BranchID, receipt.Number, receipt.Items,
+			receipt.Total, receipt.Tax, receipt.Currency).
+		Suffix("ON CONFLICT (order_id) DO NOTHING RETURNING id").ToSql()
+	if err != nil {
+		return entity.Receipt{}, false, err
+	}
+
+	// a receipt made in the meantime wins, the number taken here is rolled back
+	err = tx.QueryRow(ctx, query, args...).Scan(&receipt.ID)
+	if err == pgx.ErrNoRows {
+		tx.Rollback(ctx)
+		existing, err = r.GetByOrder(ctx, req)
+		return existing, false, err
+	}
+	if err != nil {
+		return entity.Receipt{}, false, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return entity.Receipt{}, false, err
+	}
+
+	receipt, err = r.GetByOrder(ctx, req)
+	return receipt, true, err
+}
+
+// GetByOrder returns the receipt of an order or pgx.ErrNoRows.
+func (r *ReceiptRepo) GetByOrder(ctx context.Context, req entity.Id) (entity.Receipt, error) {
+	query, args, err := r.pg.Builder.Select(receiptColumns).From("receipt").Where("order_id = ?", req.ID).ToSql()
+	if err != nil {
+		return entity.Receipt{}, err
+	}
+
+	return scanReceipt(r.pg.Pool.QueryRow(ctx, query, args...))
+}
+
+// ClaimPending returns up to limit pending receipts that are due and counts
+// the attempt. A claimed receipt is not due again until retryDelay passed, so
+// replicas do not send the same receipt at once.
+func (r *ReceiptRepo) ClaimPending(ctx context.Context, limit int, retryDelay time.Duration) ([]entity.Receipt, error) {
+	rows, err := r.pg.Pool.Query(ctx, `UPDATE receipt
+		SET attempts = attempts + 1, next_attempt_at = now() + $2 * interval '1 second'
+		WHERE id IN (
+			SELECT id FROM receipt WHERE status = 'pending' AND next_attempt_at <= now()
+			ORDER BY next_attempt_at LIMIT $1 FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+receiptColumns, limit, int(retryDelay.Seconds()))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var response []entity.Receipt
+	for rows.Next() {
+		item, err := scanReceipt(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		response = append(response, item)
+	}
+
+	return response, rows.Err()
+}
+
+// SetRegistered stores the fiscal sign, terminal and QR URL of a registered receipt.
+func (r *ReceiptRepo) SetRegistered(ctx context.Context, req entity.Receipt) error {
+	_, err := r.pg.Pool.Exec(ctx, `UPDATE receipt
+		SET status = 'registered', terminal_id = $2, fiscal_sign = $3, qr_url = $4, error = '', registered_at = now()
+		WHERE id = $1 AND status = 'pending'`, req.ID, req.TerminalID, req.FiscalSign, req.QRURL)
+
+	return err
+}
+
+// SetError stores why registering failed. The receipt is failed when final
+// is set or it ran out of attempts, otherwise it is sent again later.
+func (r *ReceiptRepo) SetError(ctx context.Context, req entity.Receipt, final bool) error {
+	_, err := r.pg.Pool.Exec(ctx, `UPDATE receipt
+		SET error = $2, status = CASE WHEN $3 OR attempts >= $4 THEN 'failed' ELSE status END
+		WHERE id = $1 AND status = 'pending'`, req.ID, req.Error, final, config.ReceiptMaxAttempts)
+
+	return err
+}
+
+// Retry makes a failed receipt pending again. It returns pgx.ErrNoRows when
+// the order has no failed receipt.
+func (r *ReceiptRepo) Retry(ctx context.Context, req entity.Id) error {
+	n, err := r.pg.Pool.Exec(ctx, `UPDATE receipt
+		SET status = 'pending', attempts = 0, error = '', next_attempt_at = now()
+		WHERE order_id = $1 AND status = 'failed'`, req.ID)
+	if err != nil {
+		return err
+	}
+
+	if n.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+func scanReceipt(row pgx.Row) (entity.Receipt, error) {
+	var (
+		item         entity.Receipt
+		createdAt    time.Time
+		registeredAt sql.NullTime
+	)
+
+	err := row.Scan(&item.ID, &item.OrderID, &item.BranchID, &item.Number, &item.Items, &item.Total, &item.Tax,
+		&item.Currency, &item.Status, &item.TerminalID, &item.FiscalSign, &item.QRURL, &item.Error, &item.Attempts,
+		&createdAt, &registeredAt)
+	if err != nil {
+		return entity.Receipt{}, err
+	}
+
+	item.CreatedAt = createdAt.Format(time.RFC3339)
+	if registeredAt.Valid {
+		item.RegisteredAt = registeredAt.Time.Format(time.RFC3339)
+	}
+
+	return item, nil
+}
@@ -50,7 +50,7 @@ func (r *ReportRepo) GetSingle(ctx context.Context, req entity.Id) (entity.Repor
 	var createdAt time.Time
 
 	queryBuilder := r.pg.Builder.
-		Select(`id, user_id, business_id, reason, created_at`).
+		Select(`id, user_id, reason, created_at`).
 		From("reports")
 
 	switch {
@@ -0,0 +1,450 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/Akrom0181/Food-Delivery/pkg/logger"
+	"github.com/Akrom0181/Food-Delivery/pkg/postgres"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+type RestaurantRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+// New -.
+func NewRestaurantRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *RestaurantRepo {
+	return &RestaurantRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+func (r *RestaurantRepo) Create(ctx context.Context, req entity.Restaurant) (entity.Restaurant, error) {
+	req.ID = uuid.NewString()
+
+	query, args, err := r.pg.Builder.Insert("restaurant").
+		Columns(`id, name, slug, description, is_active`).
+		Values(req.ID, req.Name, req.Slug, req.Description, squirrel.Expr("COALESCE(?::boolean, true)", req.IsActive)).ToSql()
+	if err != nil {
+		return entity.Restaurant{}, err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return entity.Restaurant{}, err
+	}
+
+	return r.GetSingle(ctx, entity.Id{ID: req.ID})
+}
+
+func (r *RestaurantRepo) GetSingle(ctx context.Context, req entity.Id) (entity.Restaurant, error) {
+	var (
+		response             entity.Restaurant
+		createdAt, updatedAt time.Time
+	)
+
+	query, args, err := r.pg.Builder.
+		Select(`id, name, slug, description, is_active, created_at, updated_at`).
+		From("restaurant").
+		Where("id = ?", req.ID).ToSql()
+	if err != nil {
+		return entity.Restaurant{}, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, query, args...).Scan(&response.ID, &response.Name, &response.Slug, &response.Description, &response.IsActive, &createdAt, &updatedAt)
+	if err != nil {
+		return entity.Restaurant{}, err
+	}
+
+	response.CreatedAt = createdAt.Format(time.RFC3339)
+	response.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+	return response, nil
+}
+
+// GetList lists the restaurants by name. With a location it lists those with
+// a branch within the delivery radius, by the distance of their nearest one.
+func (r *RestaurantRepo) GetList(ctx context.Context, req entity.RestaurantRequest) (entity.RestaurantList, error) {
+	response := entity.RestaurantList{Items: []entity.Restaurant{}}
+
+	if req.Limit <= 0 {
+		req.Limit = 10
+	}
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+
+	queryBuilder := r.pg.Builder.
+		Select(`r.id, r.name, r.slug, r.description, r.is_active, r.created_at, r.updated_at`).
+		From("restaurant r")
+
+	if req.Latitude != nil && req.Longitude != nil {
+		queryBuilder = queryBuilder.
+			Columns(`n.id::text, n.distance`).
+			JoinClause(`JOIN LATERAL (
+				SELECT b.id, earth_distance(ll_to_earth(?, ?), ll_to_earth(b.latitude, b.longitude)) AS distance
+				FROM branch b WHERE b.restaurant_id = r.id
+				ORDER BY 2 LIMIT 1
+			) n ON n.distance < ?`, *req.Latitude, *req.Longitude, config.DeliveryRadius).
+			Where("r.is_active").
+			OrderBy("n.distance", "r.name")
+	} else {
+		queryBuilder = queryBuilder.
+			Columns(`''`, `0::float8`).
+			OrderBy("r.name")
+	}
+
+	if req.ActiveOnly {
+		queryBuilder = queryBuilder.Where("r.is_active")
+	}
+	if req.Search != "" {
+		queryBuilder = queryBuilder.Where("r.name ILIKE '%' || ? || '%'", req.Search)
+	}
+
+	query, args, err := queryBuilder.
+		Column("COUNT(1) OVER ()").
+		Limit(uint64(req.Limit)).Offset(uint64((req.Page - 1) * req.Limit)).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			item                 entity.Restaurant
+			createdAt, updatedAt time.Time
+		)
+
+		err = rows.Scan(&item.ID, &item.Name, &item.Slug, &item.Description, &item.IsActive, &createdAt, &updatedAt,
+			&item.NearestBranchID, &item.Distance, &response.Count)
+		if err != nil {
+			return response, err
+		}
+
+		item.CreatedAt = createdAt.Format(time.RFC3339)
+		item.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+		response.Items = append(response.Items, item)
+	}
+
+	return response, rows.Err()
+}
+
+// Update leaves is_active unchanged when it is missing.
+func (r *RestaurantRepo) Update(ctx context.Context, req entity.Restaurant) (entity.Restaurant, error) {
+	mp := map[string]interface{}{
+		"name":        req.Name,
+		"slug":        req.Slug,
+		"description": req.Description,
+		"updated_at":  squirrel.Expr("now()"),
+	}
+
+	if req.IsActive != nil {
+		mp["is_active"] = *req.IsActive
+	}
+
+	query, args, err := r.pg.Builder.Update("restaurant").SetMap(mp).Where("id = ?", req.ID).ToSql()
+	if err != nil {
+		return entity.Restaurant{}, err
+	}
+
+	tag, err := r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return entity.Restaurant{}, err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.Restaurant{}, pgx.ErrNoRows
+	}
+
+	return r.GetSingle(ctx, entity.Id{ID: req.ID})
+}
+
+// Delete only deletes a restaurant without branches and catalog, its admins
+// go with it.
+func (r *RestaurantRepo) Delete(ctx context.Context, req entity.Id) error {
+	tx, err := r.pg.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	admins := squirrel.Expr("IN (SELECT user_id FROM restaurant_admin WHERE restaurant_id = ?)", req.ID)
+
+	query, args, err := r.pg.Builder.Update("users").
+		Set("user_role", "user").
+		Set("updated_at", squirrel.Expr("now()")).
+		Where(squirrel.ConcatExpr("id ", admins)).ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec(ctx, query, args...); err != nil {
+		return err
+	}
+
+	query, args, err = r.pg.Builder.Update("session").
+		Set("is_active", false).
+		Set("updated_at", squirrel.Expr("now()")).
+		Where(squirrel.ConcatExpr("user_id ", admins)).
+		Where("is_active").ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec(ctx, query, args...); err != nil {
+		return err
+	}
+
+	query, args, err = r.pg.Builder.Delete("restaurant").Where("id = ?", req.ID).ToSql()
+	if err != nil {
+		return err
+	}
+
+	tag, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Owns reports whether every row of table in ids is of the restaurant. table
+// is branch, category, product or banner.
+func (r *RestaurantRepo) Owns(ctx context.Context, table, restaurantID string, ids []string) (bool, error) {
+	switch table {
+	case "branch", "category", "product", "banner":
+	default:
+		return false, fmt.Errorf("Owns - invalid table %q", table)
+	}
+
+	var owns bool
+
+	query, args, err := r.pg.Builder.Select().
+		Column(`NOT EXISTS (
+			SELECT 1 FROM unnest(?::uuid[]) AS i(id)
+			WHERE NOT EXISTS (SELECT 1 FROM `+table+` t WHERE t.id = i.id AND t.restaurant_id = ?)
+		)`, ids, restaurantID).ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, query, args...).Scan(&owns)
+	return owns, err
+}
+
+// GetProductRestaurants returns the restaurants the products are of.
+func (r *RestaurantRepo) GetProductRestaurants(ctx context.Context, productIDs []string) ([]string, error) {
+	restaurants := []string{}
+
+	query, args, err := r.pg.Builder.Select("restaurant_id::text").Distinct().
+		From("product").
+		Where("id = ANY(?::uuid[])", productIDs).ToSql()
+	if err != nil {
+		return restaurants, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return restaurants, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		if err = rows.Scan(&id); err != nil {
+			return restaurants, err
+		}
+
+		restaurants = append(restaurants, id)
+	}
+
+	return restaurants, rows.Err()
+}
+
+// SaveAdmin makes the user the admin of the restaurant, moving them from the
+// one they had. Only users and restaurant admins can be made restaurant
+// admins, for anyone else it returns pgx.ErrNoRows. The sessions of the user
+// are ended so the role is picked up at the next login.
+func (r *RestaurantRepo) SaveAdmin(ctx context.Context, req entity.RestaurantAdmin) (entity.RestaurantAdmin, error) {
+	tx, err := r.pg.Pool.Begin(ctx)
+	if err != nil {
+		return entity.RestaurantAdmin{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	query, args, err := r.pg.Builder.Update("users").
+		Set("user_role", config.RestaurantAdminRole).
+		Set("updated_at", squirrel.Expr("now()")).
+		Where(squirrel.Eq{"id": req.UserID, "user_role::text": []string{"user", config.RestaurantAdminRole}}).ToSql()
+	if err != nil {
+		return entity.RestaurantAdmin{}, err
+	}
+
+	tag, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return entity.RestaurantAdmin{}, err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.RestaurantAdmin{}, pgx.ErrNoRows
+	}
+
+	query, args, err = r.pg.Builder.Insert("restaurant_admin").
+		Columns("user_id, restaurant_id").
+		Values(req.UserID, req.RestaurantID).
+		Suffix("ON CONFLICT (user_id) DO UPDATE SET restaurant_id = EXCLUDED.restaurant_id, created_at = now()").ToSql()
+	if err != nil {
+		return entity.RestaurantAdmin{}, err
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+	if err != nil {
+		return entity.RestaurantAdmin{}, err
+	}
+
+	if err = endSessions(ctx, tx, r.pg.Builder, req.UserID); err != nil {
+		return entity.RestaurantAdmin{}, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return entity.RestaurantAdmin{}, err
+	}
+
+	return r.GetAdmin(ctx, entity.Id{ID: req.UserID})
+}
+
+// GetAdmin returns pgx.ErrNoRows when the user is not a restaurant admin.
+func (r *RestaurantRepo) GetAdmin(ctx context.Context, req entity.Id) (entity.RestaurantAdmin, error) {
+	var item entity.RestaurantAdmin
+
+	query, args, err := r.adminQuery().Where("ra.user_id = ?", req.ID).ToSql()
+	if err != nil {
+		return entity.RestaurantAdmin{}, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, query, args...).Scan(&item.UserID, &item.FullName, &item.RestaurantID)
+	if err != nil {
+		return entity.RestaurantAdmin{}, err
+	}
+
+	return item, nil
+}
+
+func (r *RestaurantRepo) GetAdmins(ctx context.Context, req entity.RestaurantAdminRequest) (entity.RestaurantAdminList, error) {
+	response := entity.RestaurantAdminList{Items: []entity.RestaurantAdmin{}}
+
+	if req.Limit <= 0 {
+		req.Limit = 10
+	}
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+
+	where := squirrel.And{}
+	if req.RestaurantID != "" {
+		where = append(where, squirrel.Eq{"ra.restaurant_id": req.RestaurantID})
+	}
+
+	query, args, err := r.adminQuery().Where(where).
+		OrderBy("u.full_name", "u.id").
+		Limit(uint64(req.Limit)).Offset(uint64((req.Page - 1) * req.Limit)).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item entity.RestaurantAdmin
+		if err = rows.Scan(&item.UserID, &item.FullName, &item.RestaurantID); err != nil {
+			return response, err
+		}
+
+		response.Items = append(response.Items, item)
+	}
+	if err = rows.Err(); err != nil {
+		return response, err
+	}
+
+	countQuery, args, err := r.pg.Builder.Select("COUNT(1)").From("restaurant_admin ra").Where(where).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(&response.Count)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// DeleteAdmin makes the restaurant admin a plain user again and ends their
+// sessions. It returns pgx.ErrNoRows when the user is not a restaurant admin.
+func (r *RestaurantRepo) DeleteAdmin(ctx context.Context, req entity.Id) error {
+	tx, err := r.pg.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query, args, err := r.pg.Builder.Delete("restaurant_admin").Where("user_id = ?", req.ID).ToSql()
+	if err != nil {
+		return err
+	}
+
+	tag, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	query, args, err = r.pg.Builder.Update("users").
+		Set("user_role", "user").
+		Set("updated_at", squirrel.Expr("now()")).
+		Where(squirrel.Eq{"id": req.ID, "user_role::text": config.RestaurantAdminRole}).ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec(ctx, query, args...); err != nil {
+		return err
+	}
+
+	if err = endSessions(ctx, tx, r.pg.Builder, req.ID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// adminQuery selects a restaurant admin with their name.
+func (r *RestaurantRepo) adminQuery() squirrel.SelectBuilder {
+	return r.pg.Builder.
+		Select(`u.id, u.full_name, ra.restaurant_id::text`).
+		From("restaurant_admin ra").
+		Join("users u ON u.id = ra.user_id")
+}
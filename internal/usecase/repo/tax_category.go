@@ -0,0 +1,159 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/Akrom0181/Food-Delivery/pkg/logger"
+	"github.com/Akrom0181/Food-Delivery/pkg/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+type TaxCategoryRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+// New -.
+func NewTaxCategoryRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *TaxCategoryRepo {
+	return &TaxCategoryRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+func (r *TaxCategoryRepo) Create(ctx context.Context, req entity.TaxCategory) (entity.TaxCategory, error) {
+	req.ID = uuid.NewString()
+
+	query, args, err := r.pg.Builder.Insert("tax_category").
+		Columns(`id, name, rate`).
+		Values(req.ID, req.Name, req.Rate).ToSql()
+	if err != nil {
+		return entity.TaxCategory{}, err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return entity.TaxCategory{}, err
+	}
+
+	return r.GetSingle(ctx, entity.Id{ID: req.ID})
+}
+
+func (r *TaxCategoryRepo) GetSingle(ctx context.Context, req entity.Id) (entity.TaxCategory, error) {
+	if req.ID == "" {
+		return entity.TaxCategory{}, fmt.Errorf("GetSingle - invalid request")
+	}
+
+	query, args, err := r.pg.Builder.Select(`id, name, rate, created_at, updated_at`).
+		From("tax_category").Where("id = ?", req.ID).ToSql()
+	if err != nil {
+		return entity.TaxCategory{}, err
+	}
+
+	return scanTaxCategory(r.pg.Pool.QueryRow(ctx, query, args...))
+}
+
+func (r *TaxCategoryRepo) GetList(ctx context.Context, req entity.GetListFilter) (entity.TaxCategoryList, error) {
+	response := entity.TaxCategoryList{Items: []entity.TaxCategory{}}
+
+	queryBuilder, where := PrepareGetListQuery(r.pg.Builder.Select(`id, name, rate, created_at, updated_at`).From("tax_category"), req)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item, err := scanTaxCategory(rows)
+		if err != nil {
+			return response, err
+		}
+
+		response.Items = append(response.Items, item)
+	}
+
+	countQuery, args, err := r.pg.Builder.Select("COUNT(1)").From("tax_category").Where(where).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(&response.Count)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// Update changes the name and rate. Orders keep the rate they were taxed at.
+func (r *TaxCategoryRepo) Update(ctx context.Context, req entity.TaxCategory) (entity.TaxCategory, error) {
+	query, args, err := r.pg.Builder.Update("tax_category").
+		SetMap(map[string]interface{}{
+			"name":       req.Name,
+			"rate":       req.Rate,
+			"updated_at": "now()",
+		}).Where("id = ?", req.ID).ToSql()
+	if err != nil {
+		return entity.TaxCategory{}, err
+	}
+
+	n, err := r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return entity.TaxCategory{}, err
+	}
+
+	if n.RowsAffected() == 0 {
+		return entity.TaxCategory{}, pgx.ErrNoRows
+	}
+
+	return r.GetSingle(ctx, entity.Id{ID: req.ID})
+}
+
+// Delete fails with a foreign key violation while products use the category.
+func (r *TaxCategoryRepo) Delete(ctx context.Context, req entity.Id) error {
+	query, args, err := r.pg.Builder.Delete("tax_category").Where("id = ?", req.ID).ToSql()
+	if err != nil {
+		return err
+	}
+
+	n, err := r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if n.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+func scanTaxCategory(row pgx.Row) (entity.TaxCategory, error) {
+	var (
+		item                 entity.TaxCategory
+		createdAt, updatedAt time.Time
+	)
+
+	err := row.Scan(&item.ID, &item.Name, &item.Rate, &createdAt, &updatedAt)
+	if err != nil {
+		return entity.TaxCategory{}, err
+	}
+
+	item.CreatedAt = createdAt.Format(time.RFC3339)
+	item.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+	return item, nil
+}
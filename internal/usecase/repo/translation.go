@@ -0,0 +1,189 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/Akrom0181/Food-Delivery/pkg/logger"
+	"github.com/Akrom0181/Food-Delivery/pkg/postgres"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+)
+
+// Entity types with translatable content.
+const (
+	TranslationEntityProduct  = "product"
+	TranslationEntityCategory = "category"
+	TranslationEntityBanner   = "banner"
+)
+
+type translationTable struct {
+	table  string // of the entity
+	key    string // referencing the entity in the translation table
+	fields []string
+}
+
+var translationTables = map[string]translationTable{
+	TranslationEntityProduct:  {table: "product", key: "product_id", fields: []string{"name", "description"}},
+	TranslationEntityCategory: {table: "category", key: "category_id", fields: []string{"name"}},
+	TranslationEntityBanner:   {table: "banner", key: "banner_id", fields: []string{"title"}},
+}
+
+// TranslationRepo stores catalog content in the locales other than the
+// default one, which stays in the entities themselves. The entity repos read
+// it through translatedColumn.
+type TranslationRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+// New -.
+func NewTranslationRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *TranslationRepo {
+	return &TranslationRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+// GetList returns every translation of an entity.
+func (r *TranslationRepo) GetList(ctx context.Context, entityType string, req entity.Id) (entity.TranslationList, error) {
+	var response = entity.TranslationList{}
+
+	t, ok := translationTables[entityType]
+	if !ok {
+		return response, fmt.Errorf("TranslationRepo - GetList - unknown entity type %q", entityType)
+	}
+
+	query, args, err := r.pg.Builder.
+		Select("locale, "+strings.Join(t.fields, ", ")+", created_at, updated_at").
+		From(t.table+"_translation").
+		Where(t.key+" = ?", req.ID).
+		OrderBy("locale").ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			item                 entity.Translation
+			createdAt, updatedAt time.Time
+		)
+
+		dest := []interface{}{&item.Locale}
+		for _, field := range t.fields {
+			dest = append(dest, translationField(&item, field))
+		}
+		dest = append(dest, &createdAt, &updatedAt)
+
+		if err = rows.Scan(dest...); err != nil {
+			return response, err
+		}
+
+		item.CreatedAt = createdAt.Format(time.RFC3339)
+		item.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+		response.Items = append(response.Items, item)
+	}
+
+	return response, rows.Err()
+}
+
+// Upsert creates or replaces the translation of an entity in a locale. It
+// returns pgx.ErrNoRows when the entity does not exist.
+func (r *TranslationRepo) Upsert(ctx context.Context, entityType string, req entity.Id, translation entity.Translation) error {
+	t, ok := translationTables[entityType]
+	if !ok {
+		return fmt.Errorf("TranslationRepo - Upsert - unknown entity type %q", entityType)
+	}
+
+	var (
+		values  = []string{"id", "$2::varchar"}
+		updates = []string{"updated_at = now()"}
+		args    = []interface{}{req.ID, translation.Locale}
+	)
+
+	for i, field := range t.fields {
+		values = append(values, fmt.Sprintf("$%d::text", i+3))
+		updates = append(updates, field+" = EXCLUDED."+field)
+		args = append(args, *translationField(&translation, field))
+	}
+
+	// selecting from the entity writes nothing when it does not exist
+	query := fmt.Sprintf(`INSERT INTO %[1]s_translation (%[2]s, locale, %[3]s)
+		SELECT %[4]s FROM %[1]s WHERE id = $1
+		ON CONFLICT (%[2]s, locale) DO UPDATE SET %[5]s`,
+		t.table, t.key, strings.Join(t.fields, ", "), strings.Join(values, ", "), strings.Join(updates, ", "))
+
+	n, err := r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if n.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// Delete removes the translation of an entity in a locale.
+func (r *TranslationRepo) Delete(ctx context.Context, entityType string, req entity.Id, locale string) error {
+	t, ok := translationTables[entityType]
+	if !ok {
+		return fmt.Errorf("TranslationRepo - Delete - unknown entity type %q", entityType)
+	}
+
+	query, args, err := r.pg.Builder.Delete(t.table+"_translation").
+		Where(t.key+" = ? AND locale = ?", req.ID, locale).ToSql()
+	if err != nil {
+		return err
+	}
+
+	n, err := r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if n.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// translatedColumn selects column of the entity aliased as alias in the first
+// of locales that has it translated, or the column of the entity itself.
+func translatedColumn(entityType, alias, column string, locales []string) squirrel.Sqlizer {
+	if len(locales) == 0 {
+		return squirrel.Expr(alias + "." + column)
+	}
+
+	t := translationTables[entityType]
+
+	return squirrel.Expr(fmt.Sprintf(`COALESCE((SELECT t.%[1]s FROM %[2]s_translation t
+		WHERE t.%[3]s = %[4]s.id AND t.locale = ANY(?::text[]) AND t.%[1]s <> ''
+		ORDER BY array_position(?::text[], t.locale::text) LIMIT 1), %[4]s.%[1]s)`, column, t.table, t.key, alias), locales, locales)
+}
+
+func translationField(t *entity.Translation, field string) *string {
+	switch field {
+	case "name":
+		return &t.Name
+	case "description":
+		return &t.Description
+	default:
+		return &t.Title
+	}
+}
@@ -0,0 +1,228 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/Akrom0181/Food-Delivery/pkg/logger"
+	"github.com/Akrom0181/Food-Delivery/pkg/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+type TwoFactorRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+// New -.
+func NewTwoFactorRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *TwoFactorRepo {
+	return &TwoFactorRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+// Upsert stores a new (not yet enabled) secret for the user, replacing any previous one.
+func (r *TwoFactorRepo) Upsert(ctx context.Context, req entity.UserTwoFactor) (entity.UserTwoFactor, error) {
+	query, args, err := r.pg.Builder.Insert("user_two_factor").
+		Columns(`user_id, secret, is_enabled`).
+		Values(req.UserID, req.Secret, false).
+		Suffix(`ON CONFLICT (user_id) DO UPDATE SET secret = EXCLUDED.secret, is_enabled = FALSE, enabled_at = NULL, updated_at = now()`).
+		ToSql()
+	if err != nil {
+		return entity.UserTwoFactor{}, err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return entity.UserTwoFactor{}, err
+	}
+
+	req.IsEnabled = false
+
+	return req, nil
+}
+
+func (r *TwoFactorRepo) GetSingle(ctx context.Context, req entity.Id) (entity.UserTwoFactor, error) {
+	var (
+		response             entity.UserTwoFactor
+		enabledAt            sql.NullTime
+		createdAt, updatedAt time.Time
+	)
+
+	query, args, err := r.pg.Builder.
+		Select(`user_id, secret, is_enabled, enabled_at, created_at, updated_at`).
+		From("user_two_factor").
+		Where("user_id = ?", req.ID).ToSql()
+	if err != nil {
+		return entity.UserTwoFactor{}, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, query, args...).
+		Scan(&response.UserID, &response.Secret, &response.IsEnabled, &enabledAt, &createdAt, &updatedAt)
+	if err != nil {
+		return entity.UserTwoFactor{}, err
+	}
+
+	if enabledAt.Valid {
+		response.EnabledAt = enabledAt.Time.Format(time.RFC3339)
+	}
+	response.CreatedAt = createdAt.Format(time.RFC3339)
+	response.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+	return response, nil
+}
+
+func (r *TwoFactorRepo) Enable(ctx context.Context, req entity.Id) error {
+	query, args, err := r.pg.Builder.Update("user_two_factor").
+		Set("is_enabled", true).
+		Set("enabled_at", "now()").
+		Set("updated_at", "now()").
+		Where("user_id = ?", req.ID).ToSql()
+	if err != nil {
+		return err
+	}
+
+	n, err := r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if n.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// Delete removes the user's secret together with the recovery codes.
+func (r *TwoFactorRepo) Delete(ctx context.Context, req entity.Id) error {
+	tx, err := r.pg.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query, args, err := r.pg.Builder.Delete("user_recovery_code").Where("user_id = ?", req.ID).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	query, args, err = r.pg.Builder.Delete("user_two_factor").Where("user_id = ?", req.ID).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ReplaceRecoveryCodes drops the user's previous recovery codes and stores the new hashes.
+func (r *TwoFactorRepo) ReplaceRecoveryCodes(ctx context.Context, userID string, hashes []string) error {
+	tx, err := r.pg.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query, args, err := r.pg.Builder.Delete("user_recovery_code").Where("user_id = ?", userID).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if len(hashes) > 0 {
+		insert := r.pg.Builder.Insert("user_recovery_code").Columns(`id, user_id, code_hash`)
+		for _, hash := range hashes {
+			insert = insert.Values(uuid.NewString(), userID, hash)
+		}
+
+		query, args, err = insert.ToSql()
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// GetRecoveryCodes returns the user's unused recovery codes.
+func (r *TwoFactorRepo) GetRecoveryCodes(ctx context.Context, req entity.Id) ([]entity.RecoveryCode, error) {
+	var response []entity.RecoveryCode
+
+	query, args, err := r.pg.Builder.
+		Select(`id, user_id, code_hash, created_at`).
+		From("user_recovery_code").
+		Where("user_id = ? AND used_at IS NULL", req.ID).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			item      entity.RecoveryCode
+			createdAt time.Time
+		)
+
+		err = rows.Scan(&item.ID, &item.UserID, &item.CodeHash, &createdAt)
+		if err != nil {
+			return nil, err
+		}
+
+		item.CreatedAt = createdAt.Format(time.RFC3339)
+		response = append(response, item)
+	}
+
+	return response, rows.Err()
+}
+
+// UseRecoveryCode marks a code as used. It returns pgx.ErrNoRows when the code was already used.
+func (r *TwoFactorRepo) UseRecoveryCode(ctx context.Context, req entity.Id) error {
+	query, args, err := r.pg.Builder.Update("user_recovery_code").
+		Set("used_at", "now()").
+		Where("id = ? AND used_at IS NULL", req.ID).ToSql()
+	if err != nil {
+		return err
+	}
+
+	n, err := r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if n.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
@@ -0,0 +1,318 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/Akrom0181/Food-Delivery/pkg/logger"
+	"github.com/Akrom0181/Food-Delivery/pkg/postgres"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+)
+
+// Entity types an upload can be referenced by, with the table holding them.
+const (
+	UploadEntityProduct  = "product"
+	UploadEntityBanner   = "banner"
+	UploadEntityUser     = "user"
+	UploadEntityCategory = "category"
+)
+
+var uploadEntityTables = map[string]string{
+	UploadEntityProduct:  "product",
+	UploadEntityBanner:   "banner",
+	UploadEntityUser:     "users",
+	UploadEntityCategory: "category",
+}
+
+// UploadRepo tracks the objects put into file storage. References are written
+// by the repos of the referencing entities, see linkUploads.
+type UploadRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+// New -.
+func NewUploadRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *UploadRepo {
+	return &UploadRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+// Create records a stored object. Storing the same content again restarts the
+// grace period of an orphaned object so the new uploader gets to use it.
+func (r *UploadRepo) Create(ctx context.Context, req entity.Upload) (entity.Upload, error) {
+	query, args, err := r.pg.Builder.Insert("uploads").
+		Columns(`key, url, content_type, size, owner_id`).
+		Values(req.Key, req.URL, req.ContentType, req.Size, squirrel.Expr("NULLIF(?, '')::uuid", req.OwnerID)).
+		Suffix(`ON CONFLICT (key) DO UPDATE SET url = EXCLUDED.url,
+			orphaned_at = CASE WHEN uploads.orphaned_at IS NULL THEN NULL ELSE now() END`).ToSql()
+	if err != nil {
+		return entity.Upload{}, err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return entity.Upload{}, err
+	}
+
+	return req, nil
+}
+
+// Delete forgets an object that was deleted from storage, along with its references.
+func (r *UploadRepo) Delete(ctx context.Context, req entity.Id) error {
+	query, args, err := r.pg.Builder.Delete("uploads").Where("key = ?", req.ID).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	return err
+}
+
+// GetOrphans returns objects that have been unreferenced since before the given time.
+func (r *UploadRepo) GetOrphans(ctx context.Context, before time.Time, limit int) ([]entity.Upload, error) {
+	var response []entity.Upload
+
+	query, args, err := r.pg.Builder.
+		Select(`key, url, content_type, size, COALESCE(owner_id::text, ''), orphaned_at, created_at`).
+		From("uploads").
+		Where("orphaned_at < ?", before).
+		OrderBy("orphaned_at").
+		Limit(uint64(limit)).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			item       entity.Upload
+			orphanedAt sql.NullTime
+			createdAt  time.Time
+		)
+
+		err = rows.Scan(&item.Key, &item.URL, &item.ContentType, &item.Size, &item.OwnerID, &orphanedAt, &createdAt)
+		if err != nil {
+			return nil, err
+		}
+
+		if orphanedAt.Valid {
+			item.OrphanedAt = orphanedAt.Time.Format(time.RFC3339)
+		}
+		item.CreatedAt = createdAt.Format(time.RFC3339)
+
+		response = append(response, item)
+	}
+
+	return response, rows.Err()
+}
+
+// DeleteOrphan removes the row of an object that is still orphaned since
+// before the given time and calls deleteObject while the row is locked, so a
+// concurrent link or upload of the same object waits for the outcome. It
+// reports false when the object was linked or uploaded again in the meantime.
+func (r *UploadRepo) DeleteOrphan(ctx context.Context, key string, before time.Time, deleteObject func(ctx context.Context) error) (bool, error) {
+	tx, err := r.pg.Pool.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	query, args, err := r.pg.Builder.Delete("uploads").
+		Where("key = ? AND orphaned_at < ?", key, before).
+		Where("NOT EXISTS (SELECT 1 FROM upload_reference WHERE upload_key = uploads.key)").ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	n, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return false, err
+	}
+
+	if n.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	err = deleteObject(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit(ctx)
+}
+
+// PruneReferences drops references to entities that no longer exist, e.g.
+// products deleted together with their category.
+func (r *UploadRepo) PruneReferences(ctx context.Context) (int64, error) {
+	var total int64
+
+	for entityType, table := range uploadEntityTables {
+		tx, err := r.pg.Pool.Begin(ctx)
+		if err != nil {
+			return total, err
+		}
+
+		rows, err := tx.Query(ctx, `DELETE FROM upload_reference ref
+			WHERE ref.entity_type = $1 AND NOT EXISTS (SELECT 1 FROM `+table+` e WHERE e.id = ref.entity_id)
+			RETURNING ref.upload_key`, entityType)
+		if err != nil {
+			tx.Rollback(ctx)
+			return total, err
+		}
+
+		keys, err := collectKeys(rows)
+		if err == nil {
+			err = markOrphans(ctx, tx, keys)
+		}
+		if err == nil {
+			err = tx.Commit(ctx)
+		}
+		if err != nil {
+			tx.Rollback(ctx)
+			return total, err
+		}
+
+		total += int64(len(keys))
+	}
+
+	return total, nil
+}
+
+// GetReport sums up the stored objects per referencing entity type.
+func (r *UploadRepo) GetReport(ctx context.Context) (entity.StorageReport, error) {
+	var response entity.StorageReport
+
+	rows, err := r.pg.Pool.Query(ctx, `SELECT entity_type, COUNT(1), COALESCE(SUM(size), 0) FROM (
+			SELECT DISTINCT COALESCE(ref.entity_type, 'unreferenced') AS entity_type, u.key, u.size
+			FROM uploads u LEFT JOIN upload_reference ref ON ref.upload_key = u.key
+		) t GROUP BY entity_type ORDER BY 3 DESC`)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item entity.StorageUsage
+		err = rows.Scan(&item.EntityType, &item.Objects, &item.Bytes)
+		if err != nil {
+			return response, err
+		}
+
+		response.Items = append(response.Items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return response, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, `SELECT COUNT(1), COALESCE(SUM(size), 0) FROM uploads`).
+		Scan(&response.TotalObjects, &response.TotalBytes)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// execLinkingUploads runs query, which writes the entity, and relinks the
+// uploads of the entity in the same transaction.
+func execLinkingUploads(ctx context.Context, pg *postgres.Postgres, query string, args []interface{}, entityType, entityID string, urls []string) error {
+	tx, err := pg.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	err = linkUploads(ctx, tx, entityType, entityID, urls)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// linkUploads replaces the references of an entity with the uploads stored
+// under urls. Urls that were not uploaded through the API are ignored. Uploads
+// that lose their last reference become orphans.
+func linkUploads(ctx context.Context, tx pgx.Tx, entityType, entityID string, urls []string) error {
+	rows, err := tx.Query(ctx, `DELETE FROM upload_reference WHERE entity_type = $1 AND entity_id = $2 RETURNING upload_key`,
+		entityType, entityID)
+	if err != nil {
+		return err
+	}
+
+	removed, err := collectKeys(rows)
+	if err != nil {
+		return err
+	}
+
+	if len(urls) > 0 {
+		_, err = tx.Exec(ctx, `INSERT INTO upload_reference (upload_key, entity_type, entity_id)
+			SELECT key, $1::varchar, $2::uuid FROM uploads WHERE url = ANY($3) ON CONFLICT DO NOTHING`, entityType, entityID, urls)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `UPDATE uploads SET orphaned_at = NULL WHERE url = ANY($1)`, urls)
+		if err != nil {
+			return err
+		}
+	}
+
+	return markOrphans(ctx, tx, removed)
+}
+
+func markOrphans(ctx context.Context, tx pgx.Tx, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	_, err := tx.Exec(ctx, `UPDATE uploads SET orphaned_at = now()
+		WHERE key = ANY($1) AND orphaned_at IS NULL
+		AND NOT EXISTS (SELECT 1 FROM upload_reference WHERE upload_key = uploads.key)`, keys)
+
+	return err
+}
+
+func collectKeys(rows pgx.Rows) ([]string, error) {
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, rows.Err()
+}
+
+func imageURLs(images entity.ImageVariants) []string {
+	urls := make([]string, 0, len(images))
+	for _, url := range images {
+		urls = append(urls, url)
+	}
+
+	return urls
+}
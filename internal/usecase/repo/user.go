@@ -9,6 +9,7 @@ import (
 	"github.com/Akrom0181/Food-Delivery/internal/entity"
 	"github.com/Akrom0181/Food-Delivery/pkg/logger"
 	"github.com/Akrom0181/Food-Delivery/pkg/postgres"
+	"github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 )
 
@@ -29,15 +30,19 @@ func NewUserRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Lo
 
 func (r *UserRepo) Create(ctx context.Context, req entity.User) (entity.User, error) {
 	req.ID = uuid.NewString()
+	if req.Images == nil {
+		req.Images = entity.ImageVariants{}
+	}
 
 	query, args, err := r.pg.Builder.Insert("users").
-		Columns(`id, full_name, email, username, password, user_type, user_role, status, profile_picture, gender, bio`).
-		Values(req.ID, req.FullName, req.Email, req.UserName, req.Password, req.UserType, req.UserRole, req.Status, req.ProfilePic, req.Gender, req.Bio).ToSql()
+		Columns(`id, full_name, email, username, password, user_type, user_role, status, images, gender, bio`).
+		Values(req.ID, req.FullName, squirrel.Expr("NULLIF(?, '')", req.Email), req.UserName, req.Password, req.UserType, req.UserRole, req.Status,
+			req.Images, squirrel.Expr("NULLIF(?, '')::gender", req.Gender), req.Bio).ToSql()
 	if err != nil {
 		return entity.User{}, err
 	}
 
-	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	err = execLinkingUploads(ctx, r.pg, query, args, UploadEntityUser, req.ID, imageURLs(req.Images))
 	if err != nil {
 		return entity.User{}, err
 	}
@@ -52,7 +57,7 @@ func (r *UserRepo) GetSingle(ctx context.Context, req entity.UserSingleRequest)
 	)
 
 	queryBuilder := r.pg.Builder.
-		Select(`id, full_name, email, username, password, user_type, user_role, status, profile_picture, gender, bio, created_at, updated_at`).
+		Select(`id, full_name, COALESCE(email, ''), username, password, user_type, user_role, status, images, COALESCE(gender::text, ''), bio, excluded_allergens, created_at, updated_at`).
 		From("users")
 
 	switch {
@@ -73,7 +78,7 @@ func (r *UserRepo) GetSingle(ctx context.Context, req entity.UserSingleRequest)
 
 	err = r.pg.Pool.QueryRow(ctx, query, args...).
 		Scan(&response.ID, &response.FullName, &response.Email, &response.UserName, &response.Password,
-			&response.UserType, &response.UserRole, &response.Status, &response.ProfilePic, &response.Gender, &response.Bio, &createdAt, &updatedAt)
+			&response.UserType, &response.UserRole, &response.Status, &response.Images, &response.Gender, &response.Bio, &response.ExcludedAllergens, &createdAt, &updatedAt)
 	if err != nil {
 		return entity.User{}, err
 	}
@@ -91,7 +96,7 @@ func (r *UserRepo) GetList(ctx context.Context, req entity.GetListFilter) (entit
 	)
 
 	queryBuilder := r.pg.Builder.
-		Select(`id, full_name,  email, username, password, user_type, user_role, status, profile_picture, gender, bio, created_at, updated_at`).
+		Select(`id, full_name, COALESCE(email, ''), username, password, user_type, user_role, status, images, COALESCE(gender::text, ''), bio, excluded_allergens, created_at, updated_at`).
 		From("users")
 
 	queryBuilder, where := PrepareGetListQuery(queryBuilder, req)
@@ -110,7 +115,7 @@ func (r *UserRepo) GetList(ctx context.Context, req entity.GetListFilter) (entit
 	for rows.Next() {
 		var item entity.User
 		err = rows.Scan(&item.ID, &item.FullName, &item.Email, &item.UserName, &item.Password,
-			&item.UserType, &item.UserRole, &item.Status, &item.ProfilePic, &item.Gender, &item.Bio, &createdAt, &updatedAt)
+			&item.UserType, &item.UserRole, &item.Status, &item.Images, &item.Gender, &item.Bio, &item.ExcludedAllergens, &createdAt, &updatedAt)
 		if err != nil {
 			return response, err
 		}
@@ -136,26 +141,37 @@ func (r *UserRepo) GetList(ctx context.Context, req entity.GetListFilter) (entit
 
 func (r *UserRepo) Update(ctx context.Context, req entity.User) (entity.User, error) {
 	mp := map[string]interface{}{
-		"full_name":       req.FullName,
-		"status":          req.Status,
-		"username":        req.UserName,
-		"email":           req.Email,
-		"profile_picture": req.ProfilePic,
-		"gender":          req.Gender,
-		"user_role":       req.UserRole,
-		"updated_at":      "now()",
+		"full_name":  req.FullName,
+		"status":     req.Status,
+		"username":   req.UserName,
+		"email":      squirrel.Expr("NULLIF(?, '')", req.Email),
+		"gender":     squirrel.Expr("NULLIF(?, '')::gender", req.Gender),
+		"user_role":  req.UserRole,
+		"updated_at": "now()",
 	}
 
 	if req.Password != "" {
 		mp["password"] = req.Password
 	}
 
+	if req.Images != nil {
+		mp["images"] = req.Images
+	}
+
+	if req.ExcludedAllergens != nil {
+		mp["excluded_allergens"] = req.ExcludedAllergens
+	}
+
 	query, args, err := r.pg.Builder.Update("users").SetMap(mp).Where("id = ?", req.ID).ToSql()
 	if err != nil {
 		return entity.User{}, err
 	}
 
-	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	if req.Images != nil {
+		err = execLinkingUploads(ctx, r.pg, query, args, UploadEntityUser, req.ID, imageURLs(req.Images))
+	} else {
+		_, err = r.pg.Pool.Exec(ctx, query, args...)
+	}
 	if err != nil {
 		return entity.User{}, err
 	}
@@ -169,7 +185,8 @@ func (r *UserRepo) Delete(ctx context.Context, req entity.Id) error {
 		return err
 	}
 
-	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	// the images of the entity become orphans and are swept later
+	err = execLinkingUploads(ctx, r.pg, query, args, UploadEntityUser, req.ID, nil)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,72 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/Akrom0181/Food-Delivery/pkg/logger"
+	"github.com/Akrom0181/Food-Delivery/pkg/postgres"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+type UserIdentityRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+// New -.
+func NewUserIdentityRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *UserIdentityRepo {
+	return &UserIdentityRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+func (r *UserIdentityRepo) Create(ctx context.Context, req entity.UserIdentity) (entity.UserIdentity, error) {
+	req.ID = uuid.NewString()
+
+	query, args, err := r.pg.Builder.Insert("user_identity").
+		Columns(`id, user_id, provider, subject, email`).
+		Values(req.ID, req.UserID, req.Provider, req.Subject, squirrel.Expr("NULLIF(?, '')", req.Email)).ToSql()
+	if err != nil {
+		return entity.UserIdentity{}, err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return entity.UserIdentity{}, err
+	}
+
+	return req, nil
+}
+
+func (r *UserIdentityRepo) GetSingle(ctx context.Context, req entity.UserIdentitySingleRequest) (entity.UserIdentity, error) {
+	var (
+		response             entity.UserIdentity
+		createdAt, updatedAt time.Time
+	)
+
+	query, args, err := r.pg.Builder.
+		Select(`id, user_id, provider, subject, COALESCE(email, ''), created_at, updated_at`).
+		From("user_identity").
+		Where("provider = ? AND subject = ?", req.Provider, req.Subject).ToSql()
+	if err != nil {
+		return entity.UserIdentity{}, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, query, args...).
+		Scan(&response.ID, &response.UserID, &response.Provider, &response.Subject, &response.Email, &createdAt, &updatedAt)
+	if err != nil {
+		return entity.UserIdentity{}, err
+	}
+
+	response.CreatedAt = createdAt.Format(time.RFC3339)
+	response.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+	return response, nil
+}
@@ -0,0 +1,49 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Akrom0181/Food-Delivery/internal/usecase"
+	"github.com/Akrom0181/Food-Delivery/pkg/logger"
+)
+
+// OrderScheduler releases scheduled orders to the kitchen once they are due.
+// Every replica may run it, an order is released only once.
+type OrderScheduler struct {
+	orders   usecase.OrderRepoI
+	logger   *logger.Logger
+	interval time.Duration
+}
+
+// NewOrderScheduler -.
+func NewOrderScheduler(orders usecase.OrderRepoI, l *logger.Logger, interval time.Duration) *OrderScheduler {
+	return &OrderScheduler{
+		orders:   orders,
+		logger:   l,
+		interval: interval,
+	}
+}
+
+// Run releases the due orders every interval until ctx is cancelled.
+func (s *OrderScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		released, err := s.orders.ReleaseScheduled(ctx)
+		if err != nil && ctx.Err() == nil {
+			s.logger.Error(fmt.Errorf("worker - OrderScheduler - ReleaseScheduled: %w", err))
+		}
+		if released > 0 {
+			s.logger.Info("worker - OrderScheduler - released %d orders", released)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Akrom0181/Food-Delivery/internal/usecase"
+	"github.com/Akrom0181/Food-Delivery/pkg/logger"
+)
+
+// PriceScheduler applies scheduled product prices once they are due. Every
+// replica may run it, a scheduled price is applied only once.
+type PriceScheduler struct {
+	products usecase.ProductRepoI
+	logger   *logger.Logger
+	interval time.Duration
+}
+
+// NewPriceScheduler -.
+func NewPriceScheduler(products usecase.ProductRepoI, l *logger.Logger, interval time.Duration) *PriceScheduler {
+	return &PriceScheduler{
+		products: products,
+		logger:   l,
+		interval: interval,
+	}
+}
+
+// Run applies the due prices every interval until ctx is cancelled.
+func (s *PriceScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		applied, err := s.products.ApplyScheduledPrices(ctx)
+		if err != nil && ctx.Err() == nil {
+			s.logger.Error(fmt.Errorf("worker - PriceScheduler - ApplyScheduledPrices: %w", err))
+		}
+		if applied > 0 {
+			s.logger.Info("worker - PriceScheduler - repriced %d products", applied)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
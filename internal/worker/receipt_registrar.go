@@ -0,0 +1,111 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Akrom0181/Food-Delivery/config"
+	"github.com/Akrom0181/Food-Delivery/internal/entity"
+	"github.com/Akrom0181/Food-Delivery/internal/usecase"
+	"github.com/Akrom0181/Food-Delivery/pkg/fiscal"
+	"github.com/Akrom0181/Food-Delivery/pkg/logger"
+)
+
+// receiptBatch is how many receipts are sent per run.
+const receiptBatch = 50
+
+// ReceiptRegistrar sends pending receipts to the fiscal provider and stores
+// the fiscal sign and QR URL it returns. Every replica may run it.
+type ReceiptRegistrar struct {
+	receipts   usecase.ReceiptRepoI
+	provider   fiscal.Provider
+	logger     *logger.Logger
+	interval   time.Duration
+	retryDelay time.Duration
+}
+
+// NewReceiptRegistrar -.
+func NewReceiptRegistrar(receipts usecase.ReceiptRepoI, provider fiscal.Provider, l *logger.Logger, interval, retryDelay time.Duration) *ReceiptRegistrar {
+	return &ReceiptRegistrar{
+		receipts:   receipts,
+		provider:   provider,
+		logger:     l,
+		interval:   interval,
+		retryDelay: retryDelay,
+	}
+}
+
+// Run registers the due receipts every interval until ctx is cancelled.
+func (s *ReceiptRegistrar) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		receipts, err := s.receipts.ClaimPending(ctx, receiptBatch, s.retryDelay)
+		if err != nil && ctx.Err() == nil {
+			s.logger.Error(fmt.Errorf("worker - ReceiptRegistrar - ClaimPending: %w", err))
+		}
+
+		for _, receipt := range receipts {
+			s.register(ctx, receipt)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *ReceiptRegistrar) register(ctx context.Context, receipt entity.Receipt) {
+	result, err := s.provider.Register(ctx, fiscalReceipt(receipt))
+	if err != nil {
+		receipt.Error = err.Error()
+		if err := s.receipts.SetError(ctx, receipt, errors.Is(err, fiscal.ErrRejected)); err != nil {
+			s.logger.Error(fmt.Errorf("worker - ReceiptRegistrar - SetError: %w", err))
+		}
+		return
+	}
+
+	receipt.TerminalID = result.TerminalID
+	receipt.FiscalSign = result.Sign
+	receipt.QRURL = result.QRURL
+
+	if err := s.receipts.SetRegistered(ctx, receipt); err != nil {
+		s.logger.Error(fmt.Errorf("worker - ReceiptRegistrar - SetRegistered: %w", err))
+	}
+}
+
+func fiscalReceipt(receipt entity.Receipt) fiscal.Receipt {
+	// receipts show the local time of the sale
+	createdAt, _ := time.Parse(time.RFC3339, receipt.CreatedAt)
+
+	response := fiscal.Receipt{
+		ID:       receipt.ID,
+		BranchID: receipt.BranchID,
+		Number:   receipt.Number,
+		Time:     createdAt.In(config.LocalTime),
+		Total:    receipt.Total,
+		VAT:      receipt.Tax,
+		Currency: receipt.Currency,
+	}
+
+	for _, item := range receipt.Items {
+		response.Items = append(response.Items, fiscal.Item{
+			Name:        item.Name,
+			MxikCode:    item.MxikCode,
+			PackageCode: item.PackageCode,
+			Quantity:    item.Quantity,
+			Price:       item.Price,
+			Discount:    item.Discount,
+			Total:       item.Total,
+			VATRate:     item.TaxRate,
+			VAT:         item.Tax,
+		})
+	}
+
+	return response
+}
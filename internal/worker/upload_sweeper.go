@@ -0,0 +1,100 @@
+// Package worker runs background jobs next to the HTTP server.
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Akrom0181/Food-Delivery/internal/usecase"
+	"github.com/Akrom0181/Food-Delivery/pkg/logger"
+	"github.com/Akrom0181/Food-Delivery/pkg/storage"
+)
+
+const sweepBatchSize = 100
+
+// UploadSweeper deletes stored objects nothing has referenced for longer than
+// the grace period. Every replica may run it, a row is deleted only once.
+type UploadSweeper struct {
+	uploads  usecase.UploadRepoI
+	storage  storage.Storage
+	logger   *logger.Logger
+	grace    time.Duration
+	interval time.Duration
+}
+
+// NewUploadSweeper -.
+func NewUploadSweeper(uploads usecase.UploadRepoI, store storage.Storage, l *logger.Logger, grace, interval time.Duration) *UploadSweeper {
+	return &UploadSweeper{
+		uploads:  uploads,
+		storage:  store,
+		logger:   l,
+		grace:    grace,
+		interval: interval,
+	}
+}
+
+// Run sweeps every interval until ctx is cancelled.
+func (s *UploadSweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		deleted, err := s.Sweep(ctx)
+		if err != nil && ctx.Err() == nil {
+			s.logger.Error(fmt.Errorf("worker - UploadSweeper - Sweep: %w", err))
+		}
+		if deleted > 0 {
+			s.logger.Info("worker - UploadSweeper - deleted %d orphaned objects", deleted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sweep deletes the objects that are orphaned for longer than the grace period.
+func (s *UploadSweeper) Sweep(ctx context.Context) (int, error) {
+	if _, err := s.uploads.PruneReferences(ctx); err != nil {
+		return 0, err
+	}
+
+	before := time.Now().Add(-s.grace)
+	deleted := 0
+
+	for {
+		orphans, err := s.uploads.GetOrphans(ctx, before, sweepBatchSize)
+		if err != nil {
+			return deleted, err
+		}
+
+		progress := false
+		for _, orphan := range orphans {
+			ok, err := s.uploads.DeleteOrphan(ctx, orphan.Key, before, func(ctx context.Context) error {
+				err := s.storage.Delete(ctx, orphan.Key)
+				if errors.Is(err, storage.ErrNotFound) {
+					return nil
+				}
+				return err
+			})
+			if err != nil {
+				// keep going, the object is retried on the next run
+				s.logger.Error(fmt.Errorf("worker - UploadSweeper - delete %s: %w", orphan.Key, err))
+				continue
+			}
+
+			if ok {
+				deleted++
+				progress = true
+			}
+		}
+
+		if len(orphans) < sweepBatchSize || !progress {
+			return deleted, nil
+		}
+	}
+}
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS user_identity;
+
+UPDATE users SET gender = 'male' WHERE gender IS NULL;
+ALTER TABLE users ALTER COLUMN gender SET DEFAULT 'male';
+ALTER TABLE users ALTER COLUMN gender SET NOT NULL;
+ALTER TABLE users ALTER COLUMN email SET NOT NULL;
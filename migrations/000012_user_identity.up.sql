@@ -0,0 +1,17 @@
+-- Accounts created through social login may have neither an e-mail (Telegram) nor a gender.
+ALTER TABLE users ALTER COLUMN email DROP NOT NULL;
+ALTER TABLE users ALTER COLUMN gender DROP NOT NULL;
+ALTER TABLE users ALTER COLUMN gender DROP DEFAULT;
+
+CREATE TABLE IF NOT EXISTS user_identity (
+  id UUID PRIMARY KEY,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  provider VARCHAR(32) NOT NULL,
+  subject VARCHAR(255) NOT NULL,
+  email VARCHAR(255),
+  created_at TIMESTAMP NOT NULL DEFAULT now(),
+  updated_at TIMESTAMP NOT NULL DEFAULT now(),
+  UNIQUE (provider, subject)
+);
+
+CREATE INDEX IF NOT EXISTS user_identity_user_id_idx ON user_identity(user_id);
@@ -0,0 +1,49 @@
+CREATE TABLE IF NOT EXISTS casbin_rule (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  ptype VARCHAR(8) NOT NULL,
+  v0 VARCHAR(255) NOT NULL DEFAULT '',
+  v1 VARCHAR(255) NOT NULL DEFAULT '',
+  v2 VARCHAR(255) NOT NULL DEFAULT '',
+  v3 VARCHAR(255) NOT NULL DEFAULT '',
+  v4 VARCHAR(255) NOT NULL DEFAULT '',
+  v5 VARCHAR(255) NOT NULL DEFAULT '',
+  created_at TIMESTAMP NOT NULL DEFAULT now(),
+  UNIQUE (ptype, v0, v1, v2, v3, v4, v5)
+);
+
+-- Policies that used to live in config/policy.csv.
+INSERT INTO casbin_rule (ptype, v0, v1, v2) VALUES
+  ('p', 'unauthorized', '/swagger/*', 'GET'),
+  ('p', 'unauthorized', '/v1/auth/*', 'GET|POST'),
+  ('p', 'user', '/v1/user/*', 'PUT|DELETE'),
+  ('p', 'user', '/v1/user/:id', 'GET'),
+  ('p', 'user', '/v1/user/2fa/*', 'POST'),
+  ('p', 'admin', '/v1/user/*', 'GET|POST|PUT|DELETE'),
+  ('p', 'user', '/v1/notification/*', 'GET|POST|PUT|DELETE'),
+  ('p', 'user', '/v1/notification/:id', 'GET'),
+  ('p', 'admin', '/v1/notification/*', 'GET|POST|PUT|DELETE'),
+  ('p', 'user', '/v1/session/*', 'GET|DELETE'),
+  ('p', 'admin', '/v1/session/*', 'GET|POST|PUT|DELETE'),
+  ('p', 'user', '/v1/firebase/*', 'POST|DELETE'),
+  ('p', 'admin', '/v1/firebase/*', 'POST|DELETE'),
+  ('p', 'user', '/v1/report/*', 'GET|POST|PUT|DELETE'),
+  ('p', 'admin', '/v1/report/*', 'GET|POST|PUT|DELETE'),
+  ('p', 'user', '/v1/category/*', 'GET'),
+  ('p', 'admin', '/v1/category/*', 'GET|POST|PUT|DELETE'),
+  ('p', 'user', '/v1/product/*', 'GET'),
+  ('p', 'admin', '/v1/product/*', 'GET|POST|PUT|DELETE'),
+  ('p', 'user', '/v1/banner/*', 'GET'),
+  ('p', 'admin', '/v1/banner/*', 'GET|POST|PUT|DELETE'),
+  ('p', 'user', '/v1/branch/*', 'GET'),
+  ('p', 'admin', '/v1/branch/*', 'GET|POST|PUT|DELETE'),
+  ('p', 'courier', '/v1/branch/*', 'GET'),
+  ('p', 'user', '/v1/user/location/*', 'GET|POST|PUT|DELETE'),
+  ('p', 'admin', '/v1/user/location/*', 'GET|POST|PUT|DELETE'),
+  ('p', 'user', '/v1/order/*', 'GET|POST|PUT|DELETE'),
+  ('p', 'admin', '/v1/order/*', 'GET|POST|PUT|DELETE'),
+  ('p', 'courier', '/v1/order/*', 'GET|PUT'),
+  ('g', 'user', 'unauthorized', ''),
+  ('g', 'courier', 'unauthorized', ''),
+  ('g', 'admin', 'user', ''),
+  ('g', 'superadmin', 'admin', '')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,4 @@
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v0 = 'admin' AND v1 = '/v1/api-key/*';
+DELETE FROM casbin_rule WHERE ptype = 'g' AND v0 LIKE 'apikey:%';
+
+DROP TABLE IF EXISTS api_key;
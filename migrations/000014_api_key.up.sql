@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS api_key (
+  id UUID PRIMARY KEY,
+  name VARCHAR(100) NOT NULL,
+  -- the account the key acts for, handlers see it as the caller
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  prefix VARCHAR(16) NOT NULL UNIQUE,
+  key_hash VARCHAR(64) NOT NULL,
+  -- the hash before the last rotation stays valid until previous_expires_at
+  previous_key_hash VARCHAR(64),
+  previous_expires_at TIMESTAMP,
+  -- casbin roles granted to the key as g rules of the subject apikey:<id>
+  scopes TEXT[] NOT NULL DEFAULT '{}',
+  rate_limit INT NOT NULL DEFAULT 60,
+  is_active BOOLEAN NOT NULL DEFAULT TRUE,
+  expires_at TIMESTAMP,
+  last_used_at TIMESTAMP,
+  created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT now(),
+  updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS api_key_user_id_idx ON api_key(user_id);
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2) VALUES
+  ('p', 'admin', '/v1/api-key/*', 'GET|POST|PUT|DELETE')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,4 @@
+-- files kept by the local storage driver are public, like the firebase and s3 urls
+INSERT INTO casbin_rule (ptype, v0, v1, v2) VALUES
+  ('p', 'unauthorized', '/files/*', 'GET|HEAD')
+ON CONFLICT DO NOTHING;
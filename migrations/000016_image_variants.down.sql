@@ -0,0 +1,12 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS profile_picture TEXT;
+UPDATE users SET profile_picture = images->>'full';
+ALTER TABLE users DROP COLUMN IF EXISTS images;
+
+ALTER TABLE banner ADD COLUMN IF NOT EXISTS image_url VARCHAR;
+UPDATE banner SET image_url = COALESCE(images->>'full', '');
+ALTER TABLE banner ALTER COLUMN image_url SET NOT NULL;
+ALTER TABLE banner DROP COLUMN IF EXISTS images;
+
+ALTER TABLE product ADD COLUMN IF NOT EXISTS image_url VARCHAR;
+UPDATE product SET image_url = images->>'full';
+ALTER TABLE product DROP COLUMN IF EXISTS images;
@@ -0,0 +1,13 @@
+-- images maps a variant name (thumbnail, card, full) to its url,
+-- existing single urls become the full variant
+ALTER TABLE product ADD COLUMN IF NOT EXISTS images JSONB NOT NULL DEFAULT '{}';
+UPDATE product SET images = jsonb_build_object('full', image_url) WHERE COALESCE(image_url, '') <> '';
+ALTER TABLE product DROP COLUMN IF EXISTS image_url;
+
+ALTER TABLE banner ADD COLUMN IF NOT EXISTS images JSONB NOT NULL DEFAULT '{}';
+UPDATE banner SET images = jsonb_build_object('full', image_url) WHERE COALESCE(image_url, '') <> '';
+ALTER TABLE banner DROP COLUMN IF EXISTS image_url;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS images JSONB NOT NULL DEFAULT '{}';
+UPDATE users SET images = jsonb_build_object('full', profile_picture) WHERE COALESCE(profile_picture, '') <> '';
+ALTER TABLE users DROP COLUMN IF EXISTS profile_picture;
@@ -0,0 +1,4 @@
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v0 = 'admin' AND v1 = '/v1/storage/*';
+
+DROP TABLE IF EXISTS upload_reference;
+DROP TABLE IF EXISTS uploads;
@@ -0,0 +1,29 @@
+-- every object put into storage, keyed by its content address
+CREATE TABLE IF NOT EXISTS uploads (
+  key VARCHAR(128) PRIMARY KEY,
+  url TEXT NOT NULL,
+  content_type VARCHAR(100) NOT NULL,
+  size BIGINT NOT NULL,
+  owner_id UUID REFERENCES users(id) ON DELETE SET NULL,
+  -- set while nothing references the object, the sweeper deletes it after a grace period
+  orphaned_at TIMESTAMP DEFAULT now(),
+  created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS uploads_url_idx ON uploads(url);
+CREATE INDEX IF NOT EXISTS uploads_orphaned_at_idx ON uploads(orphaned_at) WHERE orphaned_at IS NOT NULL;
+
+-- the same object may be used by several entities, e.g. one photo on two products
+CREATE TABLE IF NOT EXISTS upload_reference (
+  upload_key VARCHAR(128) NOT NULL REFERENCES uploads(key) ON DELETE CASCADE,
+  entity_type VARCHAR(32) NOT NULL,
+  entity_id UUID NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT now(),
+  PRIMARY KEY (upload_key, entity_type, entity_id)
+);
+
+CREATE INDEX IF NOT EXISTS upload_reference_entity_idx ON upload_reference(entity_type, entity_id);
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2) VALUES
+  ('p', 'admin', '/v1/storage/*', 'GET')
+ON CONFLICT DO NOTHING;
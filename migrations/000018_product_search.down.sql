@@ -0,0 +1,13 @@
+DROP TABLE IF EXISTS product_availability;
+
+DROP TRIGGER IF EXISTS category_search_document ON category;
+DROP TRIGGER IF EXISTS product_search_document ON product;
+DROP FUNCTION IF EXISTS category_search_document();
+DROP FUNCTION IF EXISTS product_search_document();
+
+DROP INDEX IF EXISTS product_price_idx;
+ALTER TABLE category DROP COLUMN IF EXISTS search_name;
+ALTER TABLE product DROP COLUMN IF EXISTS search_document;
+ALTER TABLE product DROP COLUMN IF EXISTS search_name;
+
+DROP FUNCTION IF EXISTS uz_translit(text);
@@ -0,0 +1,59 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- uz_translit folds Uzbek and Russian Cyrillic into apostrophe-free lower case
+-- Uzbek Latin, so "ўзбек", "o'zbek" and "ozbek" match each other.
+-- Keep in sync with pkg/translit.
+CREATE OR REPLACE FUNCTION uz_translit(value text) RETURNS text AS $$
+  SELECT translate(
+    replace(replace(replace(replace(replace(replace(replace(
+      lower(translate(value,
+        'АБВГДЕЁЖЗИЙКЛМНОПРСТУФХЦЧШЩЪЫЬЭЮЯЎҚҒҲ',
+        'абвгдеёжзийклмнопрстуфхцчшщъыьэюяўқғҳ')),
+      'ё', 'yo'), 'ю', 'yu'), 'я', 'ya'), 'ц', 'ts'), 'ч', 'ch'), 'ш', 'sh'), 'щ', 'sh'),
+    'абвгдежзийклмнопрстуфхыэўқғҳъь''’‘ʻʼ`',
+    'abvgdejziyklmnoprstufxieoqgh')
+$$ LANGUAGE sql IMMUTABLE PARALLEL SAFE;
+
+ALTER TABLE product ADD COLUMN IF NOT EXISTS search_name TEXT GENERATED ALWAYS AS (uz_translit(name)) STORED;
+ALTER TABLE product ADD COLUMN IF NOT EXISTS search_document TSVECTOR;
+ALTER TABLE category ADD COLUMN IF NOT EXISTS search_name TEXT GENERATED ALWAYS AS (uz_translit(name)) STORED;
+
+CREATE OR REPLACE FUNCTION product_search_document() RETURNS trigger AS $$
+BEGIN
+  NEW.search_document :=
+    setweight(to_tsvector('simple', uz_translit(NEW.name)), 'A') ||
+    setweight(to_tsvector('simple', uz_translit(COALESCE((SELECT name FROM category WHERE id = NEW.category_id), ''))), 'B') ||
+    setweight(to_tsvector('simple', uz_translit(COALESCE(NEW.description, ''))), 'C');
+  RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER product_search_document BEFORE INSERT OR UPDATE OF name, description, category_id ON product
+  FOR EACH ROW EXECUTE FUNCTION product_search_document();
+
+-- a renamed category changes the documents of its products
+CREATE OR REPLACE FUNCTION category_search_document() RETURNS trigger AS $$
+BEGIN
+  UPDATE product SET name = name WHERE category_id = NEW.id;
+  RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER category_search_document AFTER UPDATE OF name ON category
+  FOR EACH ROW WHEN (OLD.name IS DISTINCT FROM NEW.name) EXECUTE FUNCTION category_search_document();
+
+UPDATE product SET name = name;
+
+CREATE INDEX IF NOT EXISTS product_search_document_idx ON product USING GIN (search_document);
+CREATE INDEX IF NOT EXISTS product_search_name_trgm_idx ON product USING GIN (search_name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS category_search_name_trgm_idx ON category USING GIN (search_name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS product_price_idx ON product(price);
+
+-- a product is available at every branch unless a row here says otherwise
+CREATE TABLE IF NOT EXISTS product_availability (
+  product_id UUID NOT NULL REFERENCES product(id) ON DELETE CASCADE,
+  branch_id UUID NOT NULL REFERENCES branch(id) ON DELETE CASCADE,
+  is_available BOOLEAN NOT NULL,
+  updated_at TIMESTAMP NOT NULL DEFAULT now(),
+  PRIMARY KEY (product_id, branch_id)
+);
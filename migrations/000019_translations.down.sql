@@ -0,0 +1,18 @@
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v0 = 'admin' AND v1 = '/v1/translation/*';
+
+DROP TRIGGER IF EXISTS product_translation_refresh ON product;
+DROP FUNCTION IF EXISTS product_translation_refresh();
+
+CREATE OR REPLACE FUNCTION category_search_document() RETURNS trigger AS $$
+BEGIN
+  UPDATE product SET name = name WHERE category_id = NEW.id;
+  RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+DROP TABLE IF EXISTS banner_translation;
+DROP TABLE IF EXISTS product_translation;
+DROP TABLE IF EXISTS category_translation;
+
+DROP FUNCTION IF EXISTS category_translation_search_document();
+DROP FUNCTION IF EXISTS product_translation_search_document();
@@ -0,0 +1,99 @@
+-- Catalog content in the default locale (uz) stays in the entities
+-- themselves, the other locales are kept here.
+CREATE TABLE IF NOT EXISTS category_translation (
+  category_id UUID NOT NULL REFERENCES category(id) ON DELETE CASCADE,
+  locale VARCHAR(8) NOT NULL,
+  name VARCHAR NOT NULL DEFAULT '',
+  created_at TIMESTAMP NOT NULL DEFAULT now(),
+  updated_at TIMESTAMP NOT NULL DEFAULT now(),
+  PRIMARY KEY (category_id, locale)
+);
+
+CREATE TABLE IF NOT EXISTS product_translation (
+  product_id UUID NOT NULL REFERENCES product(id) ON DELETE CASCADE,
+  locale VARCHAR(8) NOT NULL,
+  name VARCHAR NOT NULL DEFAULT '',
+  description TEXT NOT NULL DEFAULT '',
+  search_name TEXT GENERATED ALWAYS AS (uz_translit(name)) STORED,
+  search_document TSVECTOR,
+  created_at TIMESTAMP NOT NULL DEFAULT now(),
+  updated_at TIMESTAMP NOT NULL DEFAULT now(),
+  PRIMARY KEY (product_id, locale)
+);
+
+CREATE TABLE IF NOT EXISTS banner_translation (
+  banner_id UUID NOT NULL REFERENCES banner(id) ON DELETE CASCADE,
+  locale VARCHAR(8) NOT NULL,
+  title VARCHAR NOT NULL DEFAULT '',
+  created_at TIMESTAMP NOT NULL DEFAULT now(),
+  updated_at TIMESTAMP NOT NULL DEFAULT now(),
+  PRIMARY KEY (banner_id, locale)
+);
+
+-- the document of a translation indexes the category name in the same
+-- locale, or the default one when the category is not translated
+CREATE OR REPLACE FUNCTION product_translation_search_document() RETURNS trigger AS $$
+DECLARE
+  category_name TEXT;
+BEGIN
+  SELECT COALESCE(NULLIF(ct.name, ''), c.name) INTO category_name
+  FROM product p
+  JOIN category c ON c.id = p.category_id
+  LEFT JOIN category_translation ct ON ct.category_id = c.id AND ct.locale = NEW.locale
+  WHERE p.id = NEW.product_id;
+
+  NEW.search_document :=
+    setweight(to_tsvector('simple', uz_translit(NEW.name)), 'A') ||
+    setweight(to_tsvector('simple', uz_translit(COALESCE(category_name, ''))), 'B') ||
+    setweight(to_tsvector('simple', uz_translit(NEW.description)), 'C');
+  RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER product_translation_search_document BEFORE INSERT OR UPDATE ON product_translation
+  FOR EACH ROW EXECUTE FUNCTION product_translation_search_document();
+
+CREATE OR REPLACE FUNCTION category_translation_search_document() RETURNS trigger AS $$
+DECLARE
+  changed category_translation;
+BEGIN
+  IF TG_OP = 'DELETE' THEN
+    changed := OLD;
+  ELSE
+    changed := NEW;
+  END IF;
+
+  UPDATE product_translation SET name = name
+  WHERE locale = changed.locale AND product_id IN (SELECT id FROM product WHERE category_id = changed.category_id);
+  RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER category_translation_search_document AFTER INSERT OR UPDATE OR DELETE ON category_translation
+  FOR EACH ROW EXECUTE FUNCTION category_translation_search_document();
+
+-- renaming a category or moving a product refreshes the translated documents too
+CREATE OR REPLACE FUNCTION category_search_document() RETURNS trigger AS $$
+BEGIN
+  UPDATE product SET name = name WHERE category_id = NEW.id;
+  UPDATE product_translation SET name = name WHERE product_id IN (SELECT id FROM product WHERE category_id = NEW.id);
+  RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION product_translation_refresh() RETURNS trigger AS $$
+BEGIN
+  UPDATE product_translation SET name = name WHERE product_id = NEW.id;
+  RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER product_translation_refresh AFTER UPDATE OF category_id ON product
+  FOR EACH ROW WHEN (OLD.category_id IS DISTINCT FROM NEW.category_id) EXECUTE FUNCTION product_translation_refresh();
+
+CREATE INDEX IF NOT EXISTS product_translation_search_document_idx ON product_translation USING GIN (search_document);
+CREATE INDEX IF NOT EXISTS product_translation_search_name_trgm_idx ON product_translation USING GIN (search_name gin_trgm_ops);
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2) VALUES
+  ('p', 'admin', '/v1/translation/*', 'GET|PUT|DELETE')
+ON CONFLICT DO NOTHING;
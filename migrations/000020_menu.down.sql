@@ -0,0 +1,19 @@
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v0 = 'unauthorized' AND v1 = '/v1/menu';
+
+DELETE FROM upload_reference WHERE entity_type = 'category';
+
+DROP INDEX IF EXISTS product_category_sort_idx;
+DROP INDEX IF EXISTS category_parent_sort_idx;
+
+DROP TRIGGER IF EXISTS category_parent_check ON category;
+DROP FUNCTION IF EXISTS category_parent_check();
+
+ALTER TABLE product DROP COLUMN IF EXISTS is_hidden;
+ALTER TABLE product DROP COLUMN IF EXISTS is_active;
+ALTER TABLE product DROP COLUMN IF EXISTS sort_order;
+
+ALTER TABLE category DROP COLUMN IF EXISTS images;
+ALTER TABLE category DROP COLUMN IF EXISTS is_hidden;
+ALTER TABLE category DROP COLUMN IF EXISTS is_active;
+ALTER TABLE category DROP COLUMN IF EXISTS sort_order;
+ALTER TABLE category DROP COLUMN IF EXISTS parent_id;
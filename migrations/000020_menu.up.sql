@@ -0,0 +1,44 @@
+ALTER TABLE category ADD COLUMN IF NOT EXISTS parent_id UUID REFERENCES category(id) ON DELETE CASCADE;
+ALTER TABLE category ADD COLUMN IF NOT EXISTS sort_order INT NOT NULL DEFAULT 0;
+ALTER TABLE category ADD COLUMN IF NOT EXISTS is_active BOOLEAN NOT NULL DEFAULT true;
+ALTER TABLE category ADD COLUMN IF NOT EXISTS is_hidden BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE category ADD COLUMN IF NOT EXISTS images JSONB NOT NULL DEFAULT '{}';
+
+ALTER TABLE product ADD COLUMN IF NOT EXISTS sort_order INT NOT NULL DEFAULT 0;
+ALTER TABLE product ADD COLUMN IF NOT EXISTS is_active BOOLEAN NOT NULL DEFAULT true;
+ALTER TABLE product ADD COLUMN IF NOT EXISTS is_hidden BOOLEAN NOT NULL DEFAULT false;
+
+-- keep the order the catalog had so far, leaving gaps to move things in between
+UPDATE category c SET sort_order = o.position * 10
+FROM (SELECT id, row_number() OVER (ORDER BY created_at) AS position FROM category) o
+WHERE c.id = o.id;
+
+UPDATE product p SET sort_order = o.position * 10
+FROM (SELECT id, row_number() OVER (PARTITION BY category_id ORDER BY created_at) AS position FROM product) o
+WHERE p.id = o.id;
+
+CREATE OR REPLACE FUNCTION category_parent_check() RETURNS trigger AS $$
+BEGIN
+  IF NEW.parent_id IS NOT NULL AND EXISTS (
+    WITH RECURSIVE ancestor AS (
+      SELECT id, parent_id FROM category WHERE id = NEW.parent_id
+      UNION
+      SELECT c.id, c.parent_id FROM category c JOIN ancestor a ON c.id = a.parent_id
+    )
+    SELECT 1 FROM ancestor WHERE id = NEW.id
+  ) THEN
+    RAISE EXCEPTION 'category % cannot be nested under itself', NEW.id USING ERRCODE = 'check_violation';
+  END IF;
+  RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER category_parent_check BEFORE INSERT OR UPDATE OF parent_id ON category
+  FOR EACH ROW EXECUTE FUNCTION category_parent_check();
+
+CREATE INDEX IF NOT EXISTS category_parent_sort_idx ON category(parent_id, sort_order);
+CREATE INDEX IF NOT EXISTS product_category_sort_idx ON product(category_id, sort_order);
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2) VALUES
+  ('p', 'unauthorized', '/v1/menu', 'GET')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,11 @@
+DROP TRIGGER IF EXISTS banner_translation_catalog_change ON banner_translation;
+DROP TRIGGER IF EXISTS banner_catalog_change ON banner;
+DROP TRIGGER IF EXISTS product_availability_catalog_change ON product_availability;
+DROP TRIGGER IF EXISTS product_translation_catalog_change ON product_translation;
+DROP TRIGGER IF EXISTS product_catalog_change ON product;
+DROP TRIGGER IF EXISTS category_translation_catalog_change ON category_translation;
+DROP TRIGGER IF EXISTS category_catalog_change ON category;
+
+DROP FUNCTION IF EXISTS log_catalog_change();
+
+DROP TABLE IF EXISTS catalog_change;
@@ -0,0 +1,43 @@
+-- Every write to the catalog is logged here by triggers, whatever code path
+-- it comes from. The highest version is the version of the catalog, clients
+-- sync the changes after the version they have.
+CREATE TABLE IF NOT EXISTS catalog_change (
+  version BIGSERIAL PRIMARY KEY,
+  entity_type VARCHAR(16) NOT NULL,
+  entity_id UUID NOT NULL,
+  changed_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+-- log_catalog_change(entity_type, id_column) logs the row of the trigger
+CREATE OR REPLACE FUNCTION log_catalog_change() RETURNS trigger AS $$
+DECLARE
+  changed RECORD;
+BEGIN
+  IF TG_OP = 'DELETE' THEN
+    changed := OLD;
+  ELSE
+    changed := NEW;
+  END IF;
+
+  INSERT INTO catalog_change (entity_type, entity_id)
+  VALUES (TG_ARGV[0], (to_jsonb(changed) ->> TG_ARGV[1])::uuid);
+  RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER category_catalog_change AFTER INSERT OR UPDATE OR DELETE ON category
+  FOR EACH ROW EXECUTE FUNCTION log_catalog_change('category', 'id');
+CREATE TRIGGER category_translation_catalog_change AFTER INSERT OR UPDATE OR DELETE ON category_translation
+  FOR EACH ROW EXECUTE FUNCTION log_catalog_change('category', 'category_id');
+
+CREATE TRIGGER product_catalog_change AFTER INSERT OR UPDATE OR DELETE ON product
+  FOR EACH ROW EXECUTE FUNCTION log_catalog_change('product', 'id');
+CREATE TRIGGER product_translation_catalog_change AFTER INSERT OR UPDATE OR DELETE ON product_translation
+  FOR EACH ROW EXECUTE FUNCTION log_catalog_change('product', 'product_id');
+CREATE TRIGGER product_availability_catalog_change AFTER INSERT OR UPDATE OR DELETE ON product_availability
+  FOR EACH ROW EXECUTE FUNCTION log_catalog_change('product', 'product_id');
+
+CREATE TRIGGER banner_catalog_change AFTER INSERT OR UPDATE OR DELETE ON banner
+  FOR EACH ROW EXECUTE FUNCTION log_catalog_change('banner', 'id');
+CREATE TRIGGER banner_translation_catalog_change AFTER INSERT OR UPDATE OR DELETE ON banner_translation
+  FOR EACH ROW EXECUTE FUNCTION log_catalog_change('banner', 'banner_id');
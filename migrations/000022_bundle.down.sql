@@ -0,0 +1,16 @@
+ALTER TABLE orderitems DROP COLUMN IF EXISTS bundle_slot_id;
+DELETE FROM orderitems WHERE parent_item_id IS NOT NULL;
+ALTER TABLE orderitems DROP COLUMN IF EXISTS parent_item_id;
+
+DROP TRIGGER IF EXISTS product_availability_bundle_catalog_change ON product_availability;
+DROP TRIGGER IF EXISTS product_bundle_catalog_change ON product;
+DROP TRIGGER IF EXISTS bundle_nesting_check ON product;
+
+DROP TABLE IF EXISTS bundle_slot_option;
+DROP TABLE IF EXISTS bundle_slot;
+
+DROP FUNCTION IF EXISTS log_bundle_change();
+DROP FUNCTION IF EXISTS product_available(UUID, UUID);
+DROP FUNCTION IF EXISTS bundle_nesting_check();
+
+ALTER TABLE product DROP COLUMN IF EXISTS is_bundle;
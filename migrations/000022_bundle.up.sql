@@ -0,0 +1,98 @@
+-- A bundle is a product made of slots. A slot with one option is a fixed
+-- item, with more the customer chooses one. The price of the bundle product is
+-- the bundle price, options can add to it.
+ALTER TABLE product ADD COLUMN IF NOT EXISTS is_bundle BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS bundle_slot (
+  id UUID PRIMARY KEY,
+  bundle_id UUID NOT NULL REFERENCES product(id) ON DELETE CASCADE,
+  name VARCHAR NOT NULL,
+  quantity INT NOT NULL DEFAULT 1 CHECK (quantity > 0),
+  sort_order INT NOT NULL DEFAULT 0,
+  created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS bundle_slot_bundle_idx ON bundle_slot(bundle_id, sort_order);
+
+CREATE TABLE IF NOT EXISTS bundle_slot_option (
+  slot_id UUID NOT NULL REFERENCES bundle_slot(id) ON DELETE CASCADE,
+  product_id UUID NOT NULL REFERENCES product(id) ON DELETE CASCADE,
+  price_delta DECIMAL NOT NULL DEFAULT 0,
+  is_default BOOLEAN NOT NULL DEFAULT false,
+  sort_order INT NOT NULL DEFAULT 0,
+  PRIMARY KEY (slot_id, product_id)
+);
+
+CREATE INDEX IF NOT EXISTS bundle_slot_option_product_idx ON bundle_slot_option(product_id);
+
+-- bundles are one level deep
+CREATE OR REPLACE FUNCTION bundle_nesting_check() RETURNS trigger AS $$
+BEGIN
+  IF TG_TABLE_NAME = 'bundle_slot_option' AND EXISTS (SELECT 1 FROM product WHERE id = NEW.product_id AND is_bundle) THEN
+    RAISE EXCEPTION 'product % is a bundle and cannot be part of one', NEW.product_id USING ERRCODE = 'check_violation';
+  END IF;
+  IF TG_TABLE_NAME = 'product' AND NEW.is_bundle AND EXISTS (SELECT 1 FROM bundle_slot_option WHERE product_id = NEW.id) THEN
+    RAISE EXCEPTION 'product % is part of a bundle and cannot be one', NEW.id USING ERRCODE = 'check_violation';
+  END IF;
+  RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER bundle_nesting_check BEFORE INSERT OR UPDATE ON bundle_slot_option
+  FOR EACH ROW EXECUTE FUNCTION bundle_nesting_check();
+CREATE TRIGGER bundle_nesting_check BEFORE UPDATE OF is_bundle ON product
+  FOR EACH ROW EXECUTE FUNCTION bundle_nesting_check();
+
+-- A product is available at a branch unless marked otherwise there. A bundle
+-- also needs an active, available option in every slot.
+CREATE OR REPLACE FUNCTION product_available(product_id UUID, branch_id UUID) RETURNS BOOLEAN AS $$
+  SELECT NOT EXISTS (
+    SELECT 1 FROM product_availability pa WHERE pa.product_id = product_available.product_id AND pa.branch_id = product_available.branch_id AND NOT pa.is_available
+  ) AND NOT EXISTS (
+    SELECT 1 FROM bundle_slot s WHERE s.bundle_id = product_available.product_id AND NOT EXISTS (
+      SELECT 1 FROM bundle_slot_option o JOIN product c ON c.id = o.product_id
+      WHERE o.slot_id = s.id AND c.is_active AND NOT EXISTS (
+        SELECT 1 FROM product_availability pa WHERE pa.product_id = c.id AND pa.branch_id = product_available.branch_id AND NOT pa.is_available
+      )
+    )
+  )
+$$ LANGUAGE sql STABLE;
+
+-- a change to a bundle or to one of its components changes the bundle in the menu
+CREATE OR REPLACE FUNCTION log_bundle_change() RETURNS trigger AS $$
+DECLARE
+  changed RECORD;
+BEGIN
+  IF TG_OP = 'DELETE' THEN
+    changed := OLD;
+  ELSE
+    changed := NEW;
+  END IF;
+
+  IF TG_TABLE_NAME = 'bundle_slot' THEN
+    INSERT INTO catalog_change (entity_type, entity_id) VALUES ('product', changed.bundle_id);
+  ELSIF TG_TABLE_NAME = 'bundle_slot_option' THEN
+    INSERT INTO catalog_change (entity_type, entity_id)
+    SELECT 'product', bundle_id FROM bundle_slot WHERE id = changed.slot_id;
+  ELSE
+    INSERT INTO catalog_change (entity_type, entity_id)
+    SELECT DISTINCT 'product', s.bundle_id FROM bundle_slot s JOIN bundle_slot_option o ON o.slot_id = s.id
+    WHERE o.product_id = (to_jsonb(changed) ->> TG_ARGV[0])::uuid;
+  END IF;
+  RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER bundle_slot_catalog_change AFTER INSERT OR UPDATE OR DELETE ON bundle_slot
+  FOR EACH ROW EXECUTE FUNCTION log_bundle_change();
+CREATE TRIGGER bundle_slot_option_catalog_change AFTER INSERT OR UPDATE OR DELETE ON bundle_slot_option
+  FOR EACH ROW EXECUTE FUNCTION log_bundle_change();
+CREATE TRIGGER product_bundle_catalog_change AFTER UPDATE ON product
+  FOR EACH ROW EXECUTE FUNCTION log_bundle_change('id');
+CREATE TRIGGER product_availability_bundle_catalog_change AFTER INSERT OR UPDATE OR DELETE ON product_availability
+  FOR EACH ROW EXECUTE FUNCTION log_bundle_change('product_id');
+
+-- Component lines of a bundle point to the line of the bundle. They go to the
+-- kitchen and cost nothing, the bundle line carries the price.
+ALTER TABLE orderitems ADD COLUMN IF NOT EXISTS parent_item_id UUID REFERENCES orderitems(id) ON DELETE CASCADE;
+ALTER TABLE orderitems ADD COLUMN IF NOT EXISTS bundle_slot_id UUID REFERENCES bundle_slot(id) ON DELETE SET NULL;
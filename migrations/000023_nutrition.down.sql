@@ -0,0 +1,9 @@
+ALTER TABLE users DROP COLUMN IF EXISTS excluded_allergens;
+
+DROP INDEX IF EXISTS product_dietary_tags_idx;
+DROP INDEX IF EXISTS product_allergens_idx;
+
+ALTER TABLE product DROP COLUMN IF EXISTS spicy_level;
+ALTER TABLE product DROP COLUMN IF EXISTS dietary_tags;
+ALTER TABLE product DROP COLUMN IF EXISTS allergens;
+ALTER TABLE product DROP COLUMN IF EXISTS nutrition;
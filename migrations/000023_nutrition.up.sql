@@ -0,0 +1,13 @@
+-- Nutrition is per portion: kcal, protein, fat, carbohydrates and the portion
+-- weight in grams. Allergens and dietary tags hold the names listed in
+-- config.Allergens and config.DietaryTags.
+ALTER TABLE product ADD COLUMN IF NOT EXISTS nutrition JSONB;
+ALTER TABLE product ADD COLUMN IF NOT EXISTS allergens TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE product ADD COLUMN IF NOT EXISTS dietary_tags TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE product ADD COLUMN IF NOT EXISTS spicy_level SMALLINT NOT NULL DEFAULT 0 CHECK (spicy_level BETWEEN 0 AND 3);
+
+CREATE INDEX IF NOT EXISTS product_allergens_idx ON product USING GIN (allergens);
+CREATE INDEX IF NOT EXISTS product_dietary_tags_idx ON product USING GIN (dietary_tags);
+
+-- products with these allergens are left out of the menu of the user
+ALTER TABLE users ADD COLUMN IF NOT EXISTS excluded_allergens TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,9 @@
+ALTER TABLE orderitems DROP COLUMN IF EXISTS price_version_id;
+
+DROP TRIGGER IF EXISTS product_price_version_update ON product;
+DROP TRIGGER IF EXISTS product_price_version_insert ON product;
+DROP FUNCTION IF EXISTS product_price_version();
+
+ALTER TABLE product DROP COLUMN IF EXISTS price_version_id;
+
+DROP TABLE IF EXISTS product_price_history;
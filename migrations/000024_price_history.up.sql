@@ -0,0 +1,49 @@
+-- Every price a product had, has or is scheduled to have. A row is a price
+-- version, applied_at is set once it became the price of the product. Rows
+-- not applied yet are scheduled for effective_at.
+CREATE TABLE IF NOT EXISTS product_price_history (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  product_id UUID NOT NULL REFERENCES product(id) ON DELETE CASCADE,
+  price DECIMAL NOT NULL CHECK (price >= 0),
+  effective_at TIMESTAMP NOT NULL DEFAULT now(),
+  applied_at TIMESTAMP,
+  created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS product_price_history_product_idx ON product_price_history(product_id, effective_at DESC);
+CREATE INDEX IF NOT EXISTS product_price_history_scheduled_idx ON product_price_history(effective_at) WHERE applied_at IS NULL;
+
+-- the version the current price comes from
+ALTER TABLE product ADD COLUMN IF NOT EXISTS price_version_id UUID REFERENCES product_price_history(id) ON DELETE SET NULL;
+
+WITH versions AS (
+  INSERT INTO product_price_history (product_id, price, effective_at, applied_at)
+  SELECT id, price, created_at, created_at FROM product
+  RETURNING id, product_id
+)
+UPDATE product p SET price_version_id = v.id FROM versions v WHERE v.product_id = p.id;
+
+-- A price set directly on the product becomes a new version. Applying a
+-- scheduled price sets price_version_id along with the price and is left alone.
+CREATE OR REPLACE FUNCTION product_price_version() RETURNS trigger AS $$
+BEGIN
+  IF TG_OP = 'INSERT' THEN
+    INSERT INTO product_price_history (product_id, price, applied_at) VALUES (NEW.id, NEW.price, now())
+    RETURNING id INTO NEW.price_version_id;
+    UPDATE product SET price_version_id = NEW.price_version_id WHERE id = NEW.id;
+  ELSIF NEW.price IS DISTINCT FROM OLD.price AND NEW.price_version_id IS NOT DISTINCT FROM OLD.price_version_id THEN
+    INSERT INTO product_price_history (product_id, price, applied_at) VALUES (NEW.id, NEW.price, now())
+    RETURNING id INTO NEW.price_version_id;
+  END IF;
+  RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER product_price_version_insert AFTER INSERT ON product
+  FOR EACH ROW EXECUTE FUNCTION product_price_version();
+CREATE TRIGGER product_price_version_update BEFORE UPDATE OF price ON product
+  FOR EACH ROW EXECUTE FUNCTION product_price_version();
+
+-- the price version an order line was charged at
+ALTER TABLE orderitems ADD COLUMN IF NOT EXISTS price_version_id UUID REFERENCES product_price_history(id) ON DELETE SET NULL;
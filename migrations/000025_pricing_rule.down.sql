@@ -0,0 +1,6 @@
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v0 = 'admin' AND v1 = '/v1/pricing-rule/*';
+
+ALTER TABLE orderitems DROP COLUMN IF EXISTS applied_rules;
+ALTER TABLE orderitems DROP COLUMN IF EXISTS discount;
+
+DROP TABLE IF EXISTS pricing_rule;
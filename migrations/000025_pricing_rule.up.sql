@@ -0,0 +1,39 @@
+-- Discounts applied to order lines. kind is percent (percent off the line),
+-- amount (amount off every unit) or buy_get (every buy_quantity + get_quantity
+-- units, get_quantity are free). A rule without product, category or branch
+-- applies to all of them. days_of_week (0 is Sunday) and the time of day
+-- window are in local time, a window ending before it starts crosses midnight.
+CREATE TABLE IF NOT EXISTS pricing_rule (
+  id UUID PRIMARY KEY,
+  name VARCHAR NOT NULL,
+  kind VARCHAR NOT NULL CHECK (kind IN ('percent', 'amount', 'buy_get')),
+  percent DECIMAL NOT NULL DEFAULT 0 CHECK (percent >= 0 AND percent <= 100),
+  amount DECIMAL NOT NULL DEFAULT 0 CHECK (amount >= 0),
+  buy_quantity INT NOT NULL DEFAULT 0 CHECK (buy_quantity >= 0),
+  get_quantity INT NOT NULL DEFAULT 0 CHECK (get_quantity >= 0),
+  product_id UUID REFERENCES product(id) ON DELETE CASCADE,
+  category_id UUID REFERENCES category(id) ON DELETE CASCADE,
+  branch_id UUID REFERENCES branch(id) ON DELETE CASCADE,
+  days_of_week INT[] NOT NULL DEFAULT '{}',
+  start_time TIME,
+  end_time TIME,
+  starts_at TIMESTAMP,
+  ends_at TIMESTAMP,
+  first_order_only BOOLEAN NOT NULL DEFAULT false,
+  priority INT NOT NULL DEFAULT 0,
+  stackable BOOLEAN NOT NULL DEFAULT false,
+  is_active BOOLEAN NOT NULL DEFAULT true,
+  created_at TIMESTAMP NOT NULL DEFAULT now(),
+  updated_at TIMESTAMP NOT NULL DEFAULT now(),
+  CHECK ((start_time IS NULL) = (end_time IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS pricing_rule_active_idx ON pricing_rule(branch_id) WHERE is_active;
+
+-- the discount of a line and the rules it comes from, total_price is net of it
+ALTER TABLE orderitems ADD COLUMN IF NOT EXISTS discount DECIMAL NOT NULL DEFAULT 0;
+ALTER TABLE orderitems ADD COLUMN IF NOT EXISTS applied_rules JSONB NOT NULL DEFAULT '[]';
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2) VALUES
+  ('p', 'admin', '/v1/pricing-rule/*', 'GET|POST|PUT|DELETE')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,16 @@
+ALTER TABLE orderitems ALTER COLUMN discount TYPE DECIMAL;
+ALTER TABLE orderitems ALTER COLUMN price TYPE DECIMAL;
+ALTER TABLE orderitems ALTER COLUMN total_price TYPE DECIMAL(10,2);
+
+ALTER TABLE orders DROP COLUMN IF EXISTS currency;
+ALTER TABLE orders ALTER COLUMN total_price TYPE DECIMAL;
+
+ALTER TABLE pricing_rule ALTER COLUMN amount TYPE DECIMAL;
+ALTER TABLE bundle_slot_option ALTER COLUMN price_delta TYPE DECIMAL;
+ALTER TABLE product_price_history ALTER COLUMN price TYPE DECIMAL;
+
+-- the type of a column in a trigger's UPDATE OF list cannot change
+DROP TRIGGER IF EXISTS product_price_version_update ON product;
+ALTER TABLE product ALTER COLUMN price TYPE DECIMAL;
+CREATE TRIGGER product_price_version_update BEFORE UPDATE OF price ON product
+  FOR EACH ROW EXECUTE FUNCTION product_price_version();
@@ -0,0 +1,19 @@
+-- Amounts are kept in minor units by the application, two decimals are
+-- enough for every supported currency. Existing values are rounded.
+
+-- the type of a column in a trigger's UPDATE OF list cannot change
+DROP TRIGGER IF EXISTS product_price_version_update ON product;
+ALTER TABLE product ALTER COLUMN price TYPE DECIMAL(14,2);
+CREATE TRIGGER product_price_version_update BEFORE UPDATE OF price ON product
+  FOR EACH ROW EXECUTE FUNCTION product_price_version();
+
+ALTER TABLE product_price_history ALTER COLUMN price TYPE DECIMAL(14,2);
+ALTER TABLE bundle_slot_option ALTER COLUMN price_delta TYPE DECIMAL(14,2);
+ALTER TABLE pricing_rule ALTER COLUMN amount TYPE DECIMAL(14,2);
+
+ALTER TABLE orders ALTER COLUMN total_price TYPE DECIMAL(14,2);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'UZS';
+
+ALTER TABLE orderitems ALTER COLUMN total_price TYPE DECIMAL(14,2);
+ALTER TABLE orderitems ALTER COLUMN price TYPE DECIMAL(14,2);
+ALTER TABLE orderitems ALTER COLUMN discount TYPE DECIMAL(14,2);
@@ -0,0 +1,15 @@
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v0 = 'admin' AND v1 = '/v1/tax-category/*';
+
+DROP TABLE IF EXISTS receipt;
+DROP TABLE IF EXISTS receipt_counter;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS tax;
+ALTER TABLE orderitems DROP COLUMN IF EXISTS tax;
+ALTER TABLE orderitems DROP COLUMN IF EXISTS tax_rate;
+ALTER TABLE orderitems DROP COLUMN IF EXISTS mxik_code;
+
+ALTER TABLE product DROP COLUMN IF EXISTS package_code;
+ALTER TABLE product DROP COLUMN IF EXISTS mxik_code;
+ALTER TABLE product DROP COLUMN IF EXISTS tax_category_id;
+
+DROP TABLE IF EXISTS tax_category;
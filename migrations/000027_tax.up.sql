@@ -0,0 +1,57 @@
+-- VAT categories. Prices include VAT, the tax of a line is
+-- total_price * rate / (100 + rate).
+CREATE TABLE IF NOT EXISTS tax_category (
+  id UUID PRIMARY KEY,
+  name VARCHAR NOT NULL UNIQUE,
+  rate DECIMAL(5,2) NOT NULL CHECK (rate >= 0 AND rate < 100),
+  created_at TIMESTAMP NOT NULL DEFAULT now(),
+  updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+-- mxik_code is the 17 digit IKPU code of the product in the national
+-- classifier, package_code the code of its unit of measure
+ALTER TABLE product ADD COLUMN IF NOT EXISTS tax_category_id UUID REFERENCES tax_category(id);
+ALTER TABLE product ADD COLUMN IF NOT EXISTS mxik_code VARCHAR NOT NULL DEFAULT ''
+  CHECK (mxik_code = '' OR mxik_code ~ '^[0-9]{17}$');
+ALTER TABLE product ADD COLUMN IF NOT EXISTS package_code VARCHAR NOT NULL DEFAULT '';
+
+ALTER TABLE orderitems ADD COLUMN IF NOT EXISTS mxik_code VARCHAR NOT NULL DEFAULT '';
+ALTER TABLE orderitems ADD COLUMN IF NOT EXISTS tax_rate DECIMAL(5,2) NOT NULL DEFAULT 0;
+ALTER TABLE orderitems ADD COLUMN IF NOT EXISTS tax DECIMAL(14,2) NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS tax DECIMAL(14,2) NOT NULL DEFAULT 0;
+
+-- receipts of a branch are numbered 1, 2, 3... without gaps
+CREATE TABLE IF NOT EXISTS receipt_counter (
+  branch_id UUID PRIMARY KEY REFERENCES branch(id) ON DELETE CASCADE,
+  last_number INT NOT NULL
+);
+
+-- The fiscal receipt of a delivered order. It is pending until the fiscal
+-- provider registers it and returns the fiscal sign, after max attempts
+-- it is failed. An order with a receipt cannot be deleted.
+CREATE TABLE IF NOT EXISTS receipt (
+  id UUID PRIMARY KEY,
+  order_id UUID NOT NULL UNIQUE REFERENCES orders(id),
+  branch_id UUID NOT NULL REFERENCES branch(id),
+  number INT NOT NULL,
+  items JSONB NOT NULL,
+  total DECIMAL(14,2) NOT NULL,
+  tax DECIMAL(14,2) NOT NULL,
+  currency CHAR(3) NOT NULL,
+  status VARCHAR NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'registered', 'failed')),
+  terminal_id VARCHAR NOT NULL DEFAULT '',
+  fiscal_sign VARCHAR NOT NULL DEFAULT '',
+  qr_url VARCHAR NOT NULL DEFAULT '',
+  error VARCHAR NOT NULL DEFAULT '',
+  attempts INT NOT NULL DEFAULT 0,
+  next_attempt_at TIMESTAMP NOT NULL DEFAULT now(),
+  created_at TIMESTAMP NOT NULL DEFAULT now(),
+  registered_at TIMESTAMP,
+  UNIQUE (branch_id, number)
+);
+
+CREATE INDEX IF NOT EXISTS receipt_pending_idx ON receipt(next_attempt_at) WHERE status = 'pending';
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2) VALUES
+  ('p', 'admin', '/v1/tax-category/*', 'GET|POST|PUT|DELETE')
+ON CONFLICT DO NOTHING;
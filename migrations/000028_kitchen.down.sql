@@ -0,0 +1,19 @@
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v0 = 'admin' AND v1 IN ('/v1/kitchen/*', '/v1/kitchen-station/*');
+
+DROP TRIGGER IF EXISTS orderitems_kitchen_notify ON orderitems;
+DROP TRIGGER IF EXISTS orders_kitchen_notify ON orders;
+DROP FUNCTION IF EXISTS kitchen_notify();
+
+DROP TABLE IF EXISTS prep_time;
+
+DROP INDEX IF EXISTS orders_kitchen_idx;
+ALTER TABLE orders DROP COLUMN IF EXISTS ready_eta;
+ALTER TABLE orders DROP COLUMN IF EXISTS bumped_at;
+
+ALTER TABLE orderitems DROP COLUMN IF EXISTS ready_at;
+ALTER TABLE orderitems DROP COLUMN IF EXISTS cooking_at;
+ALTER TABLE orderitems DROP COLUMN IF EXISTS kitchen_status;
+
+ALTER TABLE category DROP COLUMN IF EXISTS kitchen_station_id;
+
+DROP TABLE IF EXISTS kitchen_station;
@@ -0,0 +1,59 @@
+-- Kitchen stations such as grill, fryer or drinks. Order lines go to the
+-- station of the category of their product, or of its parent category.
+CREATE TABLE IF NOT EXISTS kitchen_station (
+  id UUID PRIMARY KEY,
+  name VARCHAR NOT NULL UNIQUE,
+  created_at TIMESTAMP NOT NULL DEFAULT now(),
+  updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+ALTER TABLE category ADD COLUMN IF NOT EXISTS kitchen_station_id UUID REFERENCES kitchen_station(id) ON DELETE SET NULL;
+
+-- where a line is in the kitchen; bundle lines are not cooked, their components are
+ALTER TABLE orderitems ADD COLUMN IF NOT EXISTS kitchen_status VARCHAR NOT NULL DEFAULT 'queued'
+  CHECK (kitchen_status IN ('queued', 'cooking', 'ready'));
+ALTER TABLE orderitems ADD COLUMN IF NOT EXISTS cooking_at TIMESTAMP;
+ALTER TABLE orderitems ADD COLUMN IF NOT EXISTS ready_at TIMESTAMP;
+
+-- bumped orders are off the kitchen display until recalled
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS bumped_at TIMESTAMP;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS ready_eta TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS orders_kitchen_idx ON orders(branch_id, created_at)
+  WHERE status IN ('pending', 'confirmed', 'preparing');
+
+-- how long a product takes from cooking to ready at a branch, a running
+-- average of the last samples
+CREATE TABLE IF NOT EXISTS prep_time (
+  branch_id UUID NOT NULL REFERENCES branch(id) ON DELETE CASCADE,
+  product_id UUID NOT NULL REFERENCES product(id) ON DELETE CASCADE,
+  seconds DOUBLE PRECISION NOT NULL,
+  samples INT NOT NULL DEFAULT 0,
+  updated_at TIMESTAMP NOT NULL DEFAULT now(),
+  PRIMARY KEY (branch_id, product_id)
+);
+
+-- kitchen displays of the branch are told which order changed
+CREATE OR REPLACE FUNCTION kitchen_notify() RETURNS trigger AS $$
+BEGIN
+  IF TG_TABLE_NAME = 'orders' THEN
+    IF NEW.branch_id IS NOT NULL THEN
+      PERFORM pg_notify('kitchen', json_build_object('branch_id', NEW.branch_id, 'order_id', NEW.id)::text);
+    END IF;
+  ELSE
+    PERFORM pg_notify('kitchen', json_build_object('branch_id', o.branch_id, 'order_id', o.id)::text)
+      FROM orders o WHERE o.id = NEW.order_id AND o.branch_id IS NOT NULL;
+  END IF;
+  RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER orders_kitchen_notify AFTER INSERT OR UPDATE OF status, branch_id, bumped_at ON orders
+  FOR EACH ROW EXECUTE FUNCTION kitchen_notify();
+CREATE TRIGGER orderitems_kitchen_notify AFTER UPDATE OF kitchen_status ON orderitems
+  FOR EACH ROW EXECUTE FUNCTION kitchen_notify();
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2) VALUES
+  ('p', 'admin', '/v1/kitchen/*', 'GET|POST|PUT|DELETE'),
+  ('p', 'admin', '/v1/kitchen-station/*', 'GET|POST|PUT|DELETE')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,7 @@
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v0 = 'admin' AND v1 = '/v1/print/*';
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v0 = 'printer' AND v1 = '/v1/print/agent/*';
+
+DROP TRIGGER IF EXISTS print_job_notify ON print_job;
+DROP FUNCTION IF EXISTS print_job_notify();
+
+DROP TABLE IF EXISTS print_job;
@@ -0,0 +1,36 @@
+-- ESC/POS tickets waiting for the printers of a branch. Printer agents claim
+-- a job for a lease and ack it; a job whose lease ran out is claimed again.
+CREATE TABLE IF NOT EXISTS print_job (
+  id UUID PRIMARY KEY,
+  branch_id UUID NOT NULL REFERENCES branch(id) ON DELETE CASCADE,
+  order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+  kind VARCHAR NOT NULL CHECK (kind IN ('customer', 'kitchen')),
+  station_id UUID REFERENCES kitchen_station(id) ON DELETE SET NULL,
+  data BYTEA NOT NULL,
+  status VARCHAR NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'printing', 'printed', 'failed')),
+  attempts INT NOT NULL DEFAULT 0,
+  error VARCHAR NOT NULL DEFAULT '',
+  lease_until TIMESTAMP,
+  created_at TIMESTAMP NOT NULL DEFAULT now(),
+  printed_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS print_job_queue_idx ON print_job(branch_id, created_at) WHERE status IN ('pending', 'printing');
+CREATE INDEX IF NOT EXISTS print_job_order_idx ON print_job(order_id);
+
+-- waiting printer agents of the branch are woken up
+CREATE OR REPLACE FUNCTION print_job_notify() RETURNS trigger AS $$
+BEGIN
+  PERFORM pg_notify('print_job', json_build_object('branch_id', NEW.branch_id, 'order_id', NEW.order_id)::text);
+  RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER print_job_notify AFTER INSERT OR UPDATE OF status ON print_job
+  FOR EACH ROW WHEN (NEW.status = 'pending') EXECUTE FUNCTION print_job_notify();
+
+-- printer agents use API keys with the printer scope
+INSERT INTO casbin_rule (ptype, v0, v1, v2) VALUES
+  ('p', 'admin', '/v1/print/*', 'GET|POST|PUT|DELETE'),
+  ('p', 'printer', '/v1/print/agent/*', 'GET|POST')
+ON CONFLICT DO NOTHING;
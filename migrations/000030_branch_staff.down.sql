@@ -0,0 +1,6 @@
+DELETE FROM casbin_rule WHERE v0 IN ('branch_manager', 'branch_operator');
+
+-- enum values can not be dropped, staff become plain users
+UPDATE users SET user_role = 'user' WHERE user_role IN ('branch_manager', 'branch_operator');
+
+DROP TABLE IF EXISTS branch_staff;
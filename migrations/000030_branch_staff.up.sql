@@ -0,0 +1,23 @@
+-- branch staff only see and act on the orders, kitchen and printers of their branches
+ALTER TYPE user_role ADD VALUE IF NOT EXISTS 'branch_manager';
+ALTER TYPE user_role ADD VALUE IF NOT EXISTS 'branch_operator';
+
+CREATE TABLE IF NOT EXISTS branch_staff (
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  branch_id UUID NOT NULL REFERENCES branch(id) ON DELETE CASCADE,
+  created_at TIMESTAMP NOT NULL DEFAULT now(),
+  PRIMARY KEY (user_id, branch_id)
+);
+
+CREATE INDEX IF NOT EXISTS branch_staff_branch_id_idx ON branch_staff(branch_id);
+
+-- staff are users too; superadmins assign them and pass every policy
+INSERT INTO casbin_rule (ptype, v0, v1, v2) VALUES
+  ('p', 'branch_operator', '/v1/kitchen/*', 'GET|POST|PUT'),
+  ('p', 'branch_operator', '/v1/kitchen-station/*', 'GET'),
+  ('p', 'branch_operator', '/v1/print/*', 'GET|POST'),
+  ('p', 'branch_manager', '/v1/product/availability', 'PUT'),
+  ('p', 'branch_manager', '/v1/branch/*', 'PUT'),
+  ('g', 'branch_operator', 'user', ''),
+  ('g', 'branch_manager', 'branch_operator', '')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,19 @@
+DELETE FROM casbin_rule WHERE v0 = 'restaurant_admin' OR v1 = '/v1/restaurant/*';
+
+-- enum values can not be dropped, restaurant admins become plain users
+UPDATE users SET user_role = 'user' WHERE user_role = 'restaurant_admin';
+
+DROP TABLE IF EXISTS restaurant_admin;
+
+DROP TRIGGER IF EXISTS product_restaurant ON product;
+DROP FUNCTION IF EXISTS product_restaurant();
+
+ALTER TABLE category DROP CONSTRAINT IF EXISTS category_parent_restaurant_fkey;
+ALTER TABLE category DROP CONSTRAINT IF EXISTS category_id_restaurant_key;
+
+ALTER TABLE banner DROP COLUMN IF EXISTS restaurant_id;
+ALTER TABLE product DROP COLUMN IF EXISTS restaurant_id;
+ALTER TABLE category DROP COLUMN IF EXISTS restaurant_id;
+ALTER TABLE branch DROP COLUMN IF EXISTS restaurant_id;
+
+DROP TABLE IF EXISTS restaurant;
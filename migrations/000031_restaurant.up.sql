@@ -0,0 +1,69 @@
+-- restaurants own their branches, categories, products and banners; what
+-- existed before belongs to the default restaurant
+CREATE TABLE IF NOT EXISTS restaurant (
+  id UUID PRIMARY KEY,
+  name VARCHAR NOT NULL,
+  slug VARCHAR NOT NULL UNIQUE,
+  description TEXT NOT NULL DEFAULT '',
+  is_active BOOLEAN NOT NULL DEFAULT true,
+  created_at TIMESTAMP NOT NULL DEFAULT now(),
+  updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+INSERT INTO restaurant (id, name, slug) VALUES ('00000000-0000-0000-0000-000000000001', 'Default', 'default')
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE branch ADD COLUMN IF NOT EXISTS restaurant_id UUID NOT NULL
+  DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES restaurant(id);
+ALTER TABLE category ADD COLUMN IF NOT EXISTS restaurant_id UUID NOT NULL
+  DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES restaurant(id);
+ALTER TABLE product ADD COLUMN IF NOT EXISTS restaurant_id UUID NOT NULL
+  DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES restaurant(id);
+ALTER TABLE banner ADD COLUMN IF NOT EXISTS restaurant_id UUID NOT NULL
+  DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES restaurant(id);
+
+CREATE INDEX IF NOT EXISTS branch_restaurant_id_idx ON branch(restaurant_id);
+CREATE INDEX IF NOT EXISTS category_restaurant_id_idx ON category(restaurant_id);
+CREATE INDEX IF NOT EXISTS product_restaurant_id_idx ON product(restaurant_id);
+CREATE INDEX IF NOT EXISTS banner_restaurant_id_idx ON banner(restaurant_id);
+
+-- a subcategory is in the restaurant of its parent
+ALTER TABLE category ADD CONSTRAINT category_id_restaurant_key UNIQUE (id, restaurant_id);
+ALTER TABLE category ADD CONSTRAINT category_parent_restaurant_fkey
+  FOREIGN KEY (parent_id, restaurant_id) REFERENCES category(id, restaurant_id);
+
+-- a product is in the restaurant of its category
+CREATE OR REPLACE FUNCTION product_restaurant() RETURNS trigger AS $$
+BEGIN
+  NEW.restaurant_id := COALESCE((SELECT restaurant_id FROM category WHERE id = NEW.category_id), NEW.restaurant_id);
+  RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER product_restaurant BEFORE INSERT OR UPDATE OF category_id ON product
+  FOR EACH ROW EXECUTE FUNCTION product_restaurant();
+
+-- restaurant admins manage the catalog of one restaurant
+ALTER TYPE user_role ADD VALUE IF NOT EXISTS 'restaurant_admin';
+
+CREATE TABLE IF NOT EXISTS restaurant_admin (
+  user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+  restaurant_id UUID NOT NULL REFERENCES restaurant(id) ON DELETE CASCADE,
+  created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS restaurant_admin_restaurant_id_idx ON restaurant_admin(restaurant_id);
+
+-- superadmins assign restaurant admins and pass every policy
+INSERT INTO casbin_rule (ptype, v0, v1, v2) VALUES
+  ('p', 'unauthorized', '/v1/restaurant/*', 'GET'),
+  ('p', 'admin', '/v1/restaurant/*', 'GET|POST|PUT|DELETE'),
+  ('p', 'restaurant_admin', '/v1/restaurant/*', 'GET|PUT'),
+  ('p', 'restaurant_admin', '/v1/branch/*', 'GET|POST|PUT|DELETE'),
+  ('p', 'restaurant_admin', '/v1/category/*', 'GET|POST|PUT|DELETE'),
+  ('p', 'restaurant_admin', '/v1/product/*', 'GET|POST|PUT|DELETE'),
+  ('p', 'restaurant_admin', '/v1/banner/*', 'GET|POST|PUT|DELETE'),
+  ('p', 'restaurant_admin', '/v1/kitchen-station/*', 'GET'),
+  ('p', 'restaurant_admin', '/v1/tax-category/*', 'GET'),
+  ('g', 'restaurant_admin', 'user', '')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS orders_branch_status_idx;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS start_after;
+
+DROP TABLE IF EXISTS branch_capacity;
@@ -0,0 +1,15 @@
+-- how much a branch can cook; a zero limit is no limit. A new order for a full
+-- branch is handled by overflow: extend_eta promises it later, reroute sends
+-- it to the next nearest branch of the restaurant, reject turns it down.
+CREATE TABLE IF NOT EXISTS branch_capacity (
+  branch_id UUID PRIMARY KEY REFERENCES branch(id) ON DELETE CASCADE,
+  max_active_orders INT NOT NULL DEFAULT 0 CHECK (max_active_orders >= 0),
+  max_items_per_window INT NOT NULL DEFAULT 0 CHECK (max_items_per_window >= 0),
+  overflow VARCHAR NOT NULL DEFAULT 'extend_eta' CHECK (overflow IN ('extend_eta', 'reroute', 'reject')),
+  updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+-- an order taken by a full branch is not started before start_after
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS start_after TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS orders_branch_status_idx ON orders(branch_id, status);
@@ -0,0 +1,14 @@
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v0 = 'unauthorized' AND v1 = '/v1/branch/*/slots';
+
+DROP INDEX IF EXISTS orders_release_idx;
+DROP INDEX IF EXISTS orders_scheduled_idx;
+
+UPDATE orders SET status = 'pending' WHERE status = 'scheduled';
+ALTER TABLE orders DROP COLUMN IF EXISTS scheduled_at;
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_status_check;
+ALTER TABLE orders ADD CONSTRAINT orders_status_check
+  CHECK (status IN ('pending', 'confirmed', 'cancelled', 'preparing', 'picked_up', 'delivered'));
+
+ALTER TABLE branch_capacity DROP COLUMN IF EXISTS max_orders_per_slot;
+
+DROP TABLE IF EXISTS branch_hours;
@@ -0,0 +1,29 @@
+-- opening hours of a branch in local time, weekday 0 is Sunday. A branch
+-- without hours is open around the clock, one with hours is closed on the
+-- weekdays it has none for.
+CREATE TABLE IF NOT EXISTS branch_hours (
+  branch_id UUID NOT NULL REFERENCES branch(id) ON DELETE CASCADE,
+  weekday SMALLINT NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+  opens_at TIME NOT NULL,
+  closes_at TIME NOT NULL,
+  PRIMARY KEY (branch_id, weekday),
+  CHECK (closes_at > opens_at)
+);
+
+-- how many scheduled orders a branch takes per slot, zero is no limit
+ALTER TABLE branch_capacity ADD COLUMN IF NOT EXISTS max_orders_per_slot INT NOT NULL DEFAULT 0
+  CHECK (max_orders_per_slot >= 0);
+
+-- a scheduled order waits for the slot starting at scheduled_at; it is
+-- released to the kitchen as pending at start_after
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_status_check;
+ALTER TABLE orders ADD CONSTRAINT orders_status_check
+  CHECK (status IN ('scheduled', 'pending', 'confirmed', 'cancelled', 'preparing', 'picked_up', 'delivered'));
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS scheduled_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS orders_scheduled_idx ON orders(branch_id, scheduled_at) WHERE scheduled_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS orders_release_idx ON orders(start_after) WHERE status = 'scheduled';
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2) VALUES
+  ('p', 'unauthorized', '/v1/branch/*/slots', 'GET')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,3 @@
+-- the branches a key may act for, printer keys claim and ack only the print
+-- jobs of these branches
+ALTER TABLE api_key ADD COLUMN IF NOT EXISTS branch_ids UUID[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,5 @@
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v0 = 'unauthorized' AND v1 = '/v1/branch/:id/slots';
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2) VALUES
+  ('p', 'unauthorized', '/v1/branch/*/slots', 'GET')
+ON CONFLICT DO NOTHING;
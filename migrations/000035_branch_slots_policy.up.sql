@@ -0,0 +1,8 @@
+-- keyMatch ignores everything after the first *, so /v1/branch/*/slots let
+-- anonymous callers read every /v1/branch/ route. The enforced object is the
+-- gin route, which matches this rule exactly.
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v0 = 'unauthorized' AND v1 = '/v1/branch/*/slots';
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2) VALUES
+  ('p', 'unauthorized', '/v1/branch/:id/slots', 'GET')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,15 @@
+CREATE OR REPLACE FUNCTION log_catalog_change() RETURNS trigger AS $$
+DECLARE
+  changed RECORD;
+BEGIN
+  IF TG_OP = 'DELETE' THEN
+    changed := OLD;
+  ELSE
+    changed := NEW;
+  END IF;
+
+  INSERT INTO catalog_change (entity_type, entity_id)
+  VALUES (TG_ARGV[0], (to_jsonb(changed) ->> TG_ARGV[1])::uuid);
+  RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
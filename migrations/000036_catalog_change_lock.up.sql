@@ -0,0 +1,21 @@
+-- A BIGSERIAL hands out versions as writes start, not as they commit, so a
+-- client could sync up to a version while a lower one was still uncommitted
+-- and never see it. Writes to the log now take a transaction lock first: a
+-- version is only taken once every write with a lower one has committed.
+CREATE OR REPLACE FUNCTION log_catalog_change() RETURNS trigger AS $$
+DECLARE
+  changed RECORD;
+BEGIN
+  IF TG_OP = 'DELETE' THEN
+    changed := OLD;
+  ELSE
+    changed := NEW;
+  END IF;
+
+  PERFORM pg_advisory_xact_lock(hashtext('catalog_change'));
+
+  INSERT INTO catalog_change (entity_type, entity_id)
+  VALUES (TG_ARGV[0], (to_jsonb(changed) ->> TG_ARGV[1])::uuid);
+  RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,2 @@
+-- Addresses from social login can be up to 254 characters long.
+ALTER TABLE users ALTER COLUMN email TYPE VARCHAR(254);
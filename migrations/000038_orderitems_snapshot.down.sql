@@ -0,0 +1,9 @@
+DELETE FROM orderitems WHERE product_id IS NULL;
+
+ALTER TABLE orderitems DROP CONSTRAINT IF EXISTS orderitems_product_id_fkey;
+ALTER TABLE orderitems ADD CONSTRAINT orderitems_product_id_fkey
+  FOREIGN KEY (product_id) REFERENCES product(id) ON DELETE CASCADE;
+ALTER TABLE orderitems ALTER COLUMN product_id SET NOT NULL;
+
+ALTER TABLE orderitems DROP COLUMN IF EXISTS package_code;
+ALTER TABLE orderitems DROP COLUMN IF EXISTS product_name;
@@ -0,0 +1,13 @@
+-- The receipt of an order is built from its lines alone, so the name and
+-- package code of a product are kept on the line as it was ordered, and the
+-- lines stay when the product is deleted.
+ALTER TABLE orderitems ADD COLUMN IF NOT EXISTS product_name VARCHAR NOT NULL DEFAULT '';
+ALTER TABLE orderitems ADD COLUMN IF NOT EXISTS package_code VARCHAR NOT NULL DEFAULT '';
+
+UPDATE orderitems oi SET product_name = p.name, package_code = p.package_code
+FROM product p WHERE p.id = oi.product_id;
+
+ALTER TABLE orderitems ALTER COLUMN product_id DROP NOT NULL;
+ALTER TABLE orderitems DROP CONSTRAINT IF EXISTS orderitems_product_id_fkey;
+ALTER TABLE orderitems ADD CONSTRAINT orderitems_product_id_fkey
+  FOREIGN KEY (product_id) REFERENCES product(id) ON DELETE SET NULL;
@@ -0,0 +1,83 @@
+// Package apikey generates and checks API keys. A key looks like
+// fd_<prefix>_<secret>; the prefix is stored in clear to find the key, only
+// the SHA-256 of the whole key is stored.
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/hex"
+	"strings"
+)
+
+const (
+	keyPrefix = "fd"
+	// subjectPrefix is prepended to the key id to get its casbin subject.
+	subjectPrefix = "apikey:"
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// Generate returns a new key with a new prefix.
+func Generate() (key, prefix string, err error) {
+	prefix, err = random(5)
+	if err != nil {
+		return "", "", err
+	}
+
+	key, err = WithPrefix(prefix)
+
+	return key, prefix, err
+}
+
+// WithPrefix returns a new key with the given prefix, used when a key is rotated.
+func WithPrefix(prefix string) (string, error) {
+	secret, err := random(20)
+	if err != nil {
+		return "", err
+	}
+
+	return keyPrefix + "_" + prefix + "_" + secret, nil
+}
+
+// Prefix returns the prefix of a well formed key.
+func Prefix(key string) (string, bool) {
+	parts := strings.Split(key, "_")
+	if len(parts) != 3 || parts[0] != keyPrefix || parts[1] == "" || parts[2] == "" {
+		return "", false
+	}
+
+	return parts[1], true
+}
+
+// Subject returns the casbin subject of the key with the given id. The scopes
+// of the key are g rules of this subject.
+func Subject(id string) string {
+	return subjectPrefix + id
+}
+
+// Hash -.
+func Hash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Check compares the key with a stored hash in constant time.
+func Check(key, hash string) bool {
+	if hash == "" {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(Hash(key)), []byte(hash)) == 1
+}
+
+func random(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return strings.ToLower(encoding.EncodeToString(buf)), nil
+}
@@ -0,0 +1,190 @@
+// Package escpos writes the ESC/POS commands receipt printers understand.
+// Text is sent in code page PC866, so Latin and Cyrillic both print.
+package escpos
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+const (
+	esc = 0x1b
+	gs  = 0x1d
+
+	// codePagePC866 is the number of the Cyrillic code page on Epson
+	// compatible printers.
+	codePagePC866 = 17
+)
+
+// Align -.
+type Align byte
+
+const (
+	Left Align = iota
+	Center
+	Right
+)
+
+// Writer collects the commands of one print job.
+type Writer struct {
+	buf bytes.Buffer
+}
+
+// New starts a job: resets the printer and selects the code page.
+func New() *Writer {
+	w := &Writer{}
+	w.buf.Write([]byte{esc, '@', esc, 't', codePagePC866})
+
+	return w
+}
+
+// Bytes returns the job.
+func (w *Writer) Bytes() []byte {
+	return w.buf.Bytes()
+}
+
+// Align sets the alignment of the lines that follow.
+func (w *Writer) Align(a Align) {
+	w.buf.Write([]byte{esc, 'a', byte(a)})
+}
+
+// Bold turns emphasized text on or off.
+func (w *Writer) Bold(on bool) {
+	w.buf.Write([]byte{esc, 'E', flag(on)})
+}
+
+// Size scales the characters that follow, 1 to 8 times in each direction.
+func (w *Writer) Size(width, height int) {
+	w.buf.Write([]byte{gs, '!', byte((clamp(width)-1)<<4 | (clamp(height) - 1))})
+}
+
+// Text writes text as is, characters missing from the code page print as ?.
+func (w *Writer) Text(text string) {
+	w.buf.Write(encode(text))
+}
+
+// Line writes text and ends the line.
+func (w *Writer) Line(text string) {
+	w.Text(text)
+	w.buf.WriteByte('\n')
+}
+
+// Feed prints and feeds n lines.
+func (w *Writer) Feed(n int) {
+	w.buf.Write([]byte{esc, 'd', byte(n)})
+}
+
+// QR prints data as a QR code with modules of size dots, 1 to 16.
+func (w *Writer) QR(data string, size int) {
+	if size < 1 || size > 16 {
+		size = 6
+	}
+
+	// model 2, module size, error correction M
+	w.qr(0x41, 0x32, 0x00)
+	w.qr(0x43, byte(size))
+	w.qr(0x45, 0x31)
+
+	// store the data, then print it
+	w.qr(0x50, append([]byte{0x30}, data...)...)
+	w.qr(0x51, 0x30)
+}
+
+// Cut feeds the paper past the cutter and cuts it, leaving a point uncut.
+func (w *Writer) Cut() {
+	w.buf.Write([]byte{gs, 'V', 66, 0})
+}
+
+func (w *Writer) qr(fn byte, params ...byte) {
+	n := len(params) + 2
+	w.buf.Write([]byte{gs, '(', 'k', byte(n), byte(n >> 8), 0x31, fn})
+	w.buf.Write(params)
+}
+
+// Columns lays out left and right on one line of width characters, cutting
+// left short when both do not fit.
+func Columns(left, right string, width int) string {
+	space := width - utf8.RuneCountInString(right) - 1
+	if space < 1 {
+		return right
+	}
+
+	runes := []rune(left)
+	if len(runes) > space {
+		runes = runes[:space]
+	}
+
+	return string(runes) + strings.Repeat(" ", width-len(runes)-utf8.RuneCountInString(right)) + right
+}
+
+// Wrap breaks text into lines of at most width characters at spaces.
+func Wrap(text string, width int) []string {
+	var (
+		lines []string
+		line  []rune
+	)
+
+	for _, word := range strings.Fields(text) {
+		w := []rune(word)
+		if len(line) > 0 && len(line)+1+len(w) > width {
+			lines = append(lines, string(line))
+			line = nil
+		}
+		for len(w) > width {
+			lines = append(lines, string(w[:width]))
+			w = w[width:]
+		}
+		if len(line) > 0 {
+			line = append(line, ' ')
+		}
+		line = append(line, w...)
+	}
+
+	if len(line) > 0 || len(lines) == 0 {
+		lines = append(lines, string(line))
+	}
+
+	return lines
+}
+
+// replacements are characters of Uzbek and typography PC866 does not have.
+var replacements = strings.NewReplacer(
+	"ʻ", "'", "ʼ", "'", "‘", "'", "’", "'", "“", "\"", "”", "\"", "«", "\"", "»", "\"",
+	"—", "-", "–", "-", "−", "-", "…", "...", "×", "x",
+)
+
+func encode(text string) []byte {
+	text = replacements.Replace(text)
+
+	out := make([]byte, 0, len(text))
+	for _, r := range text {
+		b, ok := charmap.CodePage866.EncodeRune(r)
+		if !ok {
+			b = '?'
+		}
+		out = append(out, b)
+	}
+
+	return out
+}
+
+func flag(on bool) byte {
+	if on {
+		return 1
+	}
+	return 0
+}
+
+func clamp(n int) int {
+	switch {
+	case n < 1:
+		return 1
+	case n > 8:
+		return 8
+	default:
+		return n
+	}
+}
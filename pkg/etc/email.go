@@ -1,7 +1,10 @@
 package etc
 
 import (
+	"crypto/sha256"
+	"encoding/base64"
 	"fmt"
+	"mime"
 	"net/smtp"
 	"strings"
 	"text/template"
@@ -77,3 +80,34 @@ func GenerateNotificationEmailBody(message string) (string, error) {
 
 	return builder.String(), nil
 }
+
+// SendEmailAttachment sends an HTML email with one file attached.
+func SendEmailAttachment(smtpHost, smtpPort, from, password, to, subject, body, filename, contentType string, data []byte) error {
+	auth := smtp.PlainAuth("", from, password, smtpHost)
+
+	var b strings.Builder
+	boundary := fmt.Sprintf("%x", sha256.Sum256(data))[:32]
+
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&b, "From: %s\r\nTo: %s\r\n", from, to)
+	fmt.Fprintf(&b, "MIME-Version: 1.0\r\nContent-Type: multipart/mixed; boundary=%q\r\n\r\n", boundary)
+
+	fmt.Fprintf(&b, "--%s\r\nContent-Type: text/html; charset=\"UTF-8\"\r\n\r\n%s\r\n", boundary, body)
+
+	fmt.Fprintf(&b, "--%s\r\nContent-Type: %s\r\nContent-Transfer-Encoding: base64\r\n", boundary, contentType)
+	fmt.Fprintf(&b, "Content-Disposition: attachment; filename=%q\r\n\r\n", filename)
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 76 {
+		b.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	b.WriteString(encoded + "\r\n")
+	fmt.Fprintf(&b, "--%s--\r\n", boundary)
+
+	err := smtp.SendMail(smtpHost+":"+smtpPort, auth, from, []string{to}, []byte(b.String()))
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
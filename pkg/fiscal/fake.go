@@ -0,0 +1,49 @@
+package fiscal
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Fake registers receipts locally, for development and tests. The sign is
+// derived from the receipt so registering it again gives the same result.
+type Fake struct {
+	terminalID string
+	checkURL   string
+}
+
+// NewFake -.
+func NewFake(terminalID, checkURL string) *Fake {
+	return &Fake{terminalID: terminalID, checkURL: checkURL}
+}
+
+func (f *Fake) Register(_ context.Context, receipt Receipt) (Result, error) {
+	if len(receipt.Items) == 0 {
+		return Result{}, fmt.Errorf("%w: no items", ErrRejected)
+	}
+
+	for _, item := range receipt.Items {
+		if item.MxikCode == "" {
+			return Result{}, fmt.Errorf("%w: %s has no MXIK code", ErrRejected, item.Name)
+		}
+	}
+
+	sum := sha256.Sum256([]byte(receipt.ID + receipt.Total.String()))
+	sign := fmt.Sprintf("%012d", binary.BigEndian.Uint64(sum[:8])%1_000_000_000_000)
+
+	query := url.Values{}
+	query.Set("t", f.terminalID)
+	query.Set("r", strconv.Itoa(receipt.Number))
+	query.Set("c", receipt.Time.Format("20060102150405"))
+	query.Set("s", sign)
+
+	return Result{
+		TerminalID: f.terminalID,
+		Sign:       sign,
+		QRURL:      f.checkURL + "?" + query.Encode(),
+	}, nil
+}
@@ -0,0 +1,54 @@
+// Package fiscal registers receipts with the fiscal data operator. A
+// registered receipt gets a fiscal sign and a URL for the QR code printed on
+// it, by which the customer checks the receipt with the tax authority.
+package fiscal
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Akrom0181/Food-Delivery/pkg/money"
+)
+
+// ErrRejected is returned when the operator refuses a receipt, sending it again does not help.
+var ErrRejected = errors.New("fiscal: receipt rejected")
+
+// Provider -.
+type Provider interface {
+	// Register registers the receipt. Registering a receipt with the same ID
+	// again returns the result of the first registration.
+	Register(ctx context.Context, receipt Receipt) (Result, error)
+}
+
+// Receipt is a sale. Prices include VAT.
+type Receipt struct {
+	ID       string
+	BranchID string
+	Number   int
+	Time     time.Time
+	Items    []Item
+	Total    money.Amount
+	VAT      money.Amount
+	Currency string
+}
+
+// Item is a line of a receipt. Total is Price * Quantity - Discount.
+type Item struct {
+	Name        string
+	MxikCode    string
+	PackageCode string
+	Quantity    int
+	Price       money.Amount
+	Discount    money.Amount
+	Total       money.Amount
+	VATRate     float64
+	VAT         money.Amount
+}
+
+// Result is what the operator returns for a registered receipt.
+type Result struct {
+	TerminalID string
+	Sign       string
+	QRURL      string
+}
@@ -0,0 +1,117 @@
+// Package imageproc turns an uploaded photo into resized variants. Decoding
+// and re-encoding drops EXIF and any other metadata; the EXIF orientation is
+// applied first so phone photos are not stored sideways.
+//
+// Variants are encoded as JPEG. WebP would be smaller but there is no lossy
+// WebP encoder without cgo.
+package imageproc
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif" // register decoders
+	"image/jpeg"
+	_ "image/png"
+
+	xdraw "golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+var (
+	ErrNotImage      = errors.New("imageproc: file is not a supported image")
+	ErrImageTooLarge = errors.New("imageproc: image has too many pixels")
+)
+
+const (
+	// MaxPixels guards against decompression bombs, a small file can declare a huge canvas.
+	MaxPixels   = 50_000_000
+	jpegQuality = 82
+)
+
+// Variant is a size an image is scaled down to fit into. Images smaller than
+// MaxSide are not scaled up.
+type Variant struct {
+	Name    string
+	MaxSide int
+}
+
+// Variants are made for every product, banner and profile image.
+var Variants = []Variant{
+	{Name: "thumbnail", MaxSide: 160},
+	{Name: "card", MaxSide: 480},
+	{Name: "full", MaxSide: 1280},
+}
+
+// Output is an encoded variant.
+type Output struct {
+	Name        string
+	Data        []byte
+	ContentType string
+	Width       int
+	Height      int
+}
+
+// Process decodes data and encodes every variant.
+func Process(data []byte, variants []Variant) ([]Output, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrNotImage
+	}
+
+	if config.Width*config.Height > MaxPixels {
+		return nil, ErrImageTooLarge
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrNotImage
+	}
+
+	src = orient(src, orientation(data))
+
+	outputs := make([]Output, 0, len(variants))
+	for _, variant := range variants {
+		img := fit(src, variant.MaxSide)
+
+		var buf bytes.Buffer
+		if err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, err
+		}
+
+		outputs = append(outputs, Output{
+			Name:        variant.Name,
+			Data:        buf.Bytes(),
+			ContentType: "image/jpeg",
+			Width:       img.Bounds().Dx(),
+			Height:      img.Bounds().Dy(),
+		})
+	}
+
+	return outputs, nil
+}
+
+// fit scales src down to fit into a maxSide square. Transparent pixels end up
+// white because JPEG has no alpha channel.
+func fit(src image.Image, maxSide int) image.Image {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	if width > maxSide || height > maxSide {
+		if width >= height {
+			height = max(1, height*maxSide/width)
+			width = maxSide
+		} else {
+			width = max(1, width*maxSide/height)
+			height = maxSide
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	xdraw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, xdraw.Over, nil)
+
+	return dst
+}
@@ -0,0 +1,113 @@
+// Package locale negotiates the language a response is written in.
+package locale
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Preferred returns the supported locales in the order the client prefers
+// them: lang first, then the languages of the Accept-Language header by
+// quality. Region subtags are ignored, so "ru-RU" asks for "ru".
+func Preferred(lang, acceptLanguage string, supported []string) []string {
+	type weighted struct {
+		locale  string
+		quality float64
+	}
+
+	var requested []weighted
+	if lang != "" {
+		requested = append(requested, weighted{primary(lang), 2})
+	}
+
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		params := strings.Split(strings.TrimSpace(part), ";")
+		if params[0] == "" || params[0] == "*" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range params[1:] {
+			value, ok := strings.CutPrefix(strings.TrimSpace(param), "q=")
+			if !ok {
+				continue
+			}
+
+			q, err := strconv.ParseFloat(value, 64)
+			if err == nil {
+				quality = q
+			}
+		}
+
+		if quality > 0 {
+			requested = append(requested, weighted{primary(params[0]), quality})
+		}
+	}
+
+	sort.SliceStable(requested, func(i, j int) bool {
+		return requested[i].quality > requested[j].quality
+	})
+
+	var locales []string
+	for _, item := range requested {
+		if contains(supported, item.locale) && !contains(locales, item.locale) {
+			locales = append(locales, item.locale)
+		}
+	}
+
+	return locales
+}
+
+// Chain ends the preferred locales with def, the locale content is written
+// in. Unless the client asked for def itself, the fallbacks of the preferred
+// locales are tried before it. Locales after def are dropped since def always
+// has the content.
+func Chain(preferred []string, fallbacks map[string][]string, def string) []string {
+	var chain []string
+
+	add := func(locale string) {
+		if !contains(chain, locale) {
+			chain = append(chain, locale)
+		}
+	}
+
+	for _, locale := range preferred {
+		if locale == def {
+			return append(chain, def)
+		}
+		add(locale)
+	}
+	for _, locale := range chain {
+		for _, fallback := range fallbacks[locale] {
+			add(fallback)
+		}
+	}
+	add(def)
+
+	// a fallback can be the default locale itself
+	for i, locale := range chain {
+		if locale == def {
+			return chain[:i+1]
+		}
+	}
+
+	return chain
+}
+
+func primary(tag string) string {
+	tag, _, _ = strings.Cut(strings.TrimSpace(tag), "-")
+	tag, _, _ = strings.Cut(tag, "_")
+
+	return strings.ToLower(tag)
+}
+
+func contains(locales []string, locale string) bool {
+	for _, item := range locales {
+		if item == locale {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,68 @@
+package money
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+)
+
+// Encoding is how amounts are written to JSON.
+type Encoding int
+
+const (
+	// Number writes 12900.1, for clients that read prices as numbers.
+	Number Encoding = iota
+	// String writes "12900.10", which no JSON parser turns into a float.
+	String
+)
+
+var encoding = Number
+
+// SetEncoding sets how amounts are written to JSON. Reading accepts both.
+// It is meant to be called once at startup.
+func SetEncoding(e Encoding) {
+	encoding = e
+}
+
+// ParseEncoding returns the encoding named "number" or "string".
+func ParseEncoding(name string) (Encoding, bool) {
+	switch name {
+	case "number", "":
+		return Number, true
+	case "string":
+		return String, true
+	}
+	return Number, false
+}
+
+// MarshalJSON -.
+func (a Amount) MarshalJSON() ([]byte, error) {
+	s := a.String()
+	if encoding == String {
+		return []byte(strconv.Quote(s)), nil
+	}
+
+	// 12900.10 -> 12900.1, 3.00 -> 3
+	return []byte(strings.TrimRight(strings.TrimRight(s, "0"), ".")), nil
+}
+
+// UnmarshalJSON reads a number or a string from its text, so 0.1 stays
+// exactly 10 minor units.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	text := string(data)
+	if unquoted, err := strconv.Unquote(text); err == nil {
+		text = unquoted
+	}
+
+	v, err := Parse(text)
+	if err != nil {
+		return err
+	}
+
+	*a = v
+	return nil
+}
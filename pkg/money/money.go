@@ -0,0 +1,183 @@
+// Package money does exact arithmetic on amounts of money. An Amount is an
+// integer number of minor units (1/100 of the major unit), it is stored in
+// DECIMAL columns and written to JSON as a decimal number or string.
+package money
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Scale is the number of minor units in a major unit.
+const Scale = 100
+
+// Amount is an amount of money in minor units.
+type Amount int64
+
+// Currency holds the rounding rule of a currency: amounts computed from a
+// rate, like a percent discount or tax, are rounded half away from zero to
+// a multiple of Step minor units.
+type Currency struct {
+	Code string
+	Step int64
+}
+
+var currencies = map[string]Currency{
+	// tiyin are not in circulation, prices are whole so'm
+	"UZS": {Code: "UZS", Step: 100},
+	"USD": {Code: "USD", Step: 1},
+	"EUR": {Code: "EUR", Step: 1},
+	"RUB": {Code: "RUB", Step: 1},
+}
+
+// LookupCurrency returns the currency with the ISO 4217 code.
+func LookupCurrency(code string) (Currency, bool) {
+	c, ok := currencies[strings.ToUpper(code)]
+	return c, ok
+}
+
+// ErrInvalid is returned for text that is not an amount with at most two decimals.
+var ErrInvalid = errors.New("money: invalid amount")
+
+// Parse reads a decimal amount like "12900.10" or "-3". More than two
+// decimals is an error, the value is never rounded.
+func Parse(s string) (Amount, error) {
+	s = strings.TrimSpace(s)
+
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "-"), "+")
+
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" && frac == "" || len(frac) > 2 || !digits(whole) || !digits(frac) {
+		return 0, ErrInvalid
+	}
+	frac += strings.Repeat("0", 2-len(frac))
+
+	major, err := strconv.ParseInt("0"+whole, 10, 64)
+	if err != nil || major > (1<<63-1)/Scale-1 {
+		return 0, ErrInvalid
+	}
+	minor, _ := strconv.ParseInt(frac, 10, 64)
+
+	a := Amount(major*Scale + minor)
+	if negative {
+		a = -a
+	}
+	return a, nil
+}
+
+// String returns the amount with two decimals.
+func (a Amount) String() string {
+	sign := ""
+	v := int64(a)
+	if v < 0 {
+		sign, v = "-", -v
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, v/Scale, v%Scale)
+}
+
+// Grouped returns the amount like String with the thousands separated by
+// spaces, 12900.10 as "12 900.10".
+func (a Amount) Grouped() string {
+	s := a.String()
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+
+	whole, frac, _ := strings.Cut(s, ".")
+	var b strings.Builder
+	for i, d := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteRune(' ')
+		}
+		b.WriteRune(d)
+	}
+
+	return sign + b.String() + "." + frac
+}
+
+// Mul returns the amount times n.
+func (a Amount) Mul(n int) Amount {
+	return a * Amount(n)
+}
+
+// Percent returns percent of the amount rounded for the currency.
+func (a Amount) Percent(percent float64, c Currency) Amount {
+	rate, ok := new(big.Rat).SetString(strconv.FormatFloat(percent, 'f', -1, 64))
+	if !ok {
+		return 0
+	}
+
+	return ratio(a, rate.Quo(rate, big.NewRat(100, 1)), c)
+}
+
+// IncludedTax returns the tax at rate percent contained in the amount, for
+// prices that include the tax, rounded for the currency.
+func (a Amount) IncludedTax(rate float64, c Currency) Amount {
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(rate, 'f', -1, 64))
+	if !ok {
+		return 0
+	}
+
+	return ratio(a, r.Quo(r, new(big.Rat).Add(r, big.NewRat(100, 1))), c)
+}
+
+// Share returns part/whole of the amount rounded for the currency.
+func (a Amount) Share(part, whole int, c Currency) Amount {
+	if whole == 0 {
+		return 0
+	}
+
+	return ratio(a, big.NewRat(int64(part), int64(whole)), c)
+}
+
+// Round rounds the amount half away from zero to the step of the currency.
+func (a Amount) Round(c Currency) Amount {
+	return ratio(a, big.NewRat(1, 1), c)
+}
+
+// Min returns the smaller amount.
+func Min(a, b Amount) Amount {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func ratio(a Amount, rate *big.Rat, c Currency) Amount {
+	step := c.Step
+	if step <= 0 {
+		step = 1
+	}
+
+	// a * rate / step rounded half away from zero, times step
+	v := new(big.Rat).Mul(new(big.Rat).SetInt64(int64(a)), rate)
+	v.Quo(v, new(big.Rat).SetInt64(step))
+
+	num, den := v.Num(), v.Denom()
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() != 0 && new(big.Int).Abs(new(big.Int).Lsh(r, 1)).Cmp(den) >= 0 {
+		q.Add(q, big.NewInt(int64(num.Sign())))
+	}
+
+	return Amount(q.Int64() * step)
+}
+
+func digits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Value stores the amount as a decimal string.
+func (a Amount) Value() (driver.Value, error) {
+	return a.String(), nil
+}
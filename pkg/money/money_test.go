@@ -0,0 +1,300 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+var (
+	usd = Currency{Code: "USD", Step: 1}
+	uzs = Currency{Code: "UZS", Step: 100}
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Amount
+	}{
+		{"12900.10", 1290010},
+		{"12900.1", 1290010},
+		{"12900", 1290000},
+		{"0.01", 1},
+		{".5", 50},
+		{"5.", 500},
+		{"-3", -300},
+		{"+3.5", 350},
+		{" 7 ", 700},
+		{"0", 0},
+	}
+
+	for _, tt := range tests {
+		got, err := Parse(tt.in)
+		if err != nil {
+			t.Errorf("Parse(%q) error = %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Parse(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []string{"", "-", ".", "1.234", "1e3", "abc", "1,5", "1.-5", "99999999999999999999"} {
+		if _, err := Parse(in); !errors.Is(err, ErrInvalid) {
+			t.Errorf("Parse(%q) error = %v, want ErrInvalid", in, err)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		in      Amount
+		want    string
+		grouped string
+	}{
+		{1290010, "12900.10", "12 900.10"},
+		{-1290010, "-12900.10", "-12 900.10"},
+		{5, "0.05", "0.05"},
+		{100000000, "1000000.00", "1 000 000.00"},
+		{0, "0.00", "0.00"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.in.String(); got != tt.want {
+			t.Errorf("Amount(%d).String() = %q, want %q", tt.in, got, tt.want)
+		}
+		if got := tt.in.Grouped(); got != tt.grouped {
+			t.Errorf("Amount(%d).Grouped() = %q, want %q", tt.in, got, tt.grouped)
+		}
+	}
+}
+
+// Three items of 12 900.10 are 38 700.30 exactly, where float64 would
+// give 38700.299999999996.
+func TestMulIsExact(t *testing.T) {
+	price, err := Parse("12900.10")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	total := price.Mul(3)
+	if total != 3870030 || total.String() != "38700.30" {
+		t.Errorf("3 × 12900.10 = %s, want 38700.30", total)
+	}
+}
+
+func TestPercent(t *testing.T) {
+	tests := []struct {
+		amount   Amount
+		percent  float64
+		currency Currency
+		want     Amount
+	}{
+		{10000, 10, usd, 1000},
+		{12345, 15, usd, 1852},   // 18.5175 rounds up
+		{-12345, 15, usd, -1852}, // half away from zero
+		{12345, 12.5, usd, 1543}, // 15.43125
+		{2469000, 15, uzs, 370400},
+		{2469000, 15, usd, 370350},
+		{1290010, 0.1, uzs, 1300}, // 12.90010 so'm is 13
+		{10000, 0, usd, 0},
+		{10000, 100, usd, 10000},
+	}
+
+	for _, tt := range tests {
+		if got := tt.amount.Percent(tt.percent, tt.currency); got != tt.want {
+			t.Errorf("%s.Percent(%v, %s) = %s, want %s", tt.amount, tt.percent, tt.currency.Code, got, tt.want)
+		}
+	}
+}
+
+func TestShare(t *testing.T) {
+	tests := []struct {
+		amount      Amount
+		part, whole int
+		currency    Currency
+		want        Amount
+	}{
+		{10000, 1, 3, usd, 3333},
+		{10000, 2, 3, usd, 6667},
+		{10000, 1, 3, uzs, 3300},
+		{10000, 2, 3, uzs, 6700},
+		{3870030, 1, 3, usd, 1290010},
+		{3870030, 1, 3, uzs, 1290000},
+		{-10000, 2, 3, usd, -6667},
+		{10000, 1, 0, usd, 0},
+	}
+
+	for _, tt := range tests {
+		if got := tt.amount.Share(tt.part, tt.whole, tt.currency); got != tt.want {
+			t.Errorf("%s.Share(%d, %d, %s) = %s, want %s", tt.amount, tt.part, tt.whole, tt.currency.Code, got, tt.want)
+		}
+	}
+}
+
+func TestIncludedTax(t *testing.T) {
+	tests := []struct {
+		amount   Amount
+		rate     float64
+		currency Currency
+		want     Amount
+	}{
+		{1120000, 12, usd, 120000},
+		{1120000, 12, uzs, 120000},
+		{1290010, 12, usd, 138215}, // 1382.15357...
+		{1290010, 12, uzs, 138200},
+		{1290050, 0, uzs, 0},
+		{-1120000, 12, usd, -120000},
+	}
+
+	for _, tt := range tests {
+		if got := tt.amount.IncludedTax(tt.rate, tt.currency); got != tt.want {
+			t.Errorf("%s.IncludedTax(%v, %s) = %s, want %s", tt.amount, tt.rate, tt.currency.Code, got, tt.want)
+		}
+	}
+}
+
+func TestRound(t *testing.T) {
+	tests := []struct {
+		amount   Amount
+		currency Currency
+		want     Amount
+	}{
+		{1290010, uzs, 1290000},
+		{1290049, uzs, 1290000},
+		{1290050, uzs, 1290100},
+		{-1290050, uzs, -1290100},
+		{1290010, usd, 1290010},
+		{1290010, Currency{}, 1290010},
+	}
+
+	for _, tt := range tests {
+		if got := tt.amount.Round(tt.currency); got != tt.want {
+			t.Errorf("%s.Round(%s) = %s, want %s", tt.amount, tt.currency.Code, got, tt.want)
+		}
+	}
+}
+
+func TestLookupCurrency(t *testing.T) {
+	c, ok := LookupCurrency("uzs")
+	if !ok || c != uzs {
+		t.Errorf("LookupCurrency(uzs) = %v, %v, want %v", c, ok, uzs)
+	}
+
+	if _, ok = LookupCurrency("XYZ"); ok {
+		t.Errorf("LookupCurrency(XYZ) found a currency")
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	defer SetEncoding(encoding)
+
+	tests := []struct {
+		in     Amount
+		number string
+		str    string
+	}{
+		{1290010, `12900.1`, `"12900.10"`},
+		{3870030, `38700.3`, `"38700.30"`},
+		{300, `3`, `"3.00"`},
+		{1000, `10`, `"10.00"`},
+		{5, `0.05`, `"0.05"`},
+		{-50, `-0.5`, `"-0.50"`},
+		{0, `0`, `"0.00"`},
+	}
+
+	for _, tt := range tests {
+		SetEncoding(Number)
+		if got, _ := json.Marshal(tt.in); string(got) != tt.number {
+			t.Errorf("number encoding of %d = %s, want %s", tt.in, got, tt.number)
+		}
+
+		SetEncoding(String)
+		if got, _ := json.Marshal(tt.in); string(got) != tt.str {
+			t.Errorf("string encoding of %d = %s, want %s", tt.in, got, tt.str)
+		}
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Amount
+	}{
+		{`12900.1`, 1290010},
+		{`"12900.10"`, 1290010},
+		{`0.1`, 10},
+		{`"-3"`, -300},
+		{`38700.3`, 3870030},
+	}
+
+	for _, tt := range tests {
+		var got Amount
+		if err := json.Unmarshal([]byte(tt.in), &got); err != nil {
+			t.Errorf("Unmarshal(%s) error = %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Unmarshal(%s) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+
+	got := Amount(42)
+	if err := json.Unmarshal([]byte(`null`), &got); err != nil || got != 42 {
+		t.Errorf("Unmarshal(null) = %d, %v, want 42 untouched", got, err)
+	}
+
+	for _, in := range []string{`1.234`, `"abc"`, `1e3`, `true`} {
+		if err := json.Unmarshal([]byte(in), &got); err == nil {
+			t.Errorf("Unmarshal(%s) did not fail", in)
+		}
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	defer SetEncoding(encoding)
+
+	type line struct {
+		Price Amount `json:"price"`
+	}
+
+	for _, enc := range []Encoding{Number, String} {
+		SetEncoding(enc)
+
+		for _, want := range []Amount{0, 1, 10, 1000, 1290010, 3870030, -50, -1290010} {
+			data, err := json.Marshal(line{Price: want})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got line
+			if err = json.Unmarshal(data, &got); err != nil {
+				t.Errorf("Unmarshal(%s) error = %v", data, err)
+				continue
+			}
+			if got.Price != want {
+				t.Errorf("round trip of %d through %s = %d", want, data, got.Price)
+			}
+		}
+	}
+}
+
+func TestParseEncoding(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Encoding
+		ok   bool
+	}{
+		{"", Number, true},
+		{"number", Number, true},
+		{"string", String, true},
+		{"float", Number, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := ParseEncoding(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ParseEncoding(%q) = %v, %v, want %v, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
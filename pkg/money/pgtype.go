@@ -0,0 +1,61 @@
+package money
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/jackc/pgtype"
+)
+
+// EncodeText lets pgx send the amount as a numeric parameter.
+func (a Amount) EncodeText(_ *pgtype.ConnInfo, buf []byte) ([]byte, error) {
+	return append(buf, a.String()...), nil
+}
+
+// DecodeText scans a numeric column.
+func (a *Amount) DecodeText(ci *pgtype.ConnInfo, src []byte) error {
+	var n pgtype.Numeric
+	if err := n.DecodeText(ci, src); err != nil {
+		return err
+	}
+	return a.setNumeric(n)
+}
+
+// DecodeBinary scans a numeric column.
+func (a *Amount) DecodeBinary(ci *pgtype.ConnInfo, src []byte) error {
+	var n pgtype.Numeric
+	if err := n.DecodeBinary(ci, src); err != nil {
+		return err
+	}
+	return a.setNumeric(n)
+}
+
+// setNumeric rounds values with more than two decimals half away from zero.
+func (a *Amount) setNumeric(n pgtype.Numeric) error {
+	if n.Status != pgtype.Present {
+		return errors.New("money: cannot scan NULL")
+	}
+	if n.NaN || n.InfinityModifier != pgtype.None {
+		return ErrInvalid
+	}
+
+	v := new(big.Int).Set(n.Int)
+	exp := int64(n.Exp) + 2
+	if exp >= 0 {
+		v.Mul(v, new(big.Int).Exp(big.NewInt(10), big.NewInt(exp), nil))
+	} else {
+		den := new(big.Int).Exp(big.NewInt(10), big.NewInt(-exp), nil)
+		r := new(big.Int)
+		v.QuoRem(v, den, r)
+		if new(big.Int).Abs(r.Lsh(r, 1)).Cmp(den) >= 0 {
+			v.Add(v, big.NewInt(int64(n.Int.Sign())))
+		}
+	}
+
+	if !v.IsInt64() {
+		return ErrInvalid
+	}
+
+	*a = Amount(v.Int64())
+	return nil
+}
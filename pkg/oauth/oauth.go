@@ -0,0 +1,183 @@
+// Package oauth implements social login providers: OpenID Connect authorization
+// code flow with PKCE (Google, Apple, any OIDC issuer) and the Telegram login widget.
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+var (
+	ErrMissingIDToken = errors.New("oauth: token response has no id_token")
+	ErrInvalidNonce   = errors.New("oauth: id_token nonce mismatch")
+)
+
+// Identity is the external account returned by a provider.
+type Identity struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// Provider is an authorization code provider.
+type Provider interface {
+	Name() string
+	// AuthCodeURL returns the URL the user is sent to. verifier is the PKCE code verifier.
+	AuthCodeURL(state, verifier, nonce string) string
+	// Exchange trades the code for tokens and returns the verified identity.
+	Exchange(ctx context.Context, code, verifier, nonce string) (Identity, error)
+}
+
+// OIDCConfig describes a generic OpenID Connect provider.
+type OIDCConfig struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	// ClientSecretFunc, when set, is called on every exchange instead of using ClientSecret.
+	ClientSecretFunc func() (string, error)
+	// AuthParams are added to the authorization URL.
+	AuthParams map[string]string
+}
+
+type oidcProvider struct {
+	cfg      OIDCConfig
+	oauth2   oauth2.Config
+	verifier *oidc.IDTokenVerifier
+}
+
+// NewOIDCProvider discovers the issuer configuration and returns a provider for it.
+func NewOIDCProvider(ctx context.Context, cfg OIDCConfig) (Provider, error) {
+	provider, err := oidc.NewProvider(ctx, cfg.Issuer)
+	if err != nil {
+		return nil, fmt.Errorf("oauth - NewOIDCProvider - %s: %w", cfg.Name, err)
+	}
+
+	scopes := cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{oidc.ScopeOpenID, "email", "profile"}
+	}
+
+	return &oidcProvider{
+		cfg: cfg,
+		oauth2: oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Endpoint:     provider.Endpoint(),
+			Scopes:       scopes,
+		},
+		verifier: provider.Verifier(&oidc.Config{ClientID: cfg.ClientID}),
+	}, nil
+}
+
+func (p *oidcProvider) Name() string {
+	return p.cfg.Name
+}
+
+func (p *oidcProvider) AuthCodeURL(state, verifier, nonce string) string {
+	opts := []oauth2.AuthCodeOption{
+		oauth2.S256ChallengeOption(verifier),
+		oidc.Nonce(nonce),
+	}
+
+	for key, value := range p.cfg.AuthParams {
+		opts = append(opts, oauth2.SetAuthURLParam(key, value))
+	}
+
+	return p.oauth2.AuthCodeURL(state, opts...)
+}
+
+func (p *oidcProvider) Exchange(ctx context.Context, code, verifier, nonce string) (Identity, error) {
+	conf := p.oauth2
+	if p.cfg.ClientSecretFunc != nil {
+		secret, err := p.cfg.ClientSecretFunc()
+		if err != nil {
+			return Identity{}, fmt.Errorf("oauth - Exchange - client secret: %w", err)
+		}
+		conf.ClientSecret = secret
+	}
+
+	token, err := conf.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return Identity{}, fmt.Errorf("oauth - Exchange - %s: %w", p.cfg.Name, err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return Identity{}, ErrMissingIDToken
+	}
+
+	idToken, err := p.verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return Identity{}, fmt.Errorf("oauth - Exchange - verify id_token: %w", err)
+	}
+
+	if idToken.Nonce != nonce {
+		return Identity{}, ErrInvalidNonce
+	}
+
+	var claims struct {
+		Email         string   `json:"email"`
+		EmailVerified flexBool `json:"email_verified"`
+		Name          string   `json:"name"`
+	}
+	if err = idToken.Claims(&claims); err != nil {
+		return Identity{}, fmt.Errorf("oauth - Exchange - claims: %w", err)
+	}
+
+	return Identity{
+		Provider:      p.cfg.Name,
+		Subject:       idToken.Subject,
+		Email:         claims.Email,
+		EmailVerified: bool(claims.EmailVerified),
+		Name:          claims.Name,
+	}, nil
+}
+
+// GenerateVerifier returns a PKCE code verifier.
+func GenerateVerifier() string {
+	return oauth2.GenerateVerifier()
+}
+
+// RandomString returns a hex encoded random string used for state and nonce values.
+func RandomString() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(buf), nil
+}
+
+// flexBool accepts both JSON booleans and the "true"/"false" strings Apple sends.
+type flexBool bool
+
+func (b *flexBool) UnmarshalJSON(data []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	switch value := v.(type) {
+	case bool:
+		*b = flexBool(value)
+	case string:
+		parsed, _ := strconv.ParseBool(value)
+		*b = flexBool(parsed)
+	}
+
+	return nil
+}
@@ -0,0 +1,330 @@
+package oauth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// fakeIssuer is a minimal OpenID Connect provider: discovery, JWKS and a token
+// endpoint that checks PKCE and returns an RS256 signed id_token.
+type fakeIssuer struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	// codes maps an issued code to the PKCE challenge and nonce of its authorization request.
+	codes map[string]fakeGrant
+	// claims are added to every id_token.
+	claims jwt.MapClaims
+}
+
+type fakeGrant struct {
+	challenge string
+	nonce     string
+}
+
+func newFakeIssuer(t *testing.T) *fakeIssuer {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := &fakeIssuer{key: key, codes: map[string]fakeGrant{}, claims: jwt.MapClaims{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{
+			"issuer":                                f.server.URL,
+			"authorization_endpoint":                f.server.URL + "/authorize",
+			"token_endpoint":                        f.server.URL + "/token",
+			"jwks_uri":                              f.server.URL + "/jwks",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"alg": "RS256",
+				"use": "sig",
+				"kid": "test",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", f.token)
+
+	f.server = httptest.NewServer(mux)
+	t.Cleanup(f.server.Close)
+
+	return f
+}
+
+func (f *fakeIssuer) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	grant, ok := f.codes[r.PostForm.Get("code")]
+	if !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		writeJSON(w, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if base64.RawURLEncoding.EncodeToString(sum[:]) != grant.challenge {
+		w.WriteHeader(http.StatusBadRequest)
+		writeJSON(w, map[string]string{"error": "invalid_grant", "error_description": "PKCE verification failed"})
+		return
+	}
+
+	claims := jwt.MapClaims{
+		"iss":   f.server.URL,
+		"sub":   "subject-1",
+		"aud":   "client-1",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"iat":   time.Now().Unix(),
+		"nonce": grant.nonce,
+	}
+	for key, value := range f.claims {
+		claims[key] = value
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = "test"
+
+	idToken, err := token.SignedString(f.key)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"access_token": "access",
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     idToken,
+	})
+}
+
+// authorize plays the user agreeing on the consent page: it reads the PKCE
+// challenge and nonce from the authorization URL and returns a code.
+func (f *fakeIssuer) authorize(t *testing.T, authURL string) string {
+	t.Helper()
+
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	query := u.Query()
+	if query.Get("code_challenge_method") != "S256" {
+		t.Fatalf("code_challenge_method = %q, want S256", query.Get("code_challenge_method"))
+	}
+
+	code := "code-" + query.Get("state")
+	f.codes[code] = fakeGrant{challenge: query.Get("code_challenge"), nonce: query.Get("nonce")}
+
+	return code
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func newTestProvider(t *testing.T, issuer *fakeIssuer) Provider {
+	t.Helper()
+
+	provider, err := NewOIDCProvider(context.Background(), OIDCConfig{
+		Name:         "fake",
+		Issuer:       issuer.server.URL,
+		ClientID:     "client-1",
+		ClientSecret: "secret",
+		RedirectURL:  "http://localhost/callback",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return provider
+}
+
+func TestExchange(t *testing.T) {
+	issuer := newFakeIssuer(t)
+	issuer.claims["email"] = "user@example.com"
+	issuer.claims["email_verified"] = true
+	issuer.claims["name"] = "Test User"
+	provider := newTestProvider(t, issuer)
+
+	verifier := GenerateVerifier()
+	code := issuer.authorize(t, provider.AuthCodeURL("state-1", verifier, "nonce-1"))
+
+	identity, err := provider.Exchange(context.Background(), code, verifier, "nonce-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Identity{Provider: "fake", Subject: "subject-1", Email: "user@example.com", EmailVerified: true, Name: "Test User"}
+	if identity != want {
+		t.Fatalf("identity = %+v, want %+v", identity, want)
+	}
+}
+
+func TestExchangeStringEmailVerified(t *testing.T) {
+	// Apple sends email_verified as a string.
+	issuer := newFakeIssuer(t)
+	issuer.claims["email"] = "user@privaterelay.appleid.com"
+	issuer.claims["email_verified"] = "true"
+	provider := newTestProvider(t, issuer)
+
+	verifier := GenerateVerifier()
+	code := issuer.authorize(t, provider.AuthCodeURL("state-1", verifier, "nonce-1"))
+
+	identity, err := provider.Exchange(context.Background(), code, verifier, "nonce-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !identity.EmailVerified {
+		t.Fatal("email_verified \"true\" was not accepted")
+	}
+}
+
+func TestExchangeWrongVerifier(t *testing.T) {
+	issuer := newFakeIssuer(t)
+	provider := newTestProvider(t, issuer)
+
+	code := issuer.authorize(t, provider.AuthCodeURL("state-1", GenerateVerifier(), "nonce-1"))
+
+	if _, err := provider.Exchange(context.Background(), code, GenerateVerifier(), "nonce-1"); err == nil {
+		t.Fatal("exchange with a different PKCE verifier succeeded")
+	}
+}
+
+func TestExchangeWrongNonce(t *testing.T) {
+	issuer := newFakeIssuer(t)
+	provider := newTestProvider(t, issuer)
+
+	verifier := GenerateVerifier()
+	code := issuer.authorize(t, provider.AuthCodeURL("state-1", verifier, "nonce-1"))
+
+	_, err := provider.Exchange(context.Background(), code, verifier, "nonce-2")
+	if !errors.Is(err, ErrInvalidNonce) {
+		t.Fatalf("err = %v, want ErrInvalidNonce", err)
+	}
+}
+
+func TestExchangeWrongAudience(t *testing.T) {
+	issuer := newFakeIssuer(t)
+	issuer.claims["aud"] = "another-client"
+	provider := newTestProvider(t, issuer)
+
+	verifier := GenerateVerifier()
+	code := issuer.authorize(t, provider.AuthCodeURL("state-1", verifier, "nonce-1"))
+
+	if _, err := provider.Exchange(context.Background(), code, verifier, "nonce-1"); err == nil {
+		t.Fatal("id_token issued for another client was accepted")
+	}
+}
+
+func TestAuthCodeURLParams(t *testing.T) {
+	issuer := newFakeIssuer(t)
+
+	provider, err := NewOIDCProvider(context.Background(), OIDCConfig{
+		Name:       "fake",
+		Issuer:     issuer.server.URL,
+		ClientID:   "client-1",
+		AuthParams: map[string]string{"response_mode": "form_post"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	u, err := url.Parse(provider.AuthCodeURL("state-1", GenerateVerifier(), "nonce-1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	query := u.Query()
+	if query.Get("response_mode") != "form_post" || query.Get("state") != "state-1" || query.Get("nonce") != "nonce-1" {
+		t.Fatalf("unexpected authorization url query: %v", query)
+	}
+}
+
+func signTelegram(botToken string, data map[string]string) string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	lines := make([]string, 0, len(keys))
+	for _, key := range keys {
+		lines = append(lines, key+"="+data[key])
+	}
+
+	secret := sha256.Sum256([]byte(botToken))
+	mac := hmac.New(sha256.New, secret[:])
+	mac.Write([]byte(strings.Join(lines, "\n")))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifyTelegram(t *testing.T) {
+	const botToken = "123456:bot-token"
+
+	now := time.Unix(1700000000, 0)
+	data := map[string]string{
+		"id":         "42",
+		"first_name": "Ali",
+		"username":   "ali",
+		"auth_date":  "1699999000",
+	}
+	data["hash"] = signTelegram(botToken, data)
+
+	identity, err := VerifyTelegram(botToken, data, time.Hour, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if identity.Subject != "42" || identity.Provider != "telegram" || identity.Name != "Ali" || identity.EmailVerified {
+		t.Fatalf("unexpected identity %+v", identity)
+	}
+
+	tampered := map[string]string{}
+	for key, value := range data {
+		tampered[key] = value
+	}
+	tampered["id"] = "43"
+	if _, err = VerifyTelegram(botToken, tampered, time.Hour, now); !errors.Is(err, ErrInvalidTelegramHash) {
+		t.Fatalf("tampered data: err = %v, want ErrInvalidTelegramHash", err)
+	}
+
+	if _, err = VerifyTelegram("another:token", data, time.Hour, now); !errors.Is(err, ErrInvalidTelegramHash) {
+		t.Fatalf("wrong bot token: err = %v, want ErrInvalidTelegramHash", err)
+	}
+
+	if _, err = VerifyTelegram(botToken, data, time.Minute, now); !errors.Is(err, ErrTelegramAuthExpired) {
+		t.Fatalf("old auth_date: err = %v, want ErrTelegramAuthExpired", err)
+	}
+}
@@ -0,0 +1,91 @@
+package oauth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	GoogleIssuer = "https://accounts.google.com"
+	AppleIssuer  = "https://appleid.apple.com"
+)
+
+// NewGoogle returns the Google provider.
+func NewGoogle(ctx context.Context, clientID, clientSecret, redirectURL string) (Provider, error) {
+	return NewOIDCProvider(ctx, OIDCConfig{
+		Name:         "google",
+		Issuer:       GoogleIssuer,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		RedirectURL:  redirectURL,
+	})
+}
+
+// AppleConfig -.
+type AppleConfig struct {
+	// ClientID is the Services ID.
+	ClientID string
+	TeamID   string
+	KeyID    string
+	// PrivateKey is the PEM encoded .p8 key downloaded from the Apple developer account.
+	PrivateKey  string
+	RedirectURL string
+}
+
+// NewApple returns the Sign in with Apple provider. Apple wants the client
+// secret to be a short lived JWT signed with the team key, and posts the
+// callback as a form when the email scope is requested.
+func NewApple(ctx context.Context, cfg AppleConfig) (Provider, error) {
+	key, err := parseECPrivateKey(cfg.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("oauth - NewApple: %w", err)
+	}
+
+	return NewOIDCProvider(ctx, OIDCConfig{
+		Name:        "apple",
+		Issuer:      AppleIssuer,
+		ClientID:    cfg.ClientID,
+		RedirectURL: cfg.RedirectURL,
+		Scopes:      []string{"openid", "email", "name"},
+		AuthParams:  map[string]string{"response_mode": "form_post"},
+		ClientSecretFunc: func() (string, error) {
+			now := time.Now()
+			token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.RegisteredClaims{
+				Issuer:    cfg.TeamID,
+				Subject:   cfg.ClientID,
+				Audience:  jwt.ClaimStrings{AppleIssuer},
+				IssuedAt:  jwt.NewNumericDate(now),
+				ExpiresAt: jwt.NewNumericDate(now.Add(5 * time.Minute)),
+			})
+			token.Header["kid"] = cfg.KeyID
+
+			return token.SignedString(key)
+		},
+	})
+}
+
+func parseECPrivateKey(data string) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, errors.New("private key is not PEM encoded")
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	key, ok := parsed.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not an ECDSA key")
+	}
+
+	return key, nil
+}
@@ -0,0 +1,64 @@
+package oauth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidTelegramHash = errors.New("oauth: telegram data hash mismatch")
+	ErrTelegramAuthExpired = errors.New("oauth: telegram auth_date is too old")
+)
+
+// VerifyTelegram checks the payload of the Telegram login widget as described in
+// https://core.telegram.org/widgets/login#checking-authorization and returns the identity.
+// Telegram does not share e-mail addresses, so the identity never has a verified email.
+func VerifyTelegram(botToken string, data map[string]string, maxAge time.Duration, now time.Time) (Identity, error) {
+	received, err := hex.DecodeString(data["hash"])
+	if err != nil || len(received) == 0 {
+		return Identity{}, ErrInvalidTelegramHash
+	}
+
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		if key != "hash" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	lines := make([]string, 0, len(keys))
+	for _, key := range keys {
+		lines = append(lines, key+"="+data[key])
+	}
+
+	secret := sha256.Sum256([]byte(botToken))
+	mac := hmac.New(sha256.New, secret[:])
+	mac.Write([]byte(strings.Join(lines, "\n")))
+
+	if !hmac.Equal(mac.Sum(nil), received) {
+		return Identity{}, ErrInvalidTelegramHash
+	}
+
+	authDate, err := strconv.ParseInt(data["auth_date"], 10, 64)
+	if err != nil || now.Sub(time.Unix(authDate, 0)) > maxAge {
+		return Identity{}, ErrTelegramAuthExpired
+	}
+
+	name := strings.TrimSpace(data["first_name"] + " " + data["last_name"])
+	if name == "" {
+		name = data["username"]
+	}
+
+	return Identity{
+		Provider: "telegram",
+		Subject:  data["id"],
+		Name:     name,
+	}, nil
+}
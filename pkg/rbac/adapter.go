@@ -0,0 +1,191 @@
+package rbac
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/Akrom0181/Food-Delivery/pkg/postgres"
+	"github.com/Masterminds/squirrel"
+	"github.com/casbin/casbin/model"
+)
+
+const ruleTable = "casbin_rule"
+
+var ruleColumns = []string{"v0", "v1", "v2", "v3", "v4", "v5"}
+
+// Adapter stores casbin rules in the casbin_rule table.
+type Adapter struct {
+	pg *postgres.Postgres
+}
+
+// NewAdapter -.
+func NewAdapter(pg *postgres.Postgres) *Adapter {
+	return &Adapter{pg: pg}
+}
+
+// LoadPolicy loads all rules into the model.
+func (a *Adapter) LoadPolicy(m model.Model) error {
+	query, args, err := a.pg.Builder.
+		Select("ptype, v0, v1, v2, v3, v4, v5").
+		From(ruleTable).
+		OrderBy("created_at", "id").ToSql()
+	if err != nil {
+		return err
+	}
+
+	rows, err := a.pg.Pool.Query(context.Background(), query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			ptype string
+			v     [6]string
+		)
+
+		err = rows.Scan(&ptype, &v[0], &v[1], &v[2], &v[3], &v[4], &v[5])
+		if err != nil {
+			return err
+		}
+
+		loadRule(m, ptype, trimRule(v[:]))
+	}
+
+	return rows.Err()
+}
+
+// SavePolicy replaces all stored rules with the ones in the model.
+func (a *Adapter) SavePolicy(m model.Model) error {
+	ctx := context.Background()
+
+	tx, err := a.pg.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query, args, err := a.pg.Builder.Delete(ruleTable).ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec(ctx, query, args...); err != nil {
+		return err
+	}
+
+	for _, sec := range []string{"p", "g"} {
+		for ptype, ast := range m[sec] {
+			for _, rule := range ast.Policy {
+				query, args, err = a.insert(ptype, rule).ToSql()
+				if err != nil {
+					return err
+				}
+
+				if _, err = tx.Exec(ctx, query, args...); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// AddPolicy -.
+func (a *Adapter) AddPolicy(sec string, ptype string, rule []string) error {
+	query, args, err := a.insert(ptype, rule).Suffix("ON CONFLICT DO NOTHING").ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = a.pg.Pool.Exec(context.Background(), query, args...)
+
+	return err
+}
+
+// RemovePolicy -.
+func (a *Adapter) RemovePolicy(sec string, ptype string, rule []string) error {
+	if len(rule) > len(ruleColumns) {
+		return errors.New("rbac: rule has too many fields")
+	}
+
+	where := squirrel.Eq{"ptype": ptype}
+	for i, column := range ruleColumns {
+		value := ""
+		if i < len(rule) {
+			value = rule[i]
+		}
+		where[column] = value
+	}
+
+	return a.delete(where)
+}
+
+// RemoveFilteredPolicy -.
+func (a *Adapter) RemoveFilteredPolicy(sec string, ptype string, fieldIndex int, fieldValues ...string) error {
+	if fieldIndex < 0 || fieldIndex+len(fieldValues) > len(ruleColumns) {
+		return errors.New("rbac: invalid filter")
+	}
+
+	where := squirrel.Eq{"ptype": ptype}
+	for i, value := range fieldValues {
+		if value != "" {
+			where[ruleColumns[fieldIndex+i]] = value
+		}
+	}
+
+	return a.delete(where)
+}
+
+func (a *Adapter) insert(ptype string, rule []string) squirrel.InsertBuilder {
+	values := []interface{}{ptype}
+	for i := range ruleColumns {
+		value := ""
+		if i < len(rule) {
+			value = rule[i]
+		}
+		values = append(values, value)
+	}
+
+	return a.pg.Builder.Insert(ruleTable).
+		Columns("ptype, v0, v1, v2, v3, v4, v5").
+		Values(values...)
+}
+
+func (a *Adapter) delete(where squirrel.Eq) error {
+	query, args, err := a.pg.Builder.Delete(ruleTable).Where(where).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = a.pg.Pool.Exec(context.Background(), query, args...)
+
+	return err
+}
+
+// loadRule adds a rule to the model, skipping rule types the model does not define.
+func loadRule(m model.Model, ptype string, rule []string) {
+	if ptype == "" {
+		return
+	}
+
+	ast, ok := m[ptype[:1]][ptype]
+	if !ok {
+		return
+	}
+
+	ast.Policy = append(ast.Policy, rule)
+}
+
+// trimRule drops the empty trailing fields.
+func trimRule(values []string) []string {
+	n := len(values)
+	for n > 0 && strings.TrimSpace(values[n-1]) == "" {
+		n--
+	}
+
+	return values[:n]
+}
@@ -0,0 +1,128 @@
+// Package rbac keeps the casbin policy in postgres and reloads it on every
+// replica when it changes.
+package rbac
+
+import (
+	"fmt"
+	"regexp"
+	"sync/atomic"
+
+	"github.com/Akrom0181/Food-Delivery/pkg/logger"
+	"github.com/Akrom0181/Food-Delivery/pkg/postgres"
+	"github.com/casbin/casbin"
+	"github.com/casbin/casbin/util"
+)
+
+// Enforcer is a casbin enforcer whose policy is swapped as a whole on reload,
+// so a failed reload keeps serving the previous policy.
+type Enforcer struct {
+	modelPath string
+	adapter   *Adapter
+	watcher   *Watcher
+	logger    *logger.Logger
+
+	current atomic.Pointer[casbin.Enforcer]
+}
+
+// Route is a registered http route.
+type Route struct {
+	Method string
+	Path   string
+}
+
+// NewEnforcer loads the policy and starts watching for changes made by other replicas.
+func NewEnforcer(modelPath string, pg *postgres.Postgres, url string, l *logger.Logger) (*Enforcer, error) {
+	e := &Enforcer{
+		modelPath: modelPath,
+		adapter:   NewAdapter(pg),
+		logger:    l,
+	}
+
+	if err := e.Reload(); err != nil {
+		return nil, err
+	}
+
+	e.watcher = NewWatcher(url, pg.Pool, func(err error) {
+		l.Error(fmt.Errorf("rbac - Watcher: %w", err))
+	})
+
+	_ = e.watcher.SetUpdateCallback(func(string) {
+		if err := e.Reload(); err != nil {
+			l.Error(fmt.Errorf("rbac - Reload: %w", err))
+		}
+	})
+
+	return e, nil
+}
+
+// Reload reads the policy from the database.
+func (e *Enforcer) Reload() error {
+	enforcer, err := casbin.NewEnforcerSafe(e.modelPath, false)
+	if err != nil {
+		return err
+	}
+
+	enforcer.SetAdapter(e.adapter)
+
+	if err = enforcer.LoadPolicy(); err != nil {
+		return err
+	}
+
+	e.current.Store(enforcer)
+
+	return nil
+}
+
+// Update reloads the local policy and tells the other replicas to do the same.
+// It is called after the casbin_rule table was changed.
+func (e *Enforcer) Update() error {
+	if err := e.Reload(); err != nil {
+		return err
+	}
+
+	return e.watcher.Update()
+}
+
+// EnforceSafe -.
+func (e *Enforcer) EnforceSafe(sub, obj, act string) (bool, error) {
+	return e.current.Load().EnforceSafe(sub, obj, act)
+}
+
+// Unmatched returns the p rules whose path and methods match none of the routes.
+func (e *Enforcer) Unmatched(routes []Route) [][]string {
+	var unmatched [][]string
+
+	for _, rule := range e.current.Load().GetPolicy() {
+		if len(rule) < 3 {
+			unmatched = append(unmatched, rule)
+			continue
+		}
+
+		methods, err := regexp.Compile(rule[2])
+		if err != nil {
+			unmatched = append(unmatched, rule)
+			continue
+		}
+
+		matched := false
+		for _, route := range routes {
+			if util.KeyMatch(route.Path, rule[1]) && methods.MatchString(route.Method) {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			unmatched = append(unmatched, rule)
+		}
+	}
+
+	return unmatched
+}
+
+// Close stops watching for changes.
+func (e *Enforcer) Close() {
+	if e.watcher != nil {
+		e.watcher.Close()
+	}
+}
@@ -0,0 +1,143 @@
+package rbac
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+const (
+	notifyChannel   = "casbin_policy"
+	_reconnectDelay = 5 * time.Second
+	_notifyTimeout  = 5 * time.Second
+	_listenTimeout  = 10 * time.Second
+)
+
+// Watcher tells the other replicas that the policy changed using postgres LISTEN/NOTIFY.
+// It implements persist.Watcher.
+type Watcher struct {
+	url  string
+	pool *pgxpool.Pool
+	// id marks notifications sent by this instance so they are not handled twice.
+	id string
+
+	mu       sync.Mutex
+	callback func(string)
+	onError  func(error)
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewWatcher starts listening on a dedicated connection, so the pool keeps all of its
+// connections for queries. Notifications are sent through the pool.
+func NewWatcher(url string, pool *pgxpool.Pool, onError func(error)) *Watcher {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	w := &Watcher{
+		url:     url,
+		pool:    pool,
+		id:      uuid.NewString(),
+		onError: onError,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+
+	go w.listen(ctx)
+
+	return w
+}
+
+// SetUpdateCallback -.
+func (w *Watcher) SetUpdateCallback(callback func(string)) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.callback = callback
+
+	return nil
+}
+
+// Update notifies the other instances.
+func (w *Watcher) Update() error {
+	ctx, cancel := context.WithTimeout(context.Background(), _notifyTimeout)
+	defer cancel()
+
+	_, err := w.pool.Exec(ctx, "SELECT pg_notify($1, $2)", notifyChannel, w.id)
+
+	return err
+}
+
+// Close stops listening.
+func (w *Watcher) Close() {
+	w.cancel()
+	<-w.done
+}
+
+func (w *Watcher) listen(ctx context.Context) {
+	defer close(w.done)
+
+	for {
+		err := w.listenOnce(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		w.reportError(err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(_reconnectDelay):
+		}
+
+		// changes made while the connection was down were missed
+		w.notify("reconnect")
+	}
+}
+
+func (w *Watcher) listenOnce(ctx context.Context) error {
+	connectCtx, cancel := context.WithTimeout(ctx, _listenTimeout)
+	defer cancel()
+
+	conn, err := pgx.Connect(connectCtx, w.url)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err = conn.Exec(connectCtx, "LISTEN "+notifyChannel); err != nil {
+		return err
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		if notification.Payload != w.id {
+			w.notify(notification.Payload)
+		}
+	}
+}
+
+func (w *Watcher) notify(payload string) {
+	w.mu.Lock()
+	callback := w.callback
+	w.mu.Unlock()
+
+	if callback != nil {
+		callback(payload)
+	}
+}
+
+func (w *Watcher) reportError(err error) {
+	if err != nil && w.onError != nil {
+		w.onError(err)
+	}
+}
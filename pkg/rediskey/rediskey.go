@@ -0,0 +1,48 @@
+// Package rediskey does the atomic key operations the cache client lacks:
+// claiming a key once and counting tries, shared by all replicas.
+package rediskey
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type Client struct {
+	rdb *redis.Client
+}
+
+// New returns a client of the Redis at host:port. It connects on first use.
+func New(host string, port int) *Client {
+	return &Client{
+		rdb: redis.NewClient(&redis.Options{Addr: fmt.Sprintf("%s:%d", host, port)}),
+	}
+}
+
+// Claim sets key for ttl unless it is set already, and reports whether this
+// call set it.
+func (c *Client) Claim(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	return c.rdb.SetNX(ctx, key, "1", ttl).Result()
+}
+
+// Count adds one to key and returns the new count. The first count starts
+// the ttl of the key, so the count is of the tries within ttl.
+func (c *Client) Count(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	count, err := c.rdb.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	if count == 1 {
+		err = c.rdb.Expire(ctx, key, ttl).Err()
+	}
+
+	return count, err
+}
+
+// Del removes key.
+func (c *Client) Del(ctx context.Context, key string) error {
+	return c.rdb.Del(ctx, key).Err()
+}
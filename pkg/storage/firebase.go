@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	gcs "cloud.google.com/go/storage"
+	firebase "firebase.google.com/go"
+	"github.com/google/uuid"
+	"google.golang.org/api/option"
+)
+
+const downloadTokenKey = "firebaseStorageDownloadTokens"
+
+// Firebase keeps objects in a Firebase Storage bucket. The client is created
+// once and shared by all requests.
+type Firebase struct {
+	bucket     *gcs.BucketHandle
+	bucketName string
+}
+
+// NewFirebase -.
+func NewFirebase(ctx context.Context, credentialsFile, bucket string) (*Firebase, error) {
+	app, err := firebase.NewApp(ctx, nil, option.WithCredentialsFile(credentialsFile))
+	if err != nil {
+		return nil, fmt.Errorf("storage - NewFirebase - firebase.NewApp: %w", err)
+	}
+
+	client, err := app.Storage(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("storage - NewFirebase - app.Storage: %w", err)
+	}
+
+	handle, err := client.Bucket(bucket)
+	if err != nil {
+		return nil, fmt.Errorf("storage - NewFirebase - client.Bucket: %w", err)
+	}
+
+	return &Firebase{bucket: handle, bucketName: bucket}, nil
+}
+
+func (f *Firebase) Put(ctx context.Context, key string, data []byte, contentType string) (string, error) {
+	object := f.bucket.Object(key)
+
+	attrs, err := object.Attrs(ctx)
+	if err == nil && attrs.Metadata[downloadTokenKey] != "" {
+		return f.url(key, attrs.Metadata[downloadTokenKey]), nil
+	}
+	if err != nil && !errors.Is(err, gcs.ErrObjectNotExist) {
+		return "", err
+	}
+
+	token := uuid.NewString()
+
+	writer := object.NewWriter(ctx)
+	writer.ContentType = contentType
+	writer.CacheControl = "public, max-age=31536000, immutable"
+	writer.Metadata = map[string]string{downloadTokenKey: token}
+
+	if _, err = writer.Write(data); err != nil {
+		writer.Close()
+		return "", err
+	}
+
+	if err = writer.Close(); err != nil {
+		return "", err
+	}
+
+	return f.url(key, token), nil
+}
+
+func (f *Firebase) Delete(ctx context.Context, key string) error {
+	err := f.bucket.Object(key).Delete(ctx)
+	if errors.Is(err, gcs.ErrObjectNotExist) {
+		return ErrNotFound
+	}
+
+	return err
+}
+
+func (f *Firebase) url(key, token string) string {
+	return fmt.Sprintf("https://firebasestorage.googleapis.com/v0/b/%s/o/%s?alt=media&token=%s", f.bucketName, url.PathEscape(key), token)
+}
@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Local keeps objects in a directory, the files are served by the HTTP server.
+type Local struct {
+	dir     string
+	baseURL string
+}
+
+// NewLocal -.
+func NewLocal(dir, baseURL string) (*Local, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &Local{dir: dir, baseURL: strings.TrimRight(baseURL, "/")}, nil
+}
+
+func (l *Local) Put(_ context.Context, key string, data []byte, _ string) (string, error) {
+	path := filepath.Join(l.dir, key)
+
+	if _, err := os.Stat(path); err == nil {
+		return l.url(key), nil
+	}
+
+	// write to a temporary file first so a reader never sees half a file
+	tmp, err := os.CreateTemp(l.dir, ".upload-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return "", err
+	}
+
+	if err = tmp.Close(); err != nil {
+		return "", err
+	}
+
+	if err = os.Chmod(tmp.Name(), 0o644); err != nil {
+		return "", err
+	}
+
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return "", err
+	}
+
+	return l.url(key), nil
+}
+
+func (l *Local) Delete(_ context.Context, key string) error {
+	err := os.Remove(filepath.Join(l.dir, key))
+	if errors.Is(err, os.ErrNotExist) {
+		return ErrNotFound
+	}
+
+	return err
+}
+
+func (l *Local) url(key string) string {
+	return l.baseURL + "/" + key
+}
@@ -0,0 +1,127 @@
+// Package storage stores uploaded files in Firebase Storage, an S3 compatible
+// bucket or a local directory. Objects are content addressed: the key is the
+// SHA-256 of the file, so uploading the same file twice stores it once.
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrNotFound       = errors.New("storage: object not found")
+	ErrTooLarge       = errors.New("storage: file is too large")
+	ErrTypeNotAllowed = errors.New("storage: file type is not allowed")
+)
+
+// Storage -.
+type Storage interface {
+	// Put stores data under key unless the object already exists and returns its public URL.
+	Put(ctx context.Context, key string, data []byte, contentType string) (string, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// Limits restrict what may be uploaded. An empty AllowedTypes allows every type.
+type Limits struct {
+	MaxSize      int64
+	AllowedTypes []string
+}
+
+// Object is a stored file.
+type Object struct {
+	Key         string
+	URL         string
+	ContentType string
+	Size        int64
+}
+
+var extensions = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/gif":       ".gif",
+	"image/webp":      ".webp",
+	"application/pdf": ".pdf",
+	"video/mp4":       ".mp4",
+}
+
+var keyPattern = regexp.MustCompile(`^[0-9a-f]{64}\.[a-z0-9]+$`)
+
+// Upload reads r, checks it against limits, and stores it under its content address.
+func Upload(ctx context.Context, s Storage, r io.Reader, limits Limits) (Object, error) {
+	data, contentType, err := Read(r, limits)
+	if err != nil {
+		return Object{}, err
+	}
+
+	return Store(ctx, s, data, contentType)
+}
+
+// Read reads r and checks it against limits. The type is sniffed from the
+// content; the name and type sent by the client are ignored.
+func Read(r io.Reader, limits Limits) ([]byte, string, error) {
+	data, err := io.ReadAll(io.LimitReader(r, limits.MaxSize+1))
+	if err != nil {
+		return nil, "", err
+	}
+
+	if int64(len(data)) > limits.MaxSize {
+		return nil, "", ErrTooLarge
+	}
+
+	contentType := strings.SplitN(http.DetectContentType(data), ";", 2)[0]
+	if !allowed(contentType, limits.AllowedTypes) {
+		return nil, "", ErrTypeNotAllowed
+	}
+
+	return data, contentType, nil
+}
+
+// Store stores data under its content address.
+func Store(ctx context.Context, s Storage, data []byte, contentType string) (Object, error) {
+	key := Key(data, contentType)
+
+	url, err := s.Put(ctx, key, data, contentType)
+	if err != nil {
+		return Object{}, err
+	}
+
+	return Object{Key: key, URL: url, ContentType: contentType, Size: int64(len(data))}, nil
+}
+
+// Key returns the content address of data.
+func Key(data []byte, contentType string) string {
+	sum := sha256.Sum256(data)
+
+	ext, ok := extensions[contentType]
+	if !ok {
+		ext = ".bin"
+	}
+
+	return hex.EncodeToString(sum[:]) + ext
+}
+
+// ValidKey reports whether key looks like a key made by Key. Backends never
+// get a key from a client that does not pass it.
+func ValidKey(key string) bool {
+	return keyPattern.MatchString(key)
+}
+
+func allowed(contentType string, types []string) bool {
+	if len(types) == 0 {
+		return true
+	}
+
+	for _, t := range types {
+		if t == contentType {
+			return true
+		}
+	}
+
+	return false
+}
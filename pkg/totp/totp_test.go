@@ -0,0 +1,79 @@
+package totp
+
+import (
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA-1 key of RFC 6238 Appendix B, "12345678901234567890".
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// The RFC lists 8 digit codes, these are their last 6 digits.
+func TestGenerateCode(t *testing.T) {
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},          // 94287082
+		{1111111109, "081804"},  // 07081804
+		{1111111111, "050471"},  // 14050471
+		{1234567890, "005924"},  // 89005924
+		{2000000000, "279037"},  // 69279037
+		{20000000000, "353130"}, // 65353130
+	}
+
+	for _, tt := range tests {
+		got, err := GenerateCode(rfcSecret, time.Unix(tt.unix, 0))
+		if err != nil {
+			t.Errorf("GenerateCode(%d) error = %v", tt.unix, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("GenerateCode(%d) = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+
+	if _, err := GenerateCode("not base32!", time.Unix(59, 0)); err == nil {
+		t.Errorf("GenerateCode() of an invalid secret did not fail")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	current := counter(now)
+
+	code := func(offset int64) string {
+		c, err := generate(rfcSecret, current+offset)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+
+	tests := []struct {
+		name   string
+		code   string
+		want   bool
+		period int64
+	}{
+		{"current period", code(0), true, current},
+		{"previous period is within the skew", code(-1), true, current - 1},
+		{"next period is within the skew", code(1), true, current + 1},
+		{"surrounding spaces are ignored", " " + code(0) + "\n", true, current},
+		{"two periods ago is outside the skew", code(-2), false, 0},
+		{"two periods ahead is outside the skew", code(2), false, 0},
+		{"wrong code", "000000", false, 0},
+		{"too short", code(0)[:5], false, 0},
+		{"too long", code(0) + "0", false, 0},
+		{"empty", "", false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			period, ok := Validate(tt.code, rfcSecret, now)
+			if ok != tt.want || period != tt.period {
+				t.Errorf("Validate(%q) = %d, %v, want %d, %v", tt.code, period, ok, tt.period, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+// Package translit folds Uzbek and Russian Cyrillic into apostrophe-free
+// lower case Uzbek Latin so that search works whichever script was typed.
+// Keep in sync with the uz_translit() SQL function used to index products.
+package translit
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+var cyrillic = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo", 'ж': "j",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "x", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "sh", 'ъ': "", 'ы': "i", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya", 'ў': "o", 'қ': "q", 'ғ': "g", 'ҳ': "h",
+	// o‘zbek, g'isht: the apostrophe variants are dropped
+	'\'': "", '’': "", '‘': "", 'ʻ': "", 'ʼ': "", '`': "",
+}
+
+// Normalize returns s in the folded form.
+func Normalize(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+
+	for _, r := range strings.ToLower(s) {
+		if latin, ok := cyrillic[r]; ok {
+			b.WriteString(latin)
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// Terms returns the folded words of s, only letters and digits are kept.
+func Terms(s string) []string {
+	return strings.FieldsFunc(Normalize(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// Highlight wraps the words of text that start with one of the terms in
+// open and close. Words are compared in the folded form, so a Latin query
+// highlights Cyrillic text as well. The text is HTML escaped, only open and
+// close are markup.
+func Highlight(text string, terms []string, open, close string) string {
+	if len(terms) == 0 {
+		return html.EscapeString(text)
+	}
+
+	var (
+		b     strings.Builder
+		start = -1
+	)
+
+	flush := func(end int) {
+		word := text[start:end]
+		if matches(Normalize(word), terms) {
+			b.WriteString(open + html.EscapeString(word) + close)
+		} else {
+			b.WriteString(html.EscapeString(word))
+		}
+		start = -1
+	}
+
+	for i, r := range text {
+		if isWordRune(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+
+		if start >= 0 {
+			flush(i)
+		}
+		b.WriteString(html.EscapeString(string(r)))
+	}
+
+	if start >= 0 {
+		flush(len(text))
+	}
+
+	return b.String()
+}
+
+func isWordRune(r rune) bool {
+	if unicode.IsLetter(r) || unicode.IsDigit(r) {
+		return true
+	}
+
+	// apostrophes belong to the word in Uzbek Latin
+	_, ok := cyrillic[r]
+	return ok
+}
+
+func matches(word string, terms []string) bool {
+	for _, term := range terms {
+		if strings.HasPrefix(word, term) {
+			return true
+		}
+	}
+
+	return false
+}